@@ -0,0 +1,210 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type AlertRuleInitParameters struct {
+
+	// (String) The UID of the folder that the rule group is stored in.
+	// The UID of the folder that the rule group is stored in.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Folder
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=FolderRef
+	// +crossplane:generate:reference:selectorFieldName=FolderSelector
+	FolderUID *string `json:"folderUid,omitempty" tf:"folder_uid,omitempty"`
+
+	// Reference to a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderRef *v1.Reference `json:"folderRef,omitempty" tf:"-"`
+
+	// Selector for a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderSelector *v1.Selector `json:"folderSelector,omitempty" tf:"-"`
+
+	// (Number) The interval, in seconds, at which all rules in the group are evaluated. If a group contains many rules, the rules are evaluated sequentially.
+	// The interval, in seconds, at which all rules in the group are evaluated. If a group contains many rules, the rules are evaluated sequentially.
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty" tf:"interval_seconds,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The name of the rule group.
+	// The name of the rule group.
+	RuleGroupName *string `json:"ruleGroupName,omitempty" tf:"name,omitempty"`
+
+	// (String) Serialized JSON array containing the rules of the group, as accepted by the alerting provisioning API.
+	// Serialized JSON array containing the rules of the group, as accepted by the alerting provisioning API.
+	RulesJSON *string `json:"rulesJson,omitempty" tf:"-"`
+}
+
+type AlertRuleObservation struct {
+
+	// (String) The UID of the folder that the rule group is stored in.
+	// The UID of the folder that the rule group is stored in.
+	FolderUID *string `json:"folderUid,omitempty" tf:"folder_uid,omitempty"`
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (Number) The interval, in seconds, at which all rules in the group are evaluated. If a group contains many rules, the rules are evaluated sequentially.
+	// The interval, in seconds, at which all rules in the group are evaluated. If a group contains many rules, the rules are evaluated sequentially.
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty" tf:"interval_seconds,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (String) The name of the rule group.
+	// The name of the rule group.
+	RuleGroupName *string `json:"ruleGroupName,omitempty" tf:"name,omitempty"`
+
+	// (List of String) The UIDs Grafana assigned to the rules of the group.
+	// The UIDs Grafana assigned to the rules of the group.
+	RuleUIDs []*string `json:"ruleUids,omitempty" tf:"-"`
+}
+
+type AlertRuleParameters struct {
+
+	// (String) The UID of the folder that the rule group is stored in.
+	// The UID of the folder that the rule group is stored in.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Folder
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=FolderRef
+	// +crossplane:generate:reference:selectorFieldName=FolderSelector
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="FolderUID is immutable"
+	// +kubebuilder:validation:Optional
+	FolderUID *string `json:"folderUid,omitempty" tf:"folder_uid,omitempty"`
+
+	// Reference to a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderRef *v1.Reference `json:"folderRef,omitempty" tf:"-"`
+
+	// Selector for a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderSelector *v1.Selector `json:"folderSelector,omitempty" tf:"-"`
+
+	// (Number) The interval, in seconds, at which all rules in the group are evaluated. If a group contains many rules, the rules are evaluated sequentially.
+	// The interval, in seconds, at which all rules in the group are evaluated. If a group contains many rules, the rules are evaluated sequentially.
+	// +kubebuilder:validation:Optional
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty" tf:"interval_seconds,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The name of the rule group.
+	// The name of the rule group.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="RuleGroupName is immutable"
+	// +kubebuilder:validation:Optional
+	RuleGroupName *string `json:"ruleGroupName,omitempty" tf:"name,omitempty"`
+
+	// (String) Serialized JSON array containing the rules of the group, as accepted by the alerting provisioning API.
+	// Serialized JSON array containing the rules of the group, as accepted by the alerting provisioning API.
+	// +kubebuilder:validation:Optional
+	RulesJSON *string `json:"rulesJson,omitempty" tf:"-"`
+}
+
+// AlertRuleSpec defines the desired state of AlertRule
+type AlertRuleSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     AlertRuleParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider AlertRuleInitParameters `json:"initProvider,omitempty"`
+}
+
+// AlertRuleStatus defines the observed state of AlertRule.
+type AlertRuleStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        AlertRuleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// AlertRule is the Schema for the AlertRules API. Manages a Grafana alert rule group, the rules of a group are always written atomically. Official documentation https://grafana.com/docs/grafana/latest/alerting/alerting-rules/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#alert-rules
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type AlertRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.ruleGroupName) || (has(self.initProvider) && has(self.initProvider.ruleGroupName))",message="spec.forProvider.ruleGroupName is a required parameter"
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.rulesJson) || (has(self.initProvider) && has(self.initProvider.rulesJson))",message="spec.forProvider.rulesJson is a required parameter"
+	Spec   AlertRuleSpec   `json:"spec"`
+	Status AlertRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AlertRuleList contains a list of AlertRules
+type AlertRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertRule `json:"items"`
+}
+
+// AlertRule type metadata.
+var (
+	AlertRuleKind             = reflect.TypeOf(AlertRule{}).Name()
+	AlertRuleGroupKind        = schema.GroupKind{Group: Group, Kind: AlertRuleKind}.String()
+	AlertRuleKindAPIVersion   = AlertRuleKind + "." + SchemeGroupVersion.String()
+	AlertRuleGroupVersionKind = SchemeGroupVersion.WithKind(AlertRuleKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&AlertRule{}, &AlertRuleList{})
+}
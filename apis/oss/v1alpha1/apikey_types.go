@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type APIKeyInitParameters struct {
+
+	// (String) The name of the API key.
+	// The name of the API key.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The role of the API key in the organization. Must be one of None, Viewer, Editor or Admin. Defaults to Viewer.
+	// The role of the API key in the organization. Must be one of `None`, `Viewer`, `Editor` or `Admin`. Defaults to `Viewer`.
+	Role *string `json:"role,omitempty" tf:"role,omitempty"`
+
+	// (Number) How many seconds the API key is valid after it was created. The API key never expires if unset.
+	// How many seconds the API key is valid after it was created. The API key never expires if unset.
+	SecondsToLive *int64 `json:"secondsToLive,omitempty" tf:"seconds_to_live,omitempty"`
+}
+
+type APIKeyObservation struct {
+
+	// (String) When the API key expires, as RFC 3339 timestamp.
+	// When the API key expires, as RFC 3339 timestamp.
+	ExpiresAt *string `json:"expiresAt,omitempty" tf:"expires_at,omitempty"`
+
+	// (String) The ID of this resource.
+	// The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (Number) The ID of the API key in Grafana.
+	// The ID of the API key in Grafana.
+	KeyID *int64 `json:"keyId,omitempty" tf:"key_id,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+}
+
+type APIKeyParameters struct {
+
+	// (String) The name of the API key.
+	// The name of the API key.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Name is immutable"
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The role of the API key in the organization. Must be one of None, Viewer, Editor or Admin. Defaults to Viewer.
+	// The role of the API key in the organization. Must be one of `None`, `Viewer`, `Editor` or `Admin`. Defaults to `Viewer`.
+	// +kubebuilder:validation:Enum=None;Viewer;Editor;Admin
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Role is immutable"
+	// +kubebuilder:validation:Optional
+	Role *string `json:"role,omitempty" tf:"role,omitempty"`
+
+	// (Number) How many seconds the API key is valid after it was created. The API key never expires if unset.
+	// How many seconds the API key is valid after it was created. The API key never expires if unset.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="SecondsToLive is immutable"
+	// +kubebuilder:validation:Optional
+	SecondsToLive *int64 `json:"secondsToLive,omitempty" tf:"seconds_to_live,omitempty"`
+}
+
+// APIKeySpec defines the desired state of APIKey
+type APIKeySpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     APIKeyParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider APIKeyInitParameters `json:"initProvider,omitempty"`
+}
+
+// APIKeyStatus defines the observed state of APIKey.
+type APIKeyStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        APIKeyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// APIKey is the Schema for the APIKeys API. Manages a Grafana API key. API keys are deprecated in favor of service accounts, but still in wide use. The key is only returned once, when it is created, and published as connection detail apiKey. Official documentation https://grafana.com/docs/grafana/latest/administration/api-keys/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/auth/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="EXPIRES",type="string",JSONPath=".status.atProvider.expiresAt"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type APIKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.name) || (has(self.initProvider) && has(self.initProvider.name))",message="spec.forProvider.name is a required parameter"
+	Spec   APIKeySpec   `json:"spec"`
+	Status APIKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// APIKeyList contains a list of APIKeys
+type APIKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []APIKey `json:"items"`
+}
+
+// APIKey type metadata.
+var (
+	APIKeyKind             = reflect.TypeOf(APIKey{}).Name()
+	APIKeyGroupKind        = schema.GroupKind{Group: Group, Kind: APIKeyKind}.String()
+	APIKeyKindAPIVersion   = APIKeyKind + "." + SchemeGroupVersion.String()
+	APIKeyGroupVersionKind = SchemeGroupVersion.WithKind(APIKeyKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&APIKey{}, &APIKeyList{})
+}
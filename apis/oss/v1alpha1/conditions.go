@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ReasonAPIUnavailable indicates that a managed resource could not be synced
+// because Grafana is unavailable, as opposed to a ReconcileError caused by the
+// resource itself.
+const ReasonAPIUnavailable v1.ConditionReason = "APIUnavailable"
+
+// APIUnavailable returns a Synced condition that indicates that Grafana failed
+// too often in a row and is not asked again until the circuit breaker of its
+// host closes.
+func APIUnavailable(message string) v1.Condition {
+	return v1.Condition{
+		Type:               v1.TypeSynced,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonAPIUnavailable,
+		Message:            message,
+	}
+}
+
+// ReasonEnterpriseRequired indicates that a managed resource is not applied
+// because Grafana lacks its API, which only Grafana Enterprise and Grafana
+// Cloud offer.
+const ReasonEnterpriseRequired v1.ConditionReason = "EnterpriseRequired"
+
+// EnterpriseRequired returns a Ready condition that indicates that the managed
+// resource requires Grafana Enterprise or Grafana Cloud. It is not reconciled
+// further until Grafana offers its API.
+func EnterpriseRequired(message string) v1.Condition {
+	return v1.Condition{
+		Type:               v1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonEnterpriseRequired,
+		Message:            message,
+	}
+}
+
+// TypeDrifted indicates whether a managed resource differs from its spec in
+// Grafana, e.g. because it was edited in the UI. Unlike the message of the
+// Synced condition, which the managed reconciler replaces after every
+// reconcile, it keeps the differences until the resource matches its spec.
+const TypeDrifted v1.ConditionType = "Drifted"
+
+// Reasons a managed resource has or has not drifted from its spec.
+const (
+	ReasonDriftDetected v1.ConditionReason = "DriftDetected"
+	ReasonNoDrift       v1.ConditionReason = "NoDrift"
+)
+
+// Drifted returns a condition that indicates the managed resource in Grafana
+// differs from its spec, with the supplied message describing the differences.
+func Drifted(message string) v1.Condition {
+	return v1.Condition{
+		Type:               TypeDrifted,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDriftDetected,
+		Message:            message,
+	}
+}
+
+// NotDrifted returns a condition that indicates the managed resource in
+// Grafana matches its spec.
+func NotDrifted() v1.Condition {
+	return v1.Condition{
+		Type:               TypeDrifted,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoDrift,
+	}
+}
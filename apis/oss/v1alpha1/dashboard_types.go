@@ -15,20 +15,49 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reference"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// ConfigMapKeySelector selects a key of a ConfigMap in a namespace.
+type ConfigMapKeySelector struct {
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// The key to select.
+	Key string `json:"key"`
+}
+
 type DashboardInitParameters struct {
 
+	// (String) What to do if the dashboard was modified in Grafana since it was written last, overwrite or reject. Defaults to overwrite if overwrite is true and to reject otherwise.
+	// What to do if the dashboard was modified in Grafana since it was written last. `overwrite` replaces the
+	// modifications with the spec, `reject` leaves them in place and reports a VersionConflict until the strategy is
+	// changed. Defaults to `overwrite` if `overwrite` is true and to `reject` otherwise.
+	// +kubebuilder:validation:Enum=overwrite;reject
+	ConflictStrategy *string `json:"conflictStrategy,omitempty" tf:"-"`
+
 	// (String) The complete dashboard model JSON.
 	// The complete dashboard model JSON.
 	ConfigJSON *string `json:"configJson,omitempty" tf:"config_json,omitempty"`
 
-	// (String) The id or UID of the folder to save the dashboard in.
-	// The id or UID of the folder to save the dashboard in.
+	// A key of a ConfigMap that contains the complete dashboard model JSON, as an alternative to configJson. The
+	// ConfigMap is read on every reconcile.
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty" tf:"-"`
+
+	// (Block List) Binds datasource template variables of the dashboard model to DataSources.
+	// Binds datasource template variables of the dashboard model to DataSources. The UID of the DataSource is set as
+	// the query of the variable with the same name before the dashboard is sent to Grafana.
+	DataSourceRef []DataSourceVariableRef `json:"dataSourceRef,omitempty" tf:"-"`
+
+	// (String) The id, UID or title of the folder to save the dashboard in.
+	// The id, UID or title of the folder to save the dashboard in. A title must match exactly one folder.
 	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Folder
 	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
 	// +crossplane:generate:reference:refFieldName=FolderRef
@@ -78,6 +107,12 @@ type DashboardObservation struct {
 	// The numeric ID of the dashboard computed by Grafana.
 	DashboardID *int64 `json:"dashboardId,omitempty" tf:"dashboard_id,omitempty"`
 
+	// (Number) The number of data sources the panels of the dashboard reference.
+	DataSourceCount *int64 `json:"dataSourceCount,omitempty" tf:"-"`
+
+	// (List of String) The UIDs of the data sources the panels of the dashboard reference.
+	DataSourceUIDs []string `json:"dataSourceUids,omitempty" tf:"-"`
+
 	// (String) The id or UID of the folder to save the dashboard in.
 	// The id or UID of the folder to save the dashboard in.
 	Folder *string `json:"folder,omitempty" tf:"folder,omitempty"`
@@ -115,13 +150,32 @@ type DashboardObservation struct {
 
 type DashboardParameters struct {
 
+	// (String) What to do if the dashboard was modified in Grafana since it was written last, overwrite or reject. Defaults to overwrite if overwrite is true and to reject otherwise.
+	// What to do if the dashboard was modified in Grafana since it was written last. `overwrite` replaces the
+	// modifications with the spec, `reject` leaves them in place and reports a VersionConflict until the strategy is
+	// changed. Defaults to `overwrite` if `overwrite` is true and to `reject` otherwise.
+	// +kubebuilder:validation:Enum=overwrite;reject
+	// +kubebuilder:validation:Optional
+	ConflictStrategy *string `json:"conflictStrategy,omitempty" tf:"-"`
+
 	// (String) The complete dashboard model JSON.
-	// The complete dashboard model JSON.
+	// The complete dashboard model JSON. If the Dashboard is annotated with `grafana.crossplane.io/adopt-by-uid: "true"` and the model sets a `uid`, an existing dashboard with that UID is adopted instead of creating a new one.
 	// +kubebuilder:validation:Optional
 	ConfigJSON *string `json:"configJson,omitempty" tf:"config_json,omitempty"`
 
-	// (String) The id or UID of the folder to save the dashboard in.
-	// The id or UID of the folder to save the dashboard in.
+	// A key of a ConfigMap that contains the complete dashboard model JSON, as an alternative to configJson. The
+	// ConfigMap is read on every reconcile.
+	// +kubebuilder:validation:Optional
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty" tf:"-"`
+
+	// (Block List) Binds datasource template variables of the dashboard model to DataSources.
+	// Binds datasource template variables of the dashboard model to DataSources. The UID of the DataSource is set as
+	// the query of the variable with the same name before the dashboard is sent to Grafana.
+	// +kubebuilder:validation:Optional
+	DataSourceRef []DataSourceVariableRef `json:"dataSourceRef,omitempty" tf:"-"`
+
+	// (String) The id, UID or title of the folder to save the dashboard in.
+	// The id, UID or title of the folder to save the dashboard in. A title must match exactly one folder.
 	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Folder
 	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
 	// +crossplane:generate:reference:refFieldName=FolderRef
@@ -166,6 +220,32 @@ type DashboardParameters struct {
 	Overwrite *bool `json:"overwrite,omitempty" tf:"overwrite,omitempty"`
 }
 
+// DataSourceVariableRef binds a datasource template variable of a dashboard to a DataSource.
+type DataSourceVariableRef struct {
+
+	// (String) The name of the template variable of type datasource.
+	// The name of the template variable of type datasource.
+	// +kubebuilder:validation:Required
+	VariableName *string `json:"variableName" tf:"-"`
+
+	// (String) The UID of the data source to set as query of the variable.
+	// The UID of the data source to set as query of the variable.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.DataSource
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=DataSourceRef
+	// +crossplane:generate:reference:selectorFieldName=DataSourceSelector
+	// +kubebuilder:validation:Optional
+	DataSourceUID *string `json:"dataSourceUid,omitempty" tf:"-"`
+
+	// Reference to a DataSource in oss to populate dataSourceUid.
+	// +kubebuilder:validation:Optional
+	DataSourceRef *v1.Reference `json:"dataSourceRef,omitempty" tf:"-"`
+
+	// Selector for a DataSource in oss to populate dataSourceUid.
+	// +kubebuilder:validation:Optional
+	DataSourceSelector *v1.Selector `json:"dataSourceSelector,omitempty" tf:"-"`
+}
+
 // DashboardSpec defines the desired state of Dashboard
 type DashboardSpec struct {
 	v1.ResourceSpec `json:",inline"`
@@ -197,12 +277,15 @@ type DashboardStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="DATASOURCES",type="integer",JSONPath=".status.atProvider.dataSourceCount"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
 type Dashboard struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.configJson) || (has(self.initProvider) && has(self.initProvider.configJson))",message="spec.forProvider.configJson is a required parameter"
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.configJson) || has(self.forProvider.configMapRef) || (has(self.initProvider) && (has(self.initProvider.configJson) || has(self.initProvider.configMapRef)))",message="spec.forProvider.configJson or spec.forProvider.configMapRef is a required parameter"
+	// +kubebuilder:validation:XValidation:rule="!(has(self.forProvider.configJson) && has(self.forProvider.configMapRef))",message="spec.forProvider.configJson and spec.forProvider.configMapRef are mutually exclusive"
+	// +kubebuilder:validation:XValidation:rule="!has(self.initProvider) || !(has(self.initProvider.configJson) && has(self.initProvider.configMapRef))",message="spec.initProvider.configJson and spec.initProvider.configMapRef are mutually exclusive"
 	Spec   DashboardSpec   `json:"spec"`
 	Status DashboardStatus `json:"status,omitempty"`
 }
@@ -216,6 +299,46 @@ type DashboardList struct {
 	Items           []Dashboard `json:"items"`
 }
 
+// TypeVersionConflict indicates that a Dashboard could not be written, because
+// it was modified in Grafana and overwrite is disabled.
+const TypeVersionConflict v1.ConditionType = "VersionConflict"
+
+// Strategies to handle a Dashboard that was modified in Grafana since it was
+// written last.
+const (
+	ConflictStrategyOverwrite = "overwrite"
+	ConflictStrategyReject    = "reject"
+)
+
+// Reasons a Dashboard does or does not have a version conflict.
+const (
+	ReasonVersionMismatch v1.ConditionReason = "VersionMismatch"
+	ReasonVersionMatches  v1.ConditionReason = "VersionMatches"
+)
+
+// VersionConflict returns a condition that indicates the Dashboard was modified
+// in Grafana and cannot be written without overwrite, with the supplied message.
+func VersionConflict(message string) v1.Condition {
+	return v1.Condition{
+		Type:               TypeVersionConflict,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVersionMismatch,
+		Message:            message,
+	}
+}
+
+// NoVersionConflict returns a condition that indicates the Dashboard was
+// written to Grafana without a version conflict.
+func NoVersionConflict() v1.Condition {
+	return v1.Condition{
+		Type:               TypeVersionConflict,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVersionMatches,
+	}
+}
+
 // Dashboard type metadata.
 var (
 	DashboardKind             = reflect.TypeOf(Dashboard{}).Name()
@@ -11,18 +11,46 @@ package v1alpha1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// KnownDataSourceTypes are the plugin IDs accepted as type of a DataSource.
+// Keep the Enum markers of the type fields in sync when changing this list.
+var KnownDataSourceTypes = []string{
+	"prometheus",
+	"loki",
+	"tempo",
+	"grafana-azure-monitor-datasource",
+	"elasticsearch",
+	"graphite",
+	"influxdb",
+	"mixed",
+	"mysql",
+	"mssql",
+	"postgres",
+	"cloudwatch",
+	"stackdriver",
+	"jaeger",
+	"zipkin",
+	"parca",
+	"pyroscope",
+	"testdata",
+}
+
 type DataSourceInitParameters struct {
 
 	// (String) The method by which Grafana will access the data source: proxy or direct. Defaults to proxy.
 	// The method by which Grafana will access the data source: `proxy` or `direct`. Defaults to `proxy`.
 	AccessMode *string `json:"accessMode,omitempty" tf:"access_mode,omitempty"`
 
+	// (Boolean) Whether to add the values Grafana assumes for well-known keys of the jsonData of the type, e.g. httpMethod of Prometheus, if jsonDataEncoded does not set them. Grafana stores some of them when the data source is saved, which shows as drift otherwise. Defaults to false.
+	// Whether to add the values Grafana assumes for well-known keys of the jsonData of the type, e.g. `httpMethod` of Prometheus, if `jsonDataEncoded` does not set them. Grafana stores some of them when the data source is saved, which shows as drift otherwise. Defaults to `false`.
+	ApplyJSONDataDefaults *bool `json:"applyJsonDataDefaults,omitempty" tf:"-"`
+
 	// (Boolean) Whether to enable basic auth for the data source. Defaults to false.
 	// Whether to enable basic auth for the data source. Defaults to `false`.
 	BasicAuthEnabled *bool `json:"basicAuthEnabled,omitempty" tf:"basic_auth_enabled,omitempty"`
@@ -35,6 +63,10 @@ type DataSourceInitParameters struct {
 	// (Required by some data source types) The name of the database to use on the selected data source server. Defaults to “.
 	DatabaseName *string `json:"databaseName,omitempty" tf:"database_name,omitempty"`
 
+	// (Boolean) Whether to run the health check of the data source after it is up to date. A failing health check marks the resource as not ready and outdated, so it is updated and checked again. Defaults to false.
+	// Whether to run the health check of the data source after it is up to date. A failing health check marks the resource as not ready and outdated, so it is updated and checked again. Defaults to `false`.
+	EnableHealthCheck *bool `json:"enableHealthCheck,omitempty" tf:"-"`
+
 	// (Boolean) Whether to set the data source as default. This should only be true to a single data source. Defaults to false.
 	// Whether to set the data source as default. This should only be `true` to a single data source. Defaults to `false`.
 	IsDefault *bool `json:"isDefault,omitempty" tf:"is_default,omitempty"`
@@ -62,8 +94,13 @@ type DataSourceInitParameters struct {
 	// +kubebuilder:validation:Optional
 	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
 
+	// (Boolean) Whether the data source is read-only, which Grafana sets for provisioned data sources. It can't be changed through the Grafana API, a difference to Grafana is reported as warning event. Defaults to false.
+	// Whether the data source is read-only, which Grafana sets for provisioned data sources. It can't be changed through the Grafana API, a difference to Grafana is reported as warning event. Defaults to `false`.
+	ReadOnly *bool `json:"readOnly,omitempty" tf:"read_only,omitempty"`
+
 	// (String) The data source type. Must be one of the supported data source keywords.
 	// The data source type. Must be one of the supported data source keywords.
+	// +kubebuilder:validation:Enum=prometheus;loki;tempo;grafana-azure-monitor-datasource;elasticsearch;graphite;influxdb;mixed;mysql;mssql;postgres;cloudwatch;stackdriver;jaeger;zipkin;parca;pyroscope;testdata
 	Type *string `json:"type,omitempty" tf:"type,omitempty"`
 
 	// (String) Unique identifier. If unset, this will be automatically generated.
@@ -77,10 +114,22 @@ type DataSourceInitParameters struct {
 	// (String)  The username to use to authenticate to the data source. Defaults to “.
 	// (Required by some data source types) The username to use to authenticate to the data source. Defaults to “.
 	Username *string `json:"username,omitempty" tf:"username,omitempty"`
+
+	// (Boolean) Whether to run the health check of the data source after it was created or updated. The result is reported in the DataSourceHealthy condition. Defaults to false.
+	// Whether to run the health check of the data source after it was created or updated. The result is reported in the `DataSourceHealthy` condition. Defaults to `false`.
+	ValidateOnCreate *bool `json:"validateOnCreate,omitempty" tf:"-"`
+
+	// (Boolean) Whether to send cookies and auth headers with cross-origin requests to the data source. Defaults to false.
+	// Whether to send cookies and auth headers with cross-origin requests to the data source. Defaults to `false`.
+	WithCredentials *bool `json:"withCredentials,omitempty" tf:"with_credentials,omitempty"`
 }
 
 type DataSourceObservation struct {
 
+	// (Map of Boolean) The actions Grafana allows the provider on the data source, as computed by its access control.
+	// The actions Grafana allows the provider on the data source, as computed by its access control, e.g. `datasources:write`. Only informational, it is not compared with the spec.
+	AccessControl map[string]bool `json:"accessControl,omitempty" tf:"-"`
+
 	// (String) The method by which Grafana will access the data source: proxy or direct. Defaults to proxy.
 	// The method by which Grafana will access the data source: `proxy` or `direct`. Defaults to `proxy`.
 	AccessMode *string `json:"accessMode,omitempty" tf:"access_mode,omitempty"`
@@ -89,6 +138,10 @@ type DataSourceObservation struct {
 	// Whether to enable basic auth for the data source. Defaults to `false`.
 	BasicAuthEnabled *bool `json:"basicAuthEnabled,omitempty" tf:"basic_auth_enabled,omitempty"`
 
+	// (String) Salted HMAC-SHA256 of the basic auth password in the secure JSON data.
+	// Salted HMAC-SHA256 of the basic auth password in the secure JSON data. Used to detect changes of the password, which Grafana does not return, without a signing key.
+	BasicAuthPasswordHash *string `json:"basicAuthPasswordHash,omitempty" tf:"-"`
+
 	// (String) Basic auth username. Defaults to “.
 	// Basic auth username. Defaults to “.
 	BasicAuthUsername *string `json:"basicAuthUsername,omitempty" tf:"basic_auth_username,omitempty"`
@@ -116,6 +169,14 @@ type DataSourceObservation struct {
 	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
 	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
 
+	// (Boolean) Whether the data source is read-only, which Grafana sets for provisioned data sources.
+	// Whether the data source is read-only, which Grafana sets for provisioned data sources.
+	ReadOnly *bool `json:"readOnly,omitempty" tf:"read_only,omitempty"`
+
+	// (String) Hex encoded HMAC-SHA256 of the secure JSON data and HTTP header values, signed with the key referenced by the ProviderConfig.
+	// Hex encoded HMAC-SHA256 of the secure JSON data and HTTP header values, signed with the key referenced by the ProviderConfig. Used to detect changes of the secret values, which Grafana does not return.
+	SecureJSONDataHash *string `json:"secureJsonDataHash,omitempty" tf:"-"`
+
 	// (String) The data source type. Must be one of the supported data source keywords.
 	// The data source type. Must be one of the supported data source keywords.
 	Type *string `json:"type,omitempty" tf:"type,omitempty"`
@@ -131,6 +192,10 @@ type DataSourceObservation struct {
 	// (String)  The username to use to authenticate to the data source. Defaults to “.
 	// (Required by some data source types) The username to use to authenticate to the data source. Defaults to “.
 	Username *string `json:"username,omitempty" tf:"username,omitempty"`
+
+	// (Boolean) Whether to send cookies and auth headers with cross-origin requests to the data source. Defaults to false.
+	// Whether to send cookies and auth headers with cross-origin requests to the data source. Defaults to `false`.
+	WithCredentials *bool `json:"withCredentials,omitempty" tf:"with_credentials,omitempty"`
 }
 
 type DataSourceParameters struct {
@@ -140,6 +205,11 @@ type DataSourceParameters struct {
 	// +kubebuilder:validation:Optional
 	AccessMode *string `json:"accessMode,omitempty" tf:"access_mode,omitempty"`
 
+	// (Boolean) Whether to add the values Grafana assumes for well-known keys of the jsonData of the type, e.g. httpMethod of Prometheus, if jsonDataEncoded does not set them. Grafana stores some of them when the data source is saved, which shows as drift otherwise. Defaults to false.
+	// Whether to add the values Grafana assumes for well-known keys of the jsonData of the type, e.g. `httpMethod` of Prometheus, if `jsonDataEncoded` does not set them. Grafana stores some of them when the data source is saved, which shows as drift otherwise. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	ApplyJSONDataDefaults *bool `json:"applyJsonDataDefaults,omitempty" tf:"-"`
+
 	// (Boolean) Whether to enable basic auth for the data source. Defaults to false.
 	// Whether to enable basic auth for the data source. Defaults to `false`.
 	// +kubebuilder:validation:Optional
@@ -155,8 +225,13 @@ type DataSourceParameters struct {
 	// +kubebuilder:validation:Optional
 	DatabaseName *string `json:"databaseName,omitempty" tf:"database_name,omitempty"`
 
+	// (Boolean) Whether to run the health check of the data source after it is up to date. A failing health check marks the resource as not ready. Defaults to false.
+	// Whether to run the health check of the data source after it is up to date. A failing health check marks the resource as not ready. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	EnableHealthCheck *bool `json:"enableHealthCheck,omitempty" tf:"-"`
+
 	// (Map of String, Sensitive) Custom HTTP headers
-	// Custom HTTP headers
+	// Custom HTTP headers. They are numbered after the httpHeaderName and httpHeaderValue keys already set in the JSON data and secure JSON data.
 	// +kubebuilder:validation:Optional
 	HTTPHeadersSecretRef *v1.SecretReference `json:"httpHeadersSecretRef,omitempty" tf:"-"`
 
@@ -194,18 +269,30 @@ type DataSourceParameters struct {
 	// +kubebuilder:validation:Optional
 	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
 
+	// (Boolean) Whether the data source is read-only, which Grafana sets for provisioned data sources. It can't be changed through the Grafana API, a difference to Grafana is reported as warning event. Defaults to false.
+	// Whether the data source is read-only, which Grafana sets for provisioned data sources. It can't be changed through the Grafana API, a difference to Grafana is reported as warning event. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	ReadOnly *bool `json:"readOnly,omitempty" tf:"read_only,omitempty"`
+
 	// (String, Sensitive) Serialized JSON string containing the secure json data. This attribute can be used to pass secure configuration options to the data source. To figure out what options a datasource has available, see its docs or inspect the network data when saving it from the Grafana UI. Note that keys in this map are usually camelCased.
-	// Serialized JSON string containing the secure json data. This attribute can be used to pass secure configuration options to the data source. To figure out what options a datasource has available, see its docs or inspect the network data when saving it from the Grafana UI. Note that keys in this map are usually camelCased.
+	// Serialized JSON string containing the secure json data. This attribute can be used to pass secure configuration options to the data source. To figure out what options a datasource has available, see its docs or inspect the network data when saving it from the Grafana UI. Note that keys in this map are usually camelCased. The secret is read from the namespace of the reference, as data sources are cluster scoped.
 	// +kubebuilder:validation:Optional
 	SecureJSONDataEncodedSecretRef *v1.SecretKeySelector `json:"secureJsonDataEncodedSecretRef,omitempty" tf:"-"`
 
+	// (Secret) TLS client certificate, client key and CA certificate of the data source, in the keys tls.crt, tls.key and ca.crt
+	// Secret with the TLS client certificate and key in the keys `tls.crt` and `tls.key` and the CA certificate in the key `ca.crt`, e.g. a secret of type `kubernetes.io/tls`. They are sent as `tlsClientCert`, `tlsClientKey` and `tlsCACert` in the secure JSON data and enable `tlsAuth` and `tlsAuthWithCACert` in the JSON data. The CA certificate is optional.
+	// +kubebuilder:validation:Optional
+	TLSConfigSecretRef *v1.SecretReference `json:"tlsConfigSecretRef,omitempty" tf:"-"`
+
 	// (String) The data source type. Must be one of the supported data source keywords.
 	// The data source type. Must be one of the supported data source keywords.
+	// +kubebuilder:validation:Enum=prometheus;loki;tempo;grafana-azure-monitor-datasource;elasticsearch;graphite;influxdb;mixed;mysql;mssql;postgres;cloudwatch;stackdriver;jaeger;zipkin;parca;pyroscope;testdata
 	// +kubebuilder:validation:Optional
 	Type *string `json:"type,omitempty" tf:"type,omitempty"`
 
 	// (String) Unique identifier. If unset, this will be automatically generated.
 	// Unique identifier. If unset, this will be automatically generated.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="UID is immutable"
 	// +kubebuilder:validation:Optional
 	UID *string `json:"uid,omitempty" tf:"uid,omitempty"`
 
@@ -218,6 +305,17 @@ type DataSourceParameters struct {
 	// (Required by some data source types) The username to use to authenticate to the data source. Defaults to “.
 	// +kubebuilder:validation:Optional
 	Username *string `json:"username,omitempty" tf:"username,omitempty"`
+
+	// (Boolean) Whether to run the health check of the data source after it was created or updated. The result is reported in the DataSourceHealthy condition. Defaults to false.
+	// Whether to run the health check of the data source after it was created or updated. The result is reported in the `DataSourceHealthy` condition. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	ValidateOnCreate *bool `json:"validateOnCreate,omitempty" tf:"-"`
+
+	// (Boolean) Whether to send cookies and auth headers with cross-origin requests to the data source. Defaults to false.
+	// Whether to send cookies and auth headers with cross-origin requests to the data source. Defaults to `false`.
+	// +kubebuilder:default:=false
+	// +kubebuilder:validation:Optional
+	WithCredentials *bool `json:"withCredentials,omitempty" tf:"with_credentials,omitempty"`
 }
 
 // DataSourceSpec defines the desired state of DataSource
@@ -247,7 +345,7 @@ type DataSourceStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 
-// DataSource is the Schema for the DataSources API. Official documentation https://grafana.com/docs/grafana/latest/datasources/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/data_source/ The required arguments for this resource vary depending on the type of data source selected (via the 'type' argument).
+// DataSource is the Schema for the DataSources API. Official documentation https://grafana.com/docs/grafana/latest/datasources/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/data_source/ The required arguments for this resource vary depending on the type of data source selected (via the 'type' argument). The uid and id of the data source are published as connection details, use writeConnectionSecretToRef to store them in a secret.
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
@@ -271,6 +369,107 @@ type DataSourceList struct {
 	Items           []DataSource `json:"items"`
 }
 
+// TypeHealthCheckFailed indicates that the health check of a DataSource failed,
+// i.e. Grafana can't query the data source with its current configuration.
+const TypeHealthCheckFailed v1.ConditionType = "HealthCheckFailed"
+
+// Reasons a DataSource health check is or is not failing.
+const (
+	ReasonHealthCheckFailed v1.ConditionReason = "HealthCheckFailed"
+	ReasonHealthCheckPassed v1.ConditionReason = "HealthCheckPassed"
+)
+
+// HealthCheckFailed returns a condition that indicates the health check of the
+// DataSource failed with the supplied message.
+func HealthCheckFailed(message string) v1.Condition {
+	return v1.Condition{
+		Type:               TypeHealthCheckFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthCheckFailed,
+		Message:            message,
+	}
+}
+
+// HealthCheckPassed returns a condition that indicates the health check of the
+// DataSource passed.
+func HealthCheckPassed() v1.Condition {
+	return v1.Condition{
+		Type:               TypeHealthCheckFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonHealthCheckPassed,
+	}
+}
+
+// TypeDataSourceHealthy indicates whether the health check that ran after the
+// DataSource was created or updated succeeded.
+const TypeDataSourceHealthy v1.ConditionType = "DataSourceHealthy"
+
+// Reasons a DataSource is or is not healthy after it was created or updated.
+const (
+	ReasonDataSourceHealthy   v1.ConditionReason = "HealthCheckSucceeded"
+	ReasonDataSourceUnhealthy v1.ConditionReason = "HealthCheckFailed"
+)
+
+// DataSourceHealthy returns a condition that indicates the health check of the
+// DataSource succeeded after it was created or updated.
+func DataSourceHealthy() v1.Condition {
+	return v1.Condition{
+		Type:               TypeDataSourceHealthy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDataSourceHealthy,
+	}
+}
+
+// DataSourceUnhealthy returns a condition that indicates the health check of
+// the DataSource failed with the supplied message after it was created or
+// updated.
+func DataSourceUnhealthy(message string) v1.Condition {
+	return v1.Condition{
+		Type:               TypeDataSourceHealthy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDataSourceUnhealthy,
+		Message:            message,
+	}
+}
+
+// TypeJSONDataValid indicates whether the jsonData of a DataSource of a
+// well-known type looks like Grafana expects it. It is only a hint, the
+// DataSource is synced either way.
+const TypeJSONDataValid v1.ConditionType = "JSONDataValid"
+
+// Reasons the jsonData of a DataSource does or does not look valid.
+const (
+	ReasonJSONDataLooksValid v1.ConditionReason = "JSONDataLooksValid"
+	ReasonJSONDataSuspicious v1.ConditionReason = "JSONDataSuspicious"
+)
+
+// JSONDataLooksValid returns a condition that indicates no problems were
+// found in the jsonData of the DataSource.
+func JSONDataLooksValid() v1.Condition {
+	return v1.Condition{
+		Type:               TypeJSONDataValid,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonJSONDataLooksValid,
+	}
+}
+
+// JSONDataSuspicious returns a condition that indicates the jsonData of the
+// DataSource has the problems described by the supplied message.
+func JSONDataSuspicious(message string) v1.Condition {
+	return v1.Condition{
+		Type:               TypeJSONDataValid,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonJSONDataSuspicious,
+		Message:            message,
+	}
+}
+
 // DataSource type metadata.
 var (
 	DataSourceKind             = reflect.TypeOf(DataSource{}).Name()
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// TestDataSourceTypeEnum ensures the Enum markers of the type fields match KnownDataSourceTypes.
+func TestDataSourceTypeEnum(t *testing.T) {
+	raw, err := os.ReadFile("../../../package/crds/oss.grafana.crossplane.io_datasources.yaml")
+	if err != nil {
+		t.Fatalf("os.ReadFile(...): %v", err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(raw, crd); err != nil {
+		t.Fatalf("yaml.Unmarshal(...): %v", err)
+	}
+
+	spec := crd.Spec.Versions[0].Schema.OpenAPIV3Schema.Properties["spec"]
+	for _, params := range []string{"forProvider", "initProvider"} {
+		t.Run(params, func(t *testing.T) {
+			var got []string
+			for _, v := range spec.Properties[params].Properties["type"].Enum {
+				var s string
+				if err := yaml.Unmarshal(v.Raw, &s); err != nil {
+					t.Fatalf("yaml.Unmarshal(%s): %v", v.Raw, err)
+				}
+				got = append(got, s)
+			}
+			if diff := cmp.Diff(KnownDataSourceTypes, got); diff != "" {
+				t.Errorf("%s.type: -want KnownDataSourceTypes, +got enum:\n%s\n", params, diff)
+			}
+		})
+	}
+}
+
+func dataSourceObject(uid string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "oss.grafana.crossplane.io/v1alpha1",
+		"kind":       "DataSource",
+		"metadata":   map[string]interface{}{"name": "example"},
+		"spec": map[string]interface{}{
+			"managementPolicies": []interface{}{"*"},
+			"forProvider": map[string]interface{}{
+				"name": "prometheus",
+				"type": "prometheus",
+				"uid":  uid,
+			},
+		},
+	}
+}
+
+// TestDataSourceUIDImmutable ensures the API server rejects changes of the uid, like it does for folders.
+func TestDataSourceUIDImmutable(t *testing.T) {
+	errs := validateUpdate(t, "oss.grafana.crossplane.io_datasources.yaml", dataSourceObject("def"), dataSourceObject("abc"))
+	if len(errs) != 1 || errs[0].Detail != "UID is immutable" {
+		t.Errorf("Validate(...): want a single error %q, got %v", "UID is immutable", errs)
+	}
+
+	errs = validateUpdate(t, "oss.grafana.crossplane.io_datasources.yaml", dataSourceObject("abc"), dataSourceObject("abc"))
+	if len(errs) != 0 {
+		t.Errorf("Validate(...): want no errors, got %v", errs)
+	}
+}
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="[has(self.builtInRole), has(self.teamId), has(self.userId)].filter(x, x).size() == 1",message="exactly one of builtInRole, teamId and userId must be set"
+type DataSourcePermissionItem struct {
+
+	// (String) Name of the basic role to manage permissions for. Options: Viewer, Editor or Admin.
+	// Name of the basic role to manage permissions for. Options: `Viewer`, `Editor` or `Admin`.
+	// +kubebuilder:validation:Enum=Viewer;Editor;Admin
+	// +kubebuilder:validation:Optional
+	BuiltInRole *string `json:"builtInRole,omitempty" tf:"built_in_role,omitempty"`
+
+	// (String) Permission to associate with item. Options: Query, Edit or Admin.
+	// Permission to associate with item. Options: `Query`, `Edit` or `Admin`.
+	// +kubebuilder:validation:Enum=Query;Edit;Admin
+	Permission *string `json:"permission" tf:"permission"`
+
+	// (String) ID of the team to manage permissions for.
+	// ID of the team to manage permissions for.
+	// +kubebuilder:validation:Optional
+	TeamID *string `json:"teamId,omitempty" tf:"team_id,omitempty"`
+
+	// (String) ID of the user or service account to manage permissions for.
+	// ID of the user or service account to manage permissions for.
+	// +kubebuilder:validation:Optional
+	UserID *string `json:"userId,omitempty" tf:"user_id,omitempty"`
+}
+
+type DataSourcePermissionInitParameters struct {
+
+	// (String) UID of the datasource to apply permissions to.
+	// UID of the datasource to apply permissions to.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.DataSource
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=DataSourceRef
+	// +crossplane:generate:reference:selectorFieldName=DataSourceSelector
+	DatasourceUID *string `json:"datasourceUid,omitempty" tf:"datasource_uid,omitempty"`
+
+	// Reference to a DataSource in oss to populate datasourceUid.
+	// +kubebuilder:validation:Optional
+	DataSourceRef *v1.Reference `json:"dataSourceRef,omitempty" tf:"-"`
+
+	// Selector for a DataSource in oss to populate datasourceUid.
+	// +kubebuilder:validation:Optional
+	DataSourceSelector *v1.Selector `json:"dataSourceSelector,omitempty" tf:"-"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (Block Set) The permission items to add/update. Items that are omitted from the list will be removed.
+	// The permission items to add/update. Items that are omitted from the list will be removed.
+	Permissions []DataSourcePermissionItem `json:"permissions,omitempty" tf:"permissions,omitempty"`
+}
+
+type DataSourcePermissionObservation struct {
+
+	// (String) UID of the datasource to apply permissions to.
+	// UID of the datasource to apply permissions to.
+	DatasourceUID *string `json:"datasourceUid,omitempty" tf:"datasource_uid,omitempty"`
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (Block Set) The permission items to add/update. Items that are omitted from the list will be removed.
+	// The permission items to add/update. Items that are omitted from the list will be removed.
+	Permissions []DataSourcePermissionItem `json:"permissions,omitempty" tf:"permissions,omitempty"`
+}
+
+type DataSourcePermissionParameters struct {
+
+	// (String) UID of the datasource to apply permissions to.
+	// UID of the datasource to apply permissions to.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.DataSource
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=DataSourceRef
+	// +crossplane:generate:reference:selectorFieldName=DataSourceSelector
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="DatasourceUID is immutable"
+	// +kubebuilder:validation:Optional
+	DatasourceUID *string `json:"datasourceUid,omitempty" tf:"datasource_uid,omitempty"`
+
+	// Reference to a DataSource in oss to populate datasourceUid.
+	// +kubebuilder:validation:Optional
+	DataSourceRef *v1.Reference `json:"dataSourceRef,omitempty" tf:"-"`
+
+	// Selector for a DataSource in oss to populate datasourceUid.
+	// +kubebuilder:validation:Optional
+	DataSourceSelector *v1.Selector `json:"dataSourceSelector,omitempty" tf:"-"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (Block Set) The permission items to add/update. Items that are omitted from the list will be removed.
+	// The permission items to add/update. Items that are omitted from the list will be removed.
+	// +kubebuilder:validation:Optional
+	Permissions []DataSourcePermissionItem `json:"permissions,omitempty" tf:"permissions,omitempty"`
+}
+
+// DataSourcePermissionSpec defines the desired state of DataSourcePermission
+type DataSourcePermissionSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     DataSourcePermissionParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider DataSourcePermissionInitParameters `json:"initProvider,omitempty"`
+}
+
+// DataSourcePermissionStatus defines the observed state of DataSourcePermission.
+type DataSourcePermissionStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        DataSourcePermissionObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// DataSourcePermission is the Schema for the DataSourcePermissions API. Manages the complete set of permissions for a datasource. Permissions that aren't specified when applying this resource will be removed. Note: This resource is available only with Grafana Enterprise or Grafana Cloud. Official documentation https://grafana.com/docs/grafana/latest/administration/data-source-management/#data-source-permissionsHTTP API https://grafana.com/docs/grafana/latest/developers/http_api/datasource_permissions/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type DataSourcePermission struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.datasourceUid) || (has(self.initProvider) && has(self.initProvider.datasourceUid))",message="spec.forProvider.datasourceUid is a required parameter"
+	Spec   DataSourcePermissionSpec   `json:"spec"`
+	Status DataSourcePermissionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DataSourcePermissionList contains a list of DataSourcePermissions
+type DataSourcePermissionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DataSourcePermission `json:"items"`
+}
+
+// DataSourcePermission type metadata.
+var (
+	DataSourcePermissionKind             = reflect.TypeOf(DataSourcePermission{}).Name()
+	DataSourcePermissionGroupKind        = schema.GroupKind{Group: Group, Kind: DataSourcePermissionKind}.String()
+	DataSourcePermissionKindAPIVersion   = DataSourcePermissionKind + "." + SchemeGroupVersion.String()
+	DataSourcePermissionGroupVersionKind = SchemeGroupVersion.WithKind(DataSourcePermissionKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&DataSourcePermission{}, &DataSourcePermissionList{})
+}
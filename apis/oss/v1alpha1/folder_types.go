@@ -11,14 +11,26 @@ package v1alpha1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// Policies for the contents of a Folder when it is deleted.
+const (
+	FolderContentDeletionPolicyCascade = "Cascade"
+	FolderContentDeletionPolicyRefuse  = "Refuse"
+)
+
 type FolderInitParameters struct {
 
+	// (String) What happens to the dashboards, library panels, alert rules and subfolders in the folder when it is deleted. Cascade deletes them along with the folder, except for alert rules unless forceDeleteRules is set. Refuse fails the deletion while the folder is not empty. Defaults to Cascade.
+	// What happens to the dashboards, library panels, alert rules and subfolders in the folder when it is deleted. `Cascade` deletes them along with the folder, except for alert rules unless `forceDeleteRules` is set. `Refuse` fails the deletion while the folder is not empty. Defaults to `Cascade`.
+	// +kubebuilder:validation:Enum=Cascade;Refuse
+	ContentDeletionPolicy *string `json:"contentDeletionPolicy,omitempty" tf:"-"`
+
 	// Reference to a Folder in oss to populate parentFolderUid.
 	// +kubebuilder:validation:Optional
 	FolderRef *v1.Reference `json:"folderRef,omitempty" tf:"-"`
@@ -27,6 +39,10 @@ type FolderInitParameters struct {
 	// +kubebuilder:validation:Optional
 	FolderSelector *v1.Selector `json:"folderSelector,omitempty" tf:"-"`
 
+	// (Boolean) Whether to delete the alert rules stored in the folder when the folder is deleted. Grafana refuses to delete a folder containing alert rules otherwise. Defaults to false.
+	// Whether to delete the alert rules stored in the folder when the folder is deleted. Grafana refuses to delete a folder containing alert rules otherwise. Defaults to `false`.
+	ForceDeleteRules *bool `json:"forceDeleteRules,omitempty" tf:"-"`
+
 	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
 	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
 	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
@@ -92,6 +108,12 @@ type FolderObservation struct {
 
 type FolderParameters struct {
 
+	// (String) What happens to the dashboards, library panels, alert rules and subfolders in the folder when it is deleted. Cascade deletes them along with the folder, except for alert rules unless forceDeleteRules is set. Refuse fails the deletion while the folder is not empty. Defaults to Cascade.
+	// What happens to the dashboards, library panels, alert rules and subfolders in the folder when it is deleted. `Cascade` deletes them along with the folder, except for alert rules unless `forceDeleteRules` is set. `Refuse` fails the deletion while the folder is not empty. Defaults to `Cascade`.
+	// +kubebuilder:validation:Enum=Cascade;Refuse
+	// +kubebuilder:validation:Optional
+	ContentDeletionPolicy *string `json:"contentDeletionPolicy,omitempty" tf:"-"`
+
 	// Reference to a Folder in oss to populate parentFolderUid.
 	// +kubebuilder:validation:Optional
 	FolderRef *v1.Reference `json:"folderRef,omitempty" tf:"-"`
@@ -100,6 +122,11 @@ type FolderParameters struct {
 	// +kubebuilder:validation:Optional
 	FolderSelector *v1.Selector `json:"folderSelector,omitempty" tf:"-"`
 
+	// (Boolean) Whether to delete the alert rules stored in the folder when the folder is deleted. Grafana refuses to delete a folder containing alert rules otherwise. Defaults to false.
+	// Whether to delete the alert rules stored in the folder when the folder is deleted. Grafana refuses to delete a folder containing alert rules otherwise. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	ForceDeleteRules *bool `json:"forceDeleteRules,omitempty" tf:"-"`
+
 	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
 	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
 	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
@@ -135,6 +162,7 @@ type FolderParameters struct {
 
 	// (String) Unique identifier.
 	// Unique identifier.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="UID is immutable"
 	// +kubebuilder:validation:Optional
 	UID *string `json:"uid,omitempty" tf:"uid,omitempty"`
 }
@@ -170,6 +198,7 @@ type FolderStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.atProvider.url"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
 type Folder struct {
@@ -189,6 +218,40 @@ type FolderList struct {
 	Items           []Folder `json:"items"`
 }
 
+// TypeParentFolderApplied indicates whether the parentFolderUid of a Folder is
+// applied in Grafana, which requires the nestedFolders feature toggle.
+const TypeParentFolderApplied v1.ConditionType = "ParentFolderApplied"
+
+// Reasons the parentFolderUid of a Folder is or is not applied.
+const (
+	ReasonParentFolderApplied   v1.ConditionReason = "ParentFolderApplied"
+	ReasonNestedFoldersDisabled v1.ConditionReason = "NestedFoldersDisabled"
+)
+
+// ParentFolderApplied returns a condition that indicates the parentFolderUid
+// of the Folder is applied in Grafana.
+func ParentFolderApplied() v1.Condition {
+	return v1.Condition{
+		Type:               TypeParentFolderApplied,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonParentFolderApplied,
+	}
+}
+
+// NestedFoldersDisabled returns a condition that indicates the
+// parentFolderUid of the Folder is ignored, because the nestedFolders feature
+// toggle is not enabled in Grafana.
+func NestedFoldersDisabled() v1.Condition {
+	return v1.Condition{
+		Type:               TypeParentFolderApplied,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNestedFoldersDisabled,
+		Message:            "parentFolderUid is ignored, as the nestedFolders feature toggle is not enabled in Grafana",
+	}
+}
+
 // Folder type metadata.
 var (
 	FolderKind             = reflect.TypeOf(Folder{}).Name()
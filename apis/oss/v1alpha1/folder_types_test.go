@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/cel"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	celconfig "k8s.io/apiserver/pkg/apis/cel"
+	"sigs.k8s.io/yaml"
+)
+
+func TestFolderKind(t *testing.T) {
+	if FolderKind != "Folder" {
+		t.Errorf("FolderKind: want %q, got %q", "Folder", FolderKind)
+	}
+	if !strings.Contains(FolderGroupKind, "Folder") {
+		t.Errorf("FolderGroupKind: want it to contain %q, got %q", "Folder", FolderGroupKind)
+	}
+}
+
+// TestKindsMatchTypes ensures every kind of this group is registered for its own Go type.
+func TestKindsMatchTypes(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := SchemeBuilder.AddToScheme(s); err != nil {
+		t.Fatalf("AddToScheme(...): %v", err)
+	}
+
+	cases := map[string]struct {
+		gvk  schema.GroupVersionKind
+		want runtime.Object
+	}{
+		"AlertRule":            {gvk: AlertRuleGroupVersionKind, want: &AlertRule{}},
+		"Annotation":           {gvk: AnnotationGroupVersionKind, want: &Annotation{}},
+		"Dashboard":            {gvk: DashboardGroupVersionKind, want: &Dashboard{}},
+		"DataSource":           {gvk: DataSourceGroupVersionKind, want: &DataSource{}},
+		"DataSourcePermission": {gvk: DataSourcePermissionGroupVersionKind, want: &DataSourcePermission{}},
+		"Folder":               {gvk: FolderGroupVersionKind, want: &Folder{}},
+		"GlobalUser":           {gvk: GlobalUserGroupVersionKind, want: &GlobalUser{}},
+		"LibraryPanel":         {gvk: LibraryPanelGroupVersionKind, want: &LibraryPanel{}},
+		"Organization":         {gvk: OrganizationGroupVersionKind, want: &Organization{}},
+		"OrgPreferences":       {gvk: OrgPreferencesGroupVersionKind, want: &OrgPreferences{}},
+		"RecordingRule":        {gvk: RecordingRuleGroupVersionKind, want: &RecordingRule{}},
+		"Report":               {gvk: ReportGroupVersionKind, want: &Report{}},
+		"Role":                 {gvk: RoleGroupVersionKind, want: &Role{}},
+		"RoleAssignment":       {gvk: RoleAssignmentGroupVersionKind, want: &RoleAssignment{}},
+		"Snapshot":             {gvk: SnapshotGroupVersionKind, want: &Snapshot{}},
+		"APIKey":               {gvk: APIKeyGroupVersionKind, want: &APIKey{}},
+		"SSOSettings":          {gvk: SSOSettingsGroupVersionKind, want: &SSOSettings{}},
+		"TeamMembership":       {gvk: TeamMembershipGroupVersionKind, want: &TeamMembership{}},
+		"TeamPreferences":      {gvk: TeamPreferencesGroupVersionKind, want: &TeamPreferences{}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.gvk.Kind != name {
+				t.Errorf("Kind: want %q, got %q", name, tc.gvk.Kind)
+			}
+			got, err := s.New(tc.gvk)
+			if err != nil {
+				t.Fatalf("s.New(%s): %v", tc.gvk, err)
+			}
+			if reflect.TypeOf(got) != reflect.TypeOf(tc.want) {
+				t.Errorf("s.New(%s): want %T, got %T", tc.gvk, tc.want, got)
+			}
+		})
+	}
+}
+
+// validateUpdate validates an update of a resource with the CEL rules of its CRD, the way the API server does.
+func validateUpdate(t *testing.T, crdFile string, obj, oldObj map[string]interface{}) field.ErrorList {
+	t.Helper()
+	raw, err := os.ReadFile("../../../package/crds/" + crdFile)
+	if err != nil {
+		t.Fatalf("os.ReadFile(...): %v", err)
+	}
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := yaml.Unmarshal(raw, crd); err != nil {
+		t.Fatalf("yaml.Unmarshal(...): %v", err)
+	}
+	internal := &apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(crd.Spec.Versions[0].Schema.OpenAPIV3Schema, internal, nil); err != nil {
+		t.Fatalf("Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(...): %v", err)
+	}
+	structural, err := structuralschema.NewStructural(internal)
+	if err != nil {
+		t.Fatalf("NewStructural(...): %v", err)
+	}
+	validator := cel.NewValidator(structural, true, celconfig.PerCallLimit)
+	errs, _ := validator.Validate(context.Background(), nil, structural, obj, oldObj, celconfig.RuntimeCELCostBudget)
+	return errs
+}
+
+func folderObject(uid string) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "oss.grafana.crossplane.io/v1alpha1",
+		"kind":       "Folder",
+		"metadata":   map[string]interface{}{"name": "example"},
+		"spec": map[string]interface{}{
+			"managementPolicies": []interface{}{"*"},
+			"forProvider": map[string]interface{}{
+				"orgId": "1",
+				"title": "Example",
+				"uid":   uid,
+			},
+		},
+	}
+}
+
+// TestFolderUIDImmutable ensures the API server rejects changes of the uid, which would orphan the folder in Grafana.
+func TestFolderUIDImmutable(t *testing.T) {
+	cases := map[string]struct {
+		oldUID string
+		newUID string
+		want   string
+	}{
+		"Unchanged": {oldUID: "abc", newUID: "abc"},
+		"Changed":   {oldUID: "abc", newUID: "def", want: "UID is immutable"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			errs := validateUpdate(t, "oss.grafana.crossplane.io_folders.yaml", folderObject(tc.newUID), folderObject(tc.oldUID))
+			if tc.want == "" {
+				if len(errs) != 0 {
+					t.Errorf("Validate(...): want no errors, got %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.want) {
+				t.Errorf("Validate(...): want an error containing %q, got %v", tc.want, errs)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type GlobalUserInitParameters struct {
+
+	// (String) The email address of the Grafana user.
+	// The email address of the Grafana user.
+	Email *string `json:"email,omitempty" tf:"email,omitempty"`
+
+	// (Boolean) Whether to make user an admin. Defaults to false.
+	// Whether to make user an admin. Defaults to `false`.
+	IsAdmin *bool `json:"isAdmin,omitempty" tf:"is_admin,omitempty"`
+
+	// (Boolean) Whether the user is disabled and can't log in. Defaults to false.
+	// Whether the user is disabled and can't log in. Defaults to `false`.
+	IsDisabled *bool `json:"isDisabled,omitempty" tf:"-"`
+
+	// (String) The username for the Grafana user. Defaults to the email.
+	// The username for the Grafana user. Defaults to the email.
+	Login *string `json:"login,omitempty" tf:"login,omitempty"`
+
+	// (String) The display name for the Grafana user.
+	// The display name for the Grafana user.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String, Sensitive) The password for the Grafana user. If not set, a random password is generated.
+	// The password for the Grafana user. If not set, a random password is generated.
+	PasswordSecretRef *v1.SecretKeySelector `json:"passwordSecretRef,omitempty" tf:"-"`
+}
+
+type GlobalUserObservation struct {
+
+	// (String) The email address of the Grafana user.
+	// The email address of the Grafana user.
+	Email *string `json:"email,omitempty" tf:"email,omitempty"`
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (Boolean) Whether the user is an admin.
+	// Whether the user is an admin.
+	IsAdmin *bool `json:"isAdmin,omitempty" tf:"is_admin,omitempty"`
+
+	// (Boolean) Whether the user is disabled and can't log in.
+	// Whether the user is disabled and can't log in.
+	IsDisabled *bool `json:"isDisabled,omitempty" tf:"-"`
+
+	// (String) The username for the Grafana user.
+	// The username for the Grafana user.
+	Login *string `json:"login,omitempty" tf:"login,omitempty"`
+
+	// (String) The display name for the Grafana user.
+	// The display name for the Grafana user.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) Salted hash of the password last set by the provider, used to detect changes of the password secret.
+	// Salted hash of the password last set by the provider, used to detect changes of the password secret.
+	PasswordHash *string `json:"passwordHash,omitempty" tf:"-"`
+
+	// (Number) The numerical ID of the Grafana user.
+	// The numerical ID of the Grafana user.
+	UserID *int64 `json:"userId,omitempty" tf:"user_id,omitempty"`
+}
+
+type GlobalUserParameters struct {
+
+	// (String) The email address of the Grafana user.
+	// The email address of the Grafana user.
+	// +kubebuilder:validation:Optional
+	Email *string `json:"email,omitempty" tf:"email,omitempty"`
+
+	// (Boolean) Whether to make user an admin. Defaults to false.
+	// Whether to make user an admin. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	IsAdmin *bool `json:"isAdmin,omitempty" tf:"is_admin,omitempty"`
+
+	// (Boolean) Whether the user is disabled and can't log in. Defaults to false.
+	// Whether the user is disabled and can't log in. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	IsDisabled *bool `json:"isDisabled,omitempty" tf:"-"`
+
+	// (String) The username for the Grafana user. Defaults to the email.
+	// The username for the Grafana user. Defaults to the email.
+	// +kubebuilder:validation:Optional
+	Login *string `json:"login,omitempty" tf:"login,omitempty"`
+
+	// (String) The display name for the Grafana user.
+	// The display name for the Grafana user.
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String, Sensitive) The password for the Grafana user. If not set, a random password is generated.
+	// The password for the Grafana user. If not set, a random password is generated.
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *v1.SecretKeySelector `json:"passwordSecretRef,omitempty" tf:"-"`
+}
+
+// GlobalUserSpec defines the desired state of GlobalUser
+type GlobalUserSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     GlobalUserParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider GlobalUserInitParameters `json:"initProvider,omitempty"`
+}
+
+// GlobalUserStatus defines the observed state of GlobalUser.
+type GlobalUserStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        GlobalUserObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// GlobalUser is the Schema for the GlobalUsers API. Manages server wide Grafana users. This requires a basic auth provider configuration with server admin permissions. Official documentation https://grafana.com/docs/grafana/latest/administration/user-management/server-user-management/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/user/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type GlobalUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.email) || (has(self.initProvider) && has(self.initProvider.email))",message="spec.forProvider.email is a required parameter"
+	Spec   GlobalUserSpec   `json:"spec"`
+	Status GlobalUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GlobalUserList contains a list of GlobalUsers
+type GlobalUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GlobalUser `json:"items"`
+}
+
+// GlobalUser type metadata.
+var (
+	GlobalUserKind             = reflect.TypeOf(GlobalUser{}).Name()
+	GlobalUserGroupKind        = schema.GroupKind{Group: Group, Kind: GlobalUserKind}.String()
+	GlobalUserKindAPIVersion   = GlobalUserKind + "." + SchemeGroupVersion.String()
+	GlobalUserGroupVersionKind = SchemeGroupVersion.WithKind(GlobalUserKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&GlobalUser{}, &GlobalUserList{})
+}
@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type MuteTimingInitParameters struct {
+
+	// (String) The name of the mute timing, notification policies refer to it by this name.
+	// The name of the mute timing, notification policies refer to it by this name.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The time intervals during which notifications are muted, as JSON array. Each interval has the optional
+	// fields times, weekdays, days_of_month, months, years and location, as in the Alertmanager configuration.
+	// The time intervals during which notifications are muted, as JSON array. Each interval has the optional
+	// fields `times`, `weekdays`, `days_of_month`, `months`, `years` and `location`, as in the Alertmanager configuration.
+	TimeIntervalsJSON *string `json:"timeIntervalsJson,omitempty" tf:"-"`
+}
+
+type MuteTimingObservation struct {
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The name of the mute timing, notification policies refer to it by this name.
+	// The name of the mute timing, notification policies refer to it by this name.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+}
+
+type MuteTimingParameters struct {
+
+	// (String) The name of the mute timing, notification policies refer to it by this name.
+	// The name of the mute timing, notification policies refer to it by this name.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Name is immutable"
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The time intervals during which notifications are muted, as JSON array. Each interval has the optional
+	// fields times, weekdays, days_of_month, months, years and location, as in the Alertmanager configuration.
+	// The time intervals during which notifications are muted, as JSON array. Each interval has the optional
+	// fields `times`, `weekdays`, `days_of_month`, `months`, `years` and `location`, as in the Alertmanager configuration.
+	// +kubebuilder:validation:Optional
+	TimeIntervalsJSON *string `json:"timeIntervalsJson,omitempty" tf:"-"`
+}
+
+// MuteTimingSpec defines the desired state of MuteTiming
+type MuteTimingSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     MuteTimingParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider MuteTimingInitParameters `json:"initProvider,omitempty"`
+}
+
+// MuteTimingStatus defines the observed state of MuteTiming.
+type MuteTimingStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        MuteTimingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// MuteTiming is the Schema for the MuteTimings API. Manages a mute timing of Grafana Alerting. Grafana refuses to delete a mute timing that is still used by a notification policy, deleting it fails until it is removed from the policies. Official documentation https://grafana.com/docs/grafana/latest/alerting/manage-notifications/mute-timings/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/alerting_provisioning/#mute-timings
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type MuteTiming struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.name) || (has(self.initProvider) && has(self.initProvider.name))",message="spec.forProvider.name is a required parameter"
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.timeIntervalsJson) || (has(self.initProvider) && has(self.initProvider.timeIntervalsJson))",message="spec.forProvider.timeIntervalsJson is a required parameter"
+	Spec   MuteTimingSpec   `json:"spec"`
+	Status MuteTimingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MuteTimingList contains a list of MuteTimings
+type MuteTimingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MuteTiming `json:"items"`
+}
+
+// MuteTiming type metadata.
+var (
+	MuteTimingKind             = reflect.TypeOf(MuteTiming{}).Name()
+	MuteTimingGroupKind        = schema.GroupKind{Group: Group, Kind: MuteTimingKind}.String()
+	MuteTimingKindAPIVersion   = MuteTimingKind + "." + SchemeGroupVersion.String()
+	MuteTimingGroupVersionKind = SchemeGroupVersion.WithKind(MuteTimingKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&MuteTiming{}, &MuteTimingList{})
+}
@@ -25,6 +25,10 @@ import (
 
 type OrganizationInitParameters struct {
 
+	// (Block) The postal address of the organization. Fields that are not set keep their value in Grafana.
+	// The postal address of the organization. Fields that are not set keep their value in Grafana.
+	Address *OrganizationAddress `json:"address,omitempty" tf:"-"`
+
 	// (String) The login name of the configured default admin user for the Grafana
 	// installation. If unset, this value defaults to admin, the Grafana default.
 	// Defaults to admin.
@@ -42,6 +46,10 @@ type OrganizationInitParameters struct {
 	// +listType=set
 	Admins []*string `json:"admins,omitempty" tf:"admins,omitempty"`
 
+	// How many membership changes are sent to Grafana at once. Defaults to 1, which applies the changes one after another.
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int32 `json:"batchSize,omitempty" tf:"-"`
+
 	// (Boolean) Whether or not to create Grafana users specified in the organization's
 	// membership if they don't already exist in Grafana. If unspecified, this
 	// parameter defaults to true, creating placeholder users with the name, login,
@@ -71,6 +79,11 @@ type OrganizationInitParameters struct {
 	// The display name for the Grafana organization created.
 	Name *string `json:"name,omitempty" tf:"name,omitempty"`
 
+	// (Block) The quotas of the organization. Quotas that are not set are not managed. Requires quotas to be enabled in Grafana.
+	// The quotas of the organization. Quotas that are not set are not managed.
+	// Requires quotas to be enabled in Grafana.
+	Quotas *OrganizationQuotas `json:"quotas,omitempty" tf:"-"`
+
 	// (Set of String) A list of email addresses corresponding to users who should be given none access to the organization.
 	// Note: users specified here must already exist in Grafana, unless 'create_users' is
 	// set to true. This feature is only available in Grafana 10.2+.
@@ -92,6 +105,10 @@ type OrganizationInitParameters struct {
 
 type OrganizationObservation struct {
 
+	// (Block) The postal address of the organization. Only reported if it is managed.
+	// The postal address of the organization. Only reported if it is managed.
+	Address *OrganizationAddress `json:"address,omitempty" tf:"-"`
+
 	// (String) The login name of the configured default admin user for the Grafana
 	// installation. If unset, this value defaults to admin, the Grafana default.
 	// Defaults to admin.
@@ -145,6 +162,15 @@ type OrganizationObservation struct {
 	// The organization id assigned to this organization by Grafana.
 	OrgID *int64 `json:"orgId,omitempty" tf:"org_id,omitempty"`
 
+	// (List of String) The membership changes the next update applies, e.g. "add jane@example.com as Editor", sorted.
+	// The membership changes the next update applies, sorted. Empty if the members are up to date.
+	PendingUserChanges []string `json:"pendingUserChanges,omitempty" tf:"-"`
+
+	// (Block) The quotas of the organization. Quotas that are not set are not managed. Requires quotas to be enabled in Grafana.
+	// The quotas of the organization. Quotas that are not set are not managed.
+	// Requires quotas to be enabled in Grafana.
+	Quotas *OrganizationQuotas `json:"quotas,omitempty" tf:"-"`
+
 	// (Set of String) A list of email addresses corresponding to users who should be given none access to the organization.
 	// Note: users specified here must already exist in Grafana, unless 'create_users' is
 	// set to true. This feature is only available in Grafana 10.2+.
@@ -166,6 +192,10 @@ type OrganizationObservation struct {
 
 type OrganizationParameters struct {
 
+	// (Block) The postal address of the organization. Fields that are not set keep their value in Grafana.
+	// The postal address of the organization. Fields that are not set keep their value in Grafana.
+	Address *OrganizationAddress `json:"address,omitempty" tf:"-"`
+
 	// (String) The login name of the configured default admin user for the Grafana
 	// installation. If unset, this value defaults to admin, the Grafana default.
 	// Defaults to admin.
@@ -185,6 +215,11 @@ type OrganizationParameters struct {
 	// +listType=set
 	Admins []*string `json:"admins,omitempty" tf:"admins,omitempty"`
 
+	// How many membership changes are sent to Grafana at once. Defaults to 1, which applies the changes one after another.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int32 `json:"batchSize,omitempty" tf:"-"`
+
 	// (Boolean) Whether or not to create Grafana users specified in the organization's
 	// membership if they don't already exist in Grafana. If unspecified, this
 	// parameter defaults to true, creating placeholder users with the name, login,
@@ -218,6 +253,12 @@ type OrganizationParameters struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Name is immutable"
 	Name *string `json:"name,omitempty" tf:"name,omitempty"`
 
+	// (Block) The quotas of the organization. Quotas that are not set are not managed. Requires quotas to be enabled in Grafana.
+	// The quotas of the organization. Quotas that are not set are not managed.
+	// Requires quotas to be enabled in Grafana.
+	// +kubebuilder:validation:Optional
+	Quotas *OrganizationQuotas `json:"quotas,omitempty" tf:"-"`
+
 	// (Set of String) A list of email addresses corresponding to users who should be given none access to the organization.
 	// Note: users specified here must already exist in Grafana, unless 'create_users' is
 	// set to true. This feature is only available in Grafana 10.2+.
@@ -239,6 +280,68 @@ type OrganizationParameters struct {
 	Viewers []*string `json:"viewers,omitempty" tf:"viewers,omitempty"`
 }
 
+// OrganizationAddress is the postal address of an organization.
+type OrganizationAddress struct {
+
+	// (String) The first line of the address.
+	// The first line of the address.
+	// +kubebuilder:validation:Optional
+	Address1 *string `json:"address1,omitempty" tf:"-"`
+
+	// (String) The second line of the address.
+	// The second line of the address.
+	// +kubebuilder:validation:Optional
+	Address2 *string `json:"address2,omitempty" tf:"-"`
+
+	// (String) The city of the address.
+	// The city of the address.
+	// +kubebuilder:validation:Optional
+	City *string `json:"city,omitempty" tf:"-"`
+
+	// (String) The state of the address.
+	// The state of the address.
+	// +kubebuilder:validation:Optional
+	State *string `json:"state,omitempty" tf:"-"`
+
+	// (String) The zip code of the address.
+	// The zip code of the address.
+	// +kubebuilder:validation:Optional
+	ZipCode *string `json:"zipCode,omitempty" tf:"-"`
+
+	// (String) The country of the address.
+	// The country of the address.
+	// +kubebuilder:validation:Optional
+	Country *string `json:"country,omitempty" tf:"-"`
+}
+
+// OrganizationQuotas are the limits of an organization. A limit of -1 means unlimited.
+type OrganizationQuotas struct {
+
+	// (Number) The maximum number of alert rules.
+	// The maximum number of alert rules.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Optional
+	AlertRules *int64 `json:"alertRules,omitempty" tf:"-"`
+
+	// (Number) The maximum number of dashboards.
+	// The maximum number of dashboards.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Optional
+	Dashboards *int64 `json:"dashboards,omitempty" tf:"-"`
+
+	// (Number) The maximum number of data sources.
+	// The maximum number of data sources.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Optional
+	DataSources *int64 `json:"dataSources,omitempty" tf:"-"`
+
+	// (Number) The maximum number of users.
+	// The maximum number of users.
+	// +kubebuilder:validation:Minimum=-1
+	// +kubebuilder:validation:Optional
+	Users *int64 `json:"users,omitempty" tf:"-"`
+}
+
 // OrganizationSpec defines the desired state of Organization
 type OrganizationSpec struct {
 	v1.ResourceSpec `json:",inline"`
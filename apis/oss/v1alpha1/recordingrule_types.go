@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type RecordingRuleDefinition struct {
+
+	// (String) The PromQL or LogQL expression to evaluate.
+	// The PromQL or LogQL expression to evaluate.
+	Expr *string `json:"expr" tf:"expr"`
+
+	// (String) The name of the time series to output to.
+	// The name of the time series to output to. Must be a valid metric name.
+	Record *string `json:"record" tf:"record"`
+}
+
+type RecordingRuleInitParameters struct {
+
+	// (String) The UID of the folder that the rule group is stored in.
+	// The UID of the folder that the rule group is stored in.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Folder
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=FolderRef
+	// +crossplane:generate:reference:selectorFieldName=FolderSelector
+	FolderUID *string `json:"folderUid,omitempty" tf:"folder_uid,omitempty"`
+
+	// Reference to a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderRef *v1.Reference `json:"folderRef,omitempty" tf:"-"`
+
+	// Selector for a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderSelector *v1.Selector `json:"folderSelector,omitempty" tf:"-"`
+
+	// (String) The name of the rule group. Defaults to name.
+	// The name of the rule group. Defaults to `name`.
+	GroupName *string `json:"groupName,omitempty" tf:"group_name,omitempty"`
+
+	// (String) The interval at which all rules in the group are evaluated, e.g. 1m. Defaults to 1m.
+	// The interval at which all rules in the group are evaluated, e.g. `1m`. Defaults to `1m`.
+	Interval *string `json:"interval,omitempty" tf:"interval,omitempty"`
+
+	// (String) The name of the recording rules.
+	// The name of the recording rules.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (Block List) The recording rules of the group. They are evaluated in order.
+	// The recording rules of the group. They are evaluated in order.
+	Rules []RecordingRuleDefinition `json:"rules,omitempty" tf:"rules,omitempty"`
+}
+
+type RecordingRuleObservation struct {
+
+	// (String) The UID of the folder that the rule group is stored in.
+	// The UID of the folder that the rule group is stored in.
+	FolderUID *string `json:"folderUid,omitempty" tf:"folder_uid,omitempty"`
+
+	// (String) The name of the rule group.
+	// The name of the rule group.
+	GroupName *string `json:"groupName,omitempty" tf:"group_name,omitempty"`
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The interval at which all rules in the group are evaluated.
+	// The interval at which all rules in the group are evaluated.
+	Interval *string `json:"interval,omitempty" tf:"interval,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (Block List) The recording rules of the group. They are evaluated in order.
+	// The recording rules of the group. They are evaluated in order.
+	Rules []RecordingRuleDefinition `json:"rules,omitempty" tf:"rules,omitempty"`
+}
+
+type RecordingRuleParameters struct {
+
+	// (String) The UID of the folder that the rule group is stored in.
+	// The UID of the folder that the rule group is stored in.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Folder
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=FolderRef
+	// +crossplane:generate:reference:selectorFieldName=FolderSelector
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="FolderUID is immutable"
+	// +kubebuilder:validation:Optional
+	FolderUID *string `json:"folderUid,omitempty" tf:"folder_uid,omitempty"`
+
+	// Reference to a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderRef *v1.Reference `json:"folderRef,omitempty" tf:"-"`
+
+	// Selector for a Folder in oss to populate folderUid.
+	// +kubebuilder:validation:Optional
+	FolderSelector *v1.Selector `json:"folderSelector,omitempty" tf:"-"`
+
+	// (String) The name of the rule group. Defaults to name.
+	// The name of the rule group. Defaults to `name`.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="GroupName is immutable"
+	// +kubebuilder:validation:Optional
+	GroupName *string `json:"groupName,omitempty" tf:"group_name,omitempty"`
+
+	// (String) The interval at which all rules in the group are evaluated, e.g. 1m. Defaults to 1m.
+	// The interval at which all rules in the group are evaluated, e.g. `1m`. Defaults to `1m`.
+	// +kubebuilder:validation:Optional
+	Interval *string `json:"interval,omitempty" tf:"interval,omitempty"`
+
+	// (String) The name of the recording rules.
+	// The name of the recording rules.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Name is immutable"
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (Block List) The recording rules of the group. They are evaluated in order.
+	// The recording rules of the group. They are evaluated in order.
+	// +kubebuilder:validation:Optional
+	Rules []RecordingRuleDefinition `json:"rules,omitempty" tf:"rules,omitempty"`
+}
+
+// RecordingRuleSpec defines the desired state of RecordingRule
+type RecordingRuleSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     RecordingRuleParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider RecordingRuleInitParameters `json:"initProvider,omitempty"`
+}
+
+// RecordingRuleStatus defines the observed state of RecordingRule.
+type RecordingRuleStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        RecordingRuleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// RecordingRule is the Schema for the RecordingRules API. Manages a group of recording rules through the Grafana ruler API, the rules of a group are always written atomically. Official documentation https://grafana.com/docs/grafana/latest/alerting/alerting-rules/create-recording-rules/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type RecordingRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.name) || (has(self.initProvider) && has(self.initProvider.name))",message="spec.forProvider.name is a required parameter"
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.rules) || (has(self.initProvider) && has(self.initProvider.rules))",message="spec.forProvider.rules is a required parameter"
+	Spec   RecordingRuleSpec   `json:"spec"`
+	Status RecordingRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RecordingRuleList contains a list of RecordingRules
+type RecordingRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RecordingRule `json:"items"`
+}
+
+// RecordingRule type metadata.
+var (
+	RecordingRuleKind             = reflect.TypeOf(RecordingRule{}).Name()
+	RecordingRuleGroupKind        = schema.GroupKind{Group: Group, Kind: RecordingRuleKind}.String()
+	RecordingRuleKindAPIVersion   = RecordingRuleKind + "." + SchemeGroupVersion.String()
+	RecordingRuleGroupVersionKind = SchemeGroupVersion.WithKind(RecordingRuleKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RecordingRule{}, &RecordingRuleList{})
+}
@@ -0,0 +1,308 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type ReportSchedule struct {
+
+	// (String) How often the report is sent, one of never, once, hourly, daily, weekly or monthly.
+	// How often the report is sent, one of `never`, `once`, `hourly`, `daily`, `weekly` or `monthly`.
+	// +kubebuilder:validation:Enum=never;once;hourly;daily;weekly;monthly
+	Frequency *string `json:"frequency" tf:"frequency"`
+
+	// (String) The RFC 3339-formatted time of the first report, its time of day in UTC is used for the following ones. Defaults to the time of creation.
+	// The RFC 3339-formatted time of the first report, its time of day in UTC is used for the following ones. Defaults to the time of creation.
+	// +kubebuilder:validation:Format=date-time
+	// +kubebuilder:validation:Optional
+	StartTime *string `json:"startTime,omitempty" tf:"start_time,omitempty"`
+
+	// (String) The RFC 3339-formatted time after which no more reports are sent.
+	// The RFC 3339-formatted time after which no more reports are sent.
+	// +kubebuilder:validation:Format=date-time
+	// +kubebuilder:validation:Optional
+	EndTime *string `json:"endTime,omitempty" tf:"end_time,omitempty"`
+
+	// (Boolean) Whether to send the report only on work days. Only applies to hourly and daily reports. Defaults to false.
+	// Whether to send the report only on work days. Only applies to `hourly` and `daily` reports. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	WorkdaysOnly *bool `json:"workdaysOnly,omitempty" tf:"workdays_only,omitempty"`
+}
+
+type ReportInitParameters struct {
+
+	// (String) The UID of the dashboard the report is generated from.
+	// The UID of the dashboard the report is generated from.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Dashboard
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=DashboardRef
+	// +crossplane:generate:reference:selectorFieldName=DashboardSelector
+	DashboardUID *string `json:"dashboardUid,omitempty" tf:"dashboard_uid,omitempty"`
+
+	// Reference to a Dashboard in oss to populate dashboardUid.
+	// +kubebuilder:validation:Optional
+	DashboardRef *v1.Reference `json:"dashboardRef,omitempty" tf:"-"`
+
+	// Selector for a Dashboard in oss to populate dashboardUid.
+	// +kubebuilder:validation:Optional
+	DashboardSelector *v1.Selector `json:"dashboardSelector,omitempty" tf:"-"`
+
+	// (Set of String) The formats of the report, any of pdf, csv and image. Defaults to pdf.
+	// The formats of the report, any of `pdf`, `csv` and `image`. Defaults to `pdf`.
+	Formats []*string `json:"formats,omitempty" tf:"formats,omitempty"`
+
+	// (Boolean) Whether to include a link to the dashboard in the email. Defaults to true.
+	// Whether to include a link to the dashboard in the email. Defaults to `true`.
+	IncludeDashboardLink *bool `json:"includeDashboardLink,omitempty" tf:"include_dashboard_link,omitempty"`
+
+	// (Boolean) Whether to attach the data of table panels as CSV files. Defaults to false.
+	// Whether to attach the data of table panels as CSV files. Defaults to `false`.
+	IncludeTableCsv *bool `json:"includeTableCsv,omitempty" tf:"include_table_csv,omitempty"`
+
+	// (String) The layout of the PDF, grid or simple. Defaults to grid.
+	// The layout of the PDF, `grid` or `simple`. Defaults to `grid`.
+	// +kubebuilder:validation:Enum=grid;simple
+	Layout *string `json:"layout,omitempty" tf:"layout,omitempty"`
+
+	// (String) The message of the email.
+	// The message of the email.
+	Message *string `json:"message,omitempty" tf:"message,omitempty"`
+
+	// (String) The name of the report.
+	// The name of the report.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The orientation of the PDF, landscape or portrait. Defaults to landscape.
+	// The orientation of the PDF, `landscape` or `portrait`. Defaults to `landscape`.
+	// +kubebuilder:validation:Enum=landscape;portrait
+	Orientation *string `json:"orientation,omitempty" tf:"orientation,omitempty"`
+
+	// (List of String) The email addresses the report is sent to.
+	// The email addresses the report is sent to.
+	Recipients []*string `json:"recipients,omitempty" tf:"recipients,omitempty"`
+
+	// (String) The reply-to address of the email.
+	// The reply-to address of the email.
+	ReplyTo *string `json:"replyTo,omitempty" tf:"reply_to,omitempty"`
+
+	// (Block) When the report is sent.
+	// When the report is sent.
+	Schedule *ReportSchedule `json:"schedule,omitempty" tf:"schedule,omitempty"`
+}
+
+type ReportObservation struct {
+
+	// (String) The UID of the dashboard the report is generated from.
+	// The UID of the dashboard the report is generated from.
+	DashboardUID *string `json:"dashboardUid,omitempty" tf:"dashboard_uid,omitempty"`
+
+	// (Set of String) The formats of the report.
+	// The formats of the report.
+	Formats []*string `json:"formats,omitempty" tf:"formats,omitempty"`
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The name of the report.
+	// The name of the report.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (List of String) The email addresses the report is sent to.
+	// The email addresses the report is sent to.
+	Recipients []*string `json:"recipients,omitempty" tf:"recipients,omitempty"`
+
+	// (Number) The numeric ID of the report computed by Grafana.
+	// The numeric ID of the report computed by Grafana.
+	ReportID *int64 `json:"reportId,omitempty" tf:"report_id,omitempty"`
+
+	// (Block) When the report is sent.
+	// When the report is sent.
+	Schedule *ReportSchedule `json:"schedule,omitempty" tf:"schedule,omitempty"`
+}
+
+type ReportParameters struct {
+
+	// (String) The UID of the dashboard the report is generated from.
+	// The UID of the dashboard the report is generated from.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Dashboard
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=DashboardRef
+	// +crossplane:generate:reference:selectorFieldName=DashboardSelector
+	// +kubebuilder:validation:Optional
+	DashboardUID *string `json:"dashboardUid,omitempty" tf:"dashboard_uid,omitempty"`
+
+	// Reference to a Dashboard in oss to populate dashboardUid.
+	// +kubebuilder:validation:Optional
+	DashboardRef *v1.Reference `json:"dashboardRef,omitempty" tf:"-"`
+
+	// Selector for a Dashboard in oss to populate dashboardUid.
+	// +kubebuilder:validation:Optional
+	DashboardSelector *v1.Selector `json:"dashboardSelector,omitempty" tf:"-"`
+
+	// (Set of String) The formats of the report, any of pdf, csv and image. Defaults to pdf.
+	// The formats of the report, any of `pdf`, `csv` and `image`. Defaults to `pdf`.
+	// +kubebuilder:validation:Optional
+	Formats []*string `json:"formats,omitempty" tf:"formats,omitempty"`
+
+	// (Boolean) Whether to include a link to the dashboard in the email. Defaults to true.
+	// Whether to include a link to the dashboard in the email. Defaults to `true`.
+	// +kubebuilder:validation:Optional
+	IncludeDashboardLink *bool `json:"includeDashboardLink,omitempty" tf:"include_dashboard_link,omitempty"`
+
+	// (Boolean) Whether to attach the data of table panels as CSV files. Defaults to false.
+	// Whether to attach the data of table panels as CSV files. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	IncludeTableCsv *bool `json:"includeTableCsv,omitempty" tf:"include_table_csv,omitempty"`
+
+	// (String) The layout of the PDF, grid or simple. Defaults to grid.
+	// The layout of the PDF, `grid` or `simple`. Defaults to `grid`.
+	// +kubebuilder:validation:Enum=grid;simple
+	// +kubebuilder:validation:Optional
+	Layout *string `json:"layout,omitempty" tf:"layout,omitempty"`
+
+	// (String) The message of the email.
+	// The message of the email.
+	// +kubebuilder:validation:Optional
+	Message *string `json:"message,omitempty" tf:"message,omitempty"`
+
+	// (String) The name of the report.
+	// The name of the report.
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The orientation of the PDF, landscape or portrait. Defaults to landscape.
+	// The orientation of the PDF, `landscape` or `portrait`. Defaults to `landscape`.
+	// +kubebuilder:validation:Enum=landscape;portrait
+	// +kubebuilder:validation:Optional
+	Orientation *string `json:"orientation,omitempty" tf:"orientation,omitempty"`
+
+	// (List of String) The email addresses the report is sent to.
+	// The email addresses the report is sent to.
+	// +kubebuilder:validation:Optional
+	Recipients []*string `json:"recipients,omitempty" tf:"recipients,omitempty"`
+
+	// (String) The reply-to address of the email.
+	// The reply-to address of the email.
+	// +kubebuilder:validation:Optional
+	ReplyTo *string `json:"replyTo,omitempty" tf:"reply_to,omitempty"`
+
+	// (Block) When the report is sent.
+	// When the report is sent.
+	// +kubebuilder:validation:Optional
+	Schedule *ReportSchedule `json:"schedule,omitempty" tf:"schedule,omitempty"`
+}
+
+// ReportSpec defines the desired state of Report
+type ReportSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     ReportParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider ReportInitParameters `json:"initProvider,omitempty"`
+}
+
+// ReportStatus defines the observed state of Report.
+type ReportStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        ReportObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Report is the Schema for the Reports API. Manages scheduled reports of dashboards, which require Grafana Enterprise or Grafana Cloud. Official documentation https://grafana.com/docs/grafana/latest/dashboards/create-reports/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/reporting/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type Report struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.name) || (has(self.initProvider) && has(self.initProvider.name))",message="spec.forProvider.name is a required parameter"
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.recipients) || (has(self.initProvider) && has(self.initProvider.recipients))",message="spec.forProvider.recipients is a required parameter"
+	Spec   ReportSpec   `json:"spec"`
+	Status ReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReportList contains a list of Reports
+type ReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Report `json:"items"`
+}
+
+// Report type metadata.
+var (
+	ReportKind             = reflect.TypeOf(Report{}).Name()
+	ReportGroupKind        = schema.GroupKind{Group: Group, Kind: ReportKind}.String()
+	ReportKindAPIVersion   = ReportKind + "." + SchemeGroupVersion.String()
+	ReportGroupVersionKind = SchemeGroupVersion.WithKind(ReportKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Report{}, &ReportList{})
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type RolePermission struct {
+
+	// (String) The action that is permitted, e.g. dashboards:read.
+	// The action that is permitted, e.g. `dashboards:read`.
+	Action *string `json:"action" tf:"action"`
+
+	// (String) The scope the action is permitted on, e.g. dashboards:uid:abc. Defaults to all resources the action applies to.
+	// The scope the action is permitted on, e.g. `dashboards:uid:abc`. Defaults to all resources the action applies to.
+	// +kubebuilder:validation:Optional
+	Scope *string `json:"scope,omitempty" tf:"scope,omitempty"`
+}
+
+type RoleInitParameters struct {
+
+	// (String) A description of the role.
+	// A description of the role.
+	Description *string `json:"description,omitempty" tf:"description,omitempty"`
+
+	// (String) The name of the role shown in the UI.
+	// The name of the role shown in the UI.
+	DisplayName *string `json:"displayName,omitempty" tf:"display_name,omitempty"`
+
+	// (Boolean) Whether the role is available in all organizations. Defaults to false.
+	// Whether the role is available in all organizations. Defaults to `false`.
+	Global *bool `json:"global,omitempty" tf:"global,omitempty"`
+
+	// (String) The group the role is listed under in the UI.
+	// The group the role is listed under in the UI.
+	Group *string `json:"group,omitempty" tf:"group,omitempty"`
+
+	// (Boolean) Whether the role is hidden in the UI. Defaults to false.
+	// Whether the role is hidden in the UI. Defaults to `false`.
+	Hidden *bool `json:"hidden,omitempty" tf:"hidden,omitempty"`
+
+	// (String) The name of the role, which must be unique in the organization.
+	// The name of the role, which must be unique in the organization.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (Block Set) The permissions of the role. Permissions that are not listed are removed from it.
+	// The permissions of the role. Permissions that are not listed are removed from it.
+	Permissions []RolePermission `json:"permissions,omitempty" tf:"permissions,omitempty"`
+
+	// (String) The unique identifier of the role. Generated by Grafana if not set.
+	// The unique identifier of the role. Generated by Grafana if not set.
+	UID *string `json:"uid,omitempty" tf:"uid,omitempty"`
+}
+
+type RoleObservation struct {
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The name of the role.
+	// The name of the role.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (Block Set) The permissions of the role.
+	// The permissions of the role.
+	Permissions []RolePermission `json:"permissions,omitempty" tf:"permissions,omitempty"`
+
+	// (String) The unique identifier of the role.
+	// The unique identifier of the role.
+	UID *string `json:"uid,omitempty" tf:"uid,omitempty"`
+
+	// (Number) The version of the role, which Grafana increments on every update.
+	// The version of the role, which Grafana increments on every update.
+	Version *int64 `json:"version,omitempty" tf:"version,omitempty"`
+}
+
+type RoleParameters struct {
+
+	// (String) A description of the role.
+	// A description of the role.
+	// +kubebuilder:validation:Optional
+	Description *string `json:"description,omitempty" tf:"description,omitempty"`
+
+	// (String) The name of the role shown in the UI.
+	// The name of the role shown in the UI.
+	// +kubebuilder:validation:Optional
+	DisplayName *string `json:"displayName,omitempty" tf:"display_name,omitempty"`
+
+	// (Boolean) Whether the role is available in all organizations. Defaults to false.
+	// Whether the role is available in all organizations. Defaults to `false`.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Global is immutable"
+	// +kubebuilder:validation:Optional
+	Global *bool `json:"global,omitempty" tf:"global,omitempty"`
+
+	// (String) The group the role is listed under in the UI.
+	// The group the role is listed under in the UI.
+	// +kubebuilder:validation:Optional
+	Group *string `json:"group,omitempty" tf:"group,omitempty"`
+
+	// (Boolean) Whether the role is hidden in the UI. Defaults to false.
+	// Whether the role is hidden in the UI. Defaults to `false`.
+	// +kubebuilder:validation:Optional
+	Hidden *bool `json:"hidden,omitempty" tf:"hidden,omitempty"`
+
+	// (String) The name of the role, which must be unique in the organization.
+	// The name of the role, which must be unique in the organization.
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (Block Set) The permissions of the role. Permissions that are not listed are removed from it.
+	// The permissions of the role. Permissions that are not listed are removed from it.
+	// +kubebuilder:validation:Optional
+	Permissions []RolePermission `json:"permissions,omitempty" tf:"permissions,omitempty"`
+
+	// (String) The unique identifier of the role. Generated by Grafana if not set.
+	// The unique identifier of the role. Generated by Grafana if not set.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="UID is immutable"
+	// +kubebuilder:validation:Optional
+	UID *string `json:"uid,omitempty" tf:"uid,omitempty"`
+}
+
+// RoleSpec defines the desired state of Role
+type RoleSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     RoleParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider RoleInitParameters `json:"initProvider,omitempty"`
+}
+
+// RoleStatus defines the observed state of Role.
+type RoleStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        RoleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Role is the Schema for the Roles API. Manages a custom role of role-based access control, which requires Grafana Enterprise or Grafana Cloud. Official documentation https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/access-control/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/access_control/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type Role struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.name) || (has(self.initProvider) && has(self.initProvider.name))",message="spec.forProvider.name is a required parameter"
+	Spec   RoleSpec   `json:"spec"`
+	Status RoleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RoleList contains a list of Roles
+type RoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Role `json:"items"`
+}
+
+// Role type metadata.
+var (
+	RoleKind             = reflect.TypeOf(Role{}).Name()
+	RoleGroupKind        = schema.GroupKind{Group: Group, Kind: RoleKind}.String()
+	RoleKindAPIVersion   = RoleKind + "." + SchemeGroupVersion.String()
+	RoleGroupVersionKind = SchemeGroupVersion.WithKind(RoleKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+}
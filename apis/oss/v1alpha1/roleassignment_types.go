@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Assignment assigns the role to a single user, team or service account.
+// +kubebuilder:validation:XValidation:rule="self.type != 'user' || has(self.login) || has(self.email)",message="login or email is required if type is user"
+// +kubebuilder:validation:XValidation:rule="self.type != 'team' || has(self.team)",message="team is required if type is team"
+// +kubebuilder:validation:XValidation:rule="self.type != 'serviceAccount' || has(self.serviceAccountId)",message="serviceAccountId is required if type is serviceAccount"
+type Assignment struct {
+
+	// (String) The type of the assignee, one of user, team or serviceAccount.
+	// The type of the assignee, one of `user`, `team` or `serviceAccount`.
+	// +kubebuilder:validation:Enum=user;team;serviceAccount
+	Type *string `json:"type" tf:"type"`
+
+	// (String) The login of the user, if the type is user.
+	// The login of the user, if the type is `user`.
+	// +kubebuilder:validation:Optional
+	Login *string `json:"login,omitempty" tf:"login,omitempty"`
+
+	// (String) The email of the user, if the type is user and no login is set.
+	// The email of the user, if the type is `user` and no login is set.
+	// +kubebuilder:validation:Optional
+	Email *string `json:"email,omitempty" tf:"email,omitempty"`
+
+	// (String) The ID or UID of the team, if the type is team.
+	// The ID or UID of the team, if the type is `team`.
+	// +kubebuilder:validation:Optional
+	Team *string `json:"team,omitempty" tf:"team,omitempty"`
+
+	// (Number) The ID of the service account, if the type is serviceAccount.
+	// The ID of the service account, if the type is `serviceAccount`.
+	// +kubebuilder:validation:Optional
+	ServiceAccountID *int64 `json:"serviceAccountId,omitempty" tf:"service_account_id,omitempty"`
+}
+
+type RoleAssignmentInitParameters struct {
+
+	// (Block List) The users, teams and service accounts the role is assigned to, in addition to users, teams and serviceAccounts.
+	// The users, teams and service accounts the role is assigned to, in addition to `users`, `teams` and `serviceAccounts`.
+	Assignments []Assignment `json:"assignments,omitempty" tf:"assignments,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The UID of the role that is assigned.
+	// The UID of the role that is assigned.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Role
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=RoleRef
+	// +crossplane:generate:reference:selectorFieldName=RoleSelector
+	RoleUID *string `json:"roleUid,omitempty" tf:"role_uid,omitempty"`
+
+	// Reference to a Role in oss to populate roleUid.
+	// +kubebuilder:validation:Optional
+	RoleRef *v1.Reference `json:"roleRef,omitempty" tf:"-"`
+
+	// Selector for a Role in oss to populate roleUid.
+	// +kubebuilder:validation:Optional
+	RoleSelector *v1.Selector `json:"roleSelector,omitempty" tf:"-"`
+
+	// (Set of Number) The IDs of the service accounts the role is assigned to.
+	// The IDs of the service accounts the role is assigned to.
+	ServiceAccounts []*int64 `json:"serviceAccounts,omitempty" tf:"service_accounts,omitempty"`
+
+	// (Set of String) The IDs or UIDs of the teams the role is assigned to.
+	// The IDs or UIDs of the teams the role is assigned to.
+	Teams []*string `json:"teams,omitempty" tf:"teams,omitempty"`
+
+	// (Set of Number) The IDs of the users the role is assigned to.
+	// The IDs of the users the role is assigned to.
+	Users []*int64 `json:"users,omitempty" tf:"users,omitempty"`
+}
+
+type RoleAssignmentObservation struct {
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (String) The UID of the role that is assigned.
+	// The UID of the role that is assigned.
+	RoleUID *string `json:"roleUid,omitempty" tf:"role_uid,omitempty"`
+
+	// (Set of Number) The IDs of the service accounts the role is assigned to.
+	// The IDs of the service accounts the role is assigned to.
+	ServiceAccounts []*int64 `json:"serviceAccounts,omitempty" tf:"service_accounts,omitempty"`
+
+	// (Set of Number) The IDs of the teams the role is assigned to.
+	// The IDs of the teams the role is assigned to.
+	Teams []*int64 `json:"teams,omitempty" tf:"teams,omitempty"`
+
+	// (Set of Number) The IDs of the users the role is assigned to.
+	// The IDs of the users the role is assigned to.
+	Users []*int64 `json:"users,omitempty" tf:"users,omitempty"`
+}
+
+type RoleAssignmentParameters struct {
+
+	// (Block List) The users, teams and service accounts the role is assigned to, in addition to users, teams and serviceAccounts.
+	// The users, teams and service accounts the role is assigned to, in addition to `users`, `teams` and `serviceAccounts`.
+	// +kubebuilder:validation:Optional
+	Assignments []Assignment `json:"assignments,omitempty" tf:"assignments,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The UID of the role that is assigned.
+	// The UID of the role that is assigned.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Role
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=RoleRef
+	// +crossplane:generate:reference:selectorFieldName=RoleSelector
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="RoleUID is immutable"
+	// +kubebuilder:validation:Optional
+	RoleUID *string `json:"roleUid,omitempty" tf:"role_uid,omitempty"`
+
+	// Reference to a Role in oss to populate roleUid.
+	// +kubebuilder:validation:Optional
+	RoleRef *v1.Reference `json:"roleRef,omitempty" tf:"-"`
+
+	// Selector for a Role in oss to populate roleUid.
+	// +kubebuilder:validation:Optional
+	RoleSelector *v1.Selector `json:"roleSelector,omitempty" tf:"-"`
+
+	// (Set of Number) The IDs of the service accounts the role is assigned to.
+	// The IDs of the service accounts the role is assigned to.
+	// +kubebuilder:validation:Optional
+	ServiceAccounts []*int64 `json:"serviceAccounts,omitempty" tf:"service_accounts,omitempty"`
+
+	// (Set of String) The IDs or UIDs of the teams the role is assigned to.
+	// The IDs or UIDs of the teams the role is assigned to.
+	// +kubebuilder:validation:Optional
+	Teams []*string `json:"teams,omitempty" tf:"teams,omitempty"`
+
+	// (Set of Number) The IDs of the users the role is assigned to.
+	// The IDs of the users the role is assigned to.
+	// +kubebuilder:validation:Optional
+	Users []*int64 `json:"users,omitempty" tf:"users,omitempty"`
+}
+
+// RoleAssignmentSpec defines the desired state of RoleAssignment
+type RoleAssignmentSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     RoleAssignmentParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider RoleAssignmentInitParameters `json:"initProvider,omitempty"`
+}
+
+// RoleAssignmentStatus defines the observed state of RoleAssignment.
+type RoleAssignmentStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        RoleAssignmentObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// RoleAssignment is the Schema for the RoleAssignments API. Manages the users, teams and service accounts a role is assigned to. Assignments of the role that are not listed are removed. Note: This resource is available only with Grafana Enterprise or Grafana Cloud. Official documentation https://grafana.com/docs/grafana/latest/administration/roles-and-permissions/access-control/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/access_control/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type RoleAssignment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.roleUid) || (has(self.initProvider) && has(self.initProvider.roleUid))",message="spec.forProvider.roleUid is a required parameter"
+	Spec   RoleAssignmentSpec   `json:"spec"`
+	Status RoleAssignmentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RoleAssignmentList contains a list of RoleAssignments
+type RoleAssignmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RoleAssignment `json:"items"`
+}
+
+// RoleAssignment type metadata.
+var (
+	RoleAssignmentKind             = reflect.TypeOf(RoleAssignment{}).Name()
+	RoleAssignmentGroupKind        = schema.GroupKind{Group: Group, Kind: RoleAssignmentKind}.String()
+	RoleAssignmentKindAPIVersion   = RoleAssignmentKind + "." + SchemeGroupVersion.String()
+	RoleAssignmentGroupVersionKind = SchemeGroupVersion.WithKind(RoleAssignmentKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RoleAssignment{}, &RoleAssignmentList{})
+}
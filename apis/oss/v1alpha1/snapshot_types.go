@@ -0,0 +1,187 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type SnapshotInitParameters struct {
+
+	// (String) The complete dashboard model JSON of the snapshot, including the data of its panels. Grafana can't update snapshots, so the snapshot is deleted and re-created when this changes.
+	// The complete dashboard model JSON of the snapshot, including the data of its panels. Grafana can't update snapshots, so the snapshot is deleted and re-created when this changes.
+	DashboardJSON *string `json:"dashboardJson,omitempty" tf:"dashboard_json,omitempty"`
+
+	// (String) How long the snapshot is kept after it was created, e.g. 24h. The snapshot never expires if unset.
+	// How long the snapshot is kept after it was created, e.g. `24h`. The snapshot never expires if unset.
+	Expires *metav1.Duration `json:"expires,omitempty" tf:"expires,omitempty"`
+
+	// (Boolean) Whether the snapshot is published to the external snapshot server configured in Grafana. Defaults to false.
+	// Whether the snapshot is published to the external snapshot server configured in Grafana. Defaults to `false`.
+	External *bool `json:"external,omitempty" tf:"external,omitempty"`
+
+	// (String) The name of the snapshot.
+	// The name of the snapshot.
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+}
+
+type SnapshotObservation struct {
+
+	// (String) The key that deletes the snapshot.
+	// The key that deletes the snapshot.
+	DeleteKey *string `json:"deleteKey,omitempty" tf:"delete_key,omitempty"`
+
+	// (String) When the snapshot expires, as RFC 3339 timestamp.
+	// When the snapshot expires, as RFC 3339 timestamp.
+	ExpiresAt *string `json:"expiresAt,omitempty" tf:"expires_at,omitempty"`
+
+	// (String) The ID of this resource.
+	// The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The key of the snapshot, which is part of its URL.
+	// The key of the snapshot, which is part of its URL.
+	Key *string `json:"key,omitempty" tf:"key,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (String) The URL the snapshot is shared at.
+	// The URL the snapshot is shared at.
+	URL *string `json:"url,omitempty" tf:"url,omitempty"`
+}
+
+type SnapshotParameters struct {
+
+	// (String) The complete dashboard model JSON of the snapshot, including the data of its panels. Grafana can't update snapshots, so the snapshot is deleted and re-created when this changes.
+	// The complete dashboard model JSON of the snapshot, including the data of its panels. Grafana can't update snapshots, so the snapshot is deleted and re-created when this changes.
+	// +kubebuilder:validation:Optional
+	DashboardJSON *string `json:"dashboardJson,omitempty" tf:"dashboard_json,omitempty"`
+
+	// (String) How long the snapshot is kept after it was created, e.g. 24h. The snapshot never expires if unset.
+	// How long the snapshot is kept after it was created, e.g. `24h`. The snapshot never expires if unset.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Expires is immutable"
+	// +kubebuilder:validation:Optional
+	Expires *metav1.Duration `json:"expires,omitempty" tf:"expires,omitempty"`
+
+	// (Boolean) Whether the snapshot is published to the external snapshot server configured in Grafana. Defaults to false.
+	// Whether the snapshot is published to the external snapshot server configured in Grafana. Defaults to `false`.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="External is immutable"
+	// +kubebuilder:validation:Optional
+	External *bool `json:"external,omitempty" tf:"external,omitempty"`
+
+	// (String) The name of the snapshot.
+	// The name of the snapshot.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Name is immutable"
+	// +kubebuilder:validation:Optional
+	Name *string `json:"name,omitempty" tf:"name,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+}
+
+// SnapshotSpec defines the desired state of Snapshot
+type SnapshotSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     SnapshotParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider SnapshotInitParameters `json:"initProvider,omitempty"`
+}
+
+// SnapshotStatus defines the observed state of Snapshot.
+type SnapshotStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        SnapshotObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// Snapshot is the Schema for the Snapshots API. Manages a dashboard snapshot, a static copy of a dashboard including its data that can be shared with people without access to Grafana. Official documentation https://grafana.com/docs/grafana/latest/dashboards/share-dashboards-panels/#publish-a-snapshotHTTP API https://grafana.com/docs/grafana/latest/developers/http_api/snapshot/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type Snapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.dashboardJson) || (has(self.initProvider) && has(self.initProvider.dashboardJson))",message="spec.forProvider.dashboardJson is a required parameter"
+	Spec   SnapshotSpec   `json:"spec"`
+	Status SnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SnapshotList contains a list of Snapshots
+type SnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Snapshot `json:"items"`
+}
+
+// Snapshot type metadata.
+var (
+	SnapshotKind             = reflect.TypeOf(Snapshot{}).Name()
+	SnapshotGroupKind        = schema.GroupKind{Group: Group, Kind: SnapshotKind}.String()
+	SnapshotKindAPIVersion   = SnapshotKind + "." + SchemeGroupVersion.String()
+	SnapshotGroupVersionKind = SchemeGroupVersion.WithKind(SnapshotKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Snapshot{}, &SnapshotList{})
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type SSOSettingsInitParameters struct {
+
+	// (String) The key of the SSO provider, one of github, gitlab, google, generic_oauth, azuread, okta, grafana_com or saml.
+	// The key of the SSO provider, one of `github`, `gitlab`, `google`, `generic_oauth`, `azuread`, `okta`, `grafana_com` or `saml`.
+	// +kubebuilder:validation:Enum=github;gitlab;google;generic_oauth;azuread;okta;grafana_com;saml
+	Provider *string `json:"provider,omitempty" tf:"provider,omitempty"`
+
+	// (String) Serialized JSON string containing the settings of the provider, e.g. clientId, authUrl or allowedDomains. Settings that are not listed keep the value Grafana defaults them to. Note that keys in this map are camelCased.
+	// Serialized JSON string containing the settings of the provider, e.g. `clientId`, `authUrl` or `allowedDomains`. Settings that are not listed keep the value Grafana defaults them to. Note that keys in this map are camelCased.
+	Settings *string `json:"settings,omitempty" tf:"settings,omitempty"`
+
+	// (Map of String, Sensitive) Secret whose keys are secret settings of the provider, e.g. clientSecret or privateKey. They are merged into the settings.
+	// Secret whose keys are secret settings of the provider, e.g. `clientSecret` or `privateKey`. They are merged into the settings.
+	// +kubebuilder:validation:Optional
+	SecretsRef *v1.SecretReference `json:"secretsRef,omitempty" tf:"-"`
+
+	// (String, Deprecated) The key of the SSO provider. Deprecated, use provider instead.
+	// The key of the SSO provider. Deprecated, use `provider` instead.
+	// +kubebuilder:validation:Enum=github;gitlab;google;generic_oauth;azuread;okta;grafana_com;saml
+	ProviderName *string `json:"providerName,omitempty" tf:"provider_name,omitempty"`
+
+	// (String, Deprecated) Serialized JSON string containing the settings of the provider. Deprecated, use settings instead.
+	// Serialized JSON string containing the settings of the provider. Deprecated, use `settings` instead.
+	SettingsEncoded *string `json:"settingsEncoded,omitempty" tf:"settings_encoded,omitempty"`
+
+	// (String, Sensitive, Deprecated) Serialized JSON string containing the secret settings of the provider. They are merged into the settings. Deprecated, use secretsRef instead.
+	// Serialized JSON string containing the secret settings of the provider. They are merged into the settings. Deprecated, use `secretsRef` instead.
+	// +kubebuilder:validation:Optional
+	SecureSettingsSecretRef *v1.SecretKeySelector `json:"secureSettingsSecretRef,omitempty" tf:"-"`
+}
+
+type SSOSettingsObservation struct {
+
+	// (String) The ID of this resource.
+	// The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The key of the SSO provider.
+	// The key of the SSO provider.
+	ProviderName *string `json:"providerName,omitempty" tf:"provider_name,omitempty"`
+
+	// (String) Hex encoded HMAC-SHA256 of the secure settings, signed with the key referenced by the ProviderConfig.
+	// Hex encoded HMAC-SHA256 of the secure settings, signed with the key referenced by the ProviderConfig. Used to detect changes of the secret values, which Grafana does not return.
+	SecureSettingsHash *string `json:"secureSettingsHash,omitempty" tf:"secure_settings_hash,omitempty"`
+
+	// (String) Where Grafana reads the settings from, database once they were set by the provider.
+	// Where Grafana reads the settings from, `database` once they were set by the provider.
+	Source *string `json:"source,omitempty" tf:"source,omitempty"`
+}
+
+type SSOSettingsParameters struct {
+
+	// (String) The key of the SSO provider, one of github, gitlab, google, generic_oauth, azuread, okta, grafana_com or saml.
+	// The key of the SSO provider, one of `github`, `gitlab`, `google`, `generic_oauth`, `azuread`, `okta`, `grafana_com` or `saml`.
+	// +kubebuilder:validation:Enum=github;gitlab;google;generic_oauth;azuread;okta;grafana_com;saml
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Provider is immutable"
+	// +kubebuilder:validation:Optional
+	Provider *string `json:"provider,omitempty" tf:"provider,omitempty"`
+
+	// (String) Serialized JSON string containing the settings of the provider, e.g. clientId, authUrl or allowedDomains. Settings that are not listed keep the value Grafana defaults them to. Note that keys in this map are camelCased.
+	// Serialized JSON string containing the settings of the provider, e.g. `clientId`, `authUrl` or `allowedDomains`. Settings that are not listed keep the value Grafana defaults them to. Note that keys in this map are camelCased.
+	// +kubebuilder:validation:Optional
+	Settings *string `json:"settings,omitempty" tf:"settings,omitempty"`
+
+	// (Map of String, Sensitive) Secret whose keys are secret settings of the provider, e.g. clientSecret or privateKey. They are merged into the settings.
+	// Secret whose keys are secret settings of the provider, e.g. `clientSecret` or `privateKey`. They are merged into the settings.
+	// +kubebuilder:validation:Optional
+	SecretsRef *v1.SecretReference `json:"secretsRef,omitempty" tf:"-"`
+
+	// (String, Deprecated) The key of the SSO provider. Deprecated, use provider instead.
+	// The key of the SSO provider. Deprecated, use `provider` instead.
+	// +kubebuilder:validation:Enum=github;gitlab;google;generic_oauth;azuread;okta;grafana_com;saml
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ProviderName is immutable"
+	// +kubebuilder:validation:Optional
+	ProviderName *string `json:"providerName,omitempty" tf:"provider_name,omitempty"`
+
+	// (String, Deprecated) Serialized JSON string containing the settings of the provider. Deprecated, use settings instead.
+	// Serialized JSON string containing the settings of the provider. Deprecated, use `settings` instead.
+	// +kubebuilder:validation:Optional
+	SettingsEncoded *string `json:"settingsEncoded,omitempty" tf:"settings_encoded,omitempty"`
+
+	// (String, Sensitive, Deprecated) Serialized JSON string containing the secret settings of the provider. They are merged into the settings. Deprecated, use secretsRef instead.
+	// Serialized JSON string containing the secret settings of the provider. They are merged into the settings. Deprecated, use `secretsRef` instead.
+	// +kubebuilder:validation:Optional
+	SecureSettingsSecretRef *v1.SecretKeySelector `json:"secureSettingsSecretRef,omitempty" tf:"-"`
+}
+
+// SSOSettingsSpec defines the desired state of SSOSettings
+type SSOSettingsSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     SSOSettingsParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider SSOSettingsInitParameters `json:"initProvider,omitempty"`
+}
+
+// SSOSettingsStatus defines the observed state of SSOSettings.
+type SSOSettingsStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        SSOSettingsObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// SSOSettings is the Schema for the SSOSettings API. Manages the settings of an SSO provider, e.g. OAuth or SAML. Deleting the resource resets the provider to the settings of the Grafana configuration file. Official documentation https://grafana.com/docs/grafana/latest/setup-grafana/configure-security/configure-authentication/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/sso-settings/
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type SSOSettings struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.provider) || has(self.forProvider.providerName) || (has(self.initProvider) && (has(self.initProvider.provider) || has(self.initProvider.providerName)))",message="spec.forProvider.provider is a required parameter"
+	Spec   SSOSettingsSpec   `json:"spec"`
+	Status SSOSettingsStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SSOSettingsList contains a list of SSOSettings
+type SSOSettingsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SSOSettings `json:"items"`
+}
+
+// SSOSettings type metadata.
+var (
+	SSOSettingsKind             = reflect.TypeOf(SSOSettings{}).Name()
+	SSOSettingsGroupKind        = schema.GroupKind{Group: Group, Kind: SSOSettingsKind}.String()
+	SSOSettingsKindAPIVersion   = SSOSettingsKind + "." + SchemeGroupVersion.String()
+	SSOSettingsGroupVersionKind = SchemeGroupVersion.WithKind(SSOSettingsKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&SSOSettings{}, &SSOSettingsList{})
+}
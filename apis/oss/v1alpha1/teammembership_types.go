@@ -0,0 +1,217 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type TeamMember struct {
+
+	// (String) The email address of the user.
+	// The email address of the user.
+	Email *string `json:"email" tf:"email"`
+
+	// (String) The role of the user in the team, either Member or Admin. Defaults to Member.
+	// The role of the user in the team, either `Member` or `Admin`. Defaults to `Member`.
+	// +kubebuilder:validation:Enum=Member;Admin
+	// +kubebuilder:validation:Optional
+	Role *string `json:"role,omitempty" tf:"role,omitempty"`
+}
+
+type TeamMembershipInitParameters struct {
+
+	// (Boolean) Whether or not to create Grafana users specified as members of the team if they don't already
+	// exist in Grafana. If unspecified, this parameter defaults to true, creating placeholder users with the name,
+	// login, and email set to the email of the user, and a random password. Setting this option to false will cause
+	// an error to be thrown for any users that do not already exist in Grafana.
+	// Defaults to true.
+	// Whether or not to create Grafana users specified as members of the team if they don't already
+	// exist in Grafana. If unspecified, this parameter defaults to true, creating placeholder users with the name,
+	// login, and email set to the email of the user, and a random password. Setting this option to false will cause
+	// an error to be thrown for any users that do not already exist in Grafana.
+	// Defaults to `true`.
+	CreateUsers *bool `json:"createUsers,omitempty" tf:"create_users,omitempty"`
+
+	// (Block List) The members of the team. Members of the team that are not listed are removed from it.
+	// The members of the team. Members of the team that are not listed are removed from it.
+	Members []TeamMember `json:"members,omitempty" tf:"members,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The ID or UID of the team.
+	// The ID or UID of the team.
+	TeamID *string `json:"teamId,omitempty" tf:"team_id,omitempty"`
+}
+
+type TeamMembershipObservation struct {
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (Block List) The members of the team. Members of the team that are not listed are removed from it.
+	// The members of the team. Members of the team that are not listed are removed from it.
+	Members []TeamMember `json:"members,omitempty" tf:"members,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (Number) The numeric ID of the team.
+	// The numeric ID of the team.
+	TeamID *int64 `json:"teamId,omitempty" tf:"team_id,omitempty"`
+}
+
+type TeamMembershipParameters struct {
+
+	// (Boolean) Whether or not to create Grafana users specified as members of the team if they don't already
+	// exist in Grafana. If unspecified, this parameter defaults to true, creating placeholder users with the name,
+	// login, and email set to the email of the user, and a random password. Setting this option to false will cause
+	// an error to be thrown for any users that do not already exist in Grafana.
+	// Defaults to true.
+	// Whether or not to create Grafana users specified as members of the team if they don't already
+	// exist in Grafana. If unspecified, this parameter defaults to true, creating placeholder users with the name,
+	// login, and email set to the email of the user, and a random password. Setting this option to false will cause
+	// an error to be thrown for any users that do not already exist in Grafana.
+	// Defaults to `true`.
+	// +kubebuilder:validation:Optional
+	CreateUsers *bool `json:"createUsers,omitempty" tf:"create_users,omitempty"`
+
+	// (Block List) The members of the team. Members of the team that are not listed are removed from it.
+	// The members of the team. Members of the team that are not listed are removed from it.
+	// +kubebuilder:validation:Optional
+	Members []TeamMember `json:"members,omitempty" tf:"members,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The ID or UID of the team.
+	// The ID or UID of the team.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="TeamID is immutable"
+	// +kubebuilder:validation:Optional
+	TeamID *string `json:"teamId,omitempty" tf:"team_id,omitempty"`
+}
+
+// TeamMembershipSpec defines the desired state of TeamMembership
+type TeamMembershipSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     TeamMembershipParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider TeamMembershipInitParameters `json:"initProvider,omitempty"`
+}
+
+// TeamMembershipStatus defines the observed state of TeamMembership.
+type TeamMembershipStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        TeamMembershipObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// TeamMembership is the Schema for the TeamMemberships API. Manages the members of an existing Grafana team, independent of the team itself. There must only be one TeamMembership per team, deleting it removes its members from the team. Official documentation https://grafana.com/docs/grafana/latest/administration/team-management/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/team/#add-team-member
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,grafana}
+type TeamMembership struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.teamId) || (has(self.initProvider) && has(self.initProvider.teamId))",message="spec.forProvider.teamId is a required parameter"
+	Spec   TeamMembershipSpec   `json:"spec"`
+	Status TeamMembershipStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamMembershipList contains a list of TeamMemberships
+type TeamMembershipList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamMembership `json:"items"`
+}
+
+// TeamMembership type metadata.
+var (
+	TeamMembershipKind             = reflect.TypeOf(TeamMembership{}).Name()
+	TeamMembershipGroupKind        = schema.GroupKind{Group: Group, Kind: TeamMembershipKind}.String()
+	TeamMembershipKindAPIVersion   = TeamMembershipKind + "." + SchemeGroupVersion.String()
+	TeamMembershipGroupVersionKind = SchemeGroupVersion.WithKind(TeamMembershipKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TeamMembership{}, &TeamMembershipList{})
+}
+
+// TeamId extracts the numeric ID of the team from a TeamMembership's status, as the team itself is not managed by a
+// resource of the provider.
+func TeamId() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		paved, err := fieldpath.PaveObject(mg)
+		if err != nil {
+			return ""
+		}
+		r, err := paved.GetInteger("status.atProvider.teamId")
+		if err != nil {
+			return ""
+		}
+		return strconv.FormatInt(r, 10)
+	}
+}
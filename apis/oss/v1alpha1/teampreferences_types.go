@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2023 The Crossplane Authors <https://crossplane.io>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+/*
+Copyright 2022 Upbound Inc.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+type TeamPreferencesInitParameters struct {
+
+	// (String) The team home dashboard UID. This is only available in Grafana 9.0+.
+	// The team home dashboard UID. This is only available in Grafana 9.0+.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Dashboard
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=HomeDashboardRef
+	// +crossplane:generate:reference:selectorFieldName=HomeDashboardSelector
+	HomeDashboardUID *string `json:"homeDashboardUid,omitempty" tf:"home_dashboard_uid,omitempty"`
+
+	// Reference to a Dashboard in oss to populate homeDashboardUid.
+	// +kubebuilder:validation:Optional
+	HomeDashboardRef *v1.Reference `json:"homeDashboardRef,omitempty" tf:"-"`
+
+	// Selector for a Dashboard in oss to populate homeDashboardUid.
+	// +kubebuilder:validation:Optional
+	HomeDashboardSelector *v1.Selector `json:"homeDashboardSelector,omitempty" tf:"-"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The ID or UID of the team.
+	// The ID or UID of the team.
+	TeamID *string `json:"teamId,omitempty" tf:"team_id,omitempty"`
+
+	// (String) The team theme. Available values are light, dark, system, or an empty string for the default.
+	// The team theme. Available values are `light`, `dark`, `system`, or an empty string for the default.
+	// +kubebuilder:validation:Enum=light;dark;system;""
+	Theme *string `json:"theme,omitempty" tf:"theme,omitempty"`
+
+	// (String) The team timezone. Available values are utc, browser, or an empty string for the default.
+	// The team timezone. Available values are `utc`, `browser`, or an empty string for the default.
+	Timezone *string `json:"timezone,omitempty" tf:"timezone,omitempty"`
+
+	// (String) The team week start day. Available values are sunday, monday, saturday, or an empty string for the default.
+	// The team week start day. Available values are `sunday`, `monday`, `saturday`, or an empty string for the default.
+	// +kubebuilder:validation:Enum=sunday;monday;saturday;""
+	WeekStart *string `json:"weekStart,omitempty" tf:"week_start,omitempty"`
+}
+
+type TeamPreferencesObservation struct {
+
+	// (String) The team home dashboard UID. This is only available in Grafana 9.0+.
+	// The team home dashboard UID. This is only available in Grafana 9.0+.
+	HomeDashboardUID *string `json:"homeDashboardUid,omitempty" tf:"home_dashboard_uid,omitempty"`
+
+	// (String) The ID of this resource.
+	ID *string `json:"id,omitempty" tf:"id,omitempty"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// (Number) The numeric ID of the team.
+	// The numeric ID of the team.
+	TeamID *int64 `json:"teamId,omitempty" tf:"team_id,omitempty"`
+
+	// (String) The team theme. Available values are light, dark, system, or an empty string for the default.
+	// The team theme. Available values are `light`, `dark`, `system`, or an empty string for the default.
+	Theme *string `json:"theme,omitempty" tf:"theme,omitempty"`
+
+	// (String) The team timezone. Available values are utc, browser, or an empty string for the default.
+	// The team timezone. Available values are `utc`, `browser`, or an empty string for the default.
+	Timezone *string `json:"timezone,omitempty" tf:"timezone,omitempty"`
+
+	// (String) The team week start day. Available values are sunday, monday, saturday, or an empty string for the default.
+	// The team week start day. Available values are `sunday`, `monday`, `saturday`, or an empty string for the default.
+	WeekStart *string `json:"weekStart,omitempty" tf:"week_start,omitempty"`
+}
+
+type TeamPreferencesParameters struct {
+
+	// (String) The team home dashboard UID. This is only available in Grafana 9.0+.
+	// The team home dashboard UID. This is only available in Grafana 9.0+.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Dashboard
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.UIDExtractor()
+	// +crossplane:generate:reference:refFieldName=HomeDashboardRef
+	// +crossplane:generate:reference:selectorFieldName=HomeDashboardSelector
+	// +kubebuilder:validation:Optional
+	HomeDashboardUID *string `json:"homeDashboardUid,omitempty" tf:"home_dashboard_uid,omitempty"`
+
+	// Reference to a Dashboard in oss to populate homeDashboardUid.
+	// +kubebuilder:validation:Optional
+	HomeDashboardRef *v1.Reference `json:"homeDashboardRef,omitempty" tf:"-"`
+
+	// Selector for a Dashboard in oss to populate homeDashboardUid.
+	// +kubebuilder:validation:Optional
+	HomeDashboardSelector *v1.Selector `json:"homeDashboardSelector,omitempty" tf:"-"`
+
+	// (String) The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// The Organization ID. If not set, the Org ID defined in the provider block will be used.
+	// +crossplane:generate:reference:type=github.com/argannor/provider-grafana/apis/oss/v1alpha1.Organization
+	// +crossplane:generate:reference:refFieldName=OrganizationRef
+	// +crossplane:generate:reference:selectorFieldName=OrganizationSelector
+	// +crossplane:generate:reference:extractor=github.com/argannor/provider-grafana/apis/oss/v1alpha1.OrgId()
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="OrgID is immutable"
+	// +kubebuilder:validation:Optional
+	OrgID *string `json:"orgId,omitempty" tf:"org_id,omitempty"`
+
+	// Reference to a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationRef *v1.Reference `json:"organizationRef,omitempty" tf:"-"`
+
+	// Selector for a Organization in oss to populate orgId.
+	// +kubebuilder:validation:Optional
+	OrganizationSelector *v1.Selector `json:"organizationSelector,omitempty" tf:"-"`
+
+	// (String) The ID or UID of the team.
+	// The ID or UID of the team.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="TeamID is immutable"
+	// +kubebuilder:validation:Optional
+	TeamID *string `json:"teamId,omitempty" tf:"team_id,omitempty"`
+
+	// (String) The team theme. Available values are light, dark, system, or an empty string for the default.
+	// The team theme. Available values are `light`, `dark`, `system`, or an empty string for the default.
+	// +kubebuilder:validation:Enum=light;dark;system;""
+	// +kubebuilder:validation:Optional
+	Theme *string `json:"theme,omitempty" tf:"theme,omitempty"`
+
+	// (String) The team timezone. Available values are utc, browser, or an empty string for the default.
+	// The team timezone. Available values are `utc`, `browser`, or an empty string for the default.
+	// +kubebuilder:validation:Optional
+	Timezone *string `json:"timezone,omitempty" tf:"timezone,omitempty"`
+
+	// (String) The team week start day. Available values are sunday, monday, saturday, or an empty string for the default.
+	// The team week start day. Available values are `sunday`, `monday`, `saturday`, or an empty string for the default.
+	// +kubebuilder:validation:Enum=sunday;monday;saturday;""
+	// +kubebuilder:validation:Optional
+	WeekStart *string `json:"weekStart,omitempty" tf:"week_start,omitempty"`
+}
+
+// TeamPreferencesSpec defines the desired state of TeamPreferences
+type TeamPreferencesSpec struct {
+	v1.ResourceSpec `json:",inline"`
+	ForProvider     TeamPreferencesParameters `json:"forProvider"`
+
+	// THIS IS A BETA FIELD. It will be honored
+	// unless the Management Policies feature flag is disabled.
+	// InitProvider holds the same fields as ForProvider, with the exception
+	// of Identifier and other resource reference fields. The fields that are
+	// in InitProvider are merged into ForProvider when the resource is created.
+	// The same fields are also added to the terraform ignore_changes hook, to
+	// avoid updating them after creation. This is useful for fields that are
+	// required on creation, but we do not desire to update them after creation,
+	// for example because of an external controller is managing them, like an
+	// autoscaler.
+	InitProvider TeamPreferencesInitParameters `json:"initProvider,omitempty"`
+}
+
+// TeamPreferencesStatus defines the observed state of TeamPreferences.
+type TeamPreferencesStatus struct {
+	v1.ResourceStatus `json:",inline"`
+	AtProvider        TeamPreferencesObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+
+// TeamPreferences is the Schema for the TeamPreferences API. Manages the preferences of a Grafana team. The team
+// itself is not managed by this resource, so it can also be created outside the provider. There must only be one
+// TeamPreferences per team, deleting it resets the preferences to their defaults. Official documentation
+// https://grafana.com/docs/grafana/latest/administration/organization-preferences/HTTP API https://grafana.com/docs/grafana/latest/developers/http_api/team/#get-team-preferences
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:path=teampreferences,scope=Cluster,categories={crossplane,managed,grafana}
+type TeamPreferences struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="!('*' in self.managementPolicies || 'Create' in self.managementPolicies || 'Update' in self.managementPolicies) || has(self.forProvider.teamId) || (has(self.initProvider) && has(self.initProvider.teamId))",message="spec.forProvider.teamId is a required parameter"
+	Spec   TeamPreferencesSpec   `json:"spec"`
+	Status TeamPreferencesStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TeamPreferencesList contains a list of TeamPreferences
+type TeamPreferencesList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TeamPreferences `json:"items"`
+}
+
+// TeamPreferences type metadata.
+var (
+	TeamPreferencesKind             = reflect.TypeOf(TeamPreferences{}).Name()
+	TeamPreferencesGroupKind        = schema.GroupKind{Group: Group, Kind: TeamPreferencesKind}.String()
+	TeamPreferencesKindAPIVersion   = TeamPreferencesKind + "." + SchemeGroupVersion.String()
+	TeamPreferencesGroupVersionKind = SchemeGroupVersion.WithKind(TeamPreferencesKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TeamPreferences{}, &TeamPreferencesList{})
+}
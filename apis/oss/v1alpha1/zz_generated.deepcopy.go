@@ -22,11 +22,12 @@ package v1alpha1
 
 import (
 	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Dashboard) DeepCopyInto(out *Dashboard) {
+func (in *APIKey) DeepCopyInto(out *APIKey) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -34,18 +35,18 @@ func (in *Dashboard) DeepCopyInto(out *Dashboard) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dashboard.
-func (in *Dashboard) DeepCopy() *Dashboard {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKey.
+func (in *APIKey) DeepCopy() *APIKey {
 	if in == nil {
 		return nil
 	}
-	out := new(Dashboard)
+	out := new(APIKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Dashboard) DeepCopyObject() runtime.Object {
+func (in *APIKey) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -53,30 +54,10 @@ func (in *Dashboard) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardInitParameters) DeepCopyInto(out *DashboardInitParameters) {
+func (in *APIKeyInitParameters) DeepCopyInto(out *APIKeyInitParameters) {
 	*out = *in
-	if in.ConfigJSON != nil {
-		in, out := &in.ConfigJSON, &out.ConfigJSON
-		*out = new(string)
-		**out = **in
-	}
-	if in.Folder != nil {
-		in, out := &in.Folder, &out.Folder
-		*out = new(string)
-		**out = **in
-	}
-	if in.FolderRef != nil {
-		in, out := &in.FolderRef, &out.FolderRef
-		*out = new(v1.Reference)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.FolderSelector != nil {
-		in, out := &in.FolderSelector, &out.FolderSelector
-		*out = new(v1.Selector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Message != nil {
-		in, out := &in.Message, &out.Message
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
 		*out = new(string)
 		**out = **in
 	}
@@ -95,49 +76,54 @@ func (in *DashboardInitParameters) DeepCopyInto(out *DashboardInitParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Overwrite != nil {
-		in, out := &in.Overwrite, &out.Overwrite
-		*out = new(bool)
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecondsToLive != nil {
+		in, out := &in.SecondsToLive, &out.SecondsToLive
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardInitParameters.
-func (in *DashboardInitParameters) DeepCopy() *DashboardInitParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyInitParameters.
+func (in *APIKeyInitParameters) DeepCopy() *APIKeyInitParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardInitParameters)
+	out := new(APIKeyInitParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardList) DeepCopyInto(out *DashboardList) {
+func (in *APIKeyList) DeepCopyInto(out *APIKeyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Dashboard, len(*in))
+		*out = make([]APIKey, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardList.
-func (in *DashboardList) DeepCopy() *DashboardList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyList.
+func (in *APIKeyList) DeepCopy() *APIKeyList {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardList)
+	out := new(APIKeyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DashboardList) DeepCopyObject() runtime.Object {
+func (in *APIKeyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -145,20 +131,10 @@ func (in *DashboardList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardObservation) DeepCopyInto(out *DashboardObservation) {
+func (in *APIKeyObservation) DeepCopyInto(out *APIKeyObservation) {
 	*out = *in
-	if in.ConfigJSON != nil {
-		in, out := &in.ConfigJSON, &out.ConfigJSON
-		*out = new(string)
-		**out = **in
-	}
-	if in.DashboardID != nil {
-		in, out := &in.DashboardID, &out.DashboardID
-		*out = new(int64)
-		**out = **in
-	}
-	if in.Folder != nil {
-		in, out := &in.Folder, &out.Folder
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
 		*out = new(string)
 		**out = **in
 	}
@@ -167,9 +143,9 @@ func (in *DashboardObservation) DeepCopyInto(out *DashboardObservation) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.Message != nil {
-		in, out := &in.Message, &out.Message
-		*out = new(string)
+	if in.KeyID != nil {
+		in, out := &in.KeyID, &out.KeyID
+		*out = new(int64)
 		**out = **in
 	}
 	if in.OrgID != nil {
@@ -177,68 +153,23 @@ func (in *DashboardObservation) DeepCopyInto(out *DashboardObservation) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.Overwrite != nil {
-		in, out := &in.Overwrite, &out.Overwrite
-		*out = new(bool)
-		**out = **in
-	}
-	if in.UID != nil {
-		in, out := &in.UID, &out.UID
-		*out = new(string)
-		**out = **in
-	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
-		*out = new(string)
-		**out = **in
-	}
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(int64)
-		**out = **in
-	}
-	if in.ManagedVersion != nil {
-		in, out := &in.ManagedVersion, &out.ManagedVersion
-		*out = new(int64)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardObservation.
-func (in *DashboardObservation) DeepCopy() *DashboardObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyObservation.
+func (in *APIKeyObservation) DeepCopy() *APIKeyObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardObservation)
+	out := new(APIKeyObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardParameters) DeepCopyInto(out *DashboardParameters) {
+func (in *APIKeyParameters) DeepCopyInto(out *APIKeyParameters) {
 	*out = *in
-	if in.ConfigJSON != nil {
-		in, out := &in.ConfigJSON, &out.ConfigJSON
-		*out = new(string)
-		**out = **in
-	}
-	if in.Folder != nil {
-		in, out := &in.Folder, &out.Folder
-		*out = new(string)
-		**out = **in
-	}
-	if in.FolderRef != nil {
-		in, out := &in.FolderRef, &out.FolderRef
-		*out = new(v1.Reference)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.FolderSelector != nil {
-		in, out := &in.FolderSelector, &out.FolderSelector
-		*out = new(v1.Selector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Message != nil {
-		in, out := &in.Message, &out.Message
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
 		*out = new(string)
 		**out = **in
 	}
@@ -257,60 +188,65 @@ func (in *DashboardParameters) DeepCopyInto(out *DashboardParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Overwrite != nil {
-		in, out := &in.Overwrite, &out.Overwrite
-		*out = new(bool)
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecondsToLive != nil {
+		in, out := &in.SecondsToLive, &out.SecondsToLive
+		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardParameters.
-func (in *DashboardParameters) DeepCopy() *DashboardParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyParameters.
+func (in *APIKeyParameters) DeepCopy() *APIKeyParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardParameters)
+	out := new(APIKeyParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
+func (in *APIKeySpec) DeepCopyInto(out *APIKeySpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 	in.InitProvider.DeepCopyInto(&out.InitProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
-func (in *DashboardSpec) DeepCopy() *DashboardSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeySpec.
+func (in *APIKeySpec) DeepCopy() *APIKeySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardSpec)
+	out := new(APIKeySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DashboardStatus) DeepCopyInto(out *DashboardStatus) {
+func (in *APIKeyStatus) DeepCopyInto(out *APIKeyStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardStatus.
-func (in *DashboardStatus) DeepCopy() *DashboardStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyStatus.
+func (in *APIKeyStatus) DeepCopy() *APIKeyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DashboardStatus)
+	out := new(APIKeyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSource) DeepCopyInto(out *DataSource) {
+func (in *AlertRule) DeepCopyInto(out *AlertRule) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -318,18 +254,18 @@ func (in *DataSource) DeepCopyInto(out *DataSource) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSource.
-func (in *DataSource) DeepCopy() *DataSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRule.
+func (in *AlertRule) DeepCopy() *AlertRule {
 	if in == nil {
 		return nil
 	}
-	out := new(DataSource)
+	out := new(AlertRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataSource) DeepCopyObject() runtime.Object {
+func (in *AlertRule) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -337,41 +273,26 @@ func (in *DataSource) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSourceInitParameters) DeepCopyInto(out *DataSourceInitParameters) {
+func (in *AlertRuleInitParameters) DeepCopyInto(out *AlertRuleInitParameters) {
 	*out = *in
-	if in.AccessMode != nil {
-		in, out := &in.AccessMode, &out.AccessMode
-		*out = new(string)
-		**out = **in
-	}
-	if in.BasicAuthEnabled != nil {
-		in, out := &in.BasicAuthEnabled, &out.BasicAuthEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.BasicAuthUsername != nil {
-		in, out := &in.BasicAuthUsername, &out.BasicAuthUsername
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
 		*out = new(string)
 		**out = **in
 	}
-	if in.DatabaseName != nil {
-		in, out := &in.DatabaseName, &out.DatabaseName
-		*out = new(string)
-		**out = **in
-	}
-	if in.IsDefault != nil {
-		in, out := &in.IsDefault, &out.IsDefault
-		*out = new(bool)
-		**out = **in
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.JSONDataEncoded != nil {
-		in, out := &in.JSONDataEncoded, &out.JSONDataEncoded
-		*out = new(string)
-		**out = **in
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
 		**out = **in
 	}
 	if in.OrgID != nil {
@@ -389,64 +310,54 @@ func (in *DataSourceInitParameters) DeepCopyInto(out *DataSourceInitParameters)
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
-		*out = new(string)
-		**out = **in
-	}
-	if in.UID != nil {
-		in, out := &in.UID, &out.UID
-		*out = new(string)
-		**out = **in
-	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
+	if in.RuleGroupName != nil {
+		in, out := &in.RuleGroupName, &out.RuleGroupName
 		*out = new(string)
 		**out = **in
 	}
-	if in.Username != nil {
-		in, out := &in.Username, &out.Username
+	if in.RulesJSON != nil {
+		in, out := &in.RulesJSON, &out.RulesJSON
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceInitParameters.
-func (in *DataSourceInitParameters) DeepCopy() *DataSourceInitParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleInitParameters.
+func (in *AlertRuleInitParameters) DeepCopy() *AlertRuleInitParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(DataSourceInitParameters)
+	out := new(AlertRuleInitParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSourceList) DeepCopyInto(out *DataSourceList) {
+func (in *AlertRuleList) DeepCopyInto(out *AlertRuleList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DataSource, len(*in))
+		*out = make([]AlertRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceList.
-func (in *DataSourceList) DeepCopy() *DataSourceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleList.
+func (in *AlertRuleList) DeepCopy() *AlertRuleList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataSourceList)
+	out := new(AlertRuleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataSourceList) DeepCopyObject() runtime.Object {
+func (in *AlertRuleList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -454,25 +365,10 @@ func (in *DataSourceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSourceObservation) DeepCopyInto(out *DataSourceObservation) {
+func (in *AlertRuleObservation) DeepCopyInto(out *AlertRuleObservation) {
 	*out = *in
-	if in.AccessMode != nil {
-		in, out := &in.AccessMode, &out.AccessMode
-		*out = new(string)
-		**out = **in
-	}
-	if in.BasicAuthEnabled != nil {
-		in, out := &in.BasicAuthEnabled, &out.BasicAuthEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.BasicAuthUsername != nil {
-		in, out := &in.BasicAuthUsername, &out.BasicAuthUsername
-		*out = new(string)
-		**out = **in
-	}
-	if in.DatabaseName != nil {
-		in, out := &in.DatabaseName, &out.DatabaseName
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
 		*out = new(string)
 		**out = **in
 	}
@@ -481,19 +377,9 @@ func (in *DataSourceObservation) DeepCopyInto(out *DataSourceObservation) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.IsDefault != nil {
-		in, out := &in.IsDefault, &out.IsDefault
-		*out = new(bool)
-		**out = **in
-	}
-	if in.JSONDataEncoded != nil {
-		in, out := &in.JSONDataEncoded, &out.JSONDataEncoded
-		*out = new(string)
-		**out = **in
-	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
 		**out = **in
 	}
 	if in.OrgID != nil {
@@ -501,79 +387,55 @@ func (in *DataSourceObservation) DeepCopyInto(out *DataSourceObservation) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
-		*out = new(string)
-		**out = **in
-	}
-	if in.UID != nil {
-		in, out := &in.UID, &out.UID
-		*out = new(string)
-		**out = **in
-	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
+	if in.RuleGroupName != nil {
+		in, out := &in.RuleGroupName, &out.RuleGroupName
 		*out = new(string)
 		**out = **in
 	}
-	if in.Username != nil {
-		in, out := &in.Username, &out.Username
-		*out = new(string)
-		**out = **in
+	if in.RuleUIDs != nil {
+		in, out := &in.RuleUIDs, &out.RuleUIDs
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceObservation.
-func (in *DataSourceObservation) DeepCopy() *DataSourceObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleObservation.
+func (in *AlertRuleObservation) DeepCopy() *AlertRuleObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(DataSourceObservation)
+	out := new(AlertRuleObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSourceParameters) DeepCopyInto(out *DataSourceParameters) {
+func (in *AlertRuleParameters) DeepCopyInto(out *AlertRuleParameters) {
 	*out = *in
-	if in.AccessMode != nil {
-		in, out := &in.AccessMode, &out.AccessMode
-		*out = new(string)
-		**out = **in
-	}
-	if in.BasicAuthEnabled != nil {
-		in, out := &in.BasicAuthEnabled, &out.BasicAuthEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.BasicAuthUsername != nil {
-		in, out := &in.BasicAuthUsername, &out.BasicAuthUsername
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
 		*out = new(string)
 		**out = **in
 	}
-	if in.DatabaseName != nil {
-		in, out := &in.DatabaseName, &out.DatabaseName
-		*out = new(string)
-		**out = **in
-	}
-	if in.HTTPHeadersSecretRef != nil {
-		in, out := &in.HTTPHeadersSecretRef, &out.HTTPHeadersSecretRef
-		*out = new(v1.SecretReference)
-		**out = **in
-	}
-	if in.IsDefault != nil {
-		in, out := &in.IsDefault, &out.IsDefault
-		*out = new(bool)
-		**out = **in
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.JSONDataEncoded != nil {
-		in, out := &in.JSONDataEncoded, &out.JSONDataEncoded
-		*out = new(string)
-		**out = **in
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
 		**out = **in
 	}
 	if in.OrgID != nil {
@@ -591,80 +453,65 @@ func (in *DataSourceParameters) DeepCopyInto(out *DataSourceParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.SecureJSONDataEncodedSecretRef != nil {
-		in, out := &in.SecureJSONDataEncodedSecretRef, &out.SecureJSONDataEncodedSecretRef
-		*out = new(v1.SecretKeySelector)
-		**out = **in
-	}
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
-		*out = new(string)
-		**out = **in
-	}
-	if in.UID != nil {
-		in, out := &in.UID, &out.UID
-		*out = new(string)
-		**out = **in
-	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
+	if in.RuleGroupName != nil {
+		in, out := &in.RuleGroupName, &out.RuleGroupName
 		*out = new(string)
 		**out = **in
 	}
-	if in.Username != nil {
-		in, out := &in.Username, &out.Username
+	if in.RulesJSON != nil {
+		in, out := &in.RulesJSON, &out.RulesJSON
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceParameters.
-func (in *DataSourceParameters) DeepCopy() *DataSourceParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleParameters.
+func (in *AlertRuleParameters) DeepCopy() *AlertRuleParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(DataSourceParameters)
+	out := new(AlertRuleParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSourceSpec) DeepCopyInto(out *DataSourceSpec) {
+func (in *AlertRuleSpec) DeepCopyInto(out *AlertRuleSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 	in.InitProvider.DeepCopyInto(&out.InitProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceSpec.
-func (in *DataSourceSpec) DeepCopy() *DataSourceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleSpec.
+func (in *AlertRuleSpec) DeepCopy() *AlertRuleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataSourceSpec)
+	out := new(AlertRuleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataSourceStatus) DeepCopyInto(out *DataSourceStatus) {
+func (in *AlertRuleStatus) DeepCopyInto(out *AlertRuleStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceStatus.
-func (in *DataSourceStatus) DeepCopy() *DataSourceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertRuleStatus.
+func (in *AlertRuleStatus) DeepCopy() *AlertRuleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataSourceStatus)
+	out := new(AlertRuleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Folder) DeepCopyInto(out *Folder) {
+func (in *Annotation) DeepCopyInto(out *Annotation) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -672,18 +519,18 @@ func (in *Folder) DeepCopyInto(out *Folder) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Folder.
-func (in *Folder) DeepCopy() *Folder {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Annotation.
+func (in *Annotation) DeepCopy() *Annotation {
 	if in == nil {
 		return nil
 	}
-	out := new(Folder)
+	out := new(Annotation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Folder) DeepCopyObject() runtime.Object {
+func (in *Annotation) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -691,15 +538,20 @@ func (in *Folder) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderInitParameters) DeepCopyInto(out *FolderInitParameters) {
+func (in *AnnotationInitParameters) DeepCopyInto(out *AnnotationInitParameters) {
 	*out = *in
-	if in.FolderRef != nil {
-		in, out := &in.FolderRef, &out.FolderRef
+	if in.DashboardUID != nil {
+		in, out := &in.DashboardUID, &out.DashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DashboardRef != nil {
+		in, out := &in.DashboardRef, &out.DashboardRef
 		*out = new(v1.Reference)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.FolderSelector != nil {
-		in, out := &in.FolderSelector, &out.FolderSelector
+	if in.DashboardSelector != nil {
+		in, out := &in.DashboardSelector, &out.DashboardSelector
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
@@ -718,59 +570,75 @@ func (in *FolderInitParameters) DeepCopyInto(out *FolderInitParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ParentFolderUID != nil {
-		in, out := &in.ParentFolderUID, &out.ParentFolderUID
+	if in.PanelID != nil {
+		in, out := &in.PanelID, &out.PanelID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
 		*out = new(string)
 		**out = **in
 	}
-	if in.Title != nil {
-		in, out := &in.Title, &out.Title
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
 		*out = new(string)
 		**out = **in
 	}
-	if in.UID != nil {
-		in, out := &in.UID, &out.UID
+	if in.TimeEnd != nil {
+		in, out := &in.TimeEnd, &out.TimeEnd
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderInitParameters.
-func (in *FolderInitParameters) DeepCopy() *FolderInitParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationInitParameters.
+func (in *AnnotationInitParameters) DeepCopy() *AnnotationInitParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderInitParameters)
+	out := new(AnnotationInitParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderList) DeepCopyInto(out *FolderList) {
+func (in *AnnotationList) DeepCopyInto(out *AnnotationList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Folder, len(*in))
+		*out = make([]Annotation, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderList.
-func (in *FolderList) DeepCopy() *FolderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationList.
+func (in *AnnotationList) DeepCopy() *AnnotationList {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderList)
+	out := new(AnnotationList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FolderList) DeepCopyObject() runtime.Object {
+func (in *AnnotationList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -778,8 +646,18 @@ func (in *FolderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderObservation) DeepCopyInto(out *FolderObservation) {
+func (in *AnnotationObservation) DeepCopyInto(out *AnnotationObservation) {
 	*out = *in
+	if in.AnnotationID != nil {
+		in, out := &in.AnnotationID, &out.AnnotationID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DashboardUID != nil {
+		in, out := &in.DashboardUID, &out.DashboardUID
+		*out = new(string)
+		**out = **in
+	}
 	if in.ID != nil {
 		in, out := &in.ID, &out.ID
 		*out = new(string)
@@ -790,53 +668,64 @@ func (in *FolderObservation) DeepCopyInto(out *FolderObservation) {
 		*out = new(string)
 		**out = **in
 	}
-	if in.ParentFolderUID != nil {
-		in, out := &in.ParentFolderUID, &out.ParentFolderUID
-		*out = new(string)
+	if in.PanelID != nil {
+		in, out := &in.PanelID, &out.PanelID
+		*out = new(int64)
 		**out = **in
 	}
-	if in.Title != nil {
-		in, out := &in.Title, &out.Title
-		*out = new(string)
-		**out = **in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
 	}
-	if in.UID != nil {
-		in, out := &in.UID, &out.UID
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
 		*out = new(string)
 		**out = **in
 	}
-	if in.URL != nil {
-		in, out := &in.URL, &out.URL
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
 		*out = new(string)
 		**out = **in
 	}
-	if in.Version != nil {
-		in, out := &in.Version, &out.Version
-		*out = new(int64)
+	if in.TimeEnd != nil {
+		in, out := &in.TimeEnd, &out.TimeEnd
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderObservation.
-func (in *FolderObservation) DeepCopy() *FolderObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationObservation.
+func (in *AnnotationObservation) DeepCopy() *AnnotationObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderObservation)
+	out := new(AnnotationObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderParameters) DeepCopyInto(out *FolderParameters) {
+func (in *AnnotationParameters) DeepCopyInto(out *AnnotationParameters) {
 	*out = *in
-	if in.FolderRef != nil {
-		in, out := &in.FolderRef, &out.FolderRef
+	if in.DashboardUID != nil {
+		in, out := &in.DashboardUID, &out.DashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DashboardRef != nil {
+		in, out := &in.DashboardRef, &out.DashboardRef
 		*out = new(v1.Reference)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.FolderSelector != nil {
-		in, out := &in.FolderSelector, &out.FolderSelector
+	if in.DashboardSelector != nil {
+		in, out := &in.DashboardSelector, &out.DashboardSelector
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
@@ -855,70 +744,141 @@ func (in *FolderParameters) DeepCopyInto(out *FolderParameters) {
 		*out = new(v1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ParentFolderUID != nil {
-		in, out := &in.ParentFolderUID, &out.ParentFolderUID
+	if in.PanelID != nil {
+		in, out := &in.PanelID, &out.PanelID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Text != nil {
+		in, out := &in.Text, &out.Text
 		*out = new(string)
 		**out = **in
 	}
-	if in.Title != nil {
-		in, out := &in.Title, &out.Title
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
 		*out = new(string)
 		**out = **in
 	}
-	if in.UID != nil {
-		in, out := &in.UID, &out.UID
+	if in.TimeEnd != nil {
+		in, out := &in.TimeEnd, &out.TimeEnd
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderParameters.
-func (in *FolderParameters) DeepCopy() *FolderParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationParameters.
+func (in *AnnotationParameters) DeepCopy() *AnnotationParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderParameters)
+	out := new(AnnotationParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderSpec) DeepCopyInto(out *FolderSpec) {
+func (in *AnnotationSpec) DeepCopyInto(out *AnnotationSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 	in.InitProvider.DeepCopyInto(&out.InitProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderSpec.
-func (in *FolderSpec) DeepCopy() *FolderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationSpec.
+func (in *AnnotationSpec) DeepCopy() *AnnotationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderSpec)
+	out := new(AnnotationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FolderStatus) DeepCopyInto(out *FolderStatus) {
+func (in *AnnotationStatus) DeepCopyInto(out *AnnotationStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderStatus.
-func (in *FolderStatus) DeepCopy() *FolderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnnotationStatus.
+func (in *AnnotationStatus) DeepCopy() *AnnotationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(FolderStatus)
+	out := new(AnnotationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Organization) DeepCopyInto(out *Organization) {
+func (in *Assignment) DeepCopyInto(out *Assignment) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(string)
+		**out = **in
+	}
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(string)
+		**out = **in
+	}
+	if in.Team != nil {
+		in, out := &in.Team, &out.Team
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceAccountID != nil {
+		in, out := &in.ServiceAccountID, &out.ServiceAccountID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Assignment.
+func (in *Assignment) DeepCopy() *Assignment {
+	if in == nil {
+		return nil
+	}
+	out := new(Assignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Dashboard) DeepCopyInto(out *Dashboard) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -926,18 +886,18 @@ func (in *Organization) DeepCopyInto(out *Organization) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Organization.
-func (in *Organization) DeepCopy() *Organization {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dashboard.
+func (in *Dashboard) DeepCopy() *Dashboard {
 	if in == nil {
 		return nil
 	}
-	out := new(Organization)
+	out := new(Dashboard)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Organization) DeepCopyObject() runtime.Object {
+func (in *Dashboard) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -945,105 +905,5405 @@ func (in *Organization) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OrganizationInitParameters) DeepCopyInto(out *OrganizationInitParameters) {
+func (in *DashboardInitParameters) DeepCopyInto(out *DashboardInitParameters) {
 	*out = *in
-	if in.AdminUser != nil {
-		in, out := &in.AdminUser, &out.AdminUser
+	if in.ConflictStrategy != nil {
+		in, out := &in.ConflictStrategy, &out.ConflictStrategy
 		*out = new(string)
 		**out = **in
 	}
-	if in.Admins != nil {
-		in, out := &in.Admins, &out.Admins
-		*out = make([]*string, len(*in))
+	if in.ConfigJSON != nil {
+		in, out := &in.ConfigJSON, &out.ConfigJSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.DataSourceRef != nil {
+		in, out := &in.DataSourceRef, &out.DataSourceRef
+		*out = make([]DataSourceVariableRef, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Folder != nil {
+		in, out := &in.Folder, &out.Folder
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardInitParameters.
+func (in *DashboardInitParameters) DeepCopy() *DashboardInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardList) DeepCopyInto(out *DashboardList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Dashboard, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardList.
+func (in *DashboardList) DeepCopy() *DashboardList {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DashboardList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardObservation) DeepCopyInto(out *DashboardObservation) {
+	*out = *in
+	if in.ConfigJSON != nil {
+		in, out := &in.ConfigJSON, &out.ConfigJSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.DashboardID != nil {
+		in, out := &in.DashboardID, &out.DashboardID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DataSourceCount != nil {
+		in, out := &in.DataSourceCount, &out.DataSourceCount
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DataSourceUIDs != nil {
+		in, out := &in.DataSourceUIDs, &out.DataSourceUIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Folder != nil {
+		in, out := &in.Folder, &out.Folder
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ManagedVersion != nil {
+		in, out := &in.ManagedVersion, &out.ManagedVersion
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardObservation.
+func (in *DashboardObservation) DeepCopy() *DashboardObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardParameters) DeepCopyInto(out *DashboardParameters) {
+	*out = *in
+	if in.ConflictStrategy != nil {
+		in, out := &in.ConflictStrategy, &out.ConflictStrategy
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigJSON != nil {
+		in, out := &in.ConfigJSON, &out.ConfigJSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.DataSourceRef != nil {
+		in, out := &in.DataSourceRef, &out.DataSourceRef
+		*out = make([]DataSourceVariableRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Folder != nil {
+		in, out := &in.Folder, &out.Folder
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardParameters.
+func (in *DashboardParameters) DeepCopy() *DashboardParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
+func (in *DashboardSpec) DeepCopy() *DashboardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardStatus) DeepCopyInto(out *DashboardStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardStatus.
+func (in *DashboardStatus) DeepCopy() *DashboardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSource) DeepCopyInto(out *DataSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSource.
+func (in *DataSource) DeepCopy() *DataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceInitParameters) DeepCopyInto(out *DataSourceInitParameters) {
+	*out = *in
+	if in.AccessMode != nil {
+		in, out := &in.AccessMode, &out.AccessMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApplyJSONDataDefaults != nil {
+		in, out := &in.ApplyJSONDataDefaults, &out.ApplyJSONDataDefaults
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuthEnabled != nil {
+		in, out := &in.BasicAuthEnabled, &out.BasicAuthEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuthUsername != nil {
+		in, out := &in.BasicAuthUsername, &out.BasicAuthUsername
+		*out = new(string)
+		**out = **in
+	}
+	if in.DatabaseName != nil {
+		in, out := &in.DatabaseName, &out.DatabaseName
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableHealthCheck != nil {
+		in, out := &in.EnableHealthCheck, &out.EnableHealthCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsDefault != nil {
+		in, out := &in.IsDefault, &out.IsDefault
+		*out = new(bool)
+		**out = **in
+	}
+	if in.JSONDataEncoded != nil {
+		in, out := &in.JSONDataEncoded, &out.JSONDataEncoded
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValidateOnCreate != nil {
+		in, out := &in.ValidateOnCreate, &out.ValidateOnCreate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WithCredentials != nil {
+		in, out := &in.WithCredentials, &out.WithCredentials
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceInitParameters.
+func (in *DataSourceInitParameters) DeepCopy() *DataSourceInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceList) DeepCopyInto(out *DataSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceList.
+func (in *DataSourceList) DeepCopy() *DataSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceObservation) DeepCopyInto(out *DataSourceObservation) {
+	*out = *in
+	if in.AccessControl != nil {
+		in, out := &in.AccessControl, &out.AccessControl
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AccessMode != nil {
+		in, out := &in.AccessMode, &out.AccessMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.BasicAuthEnabled != nil {
+		in, out := &in.BasicAuthEnabled, &out.BasicAuthEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuthPasswordHash != nil {
+		in, out := &in.BasicAuthPasswordHash, &out.BasicAuthPasswordHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.BasicAuthUsername != nil {
+		in, out := &in.BasicAuthUsername, &out.BasicAuthUsername
+		*out = new(string)
+		**out = **in
+	}
+	if in.DatabaseName != nil {
+		in, out := &in.DatabaseName, &out.DatabaseName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.IsDefault != nil {
+		in, out := &in.IsDefault, &out.IsDefault
+		*out = new(bool)
+		**out = **in
+	}
+	if in.JSONDataEncoded != nil {
+		in, out := &in.JSONDataEncoded, &out.JSONDataEncoded
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecureJSONDataHash != nil {
+		in, out := &in.SecureJSONDataHash, &out.SecureJSONDataHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.WithCredentials != nil {
+		in, out := &in.WithCredentials, &out.WithCredentials
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceObservation.
+func (in *DataSourceObservation) DeepCopy() *DataSourceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceParameters) DeepCopyInto(out *DataSourceParameters) {
+	*out = *in
+	if in.AccessMode != nil {
+		in, out := &in.AccessMode, &out.AccessMode
+		*out = new(string)
+		**out = **in
+	}
+	if in.ApplyJSONDataDefaults != nil {
+		in, out := &in.ApplyJSONDataDefaults, &out.ApplyJSONDataDefaults
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuthEnabled != nil {
+		in, out := &in.BasicAuthEnabled, &out.BasicAuthEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BasicAuthUsername != nil {
+		in, out := &in.BasicAuthUsername, &out.BasicAuthUsername
+		*out = new(string)
+		**out = **in
+	}
+	if in.DatabaseName != nil {
+		in, out := &in.DatabaseName, &out.DatabaseName
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableHealthCheck != nil {
+		in, out := &in.EnableHealthCheck, &out.EnableHealthCheck
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HTTPHeadersSecretRef != nil {
+		in, out := &in.HTTPHeadersSecretRef, &out.HTTPHeadersSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.IsDefault != nil {
+		in, out := &in.IsDefault, &out.IsDefault
+		*out = new(bool)
+		**out = **in
+	}
+	if in.JSONDataEncoded != nil {
+		in, out := &in.JSONDataEncoded, &out.JSONDataEncoded
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadOnly != nil {
+		in, out := &in.ReadOnly, &out.ReadOnly
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SecureJSONDataEncodedSecretRef != nil {
+		in, out := &in.SecureJSONDataEncodedSecretRef, &out.SecureJSONDataEncodedSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.TLSConfigSecretRef != nil {
+		in, out := &in.TLSConfigSecretRef, &out.TLSConfigSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(string)
+		**out = **in
+	}
+	if in.ValidateOnCreate != nil {
+		in, out := &in.ValidateOnCreate, &out.ValidateOnCreate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WithCredentials != nil {
+		in, out := &in.WithCredentials, &out.WithCredentials
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceParameters.
+func (in *DataSourceParameters) DeepCopy() *DataSourceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermission) DeepCopyInto(out *DataSourcePermission) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermission.
+func (in *DataSourcePermission) DeepCopy() *DataSourcePermission {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataSourcePermission) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermissionInitParameters) DeepCopyInto(out *DataSourcePermissionInitParameters) {
+	*out = *in
+	if in.DatasourceUID != nil {
+		in, out := &in.DatasourceUID, &out.DatasourceUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DataSourceRef != nil {
+		in, out := &in.DataSourceRef, &out.DataSourceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataSourceSelector != nil {
+		in, out := &in.DataSourceSelector, &out.DataSourceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]DataSourcePermissionItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermissionInitParameters.
+func (in *DataSourcePermissionInitParameters) DeepCopy() *DataSourcePermissionInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermissionInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermissionItem) DeepCopyInto(out *DataSourcePermissionItem) {
+	*out = *in
+	if in.BuiltInRole != nil {
+		in, out := &in.BuiltInRole, &out.BuiltInRole
+		*out = new(string)
+		**out = **in
+	}
+	if in.Permission != nil {
+		in, out := &in.Permission, &out.Permission
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(string)
+		**out = **in
+	}
+	if in.UserID != nil {
+		in, out := &in.UserID, &out.UserID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermissionItem.
+func (in *DataSourcePermissionItem) DeepCopy() *DataSourcePermissionItem {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermissionItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermissionList) DeepCopyInto(out *DataSourcePermissionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataSourcePermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermissionList.
+func (in *DataSourcePermissionList) DeepCopy() *DataSourcePermissionList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermissionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataSourcePermissionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermissionObservation) DeepCopyInto(out *DataSourcePermissionObservation) {
+	*out = *in
+	if in.DatasourceUID != nil {
+		in, out := &in.DatasourceUID, &out.DatasourceUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]DataSourcePermissionItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermissionObservation.
+func (in *DataSourcePermissionObservation) DeepCopy() *DataSourcePermissionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermissionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermissionParameters) DeepCopyInto(out *DataSourcePermissionParameters) {
+	*out = *in
+	if in.DatasourceUID != nil {
+		in, out := &in.DatasourceUID, &out.DatasourceUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DataSourceRef != nil {
+		in, out := &in.DataSourceRef, &out.DataSourceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataSourceSelector != nil {
+		in, out := &in.DataSourceSelector, &out.DataSourceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]DataSourcePermissionItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermissionParameters.
+func (in *DataSourcePermissionParameters) DeepCopy() *DataSourcePermissionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermissionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermissionSpec) DeepCopyInto(out *DataSourcePermissionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermissionSpec.
+func (in *DataSourcePermissionSpec) DeepCopy() *DataSourcePermissionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermissionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourcePermissionStatus) DeepCopyInto(out *DataSourcePermissionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourcePermissionStatus.
+func (in *DataSourcePermissionStatus) DeepCopy() *DataSourcePermissionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourcePermissionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceSpec) DeepCopyInto(out *DataSourceSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceSpec.
+func (in *DataSourceSpec) DeepCopy() *DataSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceStatus) DeepCopyInto(out *DataSourceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceStatus.
+func (in *DataSourceStatus) DeepCopy() *DataSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceVariableRef) DeepCopyInto(out *DataSourceVariableRef) {
+	*out = *in
+	if in.VariableName != nil {
+		in, out := &in.VariableName, &out.VariableName
+		*out = new(string)
+		**out = **in
+	}
+	if in.DataSourceUID != nil {
+		in, out := &in.DataSourceUID, &out.DataSourceUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DataSourceRef != nil {
+		in, out := &in.DataSourceRef, &out.DataSourceRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DataSourceSelector != nil {
+		in, out := &in.DataSourceSelector, &out.DataSourceSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceVariableRef.
+func (in *DataSourceVariableRef) DeepCopy() *DataSourceVariableRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceVariableRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Folder) DeepCopyInto(out *Folder) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Folder.
+func (in *Folder) DeepCopy() *Folder {
+	if in == nil {
+		return nil
+	}
+	out := new(Folder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Folder) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderInitParameters) DeepCopyInto(out *FolderInitParameters) {
+	*out = *in
+	if in.ContentDeletionPolicy != nil {
+		in, out := &in.ContentDeletionPolicy, &out.ContentDeletionPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ForceDeleteRules != nil {
+		in, out := &in.ForceDeleteRules, &out.ForceDeleteRules
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParentFolderUID != nil {
+		in, out := &in.ParentFolderUID, &out.ParentFolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Title != nil {
+		in, out := &in.Title, &out.Title
+		*out = new(string)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderInitParameters.
+func (in *FolderInitParameters) DeepCopy() *FolderInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderList) DeepCopyInto(out *FolderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Folder, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderList.
+func (in *FolderList) DeepCopy() *FolderList {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FolderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderObservation) DeepCopyInto(out *FolderObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ParentFolderUID != nil {
+		in, out := &in.ParentFolderUID, &out.ParentFolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Title != nil {
+		in, out := &in.Title, &out.Title
+		*out = new(string)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderObservation.
+func (in *FolderObservation) DeepCopy() *FolderObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderParameters) DeepCopyInto(out *FolderParameters) {
+	*out = *in
+	if in.ContentDeletionPolicy != nil {
+		in, out := &in.ContentDeletionPolicy, &out.ContentDeletionPolicy
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ForceDeleteRules != nil {
+		in, out := &in.ForceDeleteRules, &out.ForceDeleteRules
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ParentFolderUID != nil {
+		in, out := &in.ParentFolderUID, &out.ParentFolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Title != nil {
+		in, out := &in.Title, &out.Title
+		*out = new(string)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderParameters.
+func (in *FolderParameters) DeepCopy() *FolderParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderSpec) DeepCopyInto(out *FolderSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderSpec.
+func (in *FolderSpec) DeepCopy() *FolderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FolderStatus) DeepCopyInto(out *FolderStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FolderStatus.
+func (in *FolderStatus) DeepCopy() *FolderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FolderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalUser) DeepCopyInto(out *GlobalUser) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalUser.
+func (in *GlobalUser) DeepCopy() *GlobalUser {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalUserInitParameters) DeepCopyInto(out *GlobalUserInitParameters) {
+	*out = *in
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(string)
+		**out = **in
+	}
+	if in.IsAdmin != nil {
+		in, out := &in.IsAdmin, &out.IsAdmin
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsDisabled != nil {
+		in, out := &in.IsDisabled, &out.IsDisabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalUserInitParameters.
+func (in *GlobalUserInitParameters) DeepCopy() *GlobalUserInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalUserInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalUserList) DeepCopyInto(out *GlobalUserList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GlobalUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalUserList.
+func (in *GlobalUserList) DeepCopy() *GlobalUserList {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalUserList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GlobalUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalUserObservation) DeepCopyInto(out *GlobalUserObservation) {
+	*out = *in
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.IsAdmin != nil {
+		in, out := &in.IsAdmin, &out.IsAdmin
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsDisabled != nil {
+		in, out := &in.IsDisabled, &out.IsDisabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordHash != nil {
+		in, out := &in.PasswordHash, &out.PasswordHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.UserID != nil {
+		in, out := &in.UserID, &out.UserID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalUserObservation.
+func (in *GlobalUserObservation) DeepCopy() *GlobalUserObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalUserObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalUserParameters) DeepCopyInto(out *GlobalUserParameters) {
+	*out = *in
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(string)
+		**out = **in
+	}
+	if in.IsAdmin != nil {
+		in, out := &in.IsAdmin, &out.IsAdmin
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IsDisabled != nil {
+		in, out := &in.IsDisabled, &out.IsDisabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Login != nil {
+		in, out := &in.Login, &out.Login
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalUserParameters.
+func (in *GlobalUserParameters) DeepCopy() *GlobalUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalUserSpec) DeepCopyInto(out *GlobalUserSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalUserSpec.
+func (in *GlobalUserSpec) DeepCopy() *GlobalUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalUserStatus) DeepCopyInto(out *GlobalUserStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalUserStatus.
+func (in *GlobalUserStatus) DeepCopy() *GlobalUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LibraryPanel) DeepCopyInto(out *LibraryPanel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LibraryPanel.
+func (in *LibraryPanel) DeepCopy() *LibraryPanel {
+	if in == nil {
+		return nil
+	}
+	out := new(LibraryPanel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LibraryPanel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LibraryPanelInitParameters) DeepCopyInto(out *LibraryPanelInitParameters) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelJSON != nil {
+		in, out := &in.ModelJSON, &out.ModelJSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LibraryPanelInitParameters.
+func (in *LibraryPanelInitParameters) DeepCopy() *LibraryPanelInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(LibraryPanelInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LibraryPanelList) DeepCopyInto(out *LibraryPanelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LibraryPanel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LibraryPanelList.
+func (in *LibraryPanelList) DeepCopy() *LibraryPanelList {
+	if in == nil {
+		return nil
+	}
+	out := new(LibraryPanelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LibraryPanelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LibraryPanelObservation) DeepCopyInto(out *LibraryPanelObservation) {
+	*out = *in
+	if in.DashboardUIDs != nil {
+		in, out := &in.DashboardUIDs, &out.DashboardUIDs
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderName != nil {
+		in, out := &in.FolderName, &out.FolderName
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.PanelID != nil {
+		in, out := &in.PanelID, &out.PanelID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LibraryPanelObservation.
+func (in *LibraryPanelObservation) DeepCopy() *LibraryPanelObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(LibraryPanelObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LibraryPanelParameters) DeepCopyInto(out *LibraryPanelParameters) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelJSON != nil {
+		in, out := &in.ModelJSON, &out.ModelJSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LibraryPanelParameters.
+func (in *LibraryPanelParameters) DeepCopy() *LibraryPanelParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(LibraryPanelParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LibraryPanelSpec) DeepCopyInto(out *LibraryPanelSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LibraryPanelSpec.
+func (in *LibraryPanelSpec) DeepCopy() *LibraryPanelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LibraryPanelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LibraryPanelStatus) DeepCopyInto(out *LibraryPanelStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LibraryPanelStatus.
+func (in *LibraryPanelStatus) DeepCopy() *LibraryPanelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LibraryPanelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTemplate) DeepCopyInto(out *MessageTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTemplate.
+func (in *MessageTemplate) DeepCopy() *MessageTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MessageTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTemplateInitParameters) DeepCopyInto(out *MessageTemplateInitParameters) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTemplateInitParameters.
+func (in *MessageTemplateInitParameters) DeepCopy() *MessageTemplateInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTemplateInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTemplateList) DeepCopyInto(out *MessageTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MessageTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTemplateList.
+func (in *MessageTemplateList) DeepCopy() *MessageTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MessageTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTemplateObservation) DeepCopyInto(out *MessageTemplateObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTemplateObservation.
+func (in *MessageTemplateObservation) DeepCopy() *MessageTemplateObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTemplateObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTemplateParameters) DeepCopyInto(out *MessageTemplateParameters) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTemplateParameters.
+func (in *MessageTemplateParameters) DeepCopy() *MessageTemplateParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTemplateParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTemplateSpec) DeepCopyInto(out *MessageTemplateSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTemplateSpec.
+func (in *MessageTemplateSpec) DeepCopy() *MessageTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MessageTemplateStatus) DeepCopyInto(out *MessageTemplateStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MessageTemplateStatus.
+func (in *MessageTemplateStatus) DeepCopy() *MessageTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MessageTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MuteTiming) DeepCopyInto(out *MuteTiming) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MuteTiming.
+func (in *MuteTiming) DeepCopy() *MuteTiming {
+	if in == nil {
+		return nil
+	}
+	out := new(MuteTiming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MuteTiming) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MuteTimingInitParameters) DeepCopyInto(out *MuteTimingInitParameters) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeIntervalsJSON != nil {
+		in, out := &in.TimeIntervalsJSON, &out.TimeIntervalsJSON
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MuteTimingInitParameters.
+func (in *MuteTimingInitParameters) DeepCopy() *MuteTimingInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MuteTimingInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MuteTimingList) DeepCopyInto(out *MuteTimingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MuteTiming, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MuteTimingList.
+func (in *MuteTimingList) DeepCopy() *MuteTimingList {
+	if in == nil {
+		return nil
+	}
+	out := new(MuteTimingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MuteTimingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MuteTimingObservation) DeepCopyInto(out *MuteTimingObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MuteTimingObservation.
+func (in *MuteTimingObservation) DeepCopy() *MuteTimingObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(MuteTimingObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MuteTimingParameters) DeepCopyInto(out *MuteTimingParameters) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeIntervalsJSON != nil {
+		in, out := &in.TimeIntervalsJSON, &out.TimeIntervalsJSON
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MuteTimingParameters.
+func (in *MuteTimingParameters) DeepCopy() *MuteTimingParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MuteTimingParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MuteTimingSpec) DeepCopyInto(out *MuteTimingSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MuteTimingSpec.
+func (in *MuteTimingSpec) DeepCopy() *MuteTimingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MuteTimingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MuteTimingStatus) DeepCopyInto(out *MuteTimingStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MuteTimingStatus.
+func (in *MuteTimingStatus) DeepCopy() *MuteTimingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MuteTimingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgPreferences) DeepCopyInto(out *OrgPreferences) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgPreferences.
+func (in *OrgPreferences) DeepCopy() *OrgPreferences {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgPreferences)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrgPreferences) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgPreferencesInitParameters) DeepCopyInto(out *OrgPreferencesInitParameters) {
+	*out = *in
+	if in.HomeDashboardUID != nil {
+		in, out := &in.HomeDashboardUID, &out.HomeDashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HomeDashboardRef != nil {
+		in, out := &in.HomeDashboardRef, &out.HomeDashboardRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HomeDashboardSelector != nil {
+		in, out := &in.HomeDashboardSelector, &out.HomeDashboardSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.WeekStart != nil {
+		in, out := &in.WeekStart, &out.WeekStart
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgPreferencesInitParameters.
+func (in *OrgPreferencesInitParameters) DeepCopy() *OrgPreferencesInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgPreferencesInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgPreferencesList) DeepCopyInto(out *OrgPreferencesList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OrgPreferences, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgPreferencesList.
+func (in *OrgPreferencesList) DeepCopy() *OrgPreferencesList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgPreferencesList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrgPreferencesList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgPreferencesObservation) DeepCopyInto(out *OrgPreferencesObservation) {
+	*out = *in
+	if in.HomeDashboardUID != nil {
+		in, out := &in.HomeDashboardUID, &out.HomeDashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.WeekStart != nil {
+		in, out := &in.WeekStart, &out.WeekStart
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgPreferencesObservation.
+func (in *OrgPreferencesObservation) DeepCopy() *OrgPreferencesObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgPreferencesObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgPreferencesParameters) DeepCopyInto(out *OrgPreferencesParameters) {
+	*out = *in
+	if in.HomeDashboardUID != nil {
+		in, out := &in.HomeDashboardUID, &out.HomeDashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HomeDashboardRef != nil {
+		in, out := &in.HomeDashboardRef, &out.HomeDashboardRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HomeDashboardSelector != nil {
+		in, out := &in.HomeDashboardSelector, &out.HomeDashboardSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.WeekStart != nil {
+		in, out := &in.WeekStart, &out.WeekStart
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgPreferencesParameters.
+func (in *OrgPreferencesParameters) DeepCopy() *OrgPreferencesParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgPreferencesParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgPreferencesSpec) DeepCopyInto(out *OrgPreferencesSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgPreferencesSpec.
+func (in *OrgPreferencesSpec) DeepCopy() *OrgPreferencesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgPreferencesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrgPreferencesStatus) DeepCopyInto(out *OrgPreferencesStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrgPreferencesStatus.
+func (in *OrgPreferencesStatus) DeepCopy() *OrgPreferencesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrgPreferencesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Organization) DeepCopyInto(out *Organization) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Organization.
+func (in *Organization) DeepCopy() *Organization {
+	if in == nil {
+		return nil
+	}
+	out := new(Organization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Organization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationAddress) DeepCopyInto(out *OrganizationAddress) {
+	*out = *in
+	if in.Address1 != nil {
+		in, out := &in.Address1, &out.Address1
+		*out = new(string)
+		**out = **in
+	}
+	if in.Address2 != nil {
+		in, out := &in.Address2, &out.Address2
+		*out = new(string)
+		**out = **in
+	}
+	if in.City != nil {
+		in, out := &in.City, &out.City
+		*out = new(string)
+		**out = **in
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(string)
+		**out = **in
+	}
+	if in.ZipCode != nil {
+		in, out := &in.ZipCode, &out.ZipCode
+		*out = new(string)
+		**out = **in
+	}
+	if in.Country != nil {
+		in, out := &in.Country, &out.Country
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationAddress.
+func (in *OrganizationAddress) DeepCopy() *OrganizationAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationInitParameters) DeepCopyInto(out *OrganizationInitParameters) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(OrganizationAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminUser != nil {
+		in, out := &in.AdminUser, &out.AdminUser
+		*out = new(string)
+		**out = **in
+	}
+	if in.Admins != nil {
+		in, out := &in.Admins, &out.Admins
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CreateUsers != nil {
+		in, out := &in.CreateUsers, &out.CreateUsers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Editors != nil {
+		in, out := &in.Editors, &out.Editors
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(OrganizationQuotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsersWithoutAccess != nil {
+		in, out := &in.UsersWithoutAccess, &out.UsersWithoutAccess
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Viewers != nil {
+		in, out := &in.Viewers, &out.Viewers
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationInitParameters.
+func (in *OrganizationInitParameters) DeepCopy() *OrganizationInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationList) DeepCopyInto(out *OrganizationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Organization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationList.
+func (in *OrganizationList) DeepCopy() *OrganizationList {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OrganizationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationObservation) DeepCopyInto(out *OrganizationObservation) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(OrganizationAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminUser != nil {
+		in, out := &in.AdminUser, &out.AdminUser
+		*out = new(string)
+		**out = **in
+	}
+	if in.Admins != nil {
+		in, out := &in.Admins, &out.Admins
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.CreateUsers != nil {
+		in, out := &in.CreateUsers, &out.CreateUsers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Editors != nil {
+		in, out := &in.Editors, &out.Editors
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PendingUserChanges != nil {
+		in, out := &in.PendingUserChanges, &out.PendingUserChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(OrganizationQuotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsersWithoutAccess != nil {
+		in, out := &in.UsersWithoutAccess, &out.UsersWithoutAccess
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Viewers != nil {
+		in, out := &in.Viewers, &out.Viewers
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationObservation.
+func (in *OrganizationObservation) DeepCopy() *OrganizationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationParameters) DeepCopyInto(out *OrganizationParameters) {
+	*out = *in
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(OrganizationAddress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdminUser != nil {
+		in, out := &in.AdminUser, &out.AdminUser
+		*out = new(string)
+		**out = **in
+	}
+	if in.Admins != nil {
+		in, out := &in.Admins, &out.Admins
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CreateUsers != nil {
+		in, out := &in.CreateUsers, &out.CreateUsers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Editors != nil {
+		in, out := &in.Editors, &out.Editors
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(OrganizationQuotas)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsersWithoutAccess != nil {
+		in, out := &in.UsersWithoutAccess, &out.UsersWithoutAccess
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Viewers != nil {
+		in, out := &in.Viewers, &out.Viewers
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationParameters.
+func (in *OrganizationParameters) DeepCopy() *OrganizationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationQuotas) DeepCopyInto(out *OrganizationQuotas) {
+	*out = *in
+	if in.AlertRules != nil {
+		in, out := &in.AlertRules, &out.AlertRules
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Dashboards != nil {
+		in, out := &in.Dashboards, &out.Dashboards
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DataSources != nil {
+		in, out := &in.DataSources, &out.DataSources
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationQuotas.
+func (in *OrganizationQuotas) DeepCopy() *OrganizationQuotas {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationQuotas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationSpec) DeepCopyInto(out *OrganizationSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSpec.
+func (in *OrganizationSpec) DeepCopy() *OrganizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationStatus) DeepCopyInto(out *OrganizationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationStatus.
+func (in *OrganizationStatus) DeepCopy() *OrganizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRule) DeepCopyInto(out *RecordingRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRule.
+func (in *RecordingRule) DeepCopy() *RecordingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordingRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleDefinition) DeepCopyInto(out *RecordingRuleDefinition) {
+	*out = *in
+	if in.Expr != nil {
+		in, out := &in.Expr, &out.Expr
+		*out = new(string)
+		**out = **in
+	}
+	if in.Record != nil {
+		in, out := &in.Record, &out.Record
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleDefinition.
+func (in *RecordingRuleDefinition) DeepCopy() *RecordingRuleDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleInitParameters) DeepCopyInto(out *RecordingRuleInitParameters) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupName != nil {
+		in, out := &in.GroupName, &out.GroupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RecordingRuleDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleInitParameters.
+func (in *RecordingRuleInitParameters) DeepCopy() *RecordingRuleInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleList) DeepCopyInto(out *RecordingRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RecordingRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleList.
+func (in *RecordingRuleList) DeepCopy() *RecordingRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RecordingRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleObservation) DeepCopyInto(out *RecordingRuleObservation) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.GroupName != nil {
+		in, out := &in.GroupName, &out.GroupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RecordingRuleDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleObservation.
+func (in *RecordingRuleObservation) DeepCopy() *RecordingRuleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleParameters) DeepCopyInto(out *RecordingRuleParameters) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GroupName != nil {
+		in, out := &in.GroupName, &out.GroupName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]RecordingRuleDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleParameters.
+func (in *RecordingRuleParameters) DeepCopy() *RecordingRuleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleSpec) DeepCopyInto(out *RecordingRuleSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleSpec.
+func (in *RecordingRuleSpec) DeepCopy() *RecordingRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordingRuleStatus) DeepCopyInto(out *RecordingRuleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordingRuleStatus.
+func (in *RecordingRuleStatus) DeepCopy() *RecordingRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordingRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Report) DeepCopyInto(out *Report) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Report.
+func (in *Report) DeepCopy() *Report {
+	if in == nil {
+		return nil
+	}
+	out := new(Report)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Report) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportInitParameters) DeepCopyInto(out *ReportInitParameters) {
+	*out = *in
+	if in.DashboardUID != nil {
+		in, out := &in.DashboardUID, &out.DashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DashboardRef != nil {
+		in, out := &in.DashboardRef, &out.DashboardRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardSelector != nil {
+		in, out := &in.DashboardSelector, &out.DashboardSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.IncludeDashboardLink != nil {
+		in, out := &in.IncludeDashboardLink, &out.IncludeDashboardLink
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludeTableCsv != nil {
+		in, out := &in.IncludeTableCsv, &out.IncludeTableCsv
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Layout != nil {
+		in, out := &in.Layout, &out.Layout
+		*out = new(string)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Orientation != nil {
+		in, out := &in.Orientation, &out.Orientation
+		*out = new(string)
+		**out = **in
+	}
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ReplyTo != nil {
+		in, out := &in.ReplyTo, &out.ReplyTo
+		*out = new(string)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ReportSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportInitParameters.
+func (in *ReportInitParameters) DeepCopy() *ReportInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportList) DeepCopyInto(out *ReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Report, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportList.
+func (in *ReportList) DeepCopy() *ReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportObservation) DeepCopyInto(out *ReportObservation) {
+	*out = *in
+	if in.DashboardUID != nil {
+		in, out := &in.DashboardUID, &out.DashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ReportID != nil {
+		in, out := &in.ReportID, &out.ReportID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ReportSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportObservation.
+func (in *ReportObservation) DeepCopy() *ReportObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportParameters) DeepCopyInto(out *ReportParameters) {
+	*out = *in
+	if in.DashboardUID != nil {
+		in, out := &in.DashboardUID, &out.DashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.DashboardRef != nil {
+		in, out := &in.DashboardRef, &out.DashboardRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardSelector != nil {
+		in, out := &in.DashboardSelector, &out.DashboardSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Formats != nil {
+		in, out := &in.Formats, &out.Formats
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.IncludeDashboardLink != nil {
+		in, out := &in.IncludeDashboardLink, &out.IncludeDashboardLink
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IncludeTableCsv != nil {
+		in, out := &in.IncludeTableCsv, &out.IncludeTableCsv
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Layout != nil {
+		in, out := &in.Layout, &out.Layout
+		*out = new(string)
+		**out = **in
+	}
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Orientation != nil {
+		in, out := &in.Orientation, &out.Orientation
+		*out = new(string)
+		**out = **in
+	}
+	if in.Recipients != nil {
+		in, out := &in.Recipients, &out.Recipients
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.ReplyTo != nil {
+		in, out := &in.ReplyTo, &out.ReplyTo
+		*out = new(string)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ReportSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportParameters.
+func (in *ReportParameters) DeepCopy() *ReportParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportSchedule) DeepCopyInto(out *ReportSchedule) {
+	*out = *in
+	if in.Frequency != nil {
+		in, out := &in.Frequency, &out.Frequency
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = new(string)
+		**out = **in
+	}
+	if in.WorkdaysOnly != nil {
+		in, out := &in.WorkdaysOnly, &out.WorkdaysOnly
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSchedule.
+func (in *ReportSchedule) DeepCopy() *ReportSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportSpec) DeepCopyInto(out *ReportSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSpec.
+func (in *ReportSpec) DeepCopy() *ReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportStatus) DeepCopyInto(out *ReportStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportStatus.
+func (in *ReportStatus) DeepCopy() *ReportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
+	if in == nil {
+		return nil
+	}
+	out := new(Role)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Role) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignment) DeepCopyInto(out *RoleAssignment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignment.
+func (in *RoleAssignment) DeepCopy() *RoleAssignment {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleAssignment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignmentInitParameters) DeepCopyInto(out *RoleAssignmentInitParameters) {
+	*out = *in
+	if in.Assignments != nil {
+		in, out := &in.Assignments, &out.Assignments
+		*out = make([]Assignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleUID != nil {
+		in, out := &in.RoleUID, &out.RoleUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleSelector != nil {
+		in, out := &in.RoleSelector, &out.RoleSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]*int64, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int64)
+				**out = **in
+			}
+		}
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]*int64, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int64)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignmentInitParameters.
+func (in *RoleAssignmentInitParameters) DeepCopy() *RoleAssignmentInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignmentInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignmentList) DeepCopyInto(out *RoleAssignmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RoleAssignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignmentList.
+func (in *RoleAssignmentList) DeepCopy() *RoleAssignmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleAssignmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignmentObservation) DeepCopyInto(out *RoleAssignmentObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleUID != nil {
+		in, out := &in.RoleUID, &out.RoleUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]*int64, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int64)
+				**out = **in
+			}
+		}
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]*int64, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int64)
+				**out = **in
+			}
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]*int64, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int64)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignmentObservation.
+func (in *RoleAssignmentObservation) DeepCopy() *RoleAssignmentObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignmentObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignmentParameters) DeepCopyInto(out *RoleAssignmentParameters) {
+	*out = *in
+	if in.Assignments != nil {
+		in, out := &in.Assignments, &out.Assignments
+		*out = make([]Assignment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleUID != nil {
+		in, out := &in.RoleUID, &out.RoleUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.RoleRef != nil {
+		in, out := &in.RoleRef, &out.RoleRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleSelector != nil {
+		in, out := &in.RoleSelector, &out.RoleSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]*int64, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int64)
+				**out = **in
+			}
+		}
+	}
+	if in.Teams != nil {
+		in, out := &in.Teams, &out.Teams
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]*int64, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(int64)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignmentParameters.
+func (in *RoleAssignmentParameters) DeepCopy() *RoleAssignmentParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignmentParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignmentSpec) DeepCopyInto(out *RoleAssignmentSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignmentSpec.
+func (in *RoleAssignmentSpec) DeepCopy() *RoleAssignmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleAssignmentStatus) DeepCopyInto(out *RoleAssignmentStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleAssignmentStatus.
+func (in *RoleAssignmentStatus) DeepCopy() *RoleAssignmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleAssignmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleInitParameters) DeepCopyInto(out *RoleInitParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Global != nil {
+		in, out := &in.Global, &out.Global
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hidden != nil {
+		in, out := &in.Hidden, &out.Hidden
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]RolePermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleInitParameters.
+func (in *RoleInitParameters) DeepCopy() *RoleInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleList) DeepCopyInto(out *RoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Role, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
+func (in *RoleList) DeepCopy() *RoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleObservation) DeepCopyInto(out *RoleObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]RolePermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleObservation.
+func (in *RoleObservation) DeepCopy() *RoleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleParameters) DeepCopyInto(out *RoleParameters) {
+	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Global != nil {
+		in, out := &in.Global, &out.Global
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Group != nil {
+		in, out := &in.Group, &out.Group
+		*out = new(string)
+		**out = **in
+	}
+	if in.Hidden != nil {
+		in, out := &in.Hidden, &out.Hidden
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]RolePermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleParameters.
+func (in *RoleParameters) DeepCopy() *RoleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolePermission) DeepCopyInto(out *RolePermission) {
+	*out = *in
+	if in.Action != nil {
+		in, out := &in.Action, &out.Action
+		*out = new(string)
+		**out = **in
+	}
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolePermission.
+func (in *RolePermission) DeepCopy() *RolePermission {
+	if in == nil {
+		return nil
+	}
+	out := new(RolePermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
+func (in *RoleStatus) DeepCopy() *RoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSOSettings) DeepCopyInto(out *SSOSettings) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSOSettings.
+func (in *SSOSettings) DeepCopy() *SSOSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(SSOSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSOSettings) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSOSettingsInitParameters) DeepCopyInto(out *SSOSettingsInitParameters) {
+	*out = *in
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretsRef != nil {
+		in, out := &in.SecretsRef, &out.SecretsRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.ProviderName != nil {
+		in, out := &in.ProviderName, &out.ProviderName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SettingsEncoded != nil {
+		in, out := &in.SettingsEncoded, &out.SettingsEncoded
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecureSettingsSecretRef != nil {
+		in, out := &in.SecureSettingsSecretRef, &out.SecureSettingsSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSOSettingsInitParameters.
+func (in *SSOSettingsInitParameters) DeepCopy() *SSOSettingsInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SSOSettingsInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSOSettingsList) DeepCopyInto(out *SSOSettingsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SSOSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSOSettingsList.
+func (in *SSOSettingsList) DeepCopy() *SSOSettingsList {
+	if in == nil {
+		return nil
+	}
+	out := new(SSOSettingsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SSOSettingsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSOSettingsObservation) DeepCopyInto(out *SSOSettingsObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProviderName != nil {
+		in, out := &in.ProviderName, &out.ProviderName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecureSettingsHash != nil {
+		in, out := &in.SecureSettingsHash, &out.SecureSettingsHash
+		*out = new(string)
+		**out = **in
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSOSettingsObservation.
+func (in *SSOSettingsObservation) DeepCopy() *SSOSettingsObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SSOSettingsObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSOSettingsParameters) DeepCopyInto(out *SSOSettingsParameters) {
+	*out = *in
+	if in.Provider != nil {
+		in, out := &in.Provider, &out.Provider
+		*out = new(string)
+		**out = **in
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecretsRef != nil {
+		in, out := &in.SecretsRef, &out.SecretsRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.ProviderName != nil {
+		in, out := &in.ProviderName, &out.ProviderName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SettingsEncoded != nil {
+		in, out := &in.SettingsEncoded, &out.SettingsEncoded
+		*out = new(string)
+		**out = **in
+	}
+	if in.SecureSettingsSecretRef != nil {
+		in, out := &in.SecureSettingsSecretRef, &out.SecureSettingsSecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSOSettingsParameters.
+func (in *SSOSettingsParameters) DeepCopy() *SSOSettingsParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SSOSettingsParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSOSettingsSpec) DeepCopyInto(out *SSOSettingsSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSOSettingsSpec.
+func (in *SSOSettingsSpec) DeepCopy() *SSOSettingsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSOSettingsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSOSettingsStatus) DeepCopyInto(out *SSOSettingsStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSOSettingsStatus.
+func (in *SSOSettingsStatus) DeepCopy() *SSOSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SSOSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Snapshot) DeepCopyInto(out *Snapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Snapshot.
+func (in *Snapshot) DeepCopy() *Snapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(Snapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Snapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotInitParameters) DeepCopyInto(out *SnapshotInitParameters) {
+	*out = *in
+	if in.DashboardJSON != nil {
+		in, out := &in.DashboardJSON, &out.DashboardJSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotInitParameters.
+func (in *SnapshotInitParameters) DeepCopy() *SnapshotInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotList) DeepCopyInto(out *SnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Snapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotList.
+func (in *SnapshotList) DeepCopy() *SnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotObservation) DeepCopyInto(out *SnapshotObservation) {
+	*out = *in
+	if in.DeleteKey != nil {
+		in, out := &in.DeleteKey, &out.DeleteKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotObservation.
+func (in *SnapshotObservation) DeepCopy() *SnapshotObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotParameters) DeepCopyInto(out *SnapshotParameters) {
+	*out = *in
+	if in.DashboardJSON != nil {
+		in, out := &in.DashboardJSON, &out.DashboardJSON
+		*out = new(string)
+		**out = **in
+	}
+	if in.Expires != nil {
+		in, out := &in.Expires, &out.Expires
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotParameters.
+func (in *SnapshotParameters) DeepCopy() *SnapshotParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotSpec) DeepCopyInto(out *SnapshotSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotSpec.
+func (in *SnapshotSpec) DeepCopy() *SnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotStatus) DeepCopyInto(out *SnapshotStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotStatus.
+func (in *SnapshotStatus) DeepCopy() *SnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMember) DeepCopyInto(out *TeamMember) {
+	*out = *in
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(string)
+		**out = **in
+	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMember.
+func (in *TeamMember) DeepCopy() *TeamMember {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMember)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMembership) DeepCopyInto(out *TeamMembership) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMembership.
+func (in *TeamMembership) DeepCopy() *TeamMembership {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMembership)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamMembership) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMembershipInitParameters) DeepCopyInto(out *TeamMembershipInitParameters) {
+	*out = *in
+	if in.CreateUsers != nil {
+		in, out := &in.CreateUsers, &out.CreateUsers
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]TeamMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMembershipInitParameters.
+func (in *TeamMembershipInitParameters) DeepCopy() *TeamMembershipInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMembershipInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMembershipList) DeepCopyInto(out *TeamMembershipList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TeamMembership, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMembershipList.
+func (in *TeamMembershipList) DeepCopy() *TeamMembershipList {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMembershipList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamMembershipList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMembershipObservation) DeepCopyInto(out *TeamMembershipObservation) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]TeamMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMembershipObservation.
+func (in *TeamMembershipObservation) DeepCopy() *TeamMembershipObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMembershipObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMembershipParameters) DeepCopyInto(out *TeamMembershipParameters) {
+	*out = *in
 	if in.CreateUsers != nil {
 		in, out := &in.CreateUsers, &out.CreateUsers
 		*out = new(bool)
 		**out = **in
 	}
-	if in.Editors != nil {
-		in, out := &in.Editors, &out.Editors
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]TeamMember, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMembershipParameters.
+func (in *TeamMembershipParameters) DeepCopy() *TeamMembershipParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMembershipParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMembershipSpec) DeepCopyInto(out *TeamMembershipSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMembershipSpec.
+func (in *TeamMembershipSpec) DeepCopy() *TeamMembershipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMembershipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMembershipStatus) DeepCopyInto(out *TeamMembershipStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMembershipStatus.
+func (in *TeamMembershipStatus) DeepCopy() *TeamMembershipStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMembershipStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPermission) DeepCopyInto(out *TeamPermission) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPermission.
+func (in *TeamPermission) DeepCopy() *TeamPermission {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPermission)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamPermission) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPermissionInitParameters) DeepCopyInto(out *TeamPermissionInitParameters) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permission != nil {
+		in, out := &in.Permission, &out.Permission
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamRef != nil {
+		in, out := &in.TeamRef, &out.TeamRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamSelector != nil {
+		in, out := &in.TeamSelector, &out.TeamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPermissionInitParameters.
+func (in *TeamPermissionInitParameters) DeepCopy() *TeamPermissionInitParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPermissionInitParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPermissionList) DeepCopyInto(out *TeamPermissionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TeamPermission, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPermissionList.
+func (in *TeamPermissionList) DeepCopy() *TeamPermissionList {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPermissionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamPermissionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPermissionObservation) DeepCopyInto(out *TeamPermissionObservation) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Permission != nil {
+		in, out := &in.Permission, &out.Permission
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPermissionObservation.
+func (in *TeamPermissionObservation) DeepCopy() *TeamPermissionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPermissionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPermissionParameters) DeepCopyInto(out *TeamPermissionParameters) {
+	*out = *in
+	if in.FolderUID != nil {
+		in, out := &in.FolderUID, &out.FolderUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.FolderRef != nil {
+		in, out := &in.FolderRef, &out.FolderRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FolderSelector != nil {
+		in, out := &in.FolderSelector, &out.FolderSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
+		**out = **in
+	}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Permission != nil {
+		in, out := &in.Permission, &out.Permission
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(string)
+		**out = **in
+	}
+	if in.TeamRef != nil {
+		in, out := &in.TeamRef, &out.TeamRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamSelector != nil {
+		in, out := &in.TeamSelector, &out.TeamSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPermissionParameters.
+func (in *TeamPermissionParameters) DeepCopy() *TeamPermissionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPermissionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPermissionSpec) DeepCopyInto(out *TeamPermissionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	in.InitProvider.DeepCopyInto(&out.InitProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPermissionSpec.
+func (in *TeamPermissionSpec) DeepCopy() *TeamPermissionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPermissionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPermissionStatus) DeepCopyInto(out *TeamPermissionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPermissionStatus.
+func (in *TeamPermissionStatus) DeepCopy() *TeamPermissionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPermissionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPreferences) DeepCopyInto(out *TeamPreferences) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPreferences.
+func (in *TeamPreferences) DeepCopy() *TeamPreferences {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamPreferences)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TeamPreferences) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamPreferencesInitParameters) DeepCopyInto(out *TeamPreferencesInitParameters) {
+	*out = *in
+	if in.HomeDashboardUID != nil {
+		in, out := &in.HomeDashboardUID, &out.HomeDashboardUID
+		*out = new(string)
+		**out = **in
+	}
+	if in.HomeDashboardRef != nil {
+		in, out := &in.HomeDashboardRef, &out.HomeDashboardRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
+	if in.HomeDashboardSelector != nil {
+		in, out := &in.HomeDashboardSelector, &out.HomeDashboardSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
 		*out = new(string)
 		**out = **in
 	}
-	if in.UsersWithoutAccess != nil {
-		in, out := &in.UsersWithoutAccess, &out.UsersWithoutAccess
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Viewers != nil {
-		in, out := &in.Viewers, &out.Viewers
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
+		*out = new(string)
+		**out = **in
+	}
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(string)
+		**out = **in
+	}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.WeekStart != nil {
+		in, out := &in.WeekStart, &out.WeekStart
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationInitParameters.
-func (in *OrganizationInitParameters) DeepCopy() *OrganizationInitParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPreferencesInitParameters.
+func (in *TeamPreferencesInitParameters) DeepCopy() *TeamPreferencesInitParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(OrganizationInitParameters)
+	out := new(TeamPreferencesInitParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OrganizationList) DeepCopyInto(out *OrganizationList) {
+func (in *TeamPreferencesList) DeepCopyInto(out *TeamPreferencesList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Organization, len(*in))
+		*out = make([]TeamPreferences, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationList.
-func (in *OrganizationList) DeepCopy() *OrganizationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPreferencesList.
+func (in *TeamPreferencesList) DeepCopy() *TeamPreferencesList {
 	if in == nil {
 		return nil
 	}
-	out := new(OrganizationList)
+	out := new(TeamPreferencesList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *OrganizationList) DeepCopyObject() runtime.Object {
+func (in *TeamPreferencesList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1051,194 +6311,151 @@ func (in *OrganizationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OrganizationObservation) DeepCopyInto(out *OrganizationObservation) {
+func (in *TeamPreferencesObservation) DeepCopyInto(out *TeamPreferencesObservation) {
 	*out = *in
-	if in.AdminUser != nil {
-		in, out := &in.AdminUser, &out.AdminUser
+	if in.HomeDashboardUID != nil {
+		in, out := &in.HomeDashboardUID, &out.HomeDashboardUID
 		*out = new(string)
 		**out = **in
 	}
-	if in.Admins != nil {
-		in, out := &in.Admins, &out.Admins
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
-	}
-	if in.CreateUsers != nil {
-		in, out := &in.CreateUsers, &out.CreateUsers
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Editors != nil {
-		in, out := &in.Editors, &out.Editors
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
-	}
 	if in.ID != nil {
 		in, out := &in.ID, &out.ID
 		*out = new(string)
 		**out = **in
 	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
 		*out = new(string)
 		**out = **in
 	}
-	if in.OrgID != nil {
-		in, out := &in.OrgID, &out.OrgID
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
 		*out = new(int64)
 		**out = **in
 	}
-	if in.UsersWithoutAccess != nil {
-		in, out := &in.UsersWithoutAccess, &out.UsersWithoutAccess
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(string)
+		**out = **in
 	}
-	if in.Viewers != nil {
-		in, out := &in.Viewers, &out.Viewers
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.WeekStart != nil {
+		in, out := &in.WeekStart, &out.WeekStart
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationObservation.
-func (in *OrganizationObservation) DeepCopy() *OrganizationObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPreferencesObservation.
+func (in *TeamPreferencesObservation) DeepCopy() *TeamPreferencesObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(OrganizationObservation)
+	out := new(TeamPreferencesObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OrganizationParameters) DeepCopyInto(out *OrganizationParameters) {
+func (in *TeamPreferencesParameters) DeepCopyInto(out *TeamPreferencesParameters) {
 	*out = *in
-	if in.AdminUser != nil {
-		in, out := &in.AdminUser, &out.AdminUser
+	if in.HomeDashboardUID != nil {
+		in, out := &in.HomeDashboardUID, &out.HomeDashboardUID
 		*out = new(string)
 		**out = **in
 	}
-	if in.Admins != nil {
-		in, out := &in.Admins, &out.Admins
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.HomeDashboardRef != nil {
+		in, out := &in.HomeDashboardRef, &out.HomeDashboardRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.CreateUsers != nil {
-		in, out := &in.CreateUsers, &out.CreateUsers
-		*out = new(bool)
+	if in.HomeDashboardSelector != nil {
+		in, out := &in.HomeDashboardSelector, &out.HomeDashboardSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OrgID != nil {
+		in, out := &in.OrgID, &out.OrgID
+		*out = new(string)
 		**out = **in
 	}
-	if in.Editors != nil {
-		in, out := &in.Editors, &out.Editors
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.OrganizationRef != nil {
+		in, out := &in.OrganizationRef, &out.OrganizationRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
+	if in.OrganizationSelector != nil {
+		in, out := &in.OrganizationSelector, &out.OrganizationSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TeamID != nil {
+		in, out := &in.TeamID, &out.TeamID
 		*out = new(string)
 		**out = **in
 	}
-	if in.UsersWithoutAccess != nil {
-		in, out := &in.UsersWithoutAccess, &out.UsersWithoutAccess
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.Theme != nil {
+		in, out := &in.Theme, &out.Theme
+		*out = new(string)
+		**out = **in
 	}
-	if in.Viewers != nil {
-		in, out := &in.Viewers, &out.Viewers
-		*out = make([]*string, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(string)
-				**out = **in
-			}
-		}
+	if in.Timezone != nil {
+		in, out := &in.Timezone, &out.Timezone
+		*out = new(string)
+		**out = **in
+	}
+	if in.WeekStart != nil {
+		in, out := &in.WeekStart, &out.WeekStart
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationParameters.
-func (in *OrganizationParameters) DeepCopy() *OrganizationParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPreferencesParameters.
+func (in *TeamPreferencesParameters) DeepCopy() *TeamPreferencesParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(OrganizationParameters)
+	out := new(TeamPreferencesParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OrganizationSpec) DeepCopyInto(out *OrganizationSpec) {
+func (in *TeamPreferencesSpec) DeepCopyInto(out *TeamPreferencesSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 	in.InitProvider.DeepCopyInto(&out.InitProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationSpec.
-func (in *OrganizationSpec) DeepCopy() *OrganizationSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPreferencesSpec.
+func (in *TeamPreferencesSpec) DeepCopy() *TeamPreferencesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(OrganizationSpec)
+	out := new(TeamPreferencesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OrganizationStatus) DeepCopyInto(out *OrganizationStatus) {
+func (in *TeamPreferencesStatus) DeepCopyInto(out *TeamPreferencesStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationStatus.
-func (in *OrganizationStatus) DeepCopy() *OrganizationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamPreferencesStatus.
+func (in *TeamPreferencesStatus) DeepCopy() *TeamPreferencesStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(OrganizationStatus)
+	out := new(TeamPreferencesStatus)
 	in.DeepCopyInto(out)
 	return out
 }
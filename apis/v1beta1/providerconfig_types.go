@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"reflect"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -26,35 +27,215 @@ import (
 )
 
 // A ProviderConfigSpec defines the desired state of a ProviderConfig.
+// +kubebuilder:validation:XValidation:rule="has(self.cloudApiKey) ? has(self.cloudOrgSlug) || has(self.cloudStackSlug) : has(self.host) && has(self.port) || has(self.cloudStackSlug)",message="host and port or cloudStackSlug are required, or cloudOrgSlug if cloudApiKey is set"
+// +kubebuilder:validation:XValidation:rule="!has(self.cloudStackSlug) || has(self.host) || has(self.cloudAccessPolicyToken)",message="cloudAccessPolicyToken is required to look up the host of cloudStackSlug"
 type ProviderConfigSpec struct {
-	// Credentials required to authenticate to this provider.
+	// Credentials required to authenticate to this provider. They are not used
+	// if CloudAPIKey is set.
 	Credentials ProviderCredentials `json:"credentials"`
 	// Host is the domain name or IP address of the host that serves the API.
-	Host string `json:"host"`
+	// It is derived from CloudOrgSlug if CloudAPIKey is set, or looked up
+	// from CloudStackSlug if it is empty.
+	// +optional
+	Host string `json:"host,omitempty"`
 	// Port is the port number of the host that serves the API.
-	Port int `json:"port"`
+	// +optional
+	Port int `json:"port,omitempty"`
 	// Schemes are the preferred schemes used by the API (https, http).
-	Schemes []string `json:"schemes"`
+	// Defaults to ["https"] if CloudAPIKey is set.
+	// +optional
+	Schemes []string `json:"schemes,omitempty"`
+	// CloudAPIKey references a Grafana Cloud API key or service account
+	// token. If set, it is sent as Bearer token instead of the basic auth
+	// Credentials, to the stack at {CloudOrgSlug}.grafana.net.
+	// +optional
+	CloudAPIKey *xpv1.SecretKeySelector `json:"cloudApiKey,omitempty"`
+	// CloudOrgSlug is the slug of the Grafana Cloud stack, e.g. "mystack"
+	// for mystack.grafana.net. Required if CloudAPIKey is set.
+	// +optional
+	CloudOrgSlug *string `json:"cloudOrgSlug,omitempty"`
+	// CloudStackSlug is the slug of a Grafana Cloud stack whose host, port
+	// and scheme are looked up via the Grafana Cloud API, unless Host is set.
+	// The lookup is cached. It takes precedence over CloudOrgSlug.
+	// +optional
+	CloudStackSlug *string `json:"cloudStackSlug,omitempty"`
+	// CloudAccessPolicyToken references a Grafana Cloud access policy token
+	// with the stacks:read scope, which is used to look up CloudStackSlug.
+	// +optional
+	CloudAccessPolicyToken *xpv1.SecretKeySelector `json:"cloudAccessPolicyToken,omitempty"`
+	// SigningKeySecretRef references the key used to sign secret values, so
+	// that changes to them can be detected without storing them. Rotating the
+	// key marks all resources using signed values as outdated once.
+	// +optional
+	SigningKeySecretRef *xpv1.SecretKeySelector `json:"signingKeySecretRef,omitempty"`
+	// DefaultOrgID is the ID of the organization that is used by resources
+	// without an orgId. It is written to their spec on the first reconcile, so
+	// changing it later does not move existing resources to another org.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DefaultOrgID *int64 `json:"defaultOrgId,omitempty"`
+	// Retry configures how requests that Grafana rejects with 429 Too Many
+	// Requests are retried.
+	// +optional
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// CircuitBreaker configures when requests to an unavailable Grafana are
+	// stopped for a while.
+	// +optional
+	CircuitBreaker *CircuitBreakerPolicy `json:"circuitBreaker,omitempty"`
 }
 
+// A RetryPolicy bounds the retries of requests rejected with 429. The delay
+// before a retry doubles with every attempt and is jittered, unless Grafana
+// asks for a delay via the Retry-After header.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts per request, including the first
+	// one. Defaults to 5, 1 disables retries.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts *int `json:"maxAttempts,omitempty"`
+	// BaseDelay is the delay before the first retry. Defaults to 500ms.
+	// +optional
+	BaseDelay *metav1.Duration `json:"baseDelay,omitempty"`
+	// MaxDelay caps the delay between two attempts, including delays asked
+	// for by Retry-After. Defaults to 10s.
+	// +optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+}
+
+// A CircuitBreakerPolicy configures the circuit breaker of a Grafana host. It
+// opens after a number of consecutive failed requests, and rejects all
+// requests to the host until the cooldown has passed.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failures that open the
+	// circuit. Defaults to 5, 0 disables the circuit breaker.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	FailureThreshold *int `json:"failureThreshold,omitempty"`
+	// Cooldown is how long the circuit stays open. Defaults to 30s.
+	// +optional
+	Cooldown *metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// CredentialsSourceEnvironmentVariable indicates that the username and
+// password are read from the environment variables of the provider named by
+// fromEnvironment.
+const CredentialsSourceEnvironmentVariable xpv1.CredentialsSource = "EnvironmentVariable"
+
+// CredentialsSourceToken indicates that a bearer token is obtained from the
+// token provider configured by token, e.g. a projected service account token.
+// The token is refreshed before it expires.
+const CredentialsSourceToken xpv1.CredentialsSource = "Token"
+
 // ProviderCredentials required to authenticate.
+// +kubebuilder:validation:XValidation:rule="self.source != 'EnvironmentVariable' || has(self.fromEnvironment)",message="fromEnvironment is required if source is EnvironmentVariable"
+// +kubebuilder:validation:XValidation:rule="self.source != 'Token' || has(self.token)",message="token is required if source is Token"
 type ProviderCredentials struct {
 	// Source of the provider credentials.
-	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem
+	// +kubebuilder:validation:Enum=None;Secret;InjectedIdentity;Environment;Filesystem;EnvironmentVariable;Token
 	Source xpv1.CredentialsSource `json:"source"`
 
 	xpv1.CommonCredentialSelectors `json:",inline"`
+
+	// FromEnvironment names the environment variables that hold the username
+	// and password, if the source is EnvironmentVariable.
+	// +optional
+	FromEnvironment *EnvironmentCredentials `json:"fromEnvironment,omitempty"`
+
+	// Token configures how the bearer token is obtained, if the source is
+	// Token.
+	// +optional
+	Token *TokenCredentials `json:"token,omitempty"`
+}
+
+// EnvironmentCredentials names the environment variables of the provider that
+// hold the credentials.
+type EnvironmentCredentials struct {
+	// UsernameVar is the name of the environment variable that holds the
+	// username.
+	UsernameVar string `json:"usernameVar"`
+	// PasswordVar is the name of the environment variable that holds the
+	// password.
+	PasswordVar string `json:"passwordVar"`
+}
+
+// TokenCredentials configure the token provider of the Token source. The token
+// is read from a file, and optionally exchanged for the token that is sent to
+// Grafana.
+type TokenCredentials struct {
+	// Path of the file that holds the token, e.g. a projected service account
+	// token. It is read again whenever the token is refreshed.
+	Path string `json:"path"`
+	// Exchange configures an OAuth 2.0 token exchange (RFC 8693), e.g. at an
+	// OIDC provider, that trades the token of the file for the token that is
+	// sent to Grafana. Without it, the token of the file is sent as is.
+	// +optional
+	Exchange *TokenExchange `json:"exchange,omitempty"`
+}
+
+// TokenExchange configures an OAuth 2.0 token exchange request.
+type TokenExchange struct {
+	// TokenURL is the URL of the token endpoint.
+	TokenURL string `json:"tokenUrl"`
+	// ClientID identifies the provider at the token endpoint.
+	// +optional
+	ClientID string `json:"clientId,omitempty"`
+	// Audience is the audience of the requested token.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+	// Scopes are the scopes of the requested token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// Version of Grafana, as reported by its frontend settings.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// FeatureToggles lists the feature toggles enabled in Grafana, e.g.
+	// nestedFolders.
+	// +optional
+	FeatureToggles []string `json:"featureToggles,omitempty"`
+}
+
+// Reasons a ProviderConfig is or is not ready.
+const (
+	ReasonCredentialsInvalid xpv1.ConditionReason = "CredentialsInvalid"
+	ReasonUnreachable        xpv1.ConditionReason = "Unreachable"
+)
+
+// CredentialsInvalid returns a condition that indicates that the credentials
+// of a ProviderConfig are missing or were rejected by Grafana.
+func CredentialsInvalid(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsInvalid,
+		Message:            msg,
+	}
+}
+
+// Unreachable returns a condition that indicates that Grafana could not be
+// reached to verify the credentials of a ProviderConfig.
+func Unreachable(msg string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUnreachable,
+		Message:            msg,
+	}
 }
 
 // +kubebuilder:object:root=true
 
 // A ProviderConfig configures a Grafana provider.
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
 // +kubebuilder:resource:scope=Cluster
@@ -21,9 +21,51 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CircuitBreakerPolicy) DeepCopyInto(out *CircuitBreakerPolicy) {
+	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int)
+		**out = **in
+	}
+	if in.Cooldown != nil {
+		in, out := &in.Cooldown, &out.Cooldown
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreakerPolicy.
+func (in *CircuitBreakerPolicy) DeepCopy() *CircuitBreakerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(CircuitBreakerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentCredentials) DeepCopyInto(out *EnvironmentCredentials) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentCredentials.
+func (in *EnvironmentCredentials) DeepCopy() *EnvironmentCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
@@ -92,6 +134,46 @@ func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.CloudAPIKey != nil {
+		in, out := &in.CloudAPIKey, &out.CloudAPIKey
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.CloudOrgSlug != nil {
+		in, out := &in.CloudOrgSlug, &out.CloudOrgSlug
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloudStackSlug != nil {
+		in, out := &in.CloudStackSlug, &out.CloudStackSlug
+		*out = new(string)
+		**out = **in
+	}
+	if in.CloudAccessPolicyToken != nil {
+		in, out := &in.CloudAccessPolicyToken, &out.CloudAccessPolicyToken
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.SigningKeySecretRef != nil {
+		in, out := &in.SigningKeySecretRef, &out.SigningKeySecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.DefaultOrgID != nil {
+		in, out := &in.DefaultOrgID, &out.DefaultOrgID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
@@ -108,6 +190,11 @@ func (in *ProviderConfigSpec) DeepCopy() *ProviderConfigSpec {
 func (in *ProviderConfigStatus) DeepCopyInto(out *ProviderConfigStatus) {
 	*out = *in
 	in.ProviderConfigStatus.DeepCopyInto(&out.ProviderConfigStatus)
+	if in.FeatureToggles != nil {
+		in, out := &in.FeatureToggles, &out.FeatureToggles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigStatus.
@@ -182,6 +269,16 @@ func (in *ProviderConfigUsageList) DeepCopyObject() runtime.Object {
 func (in *ProviderCredentials) DeepCopyInto(out *ProviderCredentials) {
 	*out = *in
 	in.CommonCredentialSelectors.DeepCopyInto(&out.CommonCredentialSelectors)
+	if in.FromEnvironment != nil {
+		in, out := &in.FromEnvironment, &out.FromEnvironment
+		*out = new(EnvironmentCredentials)
+		**out = **in
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(TokenCredentials)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredentials.
@@ -193,3 +290,73 @@ func (in *ProviderCredentials) DeepCopy() *ProviderCredentials {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicy) DeepCopyInto(out *RetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.BaseDelay != nil {
+		in, out := &in.BaseDelay, &out.BaseDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicy.
+func (in *RetryPolicy) DeepCopy() *RetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenCredentials) DeepCopyInto(out *TokenCredentials) {
+	*out = *in
+	if in.Exchange != nil {
+		in, out := &in.Exchange, &out.Exchange
+		*out = new(TokenExchange)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenCredentials.
+func (in *TokenCredentials) DeepCopy() *TokenCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenExchange) DeepCopyInto(out *TokenExchange) {
+	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenExchange.
+func (in *TokenExchange) DeepCopy() *TokenExchange {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenExchange)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -42,6 +42,7 @@ import (
 
 	"github.com/argannor/provider-grafana/apis"
 	grafana "github.com/argannor/provider-grafana/internal/controller"
+	"github.com/argannor/provider-grafana/internal/controller/common"
 	"github.com/argannor/provider-grafana/internal/features"
 )
 
@@ -54,6 +55,10 @@ func main() {
 		syncInterval     = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		userCacheTTL     = app.Flag("user-cache-ttl", "How long the users of a Grafana instance are cached when reconciling organizations. Set to 0 to disable caching.").Default(common.DefaultUserCacheTTL.String()).Duration()
+		dashboardTTL     = app.Flag("dashboard-index-ttl", "How long the dashboards of an organization are indexed to look up dashboards without searching for each one. Set to 0 to disable the index.").Default(common.DefaultDashboardIndexTTL.String()).Duration()
+		serverInfoTTL    = app.Flag("server-info-cache-ttl", "How long the version and feature toggles of a Grafana instance are cached. Set to 0 to disable caching.").Default(common.DefaultServerInfoCacheTTL.String()).Duration()
+		shutdownGrace    = app.Flag("shutdown-grace-period", "How long requests to Grafana that are in flight when the provider is asked to stop may take to finish before they are cancelled.").Default(common.DefaultShutdownGracePeriod.String()).Duration()
 
 		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
 		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
@@ -93,6 +98,10 @@ func main() {
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Grafana APIs to scheme")
 
+	common.Users.SetTTL(*userCacheTTL)
+	common.ServerInfos.SetTTL(*serverInfoTTL)
+	common.Dashboards.SetTTL(*dashboardTTL)
+
 	o := controller.Options{
 		Logger:                  log,
 		MaxConcurrentReconciles: *maxReconcileRate,
@@ -126,5 +135,16 @@ func main() {
 	}
 
 	kingpin.FatalIfError(grafana.Setup(mgr, o), "Cannot setup Grafana controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	ctx := ctrl.SetupSignalHandler()
+	go func() {
+		// let the requests to Grafana in flight finish once the provider is asked to stop, so that no change is
+		// interrupted halfway, but cancel those that hang so that the reconciles return before the manager gives up
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownGrace)
+		defer cancel()
+		if err := common.Clients.Shutdown(shutdownCtx); err != nil {
+			log.Info("Cancelled requests to Grafana that did not finish in time", "error", err)
+		}
+	}()
+	kingpin.FatalIfError(mgr.Start(ctx), "Cannot start controller manager")
 }
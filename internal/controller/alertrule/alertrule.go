@@ -0,0 +1,497 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertrule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotAlertRule = "managed resource is not a AlertRule custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errOrgIdNotInt  = "orgId is not an integer"
+	errNoFolderUID  = "folderUid is not set and could not be resolved from folderRef or folderSelector"
+
+	// msgWaitingForFolder explains why an AlertRule whose folder reference is not resolved yet is not applied.
+	msgWaitingForFolder = "waiting for the referenced folder to become ready"
+
+	errNewClient             = "cannot create new Service"
+	errFailedGetAlertRule    = "cannot get AlertRule group from Grafana API"
+	errFailedCreateAlertRule = "cannot create AlertRule group"
+	errFailedUpdateAlertRule = "cannot update AlertRule group"
+	errFailedDeleteAlertRule = "cannot delete AlertRule group"
+
+	errUnmarshalRules = "cannot unmarshal rules JSON"
+	errMarshalRules   = "cannot marshal rules"
+	errCompareRules   = "failed to compare rules"
+
+	// defaultIntervalSeconds is used if no interval is configured, it matches Grafana's default evaluation interval.
+	defaultIntervalSeconds int64 = 60
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+
+	// ignoredRuleFields are computed by Grafana and must not be considered when comparing rules.
+	ignoredRuleFields = []string{"id", "updated", "provenance"}
+)
+
+// Setup adds a controller that reconciles AlertRule managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.AlertRuleGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.AlertRuleGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.AlertRule{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.AlertRule)
+	if !ok {
+		return nil, errors.New(errNotAlertRule)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.AlertRule)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAlertRule)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	if spec.FolderUID == nil {
+		if spec.FolderRef == nil && spec.FolderSelector == nil {
+			return managed.ExternalObservation{}, errors.New(errNoFolderUID)
+		}
+		// an optional folder reference stays unresolved until the folder is ready, a rule group can't have been created
+		// without it, so there is nothing to do but to wait for the next poll
+		cr.SetConditions(v1.Unavailable().WithMessage(msgWaitingForFolder))
+		return managed.ExternalObservation{
+			ResourceExists:          !meta.WasDeleted(cr),
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        true,
+			ConnectionDetails:       managed.ConnectionDetails{},
+		}, nil
+	}
+
+	atGrafana, err := c.service.GetAlertRuleGroup(orgId, *spec.FolderUID, *spec.RuleGroupName)
+
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetAlertRule)
+	}
+
+	// Grafana has no notion of an empty rule group, a group without rules does not exist
+	if atGrafana == nil || len(atGrafana.Rules) == 0 {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	upToDate, err := isUpToDate(cr, atGrafana, orgId)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.AlertRule)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAlertRule)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	if spec.FolderUID == nil {
+		return managed.ExternalCreation{}, errors.New(errNoFolderUID)
+	}
+
+	ruleGroup, err := makeRuleGroup(cr, orgId)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	response, err := c.service.PutAlertRuleGroup(orgId, *spec.FolderUID, *spec.RuleGroupName, ruleGroup)
+
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateAlertRule)
+	}
+
+	copyToStatus(response, cr, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.AlertRule)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAlertRule)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	if spec.FolderUID == nil {
+		return managed.ExternalUpdate{}, errors.New(errNoFolderUID)
+	}
+
+	ruleGroup, err := makeRuleGroup(cr, orgId)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	atGrafana, err := c.service.GetAlertRuleGroup(orgId, *spec.FolderUID, *spec.RuleGroupName)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedGetAlertRule)
+	}
+	if atGrafana != nil {
+		// Grafana replaces rules without an UID, keep the existing ones to preserve the state and history of the rules
+		preserveRuleUIDs(ruleGroup.Rules, atGrafana.Rules)
+	}
+
+	response, err := c.service.PutAlertRuleGroup(orgId, *spec.FolderUID, *spec.RuleGroupName, ruleGroup)
+
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateAlertRule)
+	}
+
+	copyToStatus(response, cr, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.AlertRule)
+	if !ok {
+		return errors.New(errNotAlertRule)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	if spec.FolderUID == nil {
+		return errors.New(errNoFolderUID)
+	}
+
+	atGrafana, err := c.service.GetAlertRuleGroup(orgId, *spec.FolderUID, *spec.RuleGroupName)
+	if err != nil {
+		return errors.Wrap(err, errFailedDeleteAlertRule)
+	}
+	if atGrafana == nil {
+		return nil
+	}
+
+	// the provisioning API has no endpoint to delete a whole group, the group vanishes with its last rule
+	for _, rule := range atGrafana.Rules {
+		if err := c.service.DeleteAlertRule(orgId, rule.UID); err != nil {
+			return errors.Wrap(err, errFailedDeleteAlertRule)
+		}
+	}
+	return nil
+}
+
+func copyToStatus(response *models.AlertRuleGroup, cr *v1alpha1.AlertRule, orgId string) {
+	id := fmt.Sprintf("%s:%s:%s", orgId, response.FolderUID, response.Title)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.FolderUID = &response.FolderUID
+	cr.Status.AtProvider.RuleGroupName = &response.Title
+	cr.Status.AtProvider.IntervalSeconds = &response.Interval
+	ruleUIDs := make([]*string, 0, len(response.Rules))
+	for _, rule := range response.Rules {
+		uid := rule.UID
+		ruleUIDs = append(ruleUIDs, &uid)
+	}
+	cr.Status.AtProvider.RuleUIDs = ruleUIDs
+}
+
+func parseRulesJson(rulesJson *string) ([]*models.ProvisionedAlertRule, error) {
+	rules := make([]*models.ProvisionedAlertRule, 0)
+	if rulesJson == nil || *rulesJson == "" {
+		return rules, nil
+	}
+	if err := json.Unmarshal([]byte(*rulesJson), &rules); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalRules)
+	}
+	return rules, nil
+}
+
+// makeRuleGroup builds the complete rule group as it should be stored in Grafana. Fields that describe the location
+// of the rules are always taken from the managed resource, so they don't need to be repeated in every rule.
+func makeRuleGroup(cr *v1alpha1.AlertRule, orgId int64) (*models.AlertRuleGroup, error) {
+	spec := cr.Spec.ForProvider
+	rules, err := parseRulesJson(spec.RulesJSON)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		rule.FolderUID = spec.FolderUID
+		rule.RuleGroup = spec.RuleGroupName
+		rule.OrgID = &orgId
+	}
+	return &models.AlertRuleGroup{
+		FolderUID: *spec.FolderUID,
+		Interval:  common.DefaultInt64(spec.IntervalSeconds, defaultIntervalSeconds),
+		Rules:     rules,
+		Title:     *spec.RuleGroupName,
+	}, nil
+}
+
+// preserveRuleUIDs copies the UIDs of existing rules to desired rules with the same title, if they have no UID set.
+func preserveRuleUIDs(desired []*models.ProvisionedAlertRule, actual []*models.ProvisionedAlertRule) {
+	uidByTitle := make(map[string]string)
+	for _, rule := range actual {
+		if rule.Title != nil {
+			uidByTitle[*rule.Title] = rule.UID
+		}
+	}
+	for _, rule := range desired {
+		if rule.UID != "" || rule.Title == nil {
+			continue
+		}
+		if uid, ok := uidByTitle[*rule.Title]; ok {
+			rule.UID = uid
+		}
+	}
+}
+
+// normalizeRules converts the rules into their generic JSON representation, without the fields computed by Grafana.
+func normalizeRules(rules []*models.ProvisionedAlertRule) ([]interface{}, error) {
+	raw, err := json.Marshal(rules)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalRules)
+	}
+	normalized := make([]interface{}, 0)
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalRules)
+	}
+	for _, rule := range normalized {
+		if asMap, ok := rule.(map[string]interface{}); ok {
+			for _, field := range ignoredRuleFields {
+				delete(asMap, field)
+			}
+		}
+	}
+	return normalized, nil
+}
+
+func isUpToDate(cr *v1alpha1.AlertRule, atGrafana *models.AlertRuleGroup, orgId int64) (bool, error) {
+	spec := cr.Spec.ForProvider
+	upToDate := true
+
+	upToDate = upToDate && common.CompareOptional(spec.IntervalSeconds, atGrafana.Interval, defaultIntervalSeconds)
+
+	desiredGroup, err := makeRuleGroup(cr, orgId)
+	if err != nil {
+		return false, err
+	}
+	desired, err := normalizeRules(desiredGroup.Rules)
+	if err != nil {
+		return false, err
+	}
+	actual, err := normalizeRules(atGrafana.Rules)
+	if err != nil {
+		return false, err
+	}
+	// UIDs are generated by Grafana if they are not part of the desired rule
+	for i := 0; i < len(desired) && i < len(actual); i++ {
+		desiredRule, desiredOk := desired[i].(map[string]interface{})
+		actualRule, actualOk := actual[i].(map[string]interface{})
+		if _, hasUID := desiredRule["uid"]; desiredOk && actualOk && !hasUID {
+			delete(actualRule, "uid")
+		}
+	}
+	rulesUpToDate, err := common.CompareSlice(desired, actual)
+	if err != nil {
+		return false, errors.Wrap(err, errCompareRules)
+	}
+	upToDate = upToDate && rulesUpToDate
+
+	return upToDate, nil
+}
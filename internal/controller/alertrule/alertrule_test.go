@@ -0,0 +1,272 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alertrule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+const rulesJson = `[{"title": "test", "condition": "A", "for": "5m", "data": [{"refId": "A", "datasourceUid": "__expr__", "model": {"type": "math", "expression": "1"}}]}]`
+
+func alertRule() *v1alpha1.AlertRule {
+	return &v1alpha1.AlertRule{
+		Spec: v1alpha1.AlertRuleSpec{
+			ForProvider: v1alpha1.AlertRuleParameters{
+				FolderUID:     strRef("abc"),
+				OrgID:         strRef("1"),
+				RuleGroupName: strRef("test"),
+				RulesJSON:     strRef(rulesJson),
+			},
+		},
+	}
+}
+
+func grafanaRuleGroup(expression string) *models.AlertRuleGroup {
+	orgId := int64(1)
+	forDuration, _ := strfmtDuration("5m")
+	return &models.AlertRuleGroup{
+		FolderUID: "abc",
+		Interval:  60,
+		Title:     "test",
+		Rules: []*models.ProvisionedAlertRule{
+			{
+				ID:        7,
+				UID:       "rule-uid",
+				Title:     strRef("test"),
+				Condition: strRef("A"),
+				For:       forDuration,
+				FolderUID: strRef("abc"),
+				RuleGroup: strRef("test"),
+				OrgID:     &orgId,
+				Data: []*models.AlertQuery{
+					{
+						RefID:         "A",
+						DatasourceUID: "__expr__",
+						Model:         map[string]interface{}{"type": "math", "expression": expression},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type fields struct {
+		service common.GrafanaAPI
+		logger  logging.Logger
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"NotAlertRule": {
+			reason: "An error should be returned if the managed resource is not an AlertRule",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg:  &v1alpha1.Folder{},
+			},
+			want: want{
+				err: errors.New(errNotAlertRule),
+			},
+		},
+		"NoFolder": {
+			reason: "An error should be returned if neither the folder UID nor a reference to a folder is set",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg: func() resource.Managed {
+					cr := alertRule()
+					cr.Spec.ForProvider.FolderUID = nil
+					return cr
+				}(),
+			},
+			want: want{
+				err: errors.New(errNoFolderUID),
+			},
+		},
+		"FolderNotReady": {
+			reason: "The AlertRule should wait without an error while its folder reference is not resolved",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg: func() resource.Managed {
+					cr := alertRule()
+					cr.Spec.ForProvider.FolderUID = nil
+					cr.Spec.ForProvider.FolderRef = &xpv1.Reference{Name: "alerts"}
+					return cr
+				}(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the rule group cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAlertRuleGroup", int64(1), "abc", "test").Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  alertRule(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetAlertRule),
+			},
+		},
+		"NotFound": {
+			reason: "The AlertRule should be reported as missing if Grafana does not know the rule group",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAlertRuleGroup", int64(1), "abc", "test").Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  alertRule(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The AlertRule should be reported as up to date if the rules match the desired state",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAlertRuleGroup", int64(1), "abc", "test").Return(grafanaRuleGroup("1"), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  alertRule(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "The AlertRule should be reported as outdated if a rule differs from the desired state",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAlertRuleGroup", int64(1), "abc", "test").Return(grafanaRuleGroup("2"), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  alertRule(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.fields.service, logger: tc.fields.logger}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPreserveRuleUIDs(t *testing.T) {
+	desired := []*models.ProvisionedAlertRule{
+		{Title: strRef("kept")},
+		{Title: strRef("explicit"), UID: "explicit-uid"},
+		{Title: strRef("new")},
+	}
+	actual := []*models.ProvisionedAlertRule{
+		{Title: strRef("kept"), UID: "kept-uid"},
+		{Title: strRef("explicit"), UID: "other-uid"},
+	}
+	preserveRuleUIDs(desired, actual)
+	assert.Equal(t, "kept-uid", desired[0].UID)
+	assert.Equal(t, "explicit-uid", desired[1].UID)
+	assert.Equal(t, "", desired[2].UID)
+}
+
+func strfmtDuration(s string) (*strfmt.Duration, error) {
+	d, err := strfmt.ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+	duration := strfmt.Duration(d)
+	return &duration, nil
+}
+
+func strRef(s string) *string {
+	return &s
+}
@@ -0,0 +1,442 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotAnnotation = "managed resource is not an Annotation custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errOrgIdNotInt   = "orgId is not an integer"
+	errTimeFormat    = "%s is not an RFC 3339 timestamp"
+
+	errNewClient              = "cannot create new Service"
+	errFailedGetAnnotation    = "cannot get Annotation from Grafana API"
+	errFailedCreateAnnotation = "cannot create Annotation"
+	errFailedUpdateAnnotation = "cannot update Annotation"
+	errFailedDeleteAnnotation = "cannot delete Annotation"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles Annotation managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.AnnotationGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.AnnotationGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Annotation{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Annotation)
+	if !ok {
+		return nil, errors.New(errNotAnnotation)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Annotation)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAnnotation)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// annotations have no name to look them up by, so they only exist once created
+	if cr.Status.AtProvider.AnnotationID == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	atGrafana, err := c.service.GetAnnotation(orgId, *cr.Status.AtProvider.AnnotationID)
+
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetAnnotation)
+	}
+
+	if atGrafana == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	upToDate, err := isUpToDate(cr, atGrafana)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr, *cr.Spec.ForProvider.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Annotation)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAnnotation)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	start, err := toEpochMillis("time", spec.Time)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	end, err := toEpochMillis("timeEnd", spec.TimeEnd)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	id, err := c.service.CreateAnnotation(orgId, &models.PostAnnotationsCmd{
+		DashboardUID: common.DefaultString(spec.DashboardUID, ""),
+		PanelID:      common.DefaultInt64(spec.PanelID, 0),
+		Tags:         tags(spec.Tags),
+		Text:         spec.Text,
+		Time:         start,
+		TimeEnd:      end,
+	})
+
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateAnnotation)
+	}
+
+	statusId := fmt.Sprintf("%s:%d", *spec.OrgID, id)
+	cr.Status.AtProvider.ID = &statusId
+	cr.Status.AtProvider.AnnotationID = &id
+	cr.Status.AtProvider.OrgID = spec.OrgID
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Annotation)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAnnotation)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	// the update replaces the whole annotation, times that are not part of the spec are kept as observed
+	start, err := toEpochMillis("time", firstSet(spec.Time, cr.Status.AtProvider.Time))
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	end, err := toEpochMillis("timeEnd", firstSet(spec.TimeEnd, cr.Status.AtProvider.TimeEnd))
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	err = c.service.UpdateAnnotation(orgId, *cr.Status.AtProvider.AnnotationID, &models.UpdateAnnotationsCmd{
+		ID:      *cr.Status.AtProvider.AnnotationID,
+		Tags:    tags(spec.Tags),
+		Text:    common.DefaultString(spec.Text, ""),
+		Time:    start,
+		TimeEnd: end,
+	})
+
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateAnnotation)
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Annotation)
+	if !ok {
+		return errors.New(errNotAnnotation)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	err = c.service.DeleteAnnotation(orgId, *cr.Status.AtProvider.AnnotationID)
+
+	return errors.Wrap(err, errFailedDeleteAnnotation)
+}
+
+func copyToStatus(response *models.Annotation, cr *v1alpha1.Annotation, orgId string) {
+	id := fmt.Sprintf("%s:%d", orgId, response.ID)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.AnnotationID = &response.ID
+	cr.Status.AtProvider.DashboardUID = &response.DashboardUID
+	cr.Status.AtProvider.PanelID = &response.PanelID
+	cr.Status.AtProvider.Text = &response.Text
+	cr.Status.AtProvider.Tags = make([]*string, 0, len(response.Tags))
+	for i := range response.Tags {
+		cr.Status.AtProvider.Tags = append(cr.Status.AtProvider.Tags, &response.Tags[i])
+	}
+	cr.Status.AtProvider.Time = fromEpochMillis(response.Time)
+	cr.Status.AtProvider.TimeEnd = fromEpochMillis(response.TimeEnd)
+}
+
+// isUpToDate compares the text and tags of the annotation, the order of the tags does not matter. Times are compared
+// as instants and only if they are set, as Grafana defaults them on creation.
+func isUpToDate(cr *v1alpha1.Annotation, atGrafana *models.Annotation) (bool, error) {
+	spec := cr.Spec.ForProvider
+	upToDate := true
+
+	upToDate = upToDate && common.CompareOptional(spec.Text, atGrafana.Text, "")
+	upToDate = upToDate && tagsEqualIgnoreOrder(tags(spec.Tags), atGrafana.Tags)
+
+	if spec.Time != nil {
+		start, err := toEpochMillis("time", spec.Time)
+		if err != nil {
+			return false, err
+		}
+		upToDate = upToDate && start == atGrafana.Time
+	}
+	if spec.TimeEnd != nil {
+		end, err := toEpochMillis("timeEnd", spec.TimeEnd)
+		if err != nil {
+			return false, err
+		}
+		upToDate = upToDate && end == atGrafana.TimeEnd
+	}
+
+	return upToDate, nil
+}
+
+func tags(refs []*string) []string {
+	result := make([]string, 0, len(refs))
+	for _, tag := range refs {
+		if tag != nil {
+			result = append(result, *tag)
+		}
+	}
+	return result
+}
+
+func tagsEqualIgnoreOrder(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, tag := range a {
+		counts[tag]++
+	}
+	for _, tag := range b {
+		if counts[tag] == 0 {
+			return false
+		}
+		counts[tag]--
+	}
+	return true
+}
+
+// toEpochMillis converts an RFC 3339 timestamp to the epoch milliseconds expected by Grafana, nil is converted to 0,
+// which lets Grafana pick the default.
+func toEpochMillis(field string, timestamp *string) (int64, error) {
+	if timestamp == nil {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return 0, errors.Wrapf(err, errTimeFormat, field)
+	}
+	return t.UnixMilli(), nil
+}
+
+func fromEpochMillis(millis int64) *string {
+	if millis == 0 {
+		return nil
+	}
+	timestamp := time.UnixMilli(millis).UTC().Format(time.RFC3339Nano)
+	return &timestamp
+}
+
+func firstSet(values ...*string) *string {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+// 2024-01-01T12:00:00Z and 2024-01-01T12:15:00Z in epoch milliseconds
+const (
+	start int64 = 1704110400000
+	end   int64 = 1704111300000
+)
+
+func strRef(s string) *string {
+	return &s
+}
+
+func annotation() *v1alpha1.Annotation {
+	var id int64 = 7
+	return &v1alpha1.Annotation{
+		Spec: v1alpha1.AnnotationSpec{
+			ForProvider: v1alpha1.AnnotationParameters{
+				DashboardUID: strRef("abc"),
+				OrgID:        strRef("1"),
+				Tags:         []*string{strRef("deployment"), strRef("crossplane")},
+				Text:         strRef("deployed"),
+				Time:         strRef("2024-01-01T12:00:00Z"),
+				TimeEnd:      strRef("2024-01-01T12:15:00Z"),
+			},
+		},
+		Status: v1alpha1.AnnotationStatus{
+			AtProvider: v1alpha1.AnnotationObservation{
+				AnnotationID: &id,
+			},
+		},
+	}
+}
+
+func grafanaAnnotation(text string, tags ...string) *models.Annotation {
+	return &models.Annotation{
+		DashboardUID: "abc",
+		ID:           7,
+		Tags:         tags,
+		Text:         text,
+		Time:         start,
+		TimeEnd:      end,
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotAnnotation": {
+			reason:  "An error should be returned if the managed resource is not an Annotation",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotAnnotation),
+			},
+		},
+		"NotCreated": {
+			reason:  "An Annotation without ID in status should be reported as missing",
+			service: &common.MockGrafanaAPI{},
+			mg: func() resource.Managed {
+				cr := annotation()
+				cr.Status.AtProvider.AnnotationID = nil
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the annotation cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAnnotation", int64(1), int64(7)).Return(nil, errBoom)
+				return m
+			}(),
+			mg: annotation(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetAnnotation),
+			},
+		},
+		"NotFound": {
+			reason: "An Annotation should be reported as missing if it was deleted in Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAnnotation", int64(1), int64(7)).Return(nil, nil)
+				return m
+			}(),
+			mg: annotation(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "An Annotation should be up to date if text, tags and times match, regardless of the order of the tags",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAnnotation", int64(1), int64(7)).Return(grafanaAnnotation("deployed", "crossplane", "deployment"), nil)
+				return m
+			}(),
+			mg: annotation(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"TimeInOtherZone": {
+			reason: "Times should be compared as instants, not as strings",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAnnotation", int64(1), int64(7)).Return(grafanaAnnotation("deployed", "deployment", "crossplane"), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := annotation()
+				cr.Spec.ForProvider.Time = strRef("2024-01-01T13:00:00+01:00")
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"TextChanged": {
+			reason: "An Annotation should be outdated if the text differs",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAnnotation", int64(1), int64(7)).Return(grafanaAnnotation("rolled back", "deployment", "crossplane"), nil)
+				return m
+			}(),
+			mg: annotation(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"TagRemoved": {
+			reason: "An Annotation should be outdated if the tags differ",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAnnotation", int64(1), int64(7)).Return(grafanaAnnotation("deployed", "deployment"), nil)
+				return m
+			}(),
+			mg: annotation(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"InvalidTime": {
+			reason: "An error should be returned if a time is no RFC 3339 timestamp",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAnnotation", int64(1), int64(7)).Return(grafanaAnnotation("deployed", "deployment", "crossplane"), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := annotation()
+				cr.Spec.ForProvider.TimeEnd = strRef("yesterday")
+				return cr
+			}(),
+			want: want{
+				err: errors.Wrapf(func() error { _, err := time.Parse(time.RFC3339, "yesterday"); return err }(), errTimeFormat, "timeEnd"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveCopiesTimesToStatus(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetAnnotation", int64(1), int64(7)).Return(grafanaAnnotation("deployed", "deployment", "crossplane"), nil)
+
+	cr := annotation()
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(strRef("2024-01-01T12:00:00Z"), cr.Status.AtProvider.Time); diff != "" {
+		t.Errorf("e.Observe(...): -want time, +got time:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(strRef("2024-01-01T12:15:00Z"), cr.Status.AtProvider.TimeEnd); diff != "" {
+		t.Errorf("e.Observe(...): -want timeEnd, +got timeEnd:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateAnnotation", int64(1), &models.PostAnnotationsCmd{
+		DashboardUID: "abc",
+		Tags:         []string{"deployment", "crossplane"},
+		Text:         strRef("deployed"),
+		Time:         start,
+		TimeEnd:      end,
+	}).Return(int64(7), nil)
+
+	cr := annotation()
+	cr.Status.AtProvider = v1alpha1.AnnotationObservation{}
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	var id int64 = 7
+	if diff := cmp.Diff(&id, cr.Status.AtProvider.AnnotationID); diff != "" {
+		t.Errorf("e.Create(...): -want annotation ID, +got annotation ID:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(strRef("1:7"), cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateGlobalWithoutTimes(t *testing.T) {
+	// times are left to Grafana, which uses the time of creation
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateAnnotation", int64(1), &models.PostAnnotationsCmd{
+		Tags: []string{},
+		Text: strRef("deployed"),
+	}).Return(int64(7), nil)
+
+	cr := annotation()
+	cr.Spec.ForProvider = v1alpha1.AnnotationParameters{OrgID: strRef("1"), Text: strRef("deployed")}
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		mg      *v1alpha1.Annotation
+		command *models.UpdateAnnotationsCmd
+	}{
+		"TextAndTags": {
+			reason:  "Text, tags and times of the spec should be sent to Grafana",
+			mg:      annotation(),
+			command: &models.UpdateAnnotationsCmd{ID: 7, Text: "deployed", Tags: []string{"deployment", "crossplane"}, Time: start, TimeEnd: end},
+		},
+		"KeepsObservedTimes": {
+			reason: "Times that are not part of the spec should be kept as observed, as the update replaces the annotation",
+			mg: func() *v1alpha1.Annotation {
+				cr := annotation()
+				cr.Spec.ForProvider.Time = nil
+				cr.Spec.ForProvider.TimeEnd = nil
+				cr.Status.AtProvider.Time = strRef("2024-01-01T12:00:00Z")
+				cr.Status.AtProvider.TimeEnd = strRef("2024-01-01T12:15:00Z")
+				return cr
+			}(),
+			command: &models.UpdateAnnotationsCmd{ID: 7, Text: "deployed", Tags: []string{"deployment", "crossplane"}, Time: start, TimeEnd: end},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("UpdateAnnotation", int64(1), int64(7), tc.command).Return(nil)
+
+			e := external{service: m}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateFailed(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("UpdateAnnotation", int64(1), int64(7), &models.UpdateAnnotationsCmd{ID: 7, Text: "deployed", Tags: []string{"deployment", "crossplane"}, Time: start, TimeEnd: end}).Return(errBoom)
+
+	e := external{service: m}
+	_, err := e.Update(context.Background(), annotation())
+	if diff := cmp.Diff(errors.Wrap(errBoom, errFailedUpdateAnnotation), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteAnnotation", int64(1), int64(7)).Return(nil)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), annotation())
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
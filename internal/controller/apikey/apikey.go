@@ -0,0 +1,323 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotAPIKey    = "managed resource is not an APIKey custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errOrgIdNotInt  = "orgId is not an integer"
+
+	errNewClient          = "cannot create new Service"
+	errFailedGetAPIKey    = "cannot get APIKey from Grafana API"
+	errFailedCreateAPIKey = "cannot create APIKey"
+	errFailedDeleteAPIKey = "cannot delete APIKey"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles APIKey managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.APIKeyGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.APIKeyGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.APIKey{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.APIKey)
+	if !ok {
+		return nil, errors.New(errNotAPIKey)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.APIKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAPIKey)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// the key can't be read back from Grafana, so API keys that were not created by the provider can't be adopted
+	if cr.Status.AtProvider.KeyID == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	keys, err := c.service.GetAPIKeys(orgId)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetAPIKey)
+	}
+
+	atGrafana := findAPIKey(keys, *cr.Status.AtProvider.KeyID, common.DefaultString(cr.Spec.ForProvider.Name, ""))
+	// expired API keys are created again, as Grafana deletes them eventually
+	if atGrafana == nil || expired(atGrafana) {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	cr.SetConditions(v1.Available())
+	cr.Status.AtProvider.ExpiresAt = expiresAt(atGrafana)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// API keys can't be updated, all their parameters are immutable instead.
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// Create creates the API key and publishes it as connection detail, as Grafana returns it only once. An expired API key
+// of the resource is deleted first, so that its name can be used again.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.APIKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAPIKey)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	if err := c.delete(orgId, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	response, err := c.service.CreateAPIKey(orgId, &models.AddAPIKeyCommand{
+		Name:          common.DefaultString(spec.Name, ""),
+		Role:          common.DefaultString(spec.Role, ""),
+		SecondsToLive: common.DefaultInt64(spec.SecondsToLive, 0),
+	})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateAPIKey)
+	}
+
+	id := fmt.Sprintf("%s:%d", *spec.OrgID, response.ID)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = spec.OrgID
+	cr.Status.AtProvider.KeyID = &response.ID
+	cr.Status.AtProvider.ExpiresAt = nil
+	if spec.SecondsToLive != nil {
+		expiresAt := time.Now().Add(time.Duration(*spec.SecondsToLive) * time.Second).UTC().Format(time.RFC3339)
+		cr.Status.AtProvider.ExpiresAt = &expiresAt
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{"apiKey": []byte(response.Key)},
+	}, nil
+}
+
+// Update does nothing, since Grafana can't update API keys and all their parameters are immutable.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.APIKey); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAPIKey)
+	}
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.APIKey)
+	if !ok {
+		return errors.New(errNotAPIKey)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	return c.delete(orgId, cr)
+}
+
+// delete deletes the API key in the status, if any.
+func (c *external) delete(orgId int64, cr *v1alpha1.APIKey) error {
+	if cr.Status.AtProvider.KeyID == nil {
+		return nil
+	}
+	err := c.service.DeleteAPIKey(orgId, *cr.Status.AtProvider.KeyID)
+	return errors.Wrap(err, errFailedDeleteAPIKey)
+}
+
+// expired returns true if the API key expired, but Grafana did not delete it yet.
+func expired(key *models.APIKeyDTO) bool {
+	expiration := time.Time(key.Expiration)
+	return !expiration.IsZero() && !time.Now().Before(expiration)
+}
+
+// findAPIKey returns the API key with the ID, or nil if there is none. A key with the ID but another name was
+// replaced, e.g. after it was deleted and the ID was reused, and is not returned either.
+func findAPIKey(keys []*models.APIKeyDTO, id int64, name string) *models.APIKeyDTO {
+	for _, key := range keys {
+		if key.ID == id && key.Name == name {
+			return key
+		}
+	}
+	return nil
+}
+
+// expiresAt returns when the API key expires as RFC 3339 timestamp, or nil if it never expires.
+func expiresAt(key *models.APIKeyDTO) *string {
+	expiration := time.Time(key.Expiration)
+	if expiration.IsZero() {
+		return nil
+	}
+	formatted := expiration.UTC().Format(time.RFC3339)
+	return &formatted
+}
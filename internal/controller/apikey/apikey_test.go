@@ -0,0 +1,276 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func int64Ref(i int64) *int64 {
+	return &i
+}
+
+func apiKey() *v1alpha1.APIKey {
+	return &v1alpha1.APIKey{
+		Spec: v1alpha1.APIKeySpec{
+			ForProvider: v1alpha1.APIKeyParameters{
+				Name:  strRef("ci"),
+				OrgID: strRef("1"),
+				Role:  strRef("Editor"),
+			},
+		},
+		Status: v1alpha1.APIKeyStatus{
+			AtProvider: v1alpha1.APIKeyObservation{
+				ID:    strRef("1:3"),
+				KeyID: int64Ref(3),
+				OrgID: strRef("1"),
+			},
+		},
+	}
+}
+
+func grafanaAPIKey(id int64, name string, expiration time.Time) *models.APIKeyDTO {
+	return &models.APIKeyDTO{ID: id, Name: name, Role: "Editor", Expiration: strfmt.DateTime(expiration)}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotAPIKey": {
+			reason:  "An error should be returned if the managed resource is not an APIKey",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotAPIKey),
+			},
+		},
+		"NotCreated": {
+			reason:  "An APIKey without key ID in status should be reported as missing",
+			service: &common.MockGrafanaAPI{},
+			mg: func() resource.Managed {
+				cr := apiKey()
+				cr.Status.AtProvider = v1alpha1.APIKeyObservation{}
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the API keys cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAPIKeys", int64(1)).Return(nil, errBoom)
+				return m
+			}(),
+			mg: apiKey(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetAPIKey),
+			},
+		},
+		"NotFound": {
+			reason: "An APIKey should be reported as missing if it was deleted in Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAPIKeys", int64(1)).Return([]*models.APIKeyDTO{grafanaAPIKey(4, "ci", time.Time{})}, nil)
+				return m
+			}(),
+			mg: apiKey(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Replaced": {
+			reason: "An APIKey should be reported as missing if Grafana has another key with its ID",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAPIKeys", int64(1)).Return([]*models.APIKeyDTO{grafanaAPIKey(3, "deploy", time.Time{})}, nil)
+				return m
+			}(),
+			mg: apiKey(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Expired": {
+			reason: "An APIKey should be reported as missing once it expired, so that it is created again",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAPIKeys", int64(1)).Return([]*models.APIKeyDTO{grafanaAPIKey(3, "ci", time.Now().Add(-time.Minute))}, nil)
+				return m
+			}(),
+			mg: apiKey(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Exists": {
+			reason: "An APIKey should be reported as up to date if Grafana has it",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAPIKeys", int64(1)).Return([]*models.APIKeyDTO{
+					grafanaAPIKey(2, "deploy", time.Time{}),
+					grafanaAPIKey(3, "ci", time.Time{}),
+				}, nil)
+				return m
+			}(),
+			mg: apiKey(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveCopiesExpiryToStatus(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetAPIKeys", int64(1)).Return([]*models.APIKeyDTO{grafanaAPIKey(3, "ci", time.Date(2074, 1, 1, 12, 0, 0, 0, time.UTC))}, nil)
+
+	cr := apiKey()
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(strRef("2074-01-01T12:00:00Z"), cr.Status.AtProvider.ExpiresAt); diff != "" {
+		t.Errorf("e.Observe(...): -want expiresAt, +got expiresAt:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateAPIKey", int64(1), &models.AddAPIKeyCommand{
+		Name:          "ci",
+		Role:          "Editor",
+		SecondsToLive: 3600,
+	}).Return(&models.NewAPIKeyResult{ID: 3, Key: "secret", Name: "ci"}, nil)
+
+	cr := apiKey()
+	cr.Spec.ForProvider.SecondsToLive = int64Ref(3600)
+	cr.Status.AtProvider = v1alpha1.APIKeyObservation{}
+	e := external{service: m}
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	if cr.Status.AtProvider.ExpiresAt == nil {
+		t.Errorf("e.Create(...): want expiresAt to be set for an API key that expires")
+	}
+	cr.Status.AtProvider.ExpiresAt = nil
+	if diff := cmp.Diff(apiKey().Status.AtProvider, cr.Status.AtProvider); diff != "" {
+		t.Errorf("e.Create(...): -want status, +got status:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{"apiKey": []byte("secret")}}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateReplacesExpiredKey(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteAPIKey", int64(1), int64(3)).Return(nil)
+	m.On("CreateAPIKey", int64(1), &models.AddAPIKeyCommand{
+		Name: "ci",
+		Role: "Editor",
+	}).Return(&models.NewAPIKeyResult{ID: 4, Key: "new-secret", Name: "ci"}, nil)
+
+	cr := apiKey()
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	want := v1alpha1.APIKeyObservation{
+		ID:    strRef("1:4"),
+		KeyID: int64Ref(4),
+		OrgID: strRef("1"),
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("e.Create(...): -want status, +got status:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteAPIKey", int64(1), int64(3)).Return(nil)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), apiKey())
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDeleteFailed(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteAPIKey", int64(1), int64(3)).Return(errBoom)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), apiKey())
+	if diff := cmp.Diff(errors.Wrap(errBoom, errFailedDeleteAPIKey), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+}
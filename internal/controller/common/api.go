@@ -1,12 +1,22 @@
 package common
 
 import (
+	"context"
 	"crypto/rand"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/grafana/grafana-openapi-client-go/client/access_control"
+	"github.com/grafana/grafana-openapi-client-go/client/api_keys"
+	"github.com/grafana/grafana-openapi-client-go/client/datasources"
 	"github.com/grafana/grafana-openapi-client-go/client/folders"
+	"github.com/grafana/grafana-openapi-client-go/client/provisioning"
 	"github.com/grafana/grafana-openapi-client-go/client/search"
+	"github.com/grafana/grafana-openapi-client-go/client/teams"
 
 	grafana "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/client/orgs"
@@ -22,46 +32,283 @@ import (
 // 404 is returned iff the user has access to the organization and the resource type, but the resource is missing
 var ignoreStatusCodesOnObserve = []int{http.StatusForbidden, http.StatusNotFound}
 
+// ErrAmbiguousFolderTitle is returned if a folder is looked up by its title, but multiple folders have that title.
+var ErrAmbiguousFolderTitle = errors.New("title matches multiple folders")
+
+// ErrAmbiguousDashboardTitle is returned if a dashboard is looked up by its title, but multiple dashboards in the
+// folder have that title.
+var ErrAmbiguousDashboardTitle = errors.New("title matches multiple dashboards in the folder")
+
+// ErrCircuitOpen is returned instead of sending requests to a Grafana instance that failed too often in a row, until
+// the cooldown of its CircuitBreaker has passed.
+var ErrCircuitOpen = errors.New("circuit breaker is open, Grafana is unavailable")
+
+// DefaultPerPage is the number of items requested per page from paginated APIs unless configured otherwise.
+const DefaultPerPage int64 = 1000
+
+// DefaultMaxPages is the number of pages requested from paginated APIs at most unless configured otherwise.
+const DefaultMaxPages int64 = 1000
+
+// errTooManyPages is returned if a paginated API has more pages than the client requests at most.
+const errTooManyPages = "stopped after %d pages of %d items, Grafana returned more than the client requests at most"
+
+// rulerPath is the path of the ruler API for Grafana managed rules, relative to the base path of the client.
+const rulerPath = "/ruler/grafana/api/v1/rules"
+
 type ApiError interface {
 	error
 	IsCode(code int) bool
 }
 
+// A RateLimitError is returned if Grafana still rejects a request with 429 Too Many Requests after all retries.
+// RetryAfter is the delay Grafana asked for via the Retry-After header, or 0 if it didn't.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("rate limited by Grafana, retry after %s", e.RetryAfter)
+	}
+	return "rate limited by Grafana"
+}
+
+// IsCode returns true for 429 Too Many Requests.
+func (e *RateLimitError) IsCode(code int) bool {
+	return code == http.StatusTooManyRequests
+}
+
+// AsRateLimitError returns the RateLimitError that caused err, if any.
+func AsRateLimitError(err error) (*RateLimitError, bool) {
+	var rateLimitErr *RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitErr, true
+	}
+	return nil, false
+}
+
 type ApiResponse[R interface{}] interface {
 	IsCode(code int) bool
 	GetPayload() *R
 }
 
-type GrafanaAPI struct {
+// DataSourceTestResult is the outcome of a data source health check.
+type DataSourceTestResult struct {
+	Healthy bool
+	Message string
+}
+
+// ServerInfo is the version and the enabled feature toggles of a Grafana instance.
+type ServerInfo struct {
+	Version        string
+	FeatureToggles map[string]bool
+}
+
+// FeatureEnabled returns true if the feature toggle is enabled.
+func (i *ServerInfo) FeatureEnabled(name string) bool {
+	return i != nil && i.FeatureToggles[name]
+}
+
+// frontendSettings holds the fields of the frontend settings the ServerInfo is read from.
+type frontendSettings struct {
+	BuildInfo struct {
+		Version string `json:"version"`
+	} `json:"buildInfo"`
+	FeatureToggles map[string]bool `json:"featureToggles"`
+}
+
+// RulerRuleGroup is a rule group as exchanged with the ruler API. The ruler API always replaces a group as a whole.
+type RulerRuleGroup struct {
+	Name     string       `json:"name"`
+	Interval string       `json:"interval,omitempty"`
+	Rules    []*RulerRule `json:"rules"`
+}
+
+// RulerRule is a recording rule of a RulerRuleGroup.
+type RulerRule struct {
+	Record string `json:"record"`
+	Expr   string `json:"expr"`
+}
+
+// GrafanaAPI is the subset of the Grafana HTTP API used by the controllers of this provider.
+type GrafanaAPI interface {
+	GetAllUsers(ctx context.Context) ([]*models.UserSearchHitDTO, error)
+	CreateUser(user string) (int64, error)
+	GetAllOrgs(ctx context.Context) ([]*models.OrgDTO, error)
+	GetSignedInUser() (*models.UserProfileDTO, error)
+	GetSignedInUserOrgs() ([]*models.UserOrgDTO, error)
+	CreateOrg(name string) (*models.CreateOrgOKBody, error)
+	DeleteOrgByID(orgID int64, fromOrgID int64) (*models.SuccessResponseBody, error)
+	AddOrgUser(orgID int64, user *models.AddOrgUserCommand) (*models.SuccessResponseBody, error)
+	UpdateOrgUser(orgID int64, userID int64, user *models.UpdateOrgUserCommand) (*models.SuccessResponseBody, error)
+	RemoveOrgUser(userID int64, orgID int64) (*models.SuccessResponseBody, error)
+	AdminCreateUser(user *models.AdminCreateUserForm) (*models.AdminCreateUserResponse, error)
+	GetUserById(id int64) (*models.UserProfileDTO, error)
+	GetUserByLoginOrEmail(loginOrEmail string) (*models.UserProfileDTO, error)
+	UpdateUser(id int64, command *models.UpdateUserCommand) (*models.SuccessResponseBody, error)
+	UpdateUserPermissions(id int64, isAdmin bool) error
+	UpdateUserPassword(id int64, password string) error
+	DisableUser(id int64) error
+	EnableUser(id int64) error
+	DeleteUser(id int64) error
+	GetOrgByName(s string) (*models.OrgDetailsDTO, error)
+	GetOrgById(id int64) (*models.OrgDetailsDTO, error)
+	GetOrgUsers(orgId int64) ([]*models.OrgUserDTO, error)
+	GetOrgPreferences(orgId int64) (*models.Preferences, error)
+	UpdateOrgPreferences(orgId int64, command *models.UpdatePrefsCmd) (*models.SuccessResponseBody, error)
+	GetOrgQuotas(orgId int64) ([]*models.QuotaDTO, error)
+	UpdateOrgQuota(orgId int64, target string, limit int64) error
+	UpdateOrg(orgId int64, command *models.UpdateOrgAddressForm) error
+	GetDataSourceById(orgId int64, id string) (*models.DataSource, error)
+	GetDataSourceByName(orgId int64, name string) (*models.DataSource, error)
+	GetDataSourceByUid(orgId int64, uid string) (*models.DataSource, error)
+	CreateDataSource(orgId int64, command *models.AddDataSourceCommand) (*models.AddDataSourceOKBody, error)
+	UpdateDataSource(orgId int64, id string, command *models.UpdateDataSourceCommand) (*models.UpdateDataSourceByIDOKBody, error)
+	DeleteDataSource(orgId int64, id string) (*models.SuccessResponseBody, error)
+	TestDataSource(orgId int64, uid string) (*DataSourceTestResult, error)
+	CreateOrUpdateDashboard(orgId int64, command *models.SaveDashboardCommand) (*models.PostDashboardOKBody, error)
+	GetDashboardByUid(orgId int64, uid string) (*models.DashboardFullWithMeta, error)
+	GetDashboardByName(ctx context.Context, orgId int64, name string, folder *string) (*models.DashboardFullWithMeta, error)
+	SearchDashboards(ctx context.Context, orgId int64) ([]*models.Hit, error)
+	DeleteDashboard(orgId int64, uid string) (*models.DeleteDashboardByUIDOKBody, error)
+	GetFolderByUid(orgId int64, uid string) (*models.Folder, error)
+	GetFolderById(orgId int64, id int64) (*models.Folder, error)
+	GetFolderByName(ctx context.Context, orgId int64, name string, parentFolder *string) (*models.Folder, error)
+	CreateFolder(orgId int64, command *models.CreateFolderCommand) (*models.Folder, error)
+	UpdateFolder(orgId int64, uid string, command *models.UpdateFolderCommand) (*models.Folder, error)
+	MoveFolder(orgId int64, uid string, newParentUID string) (*models.Folder, error)
+	DeleteFolder(orgId int64, uid string, forceDeleteRules bool) (*models.DeleteFolderOKBody, error)
+	GetFolderDescendantCounts(orgId int64, uid string) (models.DescendantCounts, error)
+	GetLibraryPanelByUid(orgId int64, uid string) (*models.LibraryElementDTO, error)
+	GetLibraryPanelByName(orgId int64, name string, folderUID *string) (*models.LibraryElementDTO, error)
+	GetLibraryPanelConnections(orgId int64, uid string) ([]*models.LibraryElementConnectionDTO, error)
+	CreateLibraryPanel(orgId int64, command *models.CreateLibraryElementCommand) (*models.LibraryElementDTO, error)
+	UpdateLibraryPanel(orgId int64, uid string, command *models.PatchLibraryElementCommand) (*models.LibraryElementDTO, error)
+	DeleteLibraryPanel(orgId int64, uid string) (*models.SuccessResponseBody, error)
+	GetAlertRuleGroup(orgId int64, folderUID string, group string) (*models.AlertRuleGroup, error)
+	PutAlertRuleGroup(orgId int64, folderUID string, group string, ruleGroup *models.AlertRuleGroup) (*models.AlertRuleGroup, error)
+	DeleteAlertRule(orgId int64, uid string) error
+	GetMuteTiming(orgId int64, name string) (*models.MuteTimeInterval, error)
+	CreateMuteTiming(orgId int64, muteTiming *models.MuteTimeInterval) error
+	UpdateMuteTiming(orgId int64, name string, muteTiming *models.MuteTimeInterval) error
+	DeleteMuteTiming(orgId int64, name string) error
+	GetMessageTemplate(orgId int64, name string) (*models.NotificationTemplate, error)
+	SetMessageTemplate(orgId int64, name string, template string) error
+	DeleteMessageTemplate(orgId int64, name string) error
+	GetTeamById(orgId int64, id int64) (*models.TeamDTO, error)
+	GetTeamByUid(ctx context.Context, orgId int64, uid string) (*models.TeamDTO, error)
+	GetTeamMembers(orgId int64, teamId int64) ([]*models.TeamMemberDTO, error)
+	AddTeamMember(orgId int64, teamId int64, userId int64) error
+	UpdateTeamMember(orgId int64, teamId int64, userId int64, permission int64) error
+	RemoveTeamMember(orgId int64, teamId int64, userId int64) error
+	GetTeamPreferences(orgId int64, teamId int64) (*models.Preferences, error)
+	UpdateTeamPreferences(orgId int64, teamId int64, command *models.UpdatePrefsCmd) error
+	GetRulerRuleGroup(orgId int64, namespace string, group string) (*RulerRuleGroup, error)
+	SetRulerRuleGroup(orgId int64, namespace string, ruleGroup *RulerRuleGroup) error
+	DeleteRulerRuleGroup(orgId int64, namespace string, group string) error
+	GetDataSourcePermissions(orgId int64, uid string) ([]*models.ResourcePermissionDTO, error)
+	UpdateDataSourcePermissions(orgId int64, uid string, permissions []*models.SetResourcePermissionCommand) error
+	GetTeamPermissions(orgId int64, folderUid string, teamId int64) ([]*models.ResourcePermissionDTO, error)
+	AddTeamPermission(orgId int64, folderUid string, teamId int64, permission string) error
+	RemoveTeamPermission(orgId int64, folderUid string, teamId int64) error
+	GetAnnotation(orgId int64, id int64) (*models.Annotation, error)
+	CreateAnnotation(orgId int64, command *models.PostAnnotationsCmd) (int64, error)
+	UpdateAnnotation(orgId int64, id int64, command *models.UpdateAnnotationsCmd) error
+	DeleteAnnotation(orgId int64, id int64) error
+	GetReports(orgId int64) ([]*models.Report, error)
+	CreateReport(orgId int64, command *models.CreateOrUpdateReportConfig) (int64, error)
+	UpdateReport(orgId int64, id int64, command *models.CreateOrUpdateReportConfig) error
+	DeleteReport(orgId int64, id int64) error
+	GetRoles(orgId int64) ([]*models.RoleDTO, error)
+	GetRole(orgId int64, uid string) (*models.RoleDTO, error)
+	CreateRole(orgId int64, form *models.CreateRoleForm) (*models.RoleDTO, error)
+	UpdateRole(orgId int64, uid string, command *models.UpdateRoleCommand) error
+	DeleteRole(orgId int64, uid string, global bool) error
+	GetRoleAssignments(orgId int64, roleUid string) (*models.RoleAssignmentsDTO, error)
+	SetRoleAssignments(orgId int64, roleUid string, command *models.SetRoleAssignmentsCommand) error
+	GetSSOSettings(provider string) (*models.GetProviderSettingsOKBody, error)
+	UpdateSSOSettings(provider string, settings map[string]interface{}) error
+	DeleteSSOSettings(provider string) error
+	CreateSnapshot(orgId int64, command *models.CreateDashboardSnapshotCommand) (*models.CreateDashboardSnapshotOKBody, error)
+	GetSnapshotByKey(orgId int64, key string) (*models.DashboardFullWithMeta, error)
+	DeleteSnapshot(orgId int64, deleteKey string) error
+	GetAPIKeys(orgId int64) ([]*models.APIKeyDTO, error)
+	CreateAPIKey(orgId int64, command *models.AddAPIKeyCommand) (*models.NewAPIKeyResult, error)
+	DeleteAPIKey(orgId int64, id int64) error
+	GetServerInfo() (*ServerInfo, error)
+	// Shutdown waits until no request of the client is in flight, or until ctx is done, and then cancels the requests
+	// still in flight. Requests sent afterwards fail with context.Canceled.
+	Shutdown(ctx context.Context) error
+}
+
+type grafanaAPIClient struct {
 	service grafana.GrafanaHTTPAPI
+	// perPage is the number of items requested per page from paginated APIs
+	perPage int64
+	// maxPages is the number of pages requested from paginated APIs at most
+	maxPages int64
+	// requests tracks the requests of the client, so that Shutdown can wait for and cancel them
+	requests *inFlightRequests
 }
 
 func NewGrafanaAPI(service grafana.GrafanaHTTPAPI) GrafanaAPI {
-	return GrafanaAPI{service: service}
+	return NewGrafanaAPIWithPerPage(service, DefaultPerPage)
 }
 
-func (g *GrafanaAPI) GetAllUsers() ([]*models.UserSearchHitDTO, error) {
-	var allUsers []*models.UserSearchHitDTO
-	var page int64 = 0
-	params := users.NewSearchUsersParams().WithDefaults()
-	client := g.service.Clone()
+// NewGrafanaAPIWithPerPage returns a GrafanaAPI that requests perPage items per page from paginated APIs.
+func NewGrafanaAPIWithPerPage(service grafana.GrafanaHTTPAPI, perPage int64) GrafanaAPI {
+	return NewGrafanaAPIWithPagination(service, perPage, DefaultMaxPages)
+}
+
+// NewGrafanaAPIWithPagination returns a GrafanaAPI that requests perPage items per page and at most maxPages pages from
+// paginated APIs.
+func NewGrafanaAPIWithPagination(service grafana.GrafanaHTTPAPI, perPage int64, maxPages int64) GrafanaAPI {
+	return newGrafanaAPIClient(service, perPage, maxPages, Clients)
+}
+
+// newGrafanaAPIClient returns a client that is tracked by the registry while it has requests in flight.
+func newGrafanaAPIClient(service grafana.GrafanaHTTPAPI, perPage int64, maxPages int64, registry *ClientRegistry) *grafanaAPIClient {
+	g := &grafanaAPIClient{perPage: perPage, maxPages: maxPages, requests: newInFlightRequests(registry)}
+	g.service = *g.withShutdown(&service)
+	return g
+}
 
-	for {
-		resp, err := client.Users.SearchUsers(params.WithPage(&page), nil)
+// allPages calls fetch for the pages 1, 2, ... until a page has less than perPage items, and returns the items of all
+// pages. Pages of Grafana's APIs are numbered starting at 1. It stops with an error if ctx is done or if there are more
+// than maxPages pages.
+func allPages[T any](ctx context.Context, perPage int64, maxPages int64, fetch func(page int64) ([]T, error)) ([]T, error) {
+	var all []T
+	for page := int64(1); ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if page > maxPages {
+			return nil, errors.Errorf(errTooManyPages, maxPages, perPage)
+		}
+		items, err := fetch(page)
 		if err != nil {
 			return nil, err
 		}
-
-		allUsers = append(allUsers, resp.Payload...)
-		if len(resp.Payload) != int(*params.Perpage) {
-			break
+		all = append(all, items...)
+		if int64(len(items)) < perPage {
+			return all, nil
 		}
-		page++
 	}
-	return allUsers, nil
 }
 
-func (g *GrafanaAPI) CreateUser(user string) (int64, error) {
+func (g *grafanaAPIClient) GetAllUsers(ctx context.Context) ([]*models.UserSearchHitDTO, error) {
+	client := g.service.Clone()
+	return allPages(ctx, g.perPage, g.maxPages, func(page int64) ([]*models.UserSearchHitDTO, error) {
+		params := users.NewSearchUsersParams().WithContext(ctx).WithPage(&page).WithPerpage(&g.perPage)
+		resp, err := client.Users.SearchUsers(params)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Payload, nil
+	})
+}
+
+func (g *grafanaAPIClient) CreateUser(user string) (int64, error) {
 	client := g.service.Clone()
 	n := 64
 	bytes := make([]byte, n)
@@ -83,52 +330,18 @@ func (g *GrafanaAPI) CreateUser(user string) (int64, error) {
 	return resp.Payload.ID, err
 }
 
-func (g *GrafanaAPI) GetAllOrgs() ([]*models.OrgDTO, error) {
-	var allOrgs []*models.OrgDTO
-	var page int64 = 0
-	params := orgs.NewSearchOrgsParams().WithDefaults()
-	client := g.service
-	for {
-		resp, err := client.Orgs.SearchOrgs(params.WithPage(&page), nil)
+func (g *grafanaAPIClient) GetAllOrgs(ctx context.Context) ([]*models.OrgDTO, error) {
+	return allPages(ctx, g.perPage, g.maxPages, func(page int64) ([]*models.OrgDTO, error) {
+		params := orgs.NewSearchOrgsParams().WithContext(ctx).WithPage(&page).WithPerpage(&g.perPage)
+		resp, err := g.service.Orgs.SearchOrgs(params)
 		if err != nil {
 			return nil, err
 		}
-
-		allOrgs = append(allOrgs, resp.Payload...)
-		if len(resp.Payload) != int(*params.Perpage) {
-			break
-		}
-		page++
-	}
-	return allOrgs, nil
-}
-
-// SwitchToLowestOrgId switches the current user's active organization to the one with the lowest ID.
-// It first retrieves all organizations and iterates through them to find the one with the lowest ID.
-// Then, it uses the Grafana API to switch the current user's active organization to the one found.
-// This function is useful in scenarios where the user needs to be in a context that is not the organization being managed,
-// for example, when deleting an organization.
-//
-// Returns:
-//
-//	error: If an error occurred during the process. It could be due to issues in retrieving all organizations or switching the active organization.
-func (g *GrafanaAPI) SwitchToLowestOrgId() error {
-	orgas, err := g.GetAllOrgs()
-	if err != nil {
-		return err
-	}
-	var orgId int64
-	orgId = 9999999
-	for _, org := range orgas {
-		if org.ID < orgId {
-			orgId = org.ID
-		}
-	}
-	_, err = g.service.SignedInUser.UserSetUsingOrg(orgId)
-	return err
+		return resp.Payload, nil
+	})
 }
 
-func (g *GrafanaAPI) GetSignedInUser() (*models.UserProfileDTO, error) {
+func (g *grafanaAPIClient) GetSignedInUser() (*models.UserProfileDTO, error) {
 	resp, err := g.service.SignedInUser.GetSignedInUser()
 	if err != nil {
 		return nil, err
@@ -136,34 +349,37 @@ func (g *GrafanaAPI) GetSignedInUser() (*models.UserProfileDTO, error) {
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) UserSetUsingOrg(orgId int64) (*models.SuccessResponseBody, error) {
-	resp, err := g.service.Clone().WithOrgID(0).SignedInUser.UserSetUsingOrg(orgId)
+func (g *grafanaAPIClient) GetSignedInUserOrgs() ([]*models.UserOrgDTO, error) {
+	resp, err := g.withOrgID(0).SignedInUser.GetSignedInUserOrgList()
 	if err != nil {
 		return nil, err
 	}
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) CreateOrg(name string) (*models.CreateOrgOKBody, error) {
+func (g *grafanaAPIClient) CreateOrg(name string) (*models.CreateOrgOKBody, error) {
 	cmd := &models.CreateOrgCommand{
 		Name: name,
 	}
-	resp, err := g.service.Clone().WithOrgID(0).Orgs.CreateOrg(cmd)
+	resp, err := g.withOrgID(0).Orgs.CreateOrg(cmd)
 	if err != nil {
 		return nil, err
 	}
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) DeleteOrgByID(orgID int64) (*models.SuccessResponseBody, error) {
-	resp, err := g.service.WithOrgID(0).Orgs.DeleteOrgByID(orgID)
+// DeleteOrgByID deletes the organization orgID with a request made in the organization fromOrgID. Grafana refuses
+// to delete the organization a request is made in, and scoping the request avoids switching the active organization
+// of the user, which is shared by all controllers.
+func (g *grafanaAPIClient) DeleteOrgByID(orgID int64, fromOrgID int64) (*models.SuccessResponseBody, error) {
+	resp, err := g.withOrgID(fromOrgID).Orgs.DeleteOrgByID(orgID)
 	if err != nil {
 		return nil, err
 	}
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) AddOrgUser(orgID int64, user *models.AddOrgUserCommand) (*models.SuccessResponseBody, error) {
+func (g *grafanaAPIClient) AddOrgUser(orgID int64, user *models.AddOrgUserCommand) (*models.SuccessResponseBody, error) {
 	resp, err := g.service.Orgs.AddOrgUser(orgID, user)
 	if err != nil {
 		return nil, err
@@ -171,7 +387,7 @@ func (g *GrafanaAPI) AddOrgUser(orgID int64, user *models.AddOrgUserCommand) (*m
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) UpdateOrgUser(orgID int64, userID int64, user *models.UpdateOrgUserCommand) (*models.SuccessResponseBody, error) {
+func (g *grafanaAPIClient) UpdateOrgUser(orgID int64, userID int64, user *models.UpdateOrgUserCommand) (*models.SuccessResponseBody, error) {
 	params := orgs.NewUpdateOrgUserParams().WithOrgID(orgID).WithUserID(userID).WithBody(user)
 	resp, err := g.service.Orgs.UpdateOrgUser(params)
 	if err != nil {
@@ -180,7 +396,7 @@ func (g *GrafanaAPI) UpdateOrgUser(orgID int64, userID int64, user *models.Updat
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) RemoveOrgUser(userID int64, orgID int64) (*models.SuccessResponseBody, error) {
+func (g *grafanaAPIClient) RemoveOrgUser(userID int64, orgID int64) (*models.SuccessResponseBody, error) {
 	resp, err := g.service.Orgs.RemoveOrgUser(userID, orgID)
 	if err != nil {
 		return nil, err
@@ -188,7 +404,7 @@ func (g *GrafanaAPI) RemoveOrgUser(userID int64, orgID int64) (*models.SuccessRe
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) AdminCreateUser(user *models.AdminCreateUserForm) (*models.AdminCreateUserResponse, error) {
+func (g *grafanaAPIClient) AdminCreateUser(user *models.AdminCreateUserForm) (*models.AdminCreateUserResponse, error) {
 	resp, err := g.service.AdminUsers.AdminCreateUser(user)
 	if err != nil {
 		return nil, err
@@ -196,17 +412,60 @@ func (g *GrafanaAPI) AdminCreateUser(user *models.AdminCreateUserForm) (*models.
 	return resp.Payload, err
 }
 
-func (g *GrafanaAPI) GetOrgByName(s string) (*models.OrgDetailsDTO, error) {
+func (g *grafanaAPIClient) GetUserById(id int64) (*models.UserProfileDTO, error) {
+	response, err := g.service.Users.GetUserByID(id)
+	return orNilOnNotFound[models.UserProfileDTO](&response, err)
+}
+
+func (g *grafanaAPIClient) GetUserByLoginOrEmail(loginOrEmail string) (*models.UserProfileDTO, error) {
+	response, err := g.service.Users.GetUserByLoginOrEmail(loginOrEmail)
+	return orNilOnNotFound[models.UserProfileDTO](&response, err)
+}
+
+func (g *grafanaAPIClient) UpdateUser(id int64, command *models.UpdateUserCommand) (*models.SuccessResponseBody, error) {
+	resp, err := g.service.Users.UpdateUser(id, command)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Payload, err
+}
+
+func (g *grafanaAPIClient) UpdateUserPermissions(id int64, isAdmin bool) error {
+	_, err := g.service.AdminUsers.AdminUpdateUserPermissions(id, &models.AdminUpdateUserPermissionsForm{IsGrafanaAdmin: isAdmin})
+	return err
+}
+
+func (g *grafanaAPIClient) UpdateUserPassword(id int64, password string) error {
+	_, err := g.service.AdminUsers.AdminUpdateUserPassword(id, &models.AdminUpdateUserPasswordForm{Password: password})
+	return err
+}
+
+func (g *grafanaAPIClient) DisableUser(id int64) error {
+	_, err := g.service.AdminUsers.AdminDisableUser(id)
+	return err
+}
+
+func (g *grafanaAPIClient) EnableUser(id int64) error {
+	_, err := g.service.AdminUsers.AdminEnableUser(id)
+	return err
+}
+
+func (g *grafanaAPIClient) DeleteUser(id int64) error {
+	_, err := g.service.AdminUsers.AdminDeleteUser(id)
+	return err
+}
+
+func (g *grafanaAPIClient) GetOrgByName(s string) (*models.OrgDetailsDTO, error) {
 	response, err := g.service.Orgs.GetOrgByName(s)
 	return orNilOnNotFound[models.OrgDetailsDTO](&response, err)
 }
 
-func (g *GrafanaAPI) GetOrgById(id int64) (*models.OrgDetailsDTO, error) {
+func (g *grafanaAPIClient) GetOrgById(id int64) (*models.OrgDetailsDTO, error) {
 	response, err := g.service.Orgs.GetOrgByID(id)
 	return orNilOnNotFound[models.OrgDetailsDTO](&response, err)
 }
 
-func (g *GrafanaAPI) GetOrgUsers(orgId int64) ([]*models.OrgUserDTO, error) {
+func (g *grafanaAPIClient) GetOrgUsers(orgId int64) ([]*models.OrgUserDTO, error) {
 	response, err := g.service.Orgs.GetOrgUsers(orgId)
 	if err != nil {
 		return nil, err
@@ -214,34 +473,77 @@ func (g *GrafanaAPI) GetOrgUsers(orgId int64) ([]*models.OrgUserDTO, error) {
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) GetDataSourceById(orgId int64, id string) (*models.DataSource, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Datasources.GetDataSourceByID(id)
+func (g *grafanaAPIClient) GetOrgPreferences(orgId int64) (*models.Preferences, error) {
+	response, err := g.withOrgID(orgId).OrgPreferences.GetOrgPreferences()
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, err
+}
+
+func (g *grafanaAPIClient) UpdateOrgPreferences(orgId int64, command *models.UpdatePrefsCmd) (*models.SuccessResponseBody, error) {
+	response, err := g.withOrgID(orgId).OrgPreferences.UpdateOrgPreferences(command)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, err
+}
+
+func (g *grafanaAPIClient) GetOrgQuotas(orgId int64) ([]*models.QuotaDTO, error) {
+	response, err := g.service.Orgs.GetOrgQuota(orgId)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, err
+}
+
+func (g *grafanaAPIClient) UpdateOrgQuota(orgId int64, target string, limit int64) error {
+	params := orgs.NewUpdateOrgQuotaParams().
+		WithOrgID(orgId).
+		WithQuotaTarget(target).
+		WithBody(&models.UpdateQuotaCmd{Limit: limit})
+	_, err := g.service.Orgs.UpdateOrgQuota(params)
+	return err
+}
+
+func (g *grafanaAPIClient) UpdateOrg(orgId int64, command *models.UpdateOrgAddressForm) error {
+	_, err := g.service.Orgs.UpdateOrgAddress(orgId, command)
+	return err
+}
+
+func (g *grafanaAPIClient) GetDataSourceById(orgId int64, id string) (*models.DataSource, error) {
+	response, err := g.withOrgID(orgId).Datasources.GetDataSourceByID(id)
+	return orNilOnStatus[models.DataSource](&response, err, ignoreStatusCodesOnObserve...)
+}
+
+func (g *grafanaAPIClient) GetDataSourceByName(orgId int64, name string) (*models.DataSource, error) {
+	response, err := g.withOrgID(orgId).Datasources.GetDataSourceByName(name)
 	return orNilOnStatus[models.DataSource](&response, err, ignoreStatusCodesOnObserve...)
 }
 
-func (g *GrafanaAPI) GetDataSourceByName(orgId int64, name string) (*models.DataSource, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Datasources.GetDataSourceByName(name)
+func (g *grafanaAPIClient) GetDataSourceByUid(orgId int64, uid string) (*models.DataSource, error) {
+	response, err := g.withOrgID(orgId).Datasources.GetDataSourceByUID(uid)
 	return orNilOnStatus[models.DataSource](&response, err, ignoreStatusCodesOnObserve...)
 }
 
-func (g *GrafanaAPI) CreateDataSource(orgId int64, command *models.AddDataSourceCommand) (*models.AddDataSourceOKBody, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Datasources.AddDataSource(command)
+func (g *grafanaAPIClient) CreateDataSource(orgId int64, command *models.AddDataSourceCommand) (*models.AddDataSourceOKBody, error) {
+	response, err := g.withOrgID(orgId).Datasources.AddDataSource(command)
 	if err != nil {
 		return nil, err
 	}
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) UpdateDataSource(orgId int64, id string, command *models.UpdateDataSourceCommand) (*models.UpdateDataSourceByIDOKBody, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Datasources.UpdateDataSourceByID(id, command)
+func (g *grafanaAPIClient) UpdateDataSource(orgId int64, id string, command *models.UpdateDataSourceCommand) (*models.UpdateDataSourceByIDOKBody, error) {
+	response, err := g.withOrgID(orgId).Datasources.UpdateDataSourceByID(id, command)
 	if err != nil {
 		return nil, err
 	}
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) DeleteDataSource(orgId int64, id string) (*models.SuccessResponseBody, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Datasources.DeleteDataSourceByID(id)
+func (g *grafanaAPIClient) DeleteDataSource(orgId int64, id string) (*models.SuccessResponseBody, error) {
+	response, err := g.withOrgID(orgId).Datasources.DeleteDataSourceByID(id)
 	if err != nil {
 		return nil, err
 
@@ -249,35 +551,98 @@ func (g *GrafanaAPI) DeleteDataSource(orgId int64, id string) (*models.SuccessRe
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) CreateOrUpdateDashboard(orgId int64, command *models.SaveDashboardCommand) (*models.PostDashboardOKBody, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Dashboards.PostDashboard(command)
+// TestDataSource runs the health check of the data source plugin. A failing check is reported as unhealthy
+// result, errors are only returned if the check could not be run.
+func (g *grafanaAPIClient) TestDataSource(orgId int64, uid string) (*DataSourceTestResult, error) {
+	response, err := g.withOrgID(orgId).Datasources.CheckDatasourceHealthWithUID(uid)
+	var badRequest *datasources.CheckDatasourceHealthWithUIDBadRequest
+	if errors.As(err, &badRequest) {
+		message := ""
+		if badRequest.GetPayload() != nil && badRequest.GetPayload().Message != nil {
+			message = *badRequest.GetPayload().Message
+		}
+		return &DataSourceTestResult{Healthy: false, Message: message}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &DataSourceTestResult{Healthy: true, Message: response.GetPayload().Message}, nil
+}
+
+func (g *grafanaAPIClient) CreateOrUpdateDashboard(orgId int64, command *models.SaveDashboardCommand) (*models.PostDashboardOKBody, error) {
+	response, err := g.withOrgID(orgId).Dashboards.PostDashboard(command)
 	if err != nil {
 		return nil, err
 	}
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) GetDashboardByUid(orgId int64, uid string) (*models.DashboardFullWithMeta, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Dashboards.GetDashboardByUID(uid)
+func (g *grafanaAPIClient) GetDashboardByUid(orgId int64, uid string) (*models.DashboardFullWithMeta, error) {
+	response, err := g.withOrgID(orgId).Dashboards.GetDashboardByUID(uid)
 	return orNilOnStatus[models.DashboardFullWithMeta](&response, err, ignoreStatusCodesOnObserve...)
 }
 
-func (g *GrafanaAPI) GetDashboardByName(orgId int64, name string, folder *string) (*models.DashboardFullWithMeta, error) {
+// GetDashboardByName looks up the dashboard with the given title in the folder, which is either a numeric ID or an UID.
+// Dashboards without folder are looked up in the General folder. Titles are only unique per folder, so
+// ErrAmbiguousDashboardTitle is returned if Grafana reports more than one match in the folder anyway.
+func (g *grafanaAPIClient) GetDashboardByName(ctx context.Context, orgId int64, name string, folder *string) (*models.DashboardFullWithMeta, error) {
 	dashboardType := "dash-db"
 	params := &search.SearchParams{
 		Type:  &dashboardType,
 		Query: &name,
 	}
 	setFolderIdIfNotNull(folder, params)
-	response, err := g.service.Clone().WithOrgID(orgId).Search.Search(params)
+	hits, err := g.searchAll(ctx, orgId, params)
 	if err != nil {
 		return nil, err
 	}
-	if len(response.Payload) == 0 {
+	// the search matches titles partially and searches all folders if no folder is given
+	var uids []string
+	for _, hit := range hits {
+		if hit.Title == name && isInFolder(hit, folder) {
+			uids = append(uids, hit.UID)
+		}
+	}
+	if len(uids) == 0 {
 		return nil, nil
 	}
-	uid := response.Payload[0].UID
-	return g.GetDashboardByUid(orgId, uid)
+	if len(uids) > 1 {
+		return nil, ErrAmbiguousDashboardTitle
+	}
+	return g.GetDashboardByUid(orgId, uids[0])
+}
+
+// SearchDashboards returns the search hits of all dashboards of the organization.
+func (g *grafanaAPIClient) SearchDashboards(ctx context.Context, orgId int64) ([]*models.Hit, error) {
+	dashboardType := "dash-db"
+	return g.searchAll(ctx, orgId, &search.SearchParams{Type: &dashboardType})
+}
+
+// searchAll pages through the search results, since Grafana only returns up to a limit of hits per request.
+func (g *grafanaAPIClient) searchAll(ctx context.Context, orgId int64, params *search.SearchParams) ([]*models.Hit, error) {
+	client := g.withOrgID(orgId)
+	params.Limit = &g.perPage
+	params.Context = ctx
+	return allPages(ctx, g.perPage, g.maxPages, func(page int64) ([]*models.Hit, error) {
+		params.Page = &page
+		response, err := client.Search.Search(params)
+		if err != nil {
+			return nil, err
+		}
+		return response.Payload, nil
+	})
+}
+
+// isInFolder returns true if the hit is in the folder, which is either a numeric ID or an UID. A nil folder is the
+// General folder.
+func isInFolder(hit *models.Hit, folder *string) bool {
+	if folder == nil {
+		return hit.FolderUID == "" && hit.FolderID == 0
+	}
+	if folderId, err := strconv.ParseInt(*folder, 10, 64); err == nil {
+		return hit.FolderID == folderId
+	}
+	return hit.FolderUID == *folder
 }
 
 func setFolderIdIfNotNull(folder *string, params *search.SearchParams) {
@@ -292,77 +657,644 @@ func setFolderIdIfNotNull(folder *string, params *search.SearchParams) {
 	}
 }
 
-func (g *GrafanaAPI) DeleteDashboard(orgId int64, uid string) (*models.DeleteDashboardByUIDOKBody, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Dashboards.DeleteDashboardByUID(uid)
+func (g *grafanaAPIClient) DeleteDashboard(orgId int64, uid string) (*models.DeleteDashboardByUIDOKBody, error) {
+	response, err := g.withOrgID(orgId).Dashboards.DeleteDashboardByUID(uid)
 	if err != nil {
 		return nil, err
 	}
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) GetFolderByUid(orgId int64, uid string) (*models.Folder, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Folders.GetFolderByUID(uid)
+func (g *grafanaAPIClient) GetFolderByUid(orgId int64, uid string) (*models.Folder, error) {
+	response, err := g.withOrgID(orgId).Folders.GetFolderByUID(uid)
 	return orNilOnStatus[models.Folder](&response, err, ignoreStatusCodesOnObserve...)
 }
 
-func (g *GrafanaAPI) GetFolderById(orgId int64, id int64) (*models.Folder, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Folders.GetFolderByID(id)
+func (g *grafanaAPIClient) GetFolderById(orgId int64, id int64) (*models.Folder, error) {
+	response, err := g.withOrgID(orgId).Folders.GetFolderByID(id)
 	return orNilOnStatus[models.Folder](&response, err, ignoreStatusCodesOnObserve...)
 }
 
-func (g *GrafanaAPI) GetFolderByName(orgId int64, name string, parentFolder *string) (*models.Folder, error) {
+// GetFolderByName returns the folder with the title in the parent folder, which is either a numeric ID or an UID. The
+// search also returns folders of other parents, e.g. nested folders with the same title, so the parent of every match
+// is verified. A nil parent folder matches folders of any parent.
+func (g *grafanaAPIClient) GetFolderByName(ctx context.Context, orgId int64, name string, parentFolder *string) (*models.Folder, error) {
 	dashboardType := "dash-folder"
 	params := &search.SearchParams{
 		Type:  &dashboardType,
 		Query: &name,
 	}
 	setFolderIdIfNotNull(parentFolder, params)
-	response, err := g.service.Clone().WithOrgID(orgId).Search.Search(params)
+	hits, err := g.searchAll(ctx, orgId, params)
 	if err != nil {
 		return nil, err
 	}
-	if len(response.Payload) == 0 {
+	// the search matches titles partially, only exact matches are of interest
+	var found []*models.Folder
+	for _, hit := range hits {
+		if hit.Title != name {
+			continue
+		}
+		folder, err := g.GetFolderByUid(orgId, hit.UID)
+		if err != nil {
+			return nil, err
+		}
+		if folder != nil && hasParent(folder, parentFolder) {
+			found = append(found, folder)
+		}
+	}
+	if len(found) == 0 {
 		return nil, nil
 	}
-	uid := response.Payload[0].UID
-	return g.GetFolderByUid(orgId, uid)
+	if len(found) > 1 {
+		return nil, ErrAmbiguousFolderTitle
+	}
+	return found[0], nil
+}
+
+// hasParent returns true if the folder is a direct child of the parent folder, which is either a numeric ID or an UID.
+func hasParent(folder *models.Folder, parentFolder *string) bool {
+	if parentFolder == nil {
+		return true
+	}
+	if folder.ParentUID == *parentFolder {
+		return true
+	}
+	parentId, err := strconv.ParseInt(*parentFolder, 10, 64)
+	if err != nil || len(folder.Parents) == 0 {
+		return false
+	}
+	return folder.Parents[len(folder.Parents)-1].ID == parentId
+}
+
+func (g *grafanaAPIClient) CreateFolder(orgId int64, command *models.CreateFolderCommand) (*models.Folder, error) {
+	response, err := g.withOrgID(orgId).Folders.CreateFolder(command)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, err
 }
 
-func (g *GrafanaAPI) CreateFolder(orgId int64, command *models.CreateFolderCommand) (*models.Folder, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Folders.CreateFolder(command)
+func (g *grafanaAPIClient) UpdateFolder(orgId int64, uid string, command *models.UpdateFolderCommand) (*models.Folder, error) {
+	response, err := g.withOrgID(orgId).Folders.UpdateFolder(uid, command)
 	if err != nil {
 		return nil, err
 	}
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) UpdateFolder(orgId int64, uid string, command *models.UpdateFolderCommand) (*models.Folder, error) {
-	response, err := g.service.Clone().WithOrgID(orgId).Folders.UpdateFolder(uid, command)
+func (g *grafanaAPIClient) MoveFolder(orgId int64, uid string, newParentUID string) (*models.Folder, error) {
+	response, err := g.withOrgID(orgId).Folders.MoveFolder(uid, &models.MoveFolderCommand{ParentUID: newParentUID})
 	if err != nil {
 		return nil, err
 	}
 	return response.Payload, err
 }
 
-func (g *GrafanaAPI) DeleteFolder(orgId int64, uid string) (*models.DeleteFolderOKBody, error) {
-	deleteRules := false
+func (g *grafanaAPIClient) DeleteFolder(orgId int64, uid string, forceDeleteRules bool) (*models.DeleteFolderOKBody, error) {
 	params := folders.DeleteFolderParams{
 		FolderUID:        uid,
-		ForceDeleteRules: &deleteRules,
+		ForceDeleteRules: &forceDeleteRules,
+	}
+	response, err := g.withOrgID(orgId).Folders.DeleteFolder(&params)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, err
+}
+
+// GetFolderDescendantCounts returns the number of descendants of the folder by kind, e.g. "dashboard" or "alertrule".
+func (g *grafanaAPIClient) GetFolderDescendantCounts(orgId int64, uid string) (models.DescendantCounts, error) {
+	response, err := g.withOrgID(orgId).Folders.GetFolderDescendantCounts(uid)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+// libraryPanelKind is the kind of library elements that are panels, as opposed to variables
+const libraryPanelKind int64 = 1
+
+func (g *grafanaAPIClient) GetLibraryPanelByUid(orgId int64, uid string) (*models.LibraryElementDTO, error) {
+	response, err := g.withOrgID(orgId).LibraryElements.GetLibraryElementByUID(uid)
+	element, err := orNilOnStatus[models.LibraryElementResponse](&response, err, ignoreStatusCodesOnObserve...)
+	if err != nil || element == nil {
+		return nil, err
+	}
+	return element.Result, nil
+}
+
+func (g *grafanaAPIClient) GetLibraryPanelByName(orgId int64, name string, folderUID *string) (*models.LibraryElementDTO, error) {
+	response, err := g.withOrgID(orgId).LibraryElements.GetLibraryElementByName(name)
+	elements, err := orNilOnStatus[models.LibraryElementArrayResponse](&response, err, ignoreStatusCodesOnObserve...)
+	if err != nil || elements == nil {
+		return nil, err
+	}
+	// names are only unique within a folder
+	for _, element := range elements.Result {
+		if element.Kind == libraryPanelKind && element.FolderUID == DefaultString(folderUID, "") {
+			return element, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *grafanaAPIClient) GetLibraryPanelConnections(orgId int64, uid string) ([]*models.LibraryElementConnectionDTO, error) {
+	response, err := g.withOrgID(orgId).LibraryElements.GetLibraryElementConnections(uid)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload.Result, err
+}
+
+func (g *grafanaAPIClient) CreateLibraryPanel(orgId int64, command *models.CreateLibraryElementCommand) (*models.LibraryElementDTO, error) {
+	command.Kind = libraryPanelKind
+	response, err := g.withOrgID(orgId).LibraryElements.CreateLibraryElement(command)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload.Result, err
+}
+
+func (g *grafanaAPIClient) UpdateLibraryPanel(orgId int64, uid string, command *models.PatchLibraryElementCommand) (*models.LibraryElementDTO, error) {
+	command.Kind = libraryPanelKind
+	response, err := g.withOrgID(orgId).LibraryElements.UpdateLibraryElement(uid, command)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload.Result, err
+}
+
+func (g *grafanaAPIClient) DeleteLibraryPanel(orgId int64, uid string) (*models.SuccessResponseBody, error) {
+	response, err := g.withOrgID(orgId).LibraryElements.DeleteLibraryElementByUID(uid)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, err
+}
+
+func (g *grafanaAPIClient) GetAlertRuleGroup(orgId int64, folderUID string, group string) (*models.AlertRuleGroup, error) {
+	response, err := g.withOrgID(orgId).Provisioning.GetAlertRuleGroup(group, folderUID)
+	return orNilOnStatus[models.AlertRuleGroup](&response, err, ignoreStatusCodesOnObserve...)
+}
+
+func (g *grafanaAPIClient) PutAlertRuleGroup(orgId int64, folderUID string, group string, ruleGroup *models.AlertRuleGroup) (*models.AlertRuleGroup, error) {
+	params := provisioning.NewPutAlertRuleGroupParams().WithFolderUID(folderUID).WithGroup(group).WithBody(ruleGroup)
+	response, err := g.withOrgID(orgId).Provisioning.PutAlertRuleGroup(params)
+	if err != nil {
+		return nil, err
 	}
-	response, err := g.service.Clone().WithOrgID(orgId).Folders.DeleteFolder(&params)
+	return response.Payload, err
+}
+
+func (g *grafanaAPIClient) DeleteAlertRule(orgId int64, uid string) error {
+	params := provisioning.NewDeleteAlertRuleParams().WithUID(uid)
+	_, err := g.withOrgID(orgId).Provisioning.DeleteAlertRule(params)
+	return err
+}
+
+// GetMuteTiming returns the mute timing with the given name, nil is returned if there is none.
+func (g *grafanaAPIClient) GetMuteTiming(orgId int64, name string) (*models.MuteTimeInterval, error) {
+	response, err := g.withOrgID(orgId).Provisioning.GetMuteTiming(name)
+	return orNilOnNotFound[models.MuteTimeInterval](&response, err)
+}
+
+func (g *grafanaAPIClient) CreateMuteTiming(orgId int64, muteTiming *models.MuteTimeInterval) error {
+	params := provisioning.NewPostMuteTimingParams().WithBody(muteTiming)
+	_, err := g.withOrgID(orgId).Provisioning.PostMuteTiming(params)
+	return err
+}
+
+func (g *grafanaAPIClient) UpdateMuteTiming(orgId int64, name string, muteTiming *models.MuteTimeInterval) error {
+	params := provisioning.NewPutMuteTimingParams().WithName(name).WithBody(muteTiming)
+	_, err := g.withOrgID(orgId).Provisioning.PutMuteTiming(params)
+	return err
+}
+
+// DeleteMuteTiming deletes the mute timing with the given name. Grafana responds with 409 if it is still used by a
+// notification policy.
+func (g *grafanaAPIClient) DeleteMuteTiming(orgId int64, name string) error {
+	_, err := g.withOrgID(orgId).Provisioning.DeleteMuteTiming(name)
+	return err
+}
+
+// GetMessageTemplate returns the notification template with the given name, nil is returned if there is none.
+func (g *grafanaAPIClient) GetMessageTemplate(orgId int64, name string) (*models.NotificationTemplate, error) {
+	response, err := g.withOrgID(orgId).Provisioning.GetTemplate(name)
+	return orNilOnNotFound[models.NotificationTemplate](&response, err)
+}
+
+// SetMessageTemplate creates the notification template with the given name or replaces its content.
+func (g *grafanaAPIClient) SetMessageTemplate(orgId int64, name string, template string) error {
+	params := provisioning.NewPutTemplateParams().
+		WithName(name).
+		WithBody(&models.NotificationTemplateContent{Template: template})
+	_, err := g.withOrgID(orgId).Provisioning.PutTemplate(params)
+	return err
+}
+
+func (g *grafanaAPIClient) DeleteMessageTemplate(orgId int64, name string) error {
+	_, err := g.withOrgID(orgId).Provisioning.DeleteTemplate(name)
+	return err
+}
+
+func (g *grafanaAPIClient) GetTeamById(orgId int64, id int64) (*models.TeamDTO, error) {
+	response, err := g.withOrgID(orgId).Teams.GetTeamByID(strconv.FormatInt(id, 10))
+	return orNilOnStatus[models.TeamDTO](&response, err, ignoreStatusCodesOnObserve...)
+}
+
+// GetTeamByUid searches all teams of the organization for the one with the given UID, as the team API only accepts
+// numeric IDs.
+func (g *grafanaAPIClient) GetTeamByUid(ctx context.Context, orgId int64, uid string) (*models.TeamDTO, error) {
+	client := g.withOrgID(orgId)
+	all, err := allPages(ctx, g.perPage, g.maxPages, func(page int64) ([]*models.TeamDTO, error) {
+		params := teams.NewSearchTeamsParams().WithContext(ctx).WithPage(&page).WithPerpage(&g.perPage)
+		response, err := client.Teams.SearchTeams(params)
+		if err != nil {
+			return nil, err
+		}
+		return response.Payload.Teams, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, team := range all {
+		if team.UID == uid {
+			return team, nil
+		}
+	}
+	return nil, nil
+}
+
+func (g *grafanaAPIClient) GetTeamMembers(orgId int64, teamId int64) ([]*models.TeamMemberDTO, error) {
+	response, err := g.withOrgID(orgId).Teams.GetTeamMembers(strconv.FormatInt(teamId, 10))
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+func (g *grafanaAPIClient) AddTeamMember(orgId int64, teamId int64, userId int64) error {
+	_, err := g.withOrgID(orgId).Teams.AddTeamMember(strconv.FormatInt(teamId, 10), &models.AddTeamMemberCommand{UserID: userId})
+	return err
+}
+
+func (g *grafanaAPIClient) UpdateTeamMember(orgId int64, teamId int64, userId int64, permission int64) error {
+	params := teams.NewUpdateTeamMemberParams().
+		WithTeamID(strconv.FormatInt(teamId, 10)).
+		WithUserID(userId).
+		WithBody(&models.UpdateTeamMemberCommand{Permission: models.PermissionType(permission)})
+	_, err := g.withOrgID(orgId).Teams.UpdateTeamMember(params)
+	return err
+}
+
+func (g *grafanaAPIClient) RemoveTeamMember(orgId int64, teamId int64, userId int64) error {
+	_, err := g.withOrgID(orgId).Teams.RemoveTeamMember(userId, strconv.FormatInt(teamId, 10))
+	return err
+}
+
+func (g *grafanaAPIClient) GetTeamPreferences(orgId int64, teamId int64) (*models.Preferences, error) {
+	response, err := g.withOrgID(orgId).Teams.GetTeamPreferences(strconv.FormatInt(teamId, 10))
 	if err != nil {
 		return nil, err
 	}
 	return response.Payload, err
 }
 
+func (g *grafanaAPIClient) UpdateTeamPreferences(orgId int64, teamId int64, command *models.UpdatePrefsCmd) error {
+	_, err := g.withOrgID(orgId).Teams.UpdateTeamPreferences(strconv.FormatInt(teamId, 10), command)
+	return err
+}
+
+func (g *grafanaAPIClient) GetRulerRuleGroup(orgId int64, namespace string, group string) (*RulerRuleGroup, error) {
+	ruleGroup := &RulerRuleGroup{}
+	err := g.submit(orgId, "getRulerRuleGroup", http.MethodGet, rulerPath+"/{namespace}/{group}",
+		map[string]string{"namespace": namespace, "group": group}, nil, ruleGroup)
+	if err != nil && IsCode(err, ignoreStatusCodesOnObserve...) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ruleGroup, nil
+}
+
+func (g *grafanaAPIClient) SetRulerRuleGroup(orgId int64, namespace string, ruleGroup *RulerRuleGroup) error {
+	return g.submit(orgId, "setRulerRuleGroup", http.MethodPost, rulerPath+"/{namespace}",
+		map[string]string{"namespace": namespace}, ruleGroup, nil)
+}
+
+func (g *grafanaAPIClient) DeleteRulerRuleGroup(orgId int64, namespace string, group string) error {
+	err := g.submit(orgId, "deleteRulerRuleGroup", http.MethodDelete, rulerPath+"/{namespace}/{group}",
+		map[string]string{"namespace": namespace, "group": group}, nil, nil)
+	if err != nil && IsCode(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
+
+// dataSourcesResource is the name of data sources in the resource permissions API.
+const dataSourcesResource = "datasources"
+
+func (g *grafanaAPIClient) GetDataSourcePermissions(orgId int64, uid string) ([]*models.ResourcePermissionDTO, error) {
+	resp, err := g.withOrgID(orgId).AccessControl.GetResourcePermissions(uid, dataSourcesResource)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Payload, nil
+}
+
+// UpdateDataSourcePermissions sets the given permissions of a data source. Permissions of users, teams and roles
+// that are not part of permissions are left as they are, they are removed by setting an empty permission.
+func (g *grafanaAPIClient) UpdateDataSourcePermissions(orgId int64, uid string, permissions []*models.SetResourcePermissionCommand) error {
+	params := access_control.NewSetResourcePermissionsParams().
+		WithResource(dataSourcesResource).
+		WithResourceID(uid).
+		WithBody(&models.SetPermissionsCommand{Permissions: permissions})
+	_, err := g.withOrgID(orgId).AccessControl.SetResourcePermissions(params)
+	return err
+}
+
+// foldersResource is the name of folders in the resource permissions API.
+const foldersResource = "folders"
+
+// GetTeamPermissions returns the permissions the team is granted on the folder. Grafana only lists permissions per
+// resource, so the permissions of the folder are filtered down to those of the team.
+func (g *grafanaAPIClient) GetTeamPermissions(orgId int64, folderUid string, teamId int64) ([]*models.ResourcePermissionDTO, error) {
+	resp, err := g.withOrgID(orgId).AccessControl.GetResourcePermissions(folderUid, foldersResource)
+	if err != nil {
+		return nil, err
+	}
+	var permissions []*models.ResourcePermissionDTO
+	for _, permission := range resp.Payload {
+		if permission.TeamID == teamId {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions, nil
+}
+
+// AddTeamPermission grants the permission on the folder to the team, replacing a permission it had before. Permissions
+// of other teams, users and roles are left as they are.
+func (g *grafanaAPIClient) AddTeamPermission(orgId int64, folderUid string, teamId int64, permission string) error {
+	params := access_control.NewSetResourcePermissionsForTeamParams().
+		WithResource(foldersResource).
+		WithResourceID(folderUid).
+		WithTeamID(teamId).
+		WithBody(&models.SetPermissionCommand{Permission: permission})
+	_, err := g.withOrgID(orgId).AccessControl.SetResourcePermissionsForTeam(params)
+	return err
+}
+
+// RemoveTeamPermission removes the permission of the team on the folder by setting an empty permission. The client omits
+// the empty permission from the body, which Grafana reads as an empty permission all the same.
+func (g *grafanaAPIClient) RemoveTeamPermission(orgId int64, folderUid string, teamId int64) error {
+	return g.AddTeamPermission(orgId, folderUid, teamId, "")
+}
+
+func (g *grafanaAPIClient) GetAnnotation(orgId int64, id int64) (*models.Annotation, error) {
+	response, err := g.withOrgID(orgId).Annotations.GetAnnotationByID(strconv.FormatInt(id, 10))
+	return orNilOnStatus[models.Annotation](&response, err, ignoreStatusCodesOnObserve...)
+}
+
+func (g *grafanaAPIClient) CreateAnnotation(orgId int64, command *models.PostAnnotationsCmd) (int64, error) {
+	response, err := g.withOrgID(orgId).Annotations.PostAnnotation(command)
+	if err != nil {
+		return 0, err
+	}
+	return *response.Payload.ID, nil
+}
+
+// UpdateAnnotation replaces the annotation with the given ID, fields that are not set in command are cleared.
+func (g *grafanaAPIClient) UpdateAnnotation(orgId int64, id int64, command *models.UpdateAnnotationsCmd) error {
+	_, err := g.withOrgID(orgId).Annotations.UpdateAnnotation(strconv.FormatInt(id, 10), command)
+	return err
+}
+
+func (g *grafanaAPIClient) DeleteAnnotation(orgId int64, id int64) error {
+	_, err := g.withOrgID(orgId).Annotations.DeleteAnnotationByID(strconv.FormatInt(id, 10))
+	return err
+}
+
+// GetReports lists the reports of an organization. Reports are a feature of Grafana Enterprise, OSS instances respond
+// with 404, which is returned as error for the caller to tell it apart from a missing report.
+func (g *grafanaAPIClient) GetReports(orgId int64) ([]*models.Report, error) {
+	response, err := g.withOrgID(orgId).Reports.GetReports()
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+func (g *grafanaAPIClient) CreateReport(orgId int64, command *models.CreateOrUpdateReportConfig) (int64, error) {
+	response, err := g.withOrgID(orgId).Reports.CreateReport(command)
+	if err != nil {
+		return 0, err
+	}
+	return response.Payload.ID, nil
+}
+
+func (g *grafanaAPIClient) UpdateReport(orgId int64, id int64, command *models.CreateOrUpdateReportConfig) error {
+	_, err := g.withOrgID(orgId).Reports.UpdateReport(id, command)
+	return err
+}
+
+func (g *grafanaAPIClient) DeleteReport(orgId int64, id int64) error {
+	_, err := g.withOrgID(orgId).Reports.DeleteReport(id)
+	return err
+}
+
+// GetRoles lists the custom and fixed roles of an organization, including global ones. Role-based access control is a
+// feature of Grafana Enterprise, OSS instances respond with 404, which is returned as error for the caller to tell it
+// apart from a missing role.
+func (g *grafanaAPIClient) GetRoles(orgId int64) ([]*models.RoleDTO, error) {
+	response, err := g.withOrgID(orgId).AccessControl.ListRoles(access_control.NewListRolesParams())
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+// GetRole returns the role with its permissions, nil is returned if there is no role with the given UID.
+func (g *grafanaAPIClient) GetRole(orgId int64, uid string) (*models.RoleDTO, error) {
+	response, err := g.withOrgID(orgId).AccessControl.GetRole(uid)
+	return orNilOnStatus[models.RoleDTO](&response, err, ignoreStatusCodesOnObserve...)
+}
+
+func (g *grafanaAPIClient) CreateRole(orgId int64, form *models.CreateRoleForm) (*models.RoleDTO, error) {
+	response, err := g.withOrgID(orgId).AccessControl.CreateRole(form)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+// UpdateRole replaces the role with the given UID, Grafana only accepts the update if command has a version after the
+// current one.
+func (g *grafanaAPIClient) UpdateRole(orgId int64, uid string, command *models.UpdateRoleCommand) error {
+	_, err := g.withOrgID(orgId).AccessControl.UpdateRole(uid, command)
+	return err
+}
+
+// DeleteRole deletes the role with the given UID, including its assignments.
+func (g *grafanaAPIClient) DeleteRole(orgId int64, uid string, global bool) error {
+	force := true
+	params := access_control.NewDeleteRoleParams().
+		WithRoleUID(uid).
+		WithGlobal(&global).
+		WithForce(&force)
+	_, err := g.withOrgID(orgId).AccessControl.DeleteRole(params)
+	return err
+}
+
+func (g *grafanaAPIClient) GetRoleAssignments(orgId int64, roleUid string) (*models.RoleAssignmentsDTO, error) {
+	response, err := g.withOrgID(orgId).AccessControl.GetRoleAssignments(roleUid)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+// SetRoleAssignments replaces the users, teams and service accounts the role is assigned to.
+func (g *grafanaAPIClient) SetRoleAssignments(orgId int64, roleUid string, command *models.SetRoleAssignmentsCommand) error {
+	_, err := g.withOrgID(orgId).AccessControl.SetRoleAssignments(roleUid, command)
+	return err
+}
+
+// GetSSOSettings returns the settings of the SSO provider, nil is returned if Grafana does not know the provider. Secret
+// settings are returned masked.
+func (g *grafanaAPIClient) GetSSOSettings(provider string) (*models.GetProviderSettingsOKBody, error) {
+	response, err := g.service.SsoSettings.GetProviderSettings(provider)
+	return orNilOnNotFound[models.GetProviderSettingsOKBody](&response, err)
+}
+
+func (g *grafanaAPIClient) UpdateSSOSettings(provider string, settings map[string]interface{}) error {
+	_, err := g.service.SsoSettings.UpdateProviderSettings(provider, &models.UpdateProviderSettingsParamsBody{
+		Provider: provider,
+		Settings: settings,
+	})
+	return err
+}
+
+// DeleteSSOSettings removes the settings of the SSO provider from the database, so Grafana falls back to the settings of
+// its configuration file.
+func (g *grafanaAPIClient) DeleteSSOSettings(provider string) error {
+	_, err := g.service.SsoSettings.RemoveProviderSettings(provider)
+	return err
+}
+
+func (g *grafanaAPIClient) CreateSnapshot(orgId int64, command *models.CreateDashboardSnapshotCommand) (*models.CreateDashboardSnapshotOKBody, error) {
+	response, err := g.withOrgID(orgId).Snapshots.CreateDashboardSnapshot(command)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+// GetSnapshotByKey returns the dashboard of a snapshot and the time it expires at, or nil if there is no snapshot with
+// the key or it expired. The generated client drops the response body of this operation, so it is sent directly.
+func (g *grafanaAPIClient) GetSnapshotByKey(orgId int64, key string) (*models.DashboardFullWithMeta, error) {
+	snapshot := &models.DashboardFullWithMeta{}
+	err := g.submit(orgId, "getDashboardSnapshot", http.MethodGet, "/snapshots/{key}", map[string]string{"key": key}, nil, snapshot)
+	if IsCode(err, http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// DeleteSnapshot deletes a snapshot by its delete key. Snapshots that are already gone are ignored.
+func (g *grafanaAPIClient) DeleteSnapshot(orgId int64, deleteKey string) error {
+	_, err := g.withOrgID(orgId).Snapshots.DeleteDashboardSnapshotByDeleteKey(deleteKey)
+	if IsCode(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
+
+// GetAPIKeys returns the API keys of the organization, including expired ones that Grafana did not clean up yet.
+func (g *grafanaAPIClient) GetAPIKeys(orgId int64) ([]*models.APIKeyDTO, error) {
+	includeExpired := true
+	response, err := g.withOrgID(orgId).APIKeys.GetAPIkeys(api_keys.NewGetAPIkeysParams().WithIncludeExpired(&includeExpired))
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+func (g *grafanaAPIClient) CreateAPIKey(orgId int64, command *models.AddAPIKeyCommand) (*models.NewAPIKeyResult, error) {
+	response, err := g.withOrgID(orgId).APIKeys.AddAPIkey(command)
+	if err != nil {
+		return nil, err
+	}
+	return response.Payload, nil
+}
+
+// DeleteAPIKey deletes an API key by its ID. API keys that are already gone are ignored.
+func (g *grafanaAPIClient) DeleteAPIKey(orgId int64, id int64) error {
+	_, err := g.withOrgID(orgId).APIKeys.DeleteAPIkey(id)
+	if IsCode(err, http.StatusNotFound) {
+		return nil
+	}
+	return err
+}
+
+// GetServerInfo returns the version and the enabled feature toggles of Grafana. They are read from the frontend settings,
+// which, unlike the admin settings, every signed in user can read.
+func (g *grafanaAPIClient) GetServerInfo() (*ServerInfo, error) {
+	settings := &frontendSettings{}
+	if err := g.submit(0, "getFrontendSettings", http.MethodGet, "/frontend/settings", nil, nil, settings); err != nil {
+		return nil, err
+	}
+	return &ServerInfo{Version: settings.BuildInfo.Version, FeatureToggles: settings.FeatureToggles}, nil
+}
+
+// submit sends a request that is not part of the generated client, e.g. to the ruler API. The request goes through
+// the transport of the client, so authentication and the organization header are handled the same way as for the
+// generated operations. The response body is decoded into result, if result is not nil.
+func (g *grafanaAPIClient) submit(orgId int64, id string, method string, path string, pathParams map[string]string, body interface{}, result interface{}) error {
+	op := &runtime.ClientOperation{
+		ID:                 id,
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http", "https"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
+			for name, value := range pathParams {
+				if err := r.SetPathParam(name, value); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			if response.Code() < 200 || response.Code() >= 300 {
+				return nil, runtime.NewAPIError(id, response.Message(), response.Code())
+			}
+			if result != nil {
+				if err := consumer.Consume(response.Body(), result); err != nil {
+					return nil, err
+				}
+			}
+			return result, nil
+		}),
+	}
+	_, err := g.withOrgID(orgId).Transport.Submit(op)
+	return err
+}
+
 func orNilOnNotFound[R interface{}, T ApiResponse[R]](response *T, err error) (*R, error) {
 	return orNilOnStatus[R, T](response, err, 404)
 }
 
 func orNilOnStatus[R interface{}, T ApiResponse[R]](response *T, err error, status ...int) (*R, error) {
-	if err != nil && isCode(err, status...) {
+	if err != nil && IsCode(err, status...) {
 		return nil, nil
 	}
 	if err != nil {
@@ -379,8 +1311,8 @@ func orNilOnStatus[R interface{}, T ApiResponse[R]](response *T, err error, stat
 	return (*response).GetPayload(), err
 }
 
-// nolint: unparam
-func isCode(err error, codes ...int) bool {
+// IsCode returns true if err is an ApiError with one of the supplied status codes.
+func IsCode(err error, codes ...int) bool {
 	if err == nil {
 		return false
 	}
@@ -0,0 +1,930 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func strRef(s string) *string {
+	return &s
+}
+
+func Test_DeleteFolderSendsForceDeleteRules(t *testing.T) {
+	for _, force := range []bool{true, false} {
+		var request *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			request = r
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": 1, "message": "Folder deleted", "title": "test"}`))
+		}))
+
+		u, _ := url.Parse(server.URL)
+		api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+			Host:     u.Host,
+			BasePath: "/api",
+			Schemes:  []string{"http"},
+		}))
+
+		_, err := api.DeleteFolder(1, "abc", force)
+		server.Close()
+
+		assert.Nil(t, err)
+		assert.Equal(t, http.MethodDelete, request.Method)
+		assert.Equal(t, "/api/folders/abc", request.URL.Path)
+		assert.Equal(t, strconv.FormatBool(force), request.URL.Query().Get("forceDeleteRules"))
+	}
+}
+
+func Test_RulerRuleGroup(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/ruler/grafana/api/v1/rules/abc/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "rule group does not exist"}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"name": "test", "interval": "1m", "rules": [{"record": "job:up", "expr": "sum by (job) (up)"}]}`))
+		default:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"message": "rule group updated successfully"}`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	group, err := api.GetRulerRuleGroup(2, "abc", "test")
+	assert.Nil(t, err)
+	assert.Equal(t, &RulerRuleGroup{Name: "test", Interval: "1m", Rules: []*RulerRule{{Record: "job:up", Expr: "sum by (job) (up)"}}}, group)
+	assert.Equal(t, "/api/ruler/grafana/api/v1/rules/abc/test", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	group, err = api.GetRulerRuleGroup(2, "abc", "missing")
+	assert.Nil(t, err)
+	assert.Nil(t, group)
+
+	err = api.SetRulerRuleGroup(2, "abc", &RulerRuleGroup{Name: "test", Interval: "1m", Rules: []*RulerRule{{Record: "job:up", Expr: "up"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPost, requests[2].Method)
+	assert.Equal(t, "/api/ruler/grafana/api/v1/rules/abc", requests[2].URL.Path)
+	assert.JSONEq(t, `{"name": "test", "interval": "1m", "rules": [{"record": "job:up", "expr": "up"}]}`, bodies[2])
+
+	err = api.DeleteRulerRuleGroup(2, "abc", "test")
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, requests[3].Method)
+	assert.Equal(t, "/api/ruler/grafana/api/v1/rules/abc/test", requests[3].URL.Path)
+}
+
+func Test_GetFolderByNameMatchesExactTitle(t *testing.T) {
+	cases := map[string]struct {
+		hits string
+		uid  string
+		err  error
+	}{
+		"Exact": {
+			hits: `[{"uid": "a", "title": "Team A"}, {"uid": "b", "title": "Team"}]`,
+			uid:  "b",
+		},
+		"None": {
+			hits: `[{"uid": "a", "title": "Team A"}]`,
+		},
+		"Ambiguous": {
+			hits: `[{"uid": "a", "title": "Team"}, {"uid": "b", "title": "Team"}]`,
+			err:  ErrAmbiguousFolderTitle,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/api/search" {
+					_, _ = w.Write([]byte(tc.hits))
+					return
+				}
+				_, _ = w.Write([]byte(`{"uid": "` + path.Base(r.URL.Path) + `", "title": "Team"}`))
+			}))
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+				Host:     u.Host,
+				BasePath: "/api",
+				Schemes:  []string{"http"},
+			}))
+
+			folder, err := api.GetFolderByName(context.Background(), 1, "Team", nil)
+			assert.Equal(t, tc.err, err)
+			if tc.uid == "" {
+				assert.Nil(t, folder)
+				return
+			}
+			assert.Equal(t, tc.uid, folder.UID)
+		})
+	}
+}
+
+func Test_GetFolderByNameVerifiesParent(t *testing.T) {
+	// the folders "Team" in "a" and "b" share their title, and Grafana returns both regardless of the parent
+	folders := map[string]string{
+		"x": `{"uid": "x", "title": "Team", "parentUid": "a", "parents": [{"uid": "a", "id": 1}]}`,
+		"y": `{"uid": "y", "title": "Team", "parentUid": "b", "parents": [{"uid": "b", "id": 2}]}`,
+	}
+	cases := map[string]struct {
+		parent *string
+		uid    string
+		err    error
+	}{
+		"ParentUID": {
+			parent: strRef("b"),
+			uid:    "y",
+		},
+		"ParentID": {
+			parent: strRef("1"),
+			uid:    "x",
+		},
+		"OtherParent": {
+			parent: strRef("c"),
+		},
+		"AnyParent": {
+			err: ErrAmbiguousFolderTitle,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/api/search" {
+					_, _ = w.Write([]byte(`[{"uid": "x", "title": "Team"}, {"uid": "y", "title": "Team"}]`))
+					return
+				}
+				_, _ = w.Write([]byte(folders[path.Base(r.URL.Path)]))
+			}))
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+				Host:     u.Host,
+				BasePath: "/api",
+				Schemes:  []string{"http"},
+			}))
+
+			folder, err := api.GetFolderByName(context.Background(), 1, "Team", tc.parent)
+			assert.Equal(t, tc.err, err)
+			if tc.uid == "" {
+				assert.Nil(t, folder)
+				return
+			}
+			assert.Equal(t, tc.uid, folder.UID)
+		})
+	}
+}
+
+func Test_GetDashboardByNameFiltersFolder(t *testing.T) {
+	cases := map[string]struct {
+		folder *string
+		hits   string
+		uid    string
+		err    error
+	}{
+		"None": {
+			hits: `[{"uid": "a", "title": "Overview A"}]`,
+		},
+		"One": {
+			hits: `[{"uid": "a", "title": "Overview A"}, {"uid": "b", "title": "Overview"}]`,
+			uid:  "b",
+		},
+		"OtherFolder": {
+			hits: `[{"uid": "a", "title": "Overview", "folderUid": "team", "folderId": 3}, {"uid": "b", "title": "Overview"}]`,
+			uid:  "b",
+		},
+		"FolderUID": {
+			folder: strRef("team"),
+			hits:   `[{"uid": "a", "title": "Overview", "folderUid": "team", "folderId": 3}, {"uid": "b", "title": "Overview"}]`,
+			uid:    "a",
+		},
+		"FolderID": {
+			folder: strRef("3"),
+			hits:   `[{"uid": "a", "title": "Overview", "folderUid": "team", "folderId": 3}, {"uid": "b", "title": "Overview"}]`,
+			uid:    "a",
+		},
+		"MultipleInSameFolder": {
+			folder: strRef("team"),
+			hits:   `[{"uid": "a", "title": "Overview", "folderUid": "team"}, {"uid": "b", "title": "Overview", "folderUid": "team"}]`,
+			err:    ErrAmbiguousDashboardTitle,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Path == "/api/search" {
+					_, _ = w.Write([]byte(tc.hits))
+					return
+				}
+				_, _ = w.Write([]byte(`{"dashboard": {"uid": "` + path.Base(r.URL.Path) + `", "title": "Overview"}}`))
+			}))
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+				Host:     u.Host,
+				BasePath: "/api",
+				Schemes:  []string{"http"},
+			}))
+
+			dashboard, err := api.GetDashboardByName(context.Background(), 1, "Overview", tc.folder)
+			assert.Equal(t, tc.err, err)
+			if tc.uid == "" {
+				assert.Nil(t, dashboard)
+				return
+			}
+			assert.Equal(t, tc.uid, dashboard.Dashboard.(map[string]interface{})["uid"])
+		})
+	}
+}
+
+func Test_SearchPagesThroughResults(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/search" {
+			_, _ = w.Write([]byte(`{"dashboard": {"uid": "` + path.Base(r.URL.Path) + `", "title": "Overview"}}`))
+			return
+		}
+		pages = append(pages, r.URL.Query().Get("page"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`[{"uid": "a", "title": "Overview A"}, {"uid": "b", "title": "Overview B"}]`))
+		default:
+			_, _ = w.Write([]byte(`[{"uid": "c", "title": "Overview"}]`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPIWithPerPage(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}), 2)
+
+	dashboard, err := api.GetDashboardByName(context.Background(), 1, "Overview", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "c", dashboard.Dashboard.(map[string]interface{})["uid"])
+	assert.Equal(t, []string{"1", "2"}, pages)
+}
+
+// pagedServer serves count items of the given path in pages, as Grafana does: pages start at 1 and hold perpage items.
+func pagedServer(itemsPath string, count int, item func(i int) string, pages *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != itemsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		*pages = append(*pages, r.URL.Query().Get("page"))
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("perpage"))
+		var items []string
+		for i := (page - 1) * perPage; i < page*perPage && i < count; i++ {
+			items = append(items, item(i))
+		}
+		_, _ = w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+}
+
+func Test_GetAllUsersCollectsAllPages(t *testing.T) {
+	cases := map[string]struct {
+		count int
+		pages []string
+	}{
+		"Empty": {
+			count: 0,
+			pages: []string{"1"},
+		},
+		"PartialPage": {
+			count: 5,
+			pages: []string{"1", "2", "3"},
+		},
+		"LastPageFull": {
+			count: 6,
+			pages: []string{"1", "2", "3", "4"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var pages []string
+			server := pagedServer("/api/users", tc.count, func(i int) string {
+				return `{"id": ` + strconv.Itoa(i+1) + `}`
+			}, &pages)
+			defer server.Close()
+
+			u, _ := url.Parse(server.URL)
+			api := NewGrafanaAPIWithPerPage(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+				Host:     u.Host,
+				BasePath: "/api",
+				Schemes:  []string{"http"},
+			}), 2)
+
+			users, err := api.GetAllUsers(context.Background())
+			assert.Nil(t, err)
+			assert.Len(t, users, tc.count)
+			for i, user := range users {
+				assert.Equal(t, int64(i+1), user.ID)
+			}
+			assert.Equal(t, tc.pages, pages)
+		})
+	}
+}
+
+func Test_GetAllOrgsCollectsAllPages(t *testing.T) {
+	var pages []string
+	server := pagedServer("/api/orgs", 4, func(i int) string {
+		return `{"id": ` + strconv.Itoa(i+1) + `, "name": "org ` + strconv.Itoa(i+1) + `"}`
+	}, &pages)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPIWithPerPage(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}), 2)
+
+	orgs, err := api.GetAllOrgs(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.OrgDTO{{ID: 1, Name: "org 1"}, {ID: 2, Name: "org 2"}, {ID: 3, Name: "org 3"}, {ID: 4, Name: "org 4"}}, orgs)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+}
+
+func Test_GetAllUsersStopsAfterMaxPages(t *testing.T) {
+	var pages []string
+	server := pagedServer("/api/users", 10, func(i int) string {
+		return `{"id": ` + strconv.Itoa(i+1) + `}`
+	}, &pages)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPIWithPagination(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}), 2, 3)
+
+	users, err := api.GetAllUsers(context.Background())
+	assert.EqualError(t, err, fmt.Sprintf(errTooManyPages, 3, 2))
+	assert.Nil(t, users)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+}
+
+func Test_GetTeamByUidStopsAfterMaxPages(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		// a pathological instance that returns full pages forever
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"teams": [{"id": 1, "uid": "other"}, {"id": 2, "uid": "another"}]}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPIWithPagination(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}), 2, 3)
+
+	team, err := api.GetTeamByUid(context.Background(), 1, "platform")
+	assert.EqualError(t, err, fmt.Sprintf(errTooManyPages, 3, 2))
+	assert.Nil(t, team)
+	assert.Equal(t, []string{"1", "2", "3"}, pages)
+}
+
+func Test_GetAllOrgsStopsIfCancelled(t *testing.T) {
+	var pages []string
+	ctx, cancel := context.WithCancel(context.Background())
+	server := pagedServer("/api/orgs", 10, func(i int) string {
+		// the reconcile is cancelled while the first page is fetched
+		cancel()
+		return `{"id": ` + strconv.Itoa(i+1) + `}`
+	}, &pages)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPIWithPerPage(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}), 2)
+
+	_, err := api.GetAllOrgs(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.LessOrEqual(t, len(pages), 1)
+}
+
+func Test_DataSourcePermissions(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path.Base(r.URL.Path) == "missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found"}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id": 1, "teamId": 2, "permission": "Edit", "isManaged": true}]`))
+		default:
+			_, _ = w.Write([]byte(`{"message": "Permissions updated"}`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	permissions, err := api.GetDataSourcePermissions(2, "abc")
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.ResourcePermissionDTO{{ID: 1, TeamID: 2, Permission: "Edit", IsManaged: true}}, permissions)
+	assert.Equal(t, "/api/access-control/datasources/abc", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	_, err = api.GetDataSourcePermissions(2, "missing")
+	assert.True(t, IsCode(err, http.StatusNotFound))
+
+	err = api.UpdateDataSourcePermissions(2, "abc", []*models.SetResourcePermissionCommand{
+		{TeamID: 2, Permission: "Query"},
+		{BuiltInRole: "Viewer"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPost, requests[2].Method)
+	assert.Equal(t, "/api/access-control/datasources/abc", requests[2].URL.Path)
+	assert.JSONEq(t, `{"permissions": [{"teamId": 2, "permission": "Query"}, {"builtInRole": "Viewer"}]}`, bodies[2])
+}
+
+func Test_TeamPermissions(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`[{"id": 1, "teamId": 2, "permission": "Edit", "isManaged": true}, {"id": 2, "teamId": 3, "permission": "View", "isManaged": true}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"message": "Permission updated"}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	permissions, err := api.GetTeamPermissions(2, "abc", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.ResourcePermissionDTO{{ID: 1, TeamID: 2, Permission: "Edit", IsManaged: true}}, permissions)
+	assert.Equal(t, "/api/access-control/folders/abc", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	err = api.AddTeamPermission(2, "abc", 2, "Admin")
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPost, requests[1].Method)
+	assert.Equal(t, "/api/access-control/folders/abc/teams/2", requests[1].URL.Path)
+	assert.JSONEq(t, `{"permission": "Admin"}`, bodies[1])
+
+	err = api.RemoveTeamPermission(2, "abc", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, "/api/access-control/folders/abc/teams/2", requests[2].URL.Path)
+	assert.JSONEq(t, `{}`, bodies[2])
+}
+
+func Test_MuteTimings(t *testing.T) {
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path.Base(r.URL.Path) == "missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message": "mute timing is used by a notification policy"}`))
+		default:
+			_, _ = w.Write([]byte(`{"name": "weekends", "time_intervals": [{"weekdays": ["saturday"]}]}`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	muteTiming, err := api.GetMuteTiming(2, "weekends")
+	assert.Nil(t, err)
+	assert.Equal(t, &models.MuteTimeInterval{Name: "weekends", TimeIntervals: []*models.TimeInterval{{Weekdays: []string{"saturday"}}}}, muteTiming)
+	assert.Equal(t, "/api/v1/provisioning/mute-timings/weekends", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	muteTiming, err = api.GetMuteTiming(2, "missing")
+	assert.Nil(t, err)
+	assert.Nil(t, muteTiming)
+
+	err = api.DeleteMuteTiming(2, "weekends")
+	assert.True(t, IsCode(err, http.StatusConflict))
+}
+
+func Test_MessageTemplates(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path.Base(r.URL.Path) == "missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found"}`))
+		case r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"name": "slack", "template": "{{ define \"slack\" }}{{ end }}"}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			_, _ = w.Write([]byte(`{"name": "slack", "template": "{{ define \"slack\" }}{{ end }}"}`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	template, err := api.GetMessageTemplate(2, "slack")
+	assert.Nil(t, err)
+	assert.Equal(t, &models.NotificationTemplate{Name: "slack", Template: `{{ define "slack" }}{{ end }}`}, template)
+	assert.Equal(t, "/api/v1/provisioning/templates/slack", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	template, err = api.GetMessageTemplate(2, "missing")
+	assert.Nil(t, err)
+	assert.Nil(t, template)
+
+	err = api.SetMessageTemplate(2, "slack", `{{ define "slack" }}{{ end }}`)
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPut, requests[2].Method)
+	assert.Equal(t, "/api/v1/provisioning/templates/slack", requests[2].URL.Path)
+	assert.JSONEq(t, `{"template": "{{ define \"slack\" }}{{ end }}"}`, bodies[2])
+
+	err = api.DeleteMessageTemplate(2, "slack")
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, requests[3].Method)
+}
+
+func Test_Annotations(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path.Base(r.URL.Path) == "404":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Annotation not found"}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": 7, "text": "deployed", "tags": ["deployment"], "time": 1704110400000}`))
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"id": 7, "message": "Annotation added"}`))
+		default:
+			_, _ = w.Write([]byte(`{"message": "Annotation updated"}`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	annotation, err := api.GetAnnotation(2, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, &models.Annotation{ID: 7, Text: "deployed", Tags: []string{"deployment"}, Time: 1704110400000}, annotation)
+	assert.Equal(t, "/api/annotations/7", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	annotation, err = api.GetAnnotation(2, 404)
+	assert.Nil(t, err)
+	assert.Nil(t, annotation)
+
+	text := "deployed"
+	id, err := api.CreateAnnotation(2, &models.PostAnnotationsCmd{Text: &text, Tags: []string{"deployment"}, Time: 1704110400000})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), id)
+	assert.Equal(t, "/api/annotations", requests[2].URL.Path)
+	assert.JSONEq(t, `{"text": "deployed", "tags": ["deployment"], "time": 1704110400000}`, bodies[2])
+
+	err = api.UpdateAnnotation(2, 7, &models.UpdateAnnotationsCmd{ID: 7, Text: "rolled back", Tags: []string{}})
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPut, requests[3].Method)
+	assert.Equal(t, "/api/annotations/7", requests[3].URL.Path)
+
+	err = api.DeleteAnnotation(2, 7)
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, requests[4].Method)
+	assert.Equal(t, "/api/annotations/7", requests[4].URL.Path)
+}
+
+func Test_Reports(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Header.Get(grafana.OrgIDHeader) == "3":
+			// reports are not part of Grafana OSS
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Not found"}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`[{"id": 4, "name": "weekly", "recipients": "a@example.com,b@example.com"}]`))
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"id": 4, "message": "Report created"}`))
+		default:
+			_, _ = w.Write([]byte(`{"message": "Report updated"}`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	reports, err := api.GetReports(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.Report{{ID: 4, Name: "weekly", Recipients: "a@example.com,b@example.com"}}, reports)
+	assert.Equal(t, "/api/reports", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	_, err = api.GetReports(3)
+	assert.True(t, IsCode(err, http.StatusNotFound))
+
+	id, err := api.CreateReport(2, &models.CreateOrUpdateReportConfig{Name: "weekly", Formats: []models.Type{"pdf"}})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), id)
+	assert.Equal(t, "/api/reports", requests[2].URL.Path)
+	assert.JSONEq(t, `{"name": "weekly", "formats": ["pdf"], "dashboards": null}`, bodies[2])
+
+	err = api.UpdateReport(2, 4, &models.CreateOrUpdateReportConfig{Name: "monthly", Formats: []models.Type{"pdf"}})
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPut, requests[3].Method)
+	assert.Equal(t, "/api/reports/4", requests[3].URL.Path)
+
+	err = api.DeleteReport(2, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, requests[4].Method)
+	assert.Equal(t, "/api/reports/4", requests[4].URL.Path)
+}
+
+func Test_Roles(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path.Base(r.URL.Path) == "404":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Role not found"}`))
+		case r.Method == http.MethodGet && path.Base(r.URL.Path) == "roles":
+			_, _ = w.Write([]byte(`[{"uid": "abc", "name": "custom:reader", "version": 1}]`))
+		case r.Method == http.MethodGet && path.Base(r.URL.Path) == "assignments":
+			_, _ = w.Write([]byte(`{"role_uid": "abc", "users": [2], "teams": [], "service_accounts": [3]}`))
+		case r.Method == http.MethodGet, r.Method == http.MethodPost:
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			_, _ = w.Write([]byte(`{"uid": "abc", "name": "custom:reader", "version": 1, "permissions": [{"action": "dashboards:read", "scope": "dashboards:*"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"message": "Role updated"}`))
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	roles, err := api.GetRoles(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.RoleDTO{{UID: "abc", Name: "custom:reader", Version: 1}}, roles)
+	assert.Equal(t, "/api/access-control/roles", requests[0].URL.Path)
+	assert.Equal(t, "2", requests[0].Header.Get(grafana.OrgIDHeader))
+
+	role, err := api.GetRole(2, "abc")
+	assert.Nil(t, err)
+	assert.Equal(t, []*models.Permission{{Action: "dashboards:read", Scope: "dashboards:*"}}, role.Permissions)
+	assert.Equal(t, "/api/access-control/roles/abc", requests[1].URL.Path)
+
+	role, err = api.GetRole(2, "404")
+	assert.Nil(t, err)
+	assert.Nil(t, role)
+
+	role, err = api.CreateRole(2, &models.CreateRoleForm{Name: "custom:reader", Permissions: []*models.Permission{{Action: "dashboards:read"}}})
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", role.UID)
+	assert.Equal(t, "/api/access-control/roles", requests[3].URL.Path)
+	assert.Contains(t, bodies[3], `"action":"dashboards:read"`)
+
+	err = api.UpdateRole(2, "abc", &models.UpdateRoleCommand{Name: "custom:reader", Version: 2, Permissions: []*models.Permission{}})
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPut, requests[4].Method)
+	assert.Equal(t, "/api/access-control/roles/abc", requests[4].URL.Path)
+
+	err = api.DeleteRole(2, "abc", true)
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, requests[5].Method)
+	assert.Equal(t, "/api/access-control/roles/abc", requests[5].URL.Path)
+	assert.Equal(t, "true", requests[5].URL.Query().Get("global"))
+	assert.Equal(t, "true", requests[5].URL.Query().Get("force"))
+
+	assignments, err := api.GetRoleAssignments(2, "abc")
+	assert.Nil(t, err)
+	assert.Equal(t, &models.RoleAssignmentsDTO{RoleUID: "abc", Users: []int64{2}, Teams: []int64{}, ServiceAccounts: []int64{3}}, assignments)
+	assert.Equal(t, "/api/access-control/roles/abc/assignments", requests[6].URL.Path)
+
+	err = api.SetRoleAssignments(2, "abc", &models.SetRoleAssignmentsCommand{Users: []int64{2}, Teams: []int64{}, ServiceAccounts: []int64{}})
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPut, requests[7].Method)
+	assert.JSONEq(t, `{"users": [2], "teams": [], "service_accounts": []}`, bodies[7])
+}
+
+func Test_SSOSettings(t *testing.T) {
+	var requests []*http.Request
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case path.Base(r.URL.Path) == "unknown":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "SSO settings not found"}`))
+		case r.Method == http.MethodGet:
+			_, _ = w.Write([]byte(`{"id": "1", "provider": "github", "source": "database", "settings": {"clientId": "abc", "clientSecret": "*********"}}`))
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:      u.Host,
+		BasePath:  "/api",
+		Schemes:   []string{"http"},
+		BasicAuth: url.UserPassword("admin", "admin"),
+	}))
+
+	settings, err := api.GetSSOSettings("github")
+	assert.Nil(t, err)
+	assert.Equal(t, "database", settings.Source)
+	assert.Equal(t, map[string]interface{}{"clientId": "abc", "clientSecret": "*********"}, settings.Settings)
+	assert.Equal(t, "/api/v1/sso-settings/github", requests[0].URL.Path)
+
+	settings, err = api.GetSSOSettings("unknown")
+	assert.Nil(t, err)
+	assert.Nil(t, settings)
+
+	err = api.UpdateSSOSettings("github", map[string]interface{}{"clientId": "abc"})
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodPut, requests[2].Method)
+	assert.Equal(t, "/api/v1/sso-settings/github", requests[2].URL.Path)
+	assert.JSONEq(t, `{"provider": "github", "settings": {"clientId": "abc"}}`, bodies[2])
+
+	err = api.DeleteSSOSettings("github")
+	assert.Nil(t, err)
+	assert.Equal(t, http.MethodDelete, requests[3].Method)
+	assert.Equal(t, "/api/v1/sso-settings/github", requests[3].URL.Path)
+}
+
+func Test_GetSnapshotByKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/snapshots/key" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "Dashboard snapshot not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"dashboard": {"title": "Incident"}, "meta": {"isSnapshot": true, "expires": "2074-01-01T12:00:00Z"}}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}))
+
+	snapshot, err := api.GetSnapshotByKey(1, "key")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{"title": "Incident"}, snapshot.Dashboard)
+	assert.Equal(t, "2074-01-01T12:00:00.000Z", snapshot.Meta.Expires.String())
+
+	snapshot, err = api.GetSnapshotByKey(1, "expired")
+	assert.Nil(t, err)
+	assert.Nil(t, snapshot)
+}
+
+func Test_GetServerInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/api/frontend/settings" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"buildInfo": {"version": "10.3.1", "commit": "abc"}, "featureToggles": {"nestedFolders": true}}`))
+	}))
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}))
+
+	info, err := api.GetServerInfo()
+	assert.Nil(t, err)
+	assert.Equal(t, "10.3.1", info.Version)
+	assert.True(t, info.FeatureEnabled("nestedFolders"))
+	assert.False(t, info.FeatureEnabled("unknown"))
+}
@@ -0,0 +1,158 @@
+package common
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+const (
+	// DefaultCircuitBreakerThreshold is the number of consecutive failures that open the circuit unless the
+	// ProviderConfig configures otherwise.
+	DefaultCircuitBreakerThreshold = 5
+	// DefaultCircuitBreakerCooldown is how long an open circuit rejects requests unless the ProviderConfig configures
+	// otherwise.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+)
+
+// CircuitBreakers holds the circuit breakers of all Grafana instances the provider talks to. It is shared by all
+// reconcilers, so a Grafana that is down stops being asked by all of them.
+var CircuitBreakers = NewCircuitBreakerRegistry()
+
+// A CircuitBreaker counts consecutive failures of the requests to a Grafana instance. Once the threshold is reached,
+// the circuit opens and requests are rejected with ErrCircuitOpen until the cooldown has passed. Then the circuit is
+// half-open and a single request is let through as a probe: its success closes the circuit while its failure opens it
+// for another cooldown. It is safe for concurrent use.
+type CircuitBreaker struct {
+	threshold atomic.Int64
+	cooldown  atomic.Int64
+	failures  atomic.Int64
+	// openedAt is the time in unix nanoseconds the circuit was opened at the last time
+	openedAt atomic.Int64
+	// probing is set while the single request of a half-open circuit is in flight
+	probing atomic.Bool
+	now     func() time.Time
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker that opens after threshold consecutive failures for the cooldown.
+// A threshold <= 0 disables the circuit breaker.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	b := &CircuitBreaker{now: time.Now}
+	b.configure(threshold, cooldown)
+	return b
+}
+
+func (b *CircuitBreaker) configure(threshold int, cooldown time.Duration) {
+	b.threshold.Store(int64(threshold))
+	b.cooldown.Store(int64(cooldown))
+}
+
+// Allow reports whether a request may be sent, that is the circuit is closed, or its cooldown has passed and no other
+// request probes it already. A request that was allowed must be followed by Success, Failure or Release.
+func (b *CircuitBreaker) Allow() bool {
+	threshold := b.threshold.Load()
+	if threshold <= 0 || b.failures.Load() < threshold {
+		return true
+	}
+	if b.now().UnixNano()-b.openedAt.Load() < b.cooldown.Load() {
+		return false
+	}
+	return b.probing.CompareAndSwap(false, true)
+}
+
+// Success closes the circuit.
+func (b *CircuitBreaker) Success() {
+	b.failures.Store(0)
+	b.probing.Store(false)
+}
+
+// Failure counts a failed request and opens the circuit if the threshold is reached. A failure after the cooldown
+// opens it again right away, since the count is only reset by a success.
+func (b *CircuitBreaker) Failure() {
+	threshold := b.threshold.Load()
+	if b.failures.Add(1) >= threshold && threshold > 0 {
+		b.openedAt.Store(b.now().UnixNano())
+	}
+	b.probing.Store(false)
+}
+
+// Release lets another request probe a half-open circuit, if the request that was allowed says nothing about Grafana.
+func (b *CircuitBreaker) Release() {
+	b.probing.Store(false)
+}
+
+// A CircuitBreakerRegistry holds a CircuitBreaker per Grafana host. It is safe for concurrent use.
+type CircuitBreakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// NewCircuitBreakerRegistry returns an empty CircuitBreakerRegistry.
+func NewCircuitBreakerRegistry() *CircuitBreakerRegistry {
+	return &CircuitBreakerRegistry{breakers: make(map[string]*CircuitBreaker)}
+}
+
+// For returns the CircuitBreaker of the host, configured by the policy of a ProviderConfig. Unset fields of the policy
+// take their defaults. Changes of the policy apply to the existing CircuitBreaker of the host, without resetting it.
+func (r *CircuitBreakerRegistry) For(host string, policy *apisv1beta1.CircuitBreakerPolicy) *CircuitBreaker {
+	threshold := DefaultCircuitBreakerThreshold
+	cooldown := DefaultCircuitBreakerCooldown
+	if policy != nil && policy.FailureThreshold != nil {
+		threshold = *policy.FailureThreshold
+	}
+	if policy != nil && policy.Cooldown != nil {
+		cooldown = policy.Cooldown.Duration
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, ok := r.breakers[host]
+	if !ok {
+		b = NewCircuitBreaker(threshold, cooldown)
+		r.breakers[host] = b
+		return b
+	}
+	b.configure(threshold, cooldown)
+	return b
+}
+
+// A CircuitBreakerTransport rejects requests with ErrCircuitOpen while its CircuitBreaker is open, without sending
+// them. Requests that can't reach Grafana, or that Grafana answers with 502, 503 or 504, count as failures. All other
+// responses, including other errors like 404 or a RateLimitError, show that Grafana is up and close the circuit.
+type CircuitBreakerTransport struct {
+	Transport http.RoundTripper
+	Breaker   *CircuitBreaker
+}
+
+// RoundTrip sends the request unless the circuit is open.
+func (t *CircuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.Breaker.Allow() {
+		if req.Body != nil {
+			_ = req.Body.Close()
+		}
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err := t.Transport.RoundTrip(req)
+	_, rateLimited := AsRateLimitError(err)
+	switch {
+	case err != nil && req.Context().Err() != nil:
+		// canceled by the caller, which says nothing about Grafana
+		t.Breaker.Release()
+	case rateLimited:
+		// Grafana is up, it just asks to slow down
+		t.Breaker.Success()
+	case err != nil, isUnavailable(resp.StatusCode):
+		t.Breaker.Failure()
+	default:
+		t.Breaker.Success()
+	}
+	return resp, err
+}
+
+func isUnavailable(statusCode int) bool {
+	return statusCode == http.StatusBadGateway || statusCode == http.StatusServiceUnavailable || statusCode == http.StatusGatewayTimeout
+}
@@ -0,0 +1,216 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+func Test_CircuitBreakerTransport(t *testing.T) {
+	var requests int
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	client := &http.Client{Transport: &CircuitBreakerTransport{Transport: http.DefaultTransport, Breaker: breaker}}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	_, err := client.Get(server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, requests, "no request should be sent while the circuit is open")
+
+	// after the cooldown one request is let through, and its failure opens the circuit again
+	now = now.Add(time.Minute)
+	_, err = client.Get(server.URL)
+	assert.Nil(t, err)
+	_, err = client.Get(server.URL)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 3, requests)
+
+	// a success closes the circuit
+	now = now.Add(time.Minute)
+	status = http.StatusNotFound
+	for i := 0; i < 3; i++ {
+		_, err = client.Get(server.URL)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 6, requests)
+}
+
+func Test_CircuitBreakerCountsUnreachableHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := &http.Client{Transport: &CircuitBreakerTransport{Transport: http.DefaultTransport, Breaker: NewCircuitBreaker(1, time.Minute)}}
+	_, err := client.Get(url)
+	assert.NotNil(t, err)
+	assert.False(t, errors.Is(err, ErrCircuitOpen))
+
+	_, err = client.Get(url)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+func Test_GrafanaAPIReturnsErrCircuitOpen(t *testing.T) {
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.Failure()
+
+	api := NewGrafanaAPI(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     "grafana:3000",
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+		Client:   &http.Client{Transport: &CircuitBreakerTransport{Transport: http.DefaultTransport, Breaker: breaker}},
+	}))
+
+	_, err := api.GetSignedInUser()
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+}
+
+func Test_CircuitBreakerDisabled(t *testing.T) {
+	breaker := NewCircuitBreaker(0, time.Minute)
+	for i := 0; i < 10; i++ {
+		breaker.Failure()
+	}
+	assert.True(t, breaker.Allow())
+}
+
+func Test_CircuitBreakerRegistry(t *testing.T) {
+	r := NewCircuitBreakerRegistry()
+	b := r.For("grafana:3000", nil)
+	assert.Equal(t, int64(DefaultCircuitBreakerThreshold), b.threshold.Load())
+	assert.Equal(t, int64(DefaultCircuitBreakerCooldown), b.cooldown.Load())
+	b.Failure()
+
+	threshold := 3
+	same := r.For("grafana:3000", &apisv1beta1.CircuitBreakerPolicy{FailureThreshold: &threshold, Cooldown: &metav1.Duration{Duration: time.Second}})
+	assert.Same(t, b, same)
+	assert.Equal(t, int64(3), same.threshold.Load())
+	assert.Equal(t, int64(time.Second), same.cooldown.Load())
+	assert.Equal(t, int64(1), same.failures.Load(), "a changed policy should not reset the breaker")
+
+	assert.NotSame(t, b, r.For("other:3000", nil))
+}
+
+func Test_CircuitBreakerHalfOpenAllowsSingleProbe(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	breaker.Failure()
+	assert.False(t, breaker.Allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(), "the first request after the cooldown should probe the circuit")
+	assert.False(t, breaker.Allow(), "no other request should be sent while the probe is in flight")
+
+	breaker.Release()
+	assert.True(t, breaker.Allow(), "a released probe should let another request probe the circuit")
+
+	breaker.Failure()
+	assert.False(t, breaker.Allow(), "a failed probe should open the circuit for another cooldown")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow())
+	breaker.Success()
+	assert.True(t, breaker.Allow())
+	assert.True(t, breaker.Allow(), "a successful probe should close the circuit")
+}
+
+func Test_SetAPIUnavailable(t *testing.T) {
+	errCircuitOpen := errors.Wrap(ErrCircuitOpen, "cannot get Folder")
+
+	cases := map[string]struct {
+		err       error
+		condition xpv1.Condition
+		want      xpv1.ConditionReason
+	}{
+		"CircuitOpen": {
+			err:       errCircuitOpen,
+			condition: xpv1.ReconcileError(errors.Wrap(errCircuitOpen, "observe failed")),
+			want:      v1alpha1.ReasonAPIUnavailable,
+		},
+		"OtherError": {
+			err:       errors.New("boom"),
+			condition: xpv1.ReconcileError(errors.New("observe failed: boom")),
+			want:      xpv1.ReasonReconcileError,
+		},
+		"MessageMentionsCircuitBreaker": {
+			err:       errors.New(ErrCircuitOpen.Error()),
+			condition: xpv1.ReconcileError(errors.Wrap(errors.New(ErrCircuitOpen.Error()), "observe failed")),
+			want:      xpv1.ReasonReconcileError,
+		},
+		"Success": {
+			condition: xpv1.ReconcileSuccess(),
+			want:      xpv1.ReasonReconcileSuccess,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := WithAPIUnavailable(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, tc.err
+					},
+				}, nil
+			}))
+
+			mg := &v1alpha1.Folder{}
+			mg.SetUID(types.UID(name))
+			ec, err := c.Connect(context.Background(), mg)
+			assert.Nil(t, err)
+			_, err = ec.Observe(context.Background(), mg)
+			assert.Equal(t, tc.err, err)
+
+			mg.SetConditions(tc.condition)
+			SetAPIUnavailable(mg)
+			got := mg.GetCondition(xpv1.TypeSynced)
+			assert.Equal(t, tc.want, got.Reason)
+			assert.Equal(t, tc.condition.Message, got.Message)
+
+			// the reason is only replaced once, a later status update keeps the reconciler's condition
+			mg.SetConditions(tc.condition)
+			SetAPIUnavailable(mg)
+			assert.Equal(t, tc.condition.Reason, mg.GetCondition(xpv1.TypeSynced).Reason)
+		})
+	}
+}
+
+func Test_WithAPIUnavailableConnect(t *testing.T) {
+	c := WithAPIUnavailable(managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+		return nil, errors.Wrap(ErrCircuitOpen, "cannot get user")
+	}))
+
+	mg := &v1alpha1.Folder{}
+	mg.SetUID("connect")
+	_, err := c.Connect(context.Background(), mg)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	mg.SetConditions(xpv1.ReconcileError(errors.Wrap(err, "connect failed")))
+	SetAPIUnavailable(mg)
+	assert.Equal(t, v1alpha1.ReasonAPIUnavailable, mg.GetCondition(xpv1.TypeSynced).Reason)
+}
@@ -0,0 +1,150 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errLookupStack   = "cannot look up Grafana Cloud stack %q"
+	errStackStatus   = "Grafana Cloud API responded with %d: %s"
+	errStackNoURL    = "Grafana Cloud API responded without the url of the stack"
+	errStackURL      = "Grafana Cloud API responded with an invalid url of the stack"
+	errGetStackToken = "cannot get Grafana Cloud access policy token"
+
+	// DefaultCloudAPIURL is the URL of the Grafana Cloud API.
+	DefaultCloudAPIURL = "https://grafana.com"
+	// DefaultCloudStackCacheTTL is how long the host of a stack is cached. Stacks don't move, but it is looked up again
+	// now and then in case they do.
+	DefaultCloudStackCacheTTL = time.Hour
+)
+
+// CloudStacks caches the hosts of all Grafana Cloud stacks the provider talks to. It is shared by all reconcilers.
+var CloudStacks = NewCloudStackResolver(DefaultCloudAPIURL, DefaultCloudStackCacheTTL)
+
+// A CloudStack is where a Grafana Cloud stack serves its API.
+type CloudStack struct {
+	Host   string
+	Port   int
+	Scheme string
+}
+
+// CloudStackResolver looks up the hosts of Grafana Cloud stacks by their slug via the Grafana Cloud API. Lookups are
+// cached per slug, concurrent lookups of the same slug wait for a single request. Errors are not cached. It is safe for
+// concurrent use.
+type CloudStackResolver struct {
+	mu      sync.Mutex
+	apiURL  string
+	ttl     time.Duration
+	client  *http.Client
+	entries map[string]*cloudStackEntry
+	now     func() time.Time
+}
+
+type cloudStackEntry struct {
+	mu      sync.Mutex
+	stack   *CloudStack
+	expires time.Time
+}
+
+// NewCloudStackResolver returns a CloudStackResolver that asks the Grafana Cloud API at apiURL and keeps the stacks for
+// the supplied TTL.
+func NewCloudStackResolver(apiURL string, ttl time.Duration) *CloudStackResolver {
+	return &CloudStackResolver{
+		apiURL:  apiURL,
+		ttl:     ttl,
+		client:  &http.Client{Timeout: 30 * time.Second},
+		entries: make(map[string]*cloudStackEntry),
+		now:     time.Now,
+	}
+}
+
+// Resolve returns the stack of the slug, asking the Grafana Cloud API with token if it is not cached or expired.
+func (r *CloudStackResolver) Resolve(ctx context.Context, slug string, token string) (*CloudStack, error) {
+	r.mu.Lock()
+	entry, ok := r.entries[slug]
+	if !ok {
+		entry = &cloudStackEntry{}
+		r.entries[slug] = entry
+	}
+	r.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.stack != nil && r.now().Before(entry.expires) {
+		return entry.stack, nil
+	}
+	stack, err := r.lookup(ctx, slug, token)
+	if err != nil {
+		return nil, errors.Wrapf(err, errLookupStack, slug)
+	}
+	entry.stack = stack
+	entry.expires = r.now().Add(r.ttl)
+	return stack, nil
+}
+
+type cloudStackResponse struct {
+	URL string `json:"url"`
+}
+
+// lookup asks the Grafana Cloud API for the URL of the stack.
+func (r *CloudStackResolver) lookup(ctx context.Context, slug string, token string) (*CloudStack, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.apiURL+"/api/instances/"+url.PathEscape(slug), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // nothing left to read
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(errStackStatus, resp.StatusCode, string(body))
+	}
+
+	var instance cloudStackResponse
+	if err := json.Unmarshal(body, &instance); err != nil {
+		return nil, err
+	}
+	if instance.URL == "" {
+		return nil, errors.New(errStackNoURL)
+	}
+	return parseStackURL(instance.URL)
+}
+
+// parseStackURL returns the stack served at the URL. The port defaults to the one of its scheme.
+func parseStackURL(raw string) (*CloudStack, error) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return nil, errors.New(errStackURL)
+	}
+	stack := &CloudStack{Host: u.Hostname(), Scheme: u.Scheme, Port: 443}
+	if stack.Scheme == "" {
+		stack.Scheme = "https"
+	}
+	if stack.Scheme == "http" {
+		stack.Port = 80
+	}
+	if p := u.Port(); p != "" {
+		if stack.Port, err = strconv.Atoi(p); err != nil {
+			return nil, errors.New(errStackURL)
+		}
+	}
+	return stack, nil
+}
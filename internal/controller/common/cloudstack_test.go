@@ -0,0 +1,88 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// cloudAPI serves the stack at stackURL to requests with the token, and counts the requests.
+func cloudAPI(t *testing.T, stackURL string, calls *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if r.Header.Get("Authorization") != "Bearer glc_abc" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"invalid token"}`))
+			return
+		}
+		if r.URL.Path != "/api/instances/mystack" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"slug":"mystack","url":%q}`, stackURL)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_CloudStackResolverCachesPerTTL(t *testing.T) {
+	var calls int32
+	server := cloudAPI(t, "https://mystack.grafana.net", &calls)
+	now := time.Unix(0, 0)
+	resolver := NewCloudStackResolver(server.URL, time.Hour)
+	resolver.now = func() time.Time { return now }
+
+	stack, err := resolver.Resolve(context.Background(), "mystack", "glc_abc")
+	assert.Nil(t, err)
+	assert.Equal(t, &CloudStack{Host: "mystack.grafana.net", Port: 443, Scheme: "https"}, stack)
+
+	_, _ = resolver.Resolve(context.Background(), "mystack", "glc_abc")
+	assert.Equal(t, int32(1), calls, "the stack should be served from the cache within the TTL")
+
+	now = now.Add(time.Hour)
+	_, _ = resolver.Resolve(context.Background(), "mystack", "glc_abc")
+	assert.Equal(t, int32(2), calls, "the stack should be looked up again after the TTL")
+}
+
+func Test_CloudStackResolverDoesNotCacheErrors(t *testing.T) {
+	var calls int32
+	server := cloudAPI(t, "https://mystack.grafana.net", &calls)
+	resolver := NewCloudStackResolver(server.URL, time.Hour)
+
+	_, err := resolver.Resolve(context.Background(), "mystack", "wrong")
+	assert.EqualError(t, err, `cannot look up Grafana Cloud stack "mystack": Grafana Cloud API responded with 401: {"message":"invalid token"}`)
+
+	_, err = resolver.Resolve(context.Background(), "mystack", "glc_abc")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), calls)
+}
+
+func Test_parseStackURL(t *testing.T) {
+	cases := map[string]struct {
+		url  string
+		want *CloudStack
+		err  string
+	}{
+		"Https":        {url: "https://mystack.grafana.net", want: &CloudStack{Host: "mystack.grafana.net", Port: 443, Scheme: "https"}},
+		"Http":         {url: "http://grafana.local", want: &CloudStack{Host: "grafana.local", Port: 80, Scheme: "http"}},
+		"ExplicitPort": {url: "https://grafana.local:8443/", want: &CloudStack{Host: "grafana.local", Port: 8443, Scheme: "https"}},
+		"NoHost":       {url: "mystack", err: errStackURL},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseStackURL(tc.url)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package common
+
+import (
+	"context"
+	"sync"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+)
+
+// apiUnavailable holds the UIDs of the managed resources whose last operation failed with ErrCircuitOpen. It is set by
+// the external clients of WithAPIUnavailable and consumed by SetAPIUnavailable.
+var apiUnavailable sync.Map
+
+// WithAPIUnavailable returns an ExternalConnecter that remembers managed resources whose Connect or external client
+// operations failed with ErrCircuitOpen, so SetAPIUnavailable reports them with the APIUnavailable reason.
+func WithAPIUnavailable(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &apiUnavailableConnecter{ExternalConnecter: c}
+}
+
+type apiUnavailableConnecter struct {
+	managed.ExternalConnecter
+}
+
+func (c *apiUnavailableConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, checkAPIUnavailable(mg, err)
+	}
+	return &apiUnavailableExternalClient{ExternalClient: ec}, nil
+}
+
+type apiUnavailableExternalClient struct {
+	managed.ExternalClient
+}
+
+func (c *apiUnavailableExternalClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.ExternalClient.Observe(ctx, mg)
+	return o, checkAPIUnavailable(mg, err)
+}
+
+func (c *apiUnavailableExternalClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, err := c.ExternalClient.Create(ctx, mg)
+	return cr, checkAPIUnavailable(mg, err)
+}
+
+func (c *apiUnavailableExternalClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := c.ExternalClient.Update(ctx, mg)
+	return u, checkAPIUnavailable(mg, err)
+}
+
+func (c *apiUnavailableExternalClient) Delete(ctx context.Context, mg resource.Managed) error {
+	return checkAPIUnavailable(mg, c.ExternalClient.Delete(ctx, mg))
+}
+
+// checkAPIUnavailable remembers mg if err was caused by an open circuit breaker, and returns err unchanged.
+func checkAPIUnavailable(mg resource.Managed, err error) error {
+	if errors.Is(err, ErrCircuitOpen) {
+		apiUnavailable.Store(mg.GetUID(), struct{}{})
+	} else {
+		apiUnavailable.Delete(mg.GetUID())
+	}
+	return err
+}
+
+// WithAPIUnavailableCondition returns a manager whose client reports managed resources that WithAPIUnavailable
+// remembered with the APIUnavailable reason instead of ReconcileError. The managed reconciler sets the Synced
+// condition itself after an operation failed, so the reason is replaced when the status is written.
+func WithAPIUnavailableCondition(mgr ctrl.Manager) ctrl.Manager {
+	return apiUnavailableManager{Manager: mgr}
+}
+
+type apiUnavailableManager struct {
+	ctrl.Manager
+}
+
+func (m apiUnavailableManager) GetClient() client.Client {
+	return apiUnavailableClient{Client: m.Manager.GetClient()}
+}
+
+type apiUnavailableClient struct {
+	client.Client
+}
+
+func (c apiUnavailableClient) Status() client.SubResourceWriter {
+	return apiUnavailableStatusWriter{SubResourceWriter: c.Client.Status()}
+}
+
+type apiUnavailableStatusWriter struct {
+	client.SubResourceWriter
+}
+
+func (w apiUnavailableStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if mg, ok := obj.(resource.Managed); ok {
+		SetAPIUnavailable(mg)
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+// SetAPIUnavailable replaces a ReconcileError of the Synced condition with APIUnavailable, if the last operation on mg
+// failed with ErrCircuitOpen.
+func SetAPIUnavailable(mg resource.Managed) {
+	if _, ok := apiUnavailable.LoadAndDelete(mg.GetUID()); !ok {
+		return
+	}
+	c := mg.GetCondition(xpv1.TypeSynced)
+	if c.Reason == xpv1.ReasonReconcileError {
+		mg.SetConditions(v1alpha1.APIUnavailable(c.Message))
+	}
+}
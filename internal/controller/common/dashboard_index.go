@@ -0,0 +1,139 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+// DefaultDashboardIndexTTL is how long the dashboards of an organization are
+// indexed unless configured otherwise.
+const DefaultDashboardIndexTTL = time.Minute
+
+// Dashboards indexes the dashboards of all organizations the provider talks
+// to. It is shared by all reconcilers.
+var Dashboards = NewDashboardIndex(DefaultDashboardIndexTTL)
+
+// DashboardIndex caches the search hits of all dashboards per Grafana host and
+// organization, so that reconciling many dashboards lists them once per TTL
+// instead of searching for every single one. It is safe for concurrent use. A
+// nil index or a TTL <= 0 disables the index.
+type DashboardIndex struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[dashboardIndexKey]*dashboardIndexEntry
+	now     func() time.Time
+}
+
+type dashboardIndexKey struct {
+	host  string
+	orgId int64
+}
+
+type dashboardIndexEntry struct {
+	mu      sync.Mutex
+	list    *DashboardList
+	expires time.Time
+}
+
+// NewDashboardIndex returns a DashboardIndex that keeps the dashboards for the
+// supplied TTL.
+func NewDashboardIndex(ttl time.Duration) *DashboardIndex {
+	return &DashboardIndex{
+		ttl:     ttl,
+		entries: make(map[dashboardIndexKey]*dashboardIndexEntry),
+		now:     time.Now,
+	}
+}
+
+// SetTTL changes how long dashboards are indexed. Already indexed dashboards
+// keep their expiry.
+func (c *DashboardIndex) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the dashboards of the organization at the supplied host, calling
+// load if they are not indexed or expired. Concurrent calls for the same
+// organization wait for a single load. Errors are not cached. If the index is
+// disabled, nil is returned without calling load, and the dashboards have to
+// be looked up one by one.
+func (c *DashboardIndex) Get(host string, orgId int64, load func() ([]*models.Hit, error)) (*DashboardList, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	key := dashboardIndexKey{host: host, orgId: orgId}
+	c.mu.Lock()
+	ttl := c.ttl
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &dashboardIndexEntry{}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	if ttl <= 0 {
+		return nil, nil
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.list != nil && c.now().Before(entry.expires) {
+		return entry.list, nil
+	}
+	hits, err := load()
+	if err != nil {
+		return nil, err
+	}
+	entry.list = newDashboardList(hits)
+	entry.expires = c.now().Add(ttl)
+	return entry.list, nil
+}
+
+// Invalidate drops the indexed dashboards of the organization at the supplied
+// host, e.g. after a dashboard was created, updated or deleted.
+func (c *DashboardIndex) Invalidate(host string, orgId int64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, dashboardIndexKey{host: host, orgId: orgId})
+}
+
+// A DashboardList holds the search hits of all dashboards of an organization.
+// It is shared and must not be modified.
+type DashboardList struct {
+	hits  []*models.Hit
+	byUID map[string]*models.Hit
+}
+
+func newDashboardList(hits []*models.Hit) *DashboardList {
+	byUID := make(map[string]*models.Hit, len(hits))
+	for _, hit := range hits {
+		byUID[hit.UID] = hit
+	}
+	return &DashboardList{hits: hits, byUID: byUID}
+}
+
+// Contains returns true if a dashboard has the supplied UID.
+func (l *DashboardList) Contains(uid string) bool {
+	_, ok := l.byUID[uid]
+	return ok
+}
+
+// UIDsByTitle returns the UIDs of the dashboards with the supplied title in the
+// folder, which is either a numeric ID or an UID. A nil folder is the General
+// folder.
+func (l *DashboardList) UIDsByTitle(title string, folder *string) []string {
+	var uids []string
+	for _, hit := range l.hits {
+		if hit.Title == title && isInFolder(hit, folder) {
+			uids = append(uids, hit.UID)
+		}
+	}
+	return uids
+}
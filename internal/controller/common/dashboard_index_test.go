@@ -0,0 +1,96 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func dashboardLoader(calls *int, hits ...*models.Hit) func() ([]*models.Hit, error) {
+	return func() ([]*models.Hit, error) {
+		*calls++
+		return hits, nil
+	}
+}
+
+func Test_DashboardIndexLoadsOncePerTTL(t *testing.T) {
+	calls := 0
+	now := time.Unix(0, 0)
+	index := NewDashboardIndex(time.Minute)
+	index.now = func() time.Time { return now }
+	load := dashboardLoader(&calls, &models.Hit{UID: "abc", Title: "test"})
+
+	list, err := index.Get("grafana:3000", 1, load)
+	assert.Nil(t, err)
+	assert.True(t, list.Contains("abc"))
+	assert.False(t, list.Contains("other"))
+
+	_, _ = index.Get("grafana:3000", 1, load)
+	assert.Equal(t, 1, calls, "dashboards should be served from the index within the TTL")
+
+	_, _ = index.Get("grafana:3000", 2, load)
+	assert.Equal(t, 2, calls, "dashboards should be indexed per organization")
+
+	_, _ = index.Get("other:3000", 1, load)
+	assert.Equal(t, 3, calls, "dashboards should be indexed per host")
+
+	now = now.Add(time.Minute)
+	_, _ = index.Get("grafana:3000", 1, load)
+	assert.Equal(t, 4, calls, "dashboards should be listed again after the TTL")
+}
+
+func Test_DashboardIndexInvalidate(t *testing.T) {
+	calls := 0
+	index := NewDashboardIndex(time.Minute)
+	load := dashboardLoader(&calls)
+
+	_, _ = index.Get("grafana:3000", 1, load)
+	_, _ = index.Get("grafana:3000", 2, load)
+	index.Invalidate("grafana:3000", 1)
+	_, _ = index.Get("grafana:3000", 1, load)
+	_, _ = index.Get("grafana:3000", 2, load)
+	assert.Equal(t, 3, calls, "only the invalidated organization should be listed again")
+}
+
+func Test_DashboardIndexDisabled(t *testing.T) {
+	calls := 0
+	for _, index := range []*DashboardIndex{nil, NewDashboardIndex(0)} {
+		list, err := index.Get("grafana:3000", 1, dashboardLoader(&calls))
+		assert.Nil(t, err)
+		assert.Nil(t, list)
+		index.Invalidate("grafana:3000", 1)
+	}
+	assert.Equal(t, 0, calls, "a disabled index should not list the dashboards")
+}
+
+func Test_DashboardIndexDoesNotCacheErrors(t *testing.T) {
+	calls := 0
+	index := NewDashboardIndex(time.Minute)
+	_, err := index.Get("grafana:3000", 1, func() ([]*models.Hit, error) {
+		calls++
+		return nil, errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+
+	_, err = index.Get("grafana:3000", 1, dashboardLoader(&calls))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func Test_DashboardListUIDsByTitle(t *testing.T) {
+	list := newDashboardList([]*models.Hit{
+		{UID: "general", Title: "test"},
+		{UID: "by-uid", Title: "test", FolderUID: "team", FolderID: 3},
+		{UID: "other-title", Title: "testing", FolderUID: "team", FolderID: 3},
+		{UID: "duplicate", Title: "test", FolderUID: "team", FolderID: 3},
+	})
+	team, teamID := "team", "3"
+
+	assert.Equal(t, []string{"general"}, list.UIDsByTitle("test", nil))
+	assert.Equal(t, []string{"by-uid", "duplicate"}, list.UIDsByTitle("test", &team))
+	assert.Equal(t, []string{"by-uid", "duplicate"}, list.UIDsByTitle("test", &teamID))
+	assert.Empty(t, list.UIDsByTitle("missing", nil))
+}
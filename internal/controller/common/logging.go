@@ -0,0 +1,46 @@
+package common
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// ResourceLogger returns the logger with the fields that identify the managed resource of the kind. Debug messages
+// are only logged if the provider runs with --debug. A nil logger, e.g. in tests, logs nothing.
+func ResourceLogger(l logging.Logger, kind string, mg resource.Managed) logging.Logger {
+	if l == nil {
+		return logging.NewNopLogger()
+	}
+	return l.WithValues("kind", kind, "name", mg.GetName(), "external-name", meta.GetExternalName(mg))
+}
+
+// A Comparison collects the fields of a resource that differ from the spec, so that it can be logged why a resource
+// is not up to date.
+type Comparison struct {
+	differing []string
+}
+
+// Equal records the field as differing unless equal is true.
+func (c *Comparison) Equal(field string, equal bool) {
+	if !equal {
+		c.differing = append(c.differing, field)
+	}
+}
+
+// UpToDate returns true if no field differs.
+func (c *Comparison) UpToDate() bool {
+	return len(c.differing) == 0
+}
+
+// Differing returns the fields that differ in the order they were compared.
+func (c *Comparison) Differing() []string {
+	return c.differing
+}
+
+// Log logs the differing fields at debug level, if any.
+func (c *Comparison) Log(l logging.Logger) {
+	if !c.UpToDate() {
+		l.Debug("Resource is not up to date", "differing", c.Differing())
+	}
+}
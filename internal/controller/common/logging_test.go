@@ -0,0 +1,28 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+)
+
+func Test_Comparison(t *testing.T) {
+	c := &Comparison{}
+	c.Equal("title", true)
+	assert.True(t, c.UpToDate())
+	assert.Nil(t, c.Differing())
+
+	c.Equal("url", false)
+	c.Equal("type", true)
+	c.Equal("jsonData", false)
+	assert.False(t, c.UpToDate())
+	assert.Equal(t, []string{"url", "jsonData"}, c.Differing())
+}
+
+func Test_ResourceLoggerWithoutLogger(t *testing.T) {
+	l := ResourceLogger(nil, "Folder", &v1alpha1.Folder{})
+	assert.Equal(t, logging.NewNopLogger(), l)
+}
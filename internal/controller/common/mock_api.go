@@ -0,0 +1,546 @@
+package common
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGrafanaAPI is a testify based mock of GrafanaAPI to be used in unit tests.
+type MockGrafanaAPI struct {
+	mock.Mock
+}
+
+var _ GrafanaAPI = &MockGrafanaAPI{}
+
+// mockReturn returns the i-th return value of a mocked call as T, or the zero value of T if it was set to nil.
+func mockReturn[T any](args mock.Arguments, i int) T {
+	v, _ := args.Get(i).(T)
+	return v
+}
+
+func (m *MockGrafanaAPI) GetAllUsers(ctx context.Context) ([]*models.UserSearchHitDTO, error) {
+	args := m.Called(ctx)
+	return mockReturn[[]*models.UserSearchHitDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateUser(user string) (int64, error) {
+	args := m.Called(user)
+	return mockReturn[int64](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetAllOrgs(ctx context.Context) ([]*models.OrgDTO, error) {
+	args := m.Called(ctx)
+	return mockReturn[[]*models.OrgDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetSignedInUser() (*models.UserProfileDTO, error) {
+	args := m.Called()
+	return mockReturn[*models.UserProfileDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetSignedInUserOrgs() ([]*models.UserOrgDTO, error) {
+	args := m.Called()
+	return mockReturn[[]*models.UserOrgDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateOrg(name string) (*models.CreateOrgOKBody, error) {
+	args := m.Called(name)
+	return mockReturn[*models.CreateOrgOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteOrgByID(orgID int64, fromOrgID int64) (*models.SuccessResponseBody, error) {
+	args := m.Called(orgID, fromOrgID)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) AddOrgUser(orgID int64, user *models.AddOrgUserCommand) (*models.SuccessResponseBody, error) {
+	args := m.Called(orgID, user)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateOrgUser(orgID int64, userID int64, user *models.UpdateOrgUserCommand) (*models.SuccessResponseBody, error) {
+	args := m.Called(orgID, userID, user)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) RemoveOrgUser(userID int64, orgID int64) (*models.SuccessResponseBody, error) {
+	args := m.Called(userID, orgID)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) AdminCreateUser(user *models.AdminCreateUserForm) (*models.AdminCreateUserResponse, error) {
+	args := m.Called(user)
+	return mockReturn[*models.AdminCreateUserResponse](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetUserById(id int64) (*models.UserProfileDTO, error) {
+	args := m.Called(id)
+	return mockReturn[*models.UserProfileDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetUserByLoginOrEmail(loginOrEmail string) (*models.UserProfileDTO, error) {
+	args := m.Called(loginOrEmail)
+	return mockReturn[*models.UserProfileDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateUser(id int64, command *models.UpdateUserCommand) (*models.SuccessResponseBody, error) {
+	args := m.Called(id, command)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateUserPermissions(id int64, isAdmin bool) error {
+	args := m.Called(id, isAdmin)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) UpdateUserPassword(id int64, password string) error {
+	args := m.Called(id, password)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DisableUser(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) EnableUser(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteUser(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetOrgByName(s string) (*models.OrgDetailsDTO, error) {
+	args := m.Called(s)
+	return mockReturn[*models.OrgDetailsDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetOrgById(id int64) (*models.OrgDetailsDTO, error) {
+	args := m.Called(id)
+	return mockReturn[*models.OrgDetailsDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetOrgUsers(orgId int64) ([]*models.OrgUserDTO, error) {
+	args := m.Called(orgId)
+	return mockReturn[[]*models.OrgUserDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetOrgQuotas(orgId int64) ([]*models.QuotaDTO, error) {
+	args := m.Called(orgId)
+	return mockReturn[[]*models.QuotaDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateOrgQuota(orgId int64, target string, limit int64) error {
+	args := m.Called(orgId, target, limit)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) UpdateOrg(orgId int64, command *models.UpdateOrgAddressForm) error {
+	args := m.Called(orgId, command)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetOrgPreferences(orgId int64) (*models.Preferences, error) {
+	args := m.Called(orgId)
+	return mockReturn[*models.Preferences](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateOrgPreferences(orgId int64, command *models.UpdatePrefsCmd) (*models.SuccessResponseBody, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetDataSourceById(orgId int64, id string) (*models.DataSource, error) {
+	args := m.Called(orgId, id)
+	return mockReturn[*models.DataSource](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetDataSourceByName(orgId int64, name string) (*models.DataSource, error) {
+	args := m.Called(orgId, name)
+	return mockReturn[*models.DataSource](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetDataSourceByUid(orgId int64, uid string) (*models.DataSource, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*models.DataSource](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateDataSource(orgId int64, command *models.AddDataSourceCommand) (*models.AddDataSourceOKBody, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[*models.AddDataSourceOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateDataSource(orgId int64, id string, command *models.UpdateDataSourceCommand) (*models.UpdateDataSourceByIDOKBody, error) {
+	args := m.Called(orgId, id, command)
+	return mockReturn[*models.UpdateDataSourceByIDOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteDataSource(orgId int64, id string) (*models.SuccessResponseBody, error) {
+	args := m.Called(orgId, id)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) TestDataSource(orgId int64, uid string) (*DataSourceTestResult, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*DataSourceTestResult](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateOrUpdateDashboard(orgId int64, command *models.SaveDashboardCommand) (*models.PostDashboardOKBody, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[*models.PostDashboardOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetDashboardByUid(orgId int64, uid string) (*models.DashboardFullWithMeta, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*models.DashboardFullWithMeta](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetDashboardByName(ctx context.Context, orgId int64, name string, folder *string) (*models.DashboardFullWithMeta, error) {
+	args := m.Called(ctx, orgId, name, folder)
+	return mockReturn[*models.DashboardFullWithMeta](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) SearchDashboards(ctx context.Context, orgId int64) ([]*models.Hit, error) {
+	args := m.Called(ctx, orgId)
+	return mockReturn[[]*models.Hit](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteDashboard(orgId int64, uid string) (*models.DeleteDashboardByUIDOKBody, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*models.DeleteDashboardByUIDOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetFolderByUid(orgId int64, uid string) (*models.Folder, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*models.Folder](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetFolderById(orgId int64, id int64) (*models.Folder, error) {
+	args := m.Called(orgId, id)
+	return mockReturn[*models.Folder](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetFolderByName(ctx context.Context, orgId int64, name string, parentFolder *string) (*models.Folder, error) {
+	args := m.Called(ctx, orgId, name, parentFolder)
+	return mockReturn[*models.Folder](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateFolder(orgId int64, command *models.CreateFolderCommand) (*models.Folder, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[*models.Folder](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateFolder(orgId int64, uid string, command *models.UpdateFolderCommand) (*models.Folder, error) {
+	args := m.Called(orgId, uid, command)
+	return mockReturn[*models.Folder](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) MoveFolder(orgId int64, uid string, newParentUID string) (*models.Folder, error) {
+	args := m.Called(orgId, uid, newParentUID)
+	return mockReturn[*models.Folder](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteFolder(orgId int64, uid string, forceDeleteRules bool) (*models.DeleteFolderOKBody, error) {
+	args := m.Called(orgId, uid, forceDeleteRules)
+	return mockReturn[*models.DeleteFolderOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetFolderDescendantCounts(orgId int64, uid string) (models.DescendantCounts, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[models.DescendantCounts](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetLibraryPanelByUid(orgId int64, uid string) (*models.LibraryElementDTO, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*models.LibraryElementDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetLibraryPanelByName(orgId int64, name string, folderUID *string) (*models.LibraryElementDTO, error) {
+	args := m.Called(orgId, name, folderUID)
+	return mockReturn[*models.LibraryElementDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetLibraryPanelConnections(orgId int64, uid string) ([]*models.LibraryElementConnectionDTO, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[[]*models.LibraryElementConnectionDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateLibraryPanel(orgId int64, command *models.CreateLibraryElementCommand) (*models.LibraryElementDTO, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[*models.LibraryElementDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateLibraryPanel(orgId int64, uid string, command *models.PatchLibraryElementCommand) (*models.LibraryElementDTO, error) {
+	args := m.Called(orgId, uid, command)
+	return mockReturn[*models.LibraryElementDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteLibraryPanel(orgId int64, uid string) (*models.SuccessResponseBody, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*models.SuccessResponseBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetAlertRuleGroup(orgId int64, folderUID string, group string) (*models.AlertRuleGroup, error) {
+	args := m.Called(orgId, folderUID, group)
+	return mockReturn[*models.AlertRuleGroup](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) PutAlertRuleGroup(orgId int64, folderUID string, group string, ruleGroup *models.AlertRuleGroup) (*models.AlertRuleGroup, error) {
+	args := m.Called(orgId, folderUID, group, ruleGroup)
+	return mockReturn[*models.AlertRuleGroup](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteAlertRule(orgId int64, uid string) error {
+	args := m.Called(orgId, uid)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetMuteTiming(orgId int64, name string) (*models.MuteTimeInterval, error) {
+	args := m.Called(orgId, name)
+	return mockReturn[*models.MuteTimeInterval](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateMuteTiming(orgId int64, muteTiming *models.MuteTimeInterval) error {
+	args := m.Called(orgId, muteTiming)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) UpdateMuteTiming(orgId int64, name string, muteTiming *models.MuteTimeInterval) error {
+	args := m.Called(orgId, name, muteTiming)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteMuteTiming(orgId int64, name string) error {
+	args := m.Called(orgId, name)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetMessageTemplate(orgId int64, name string) (*models.NotificationTemplate, error) {
+	args := m.Called(orgId, name)
+	return mockReturn[*models.NotificationTemplate](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) SetMessageTemplate(orgId int64, name string, template string) error {
+	args := m.Called(orgId, name, template)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteMessageTemplate(orgId int64, name string) error {
+	args := m.Called(orgId, name)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetTeamById(orgId int64, id int64) (*models.TeamDTO, error) {
+	args := m.Called(orgId, id)
+	return mockReturn[*models.TeamDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetTeamByUid(ctx context.Context, orgId int64, uid string) (*models.TeamDTO, error) {
+	args := m.Called(ctx, orgId, uid)
+	return mockReturn[*models.TeamDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetTeamMembers(orgId int64, teamId int64) ([]*models.TeamMemberDTO, error) {
+	args := m.Called(orgId, teamId)
+	return mockReturn[[]*models.TeamMemberDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) AddTeamMember(orgId int64, teamId int64, userId int64) error {
+	args := m.Called(orgId, teamId, userId)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) UpdateTeamMember(orgId int64, teamId int64, userId int64, permission int64) error {
+	args := m.Called(orgId, teamId, userId, permission)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) RemoveTeamMember(orgId int64, teamId int64, userId int64) error {
+	args := m.Called(orgId, teamId, userId)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetTeamPreferences(orgId int64, teamId int64) (*models.Preferences, error) {
+	args := m.Called(orgId, teamId)
+	return mockReturn[*models.Preferences](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateTeamPreferences(orgId int64, teamId int64, command *models.UpdatePrefsCmd) error {
+	args := m.Called(orgId, teamId, command)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetRulerRuleGroup(orgId int64, namespace string, group string) (*RulerRuleGroup, error) {
+	args := m.Called(orgId, namespace, group)
+	return mockReturn[*RulerRuleGroup](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) SetRulerRuleGroup(orgId int64, namespace string, ruleGroup *RulerRuleGroup) error {
+	args := m.Called(orgId, namespace, ruleGroup)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteRulerRuleGroup(orgId int64, namespace string, group string) error {
+	args := m.Called(orgId, namespace, group)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetDataSourcePermissions(orgId int64, uid string) ([]*models.ResourcePermissionDTO, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[[]*models.ResourcePermissionDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateDataSourcePermissions(orgId int64, uid string, permissions []*models.SetResourcePermissionCommand) error {
+	args := m.Called(orgId, uid, permissions)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetTeamPermissions(orgId int64, folderUid string, teamId int64) ([]*models.ResourcePermissionDTO, error) {
+	args := m.Called(orgId, folderUid, teamId)
+	return mockReturn[[]*models.ResourcePermissionDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) AddTeamPermission(orgId int64, folderUid string, teamId int64, permission string) error {
+	args := m.Called(orgId, folderUid, teamId, permission)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) RemoveTeamPermission(orgId int64, folderUid string, teamId int64) error {
+	args := m.Called(orgId, folderUid, teamId)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetAnnotation(orgId int64, id int64) (*models.Annotation, error) {
+	args := m.Called(orgId, id)
+	return mockReturn[*models.Annotation](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateAnnotation(orgId int64, command *models.PostAnnotationsCmd) (int64, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[int64](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateAnnotation(orgId int64, id int64, command *models.UpdateAnnotationsCmd) error {
+	args := m.Called(orgId, id, command)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteAnnotation(orgId int64, id int64) error {
+	args := m.Called(orgId, id)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetReports(orgId int64) ([]*models.Report, error) {
+	args := m.Called(orgId)
+	return mockReturn[[]*models.Report](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateReport(orgId int64, command *models.CreateOrUpdateReportConfig) (int64, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[int64](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateReport(orgId int64, id int64, command *models.CreateOrUpdateReportConfig) error {
+	args := m.Called(orgId, id, command)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteReport(orgId int64, id int64) error {
+	args := m.Called(orgId, id)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetRoles(orgId int64) ([]*models.RoleDTO, error) {
+	args := m.Called(orgId)
+	return mockReturn[[]*models.RoleDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetRole(orgId int64, uid string) (*models.RoleDTO, error) {
+	args := m.Called(orgId, uid)
+	return mockReturn[*models.RoleDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateRole(orgId int64, form *models.CreateRoleForm) (*models.RoleDTO, error) {
+	args := m.Called(orgId, form)
+	return mockReturn[*models.RoleDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateRole(orgId int64, uid string, command *models.UpdateRoleCommand) error {
+	args := m.Called(orgId, uid, command)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteRole(orgId int64, uid string, global bool) error {
+	args := m.Called(orgId, uid, global)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetRoleAssignments(orgId int64, roleUid string) (*models.RoleAssignmentsDTO, error) {
+	args := m.Called(orgId, roleUid)
+	return mockReturn[*models.RoleAssignmentsDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) SetRoleAssignments(orgId int64, roleUid string, command *models.SetRoleAssignmentsCommand) error {
+	args := m.Called(orgId, roleUid, command)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetSSOSettings(provider string) (*models.GetProviderSettingsOKBody, error) {
+	args := m.Called(provider)
+	return mockReturn[*models.GetProviderSettingsOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) UpdateSSOSettings(provider string, settings map[string]interface{}) error {
+	args := m.Called(provider, settings)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) DeleteSSOSettings(provider string) error {
+	args := m.Called(provider)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) CreateSnapshot(orgId int64, command *models.CreateDashboardSnapshotCommand) (*models.CreateDashboardSnapshotOKBody, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[*models.CreateDashboardSnapshotOKBody](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) GetSnapshotByKey(orgId int64, key string) (*models.DashboardFullWithMeta, error) {
+	args := m.Called(orgId, key)
+	return mockReturn[*models.DashboardFullWithMeta](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteSnapshot(orgId int64, deleteKey string) error {
+	args := m.Called(orgId, deleteKey)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetAPIKeys(orgId int64) ([]*models.APIKeyDTO, error) {
+	args := m.Called(orgId)
+	return mockReturn[[]*models.APIKeyDTO](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) CreateAPIKey(orgId int64, command *models.AddAPIKeyCommand) (*models.NewAPIKeyResult, error) {
+	args := m.Called(orgId, command)
+	return mockReturn[*models.NewAPIKeyResult](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) DeleteAPIKey(orgId int64, id int64) error {
+	args := m.Called(orgId, id)
+	return args.Error(0)
+}
+
+func (m *MockGrafanaAPI) GetServerInfo() (*ServerInfo, error) {
+	args := m.Called()
+	return mockReturn[*ServerInfo](args, 0), args.Error(1)
+}
+
+func (m *MockGrafanaAPI) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	saltLength = 16
+
+	errHashPassword = "cannot hash password"
+)
+
+// HashPassword returns the salt and the HMAC-SHA256 of the password keyed with the salt, separated by a colon. A new
+// random salt is generated if none is supplied. The salt keeps identical passwords from having identical hashes.
+func HashPassword(password string, salt []byte) (string, error) {
+	if salt == nil {
+		salt = make([]byte, saltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", errors.Wrap(err, errHashPassword)
+		}
+	}
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(password))
+	return hex.EncodeToString(salt) + ":" + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// PasswordMatches returns true if the password hashes to the supplied hash created by HashPassword.
+func PasswordMatches(password string, hash *string) bool {
+	if hash == nil {
+		return false
+	}
+	salt, _, found := strings.Cut(*hash, ":")
+	if !found {
+		return false
+	}
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil {
+		return false
+	}
+	expected, err := HashPassword(password, saltBytes)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(expected), []byte(*hash))
+}
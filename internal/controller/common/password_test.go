@@ -0,0 +1,20 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordHashIsSalted(t *testing.T) {
+	first, err := HashPassword("secret", nil)
+	assert.Nil(t, err)
+	second, err := HashPassword("secret", nil)
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, first, second, "expected different hashes for different salts")
+	assert.True(t, PasswordMatches("secret", &first))
+	assert.True(t, PasswordMatches("secret", &second))
+	assert.False(t, PasswordMatches("other", &first))
+	assert.False(t, PasswordMatches("secret", nil))
+}
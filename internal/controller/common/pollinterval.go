@@ -0,0 +1,38 @@
+package common
+
+import (
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+)
+
+// AnnotationKeyPollInterval overrides the poll interval of a single managed resource with a duration like "10m".
+const AnnotationKeyPollInterval = "grafana.crossplane.io/poll-interval"
+
+// ReasonInvalidPollInterval is the reason of the events recorded if the poll interval annotation is no valid duration.
+const ReasonInvalidPollInterval event.Reason = "InvalidPollInterval"
+
+const errInvalidPollInterval = "ignoring annotation %s, %q is no positive duration"
+
+// PollIntervalHook returns a PollIntervalHook that polls managed resources annotated with AnnotationKeyPollInterval at
+// their own interval instead of the one of the controller. Invalid intervals are ignored, and a warning event is
+// recorded instead.
+func PollIntervalHook(recorder event.Recorder) managed.PollIntervalHook {
+	return func(mg resource.Managed, pollInterval time.Duration) time.Duration {
+		value, ok := mg.GetAnnotations()[AnnotationKeyPollInterval]
+		if !ok {
+			return pollInterval
+		}
+		interval, err := time.ParseDuration(value)
+		if err != nil || interval <= 0 {
+			if recorder != nil {
+				recorder.Event(mg, event.Warning(ReasonInvalidPollInterval, errors.Errorf(errInvalidPollInterval, AnnotationKeyPollInterval, value)))
+			}
+			return pollInterval
+		}
+		return interval
+	}
+}
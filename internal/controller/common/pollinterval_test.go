@@ -0,0 +1,56 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+)
+
+func Test_PollIntervalHook(t *testing.T) {
+	cases := map[string]struct {
+		annotation *string
+		want       time.Duration
+		events     []event.Reason
+	}{
+		"NotAnnotated": {
+			want: time.Minute,
+		},
+		"Annotated": {
+			annotation: strRef("10m"),
+			want:       10 * time.Minute,
+		},
+		"Invalid": {
+			annotation: strRef("ten minutes"),
+			want:       time.Minute,
+			events:     []event.Reason{ReasonInvalidPollInterval},
+		},
+		"NotPositive": {
+			annotation: strRef("0s"),
+			want:       time.Minute,
+			events:     []event.Reason{ReasonInvalidPollInterval},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Dashboard{}
+			if tc.annotation != nil {
+				meta.AddAnnotations(cr, map[string]string{AnnotationKeyPollInterval: *tc.annotation})
+			}
+			recorder := &eventRecorder{}
+
+			assert.Equal(t, tc.want, PollIntervalHook(recorder)(cr, time.Minute))
+			var events []event.Reason
+			for _, e := range recorder.events {
+				assert.Equal(t, event.TypeWarning, e.Type)
+				events = append(events, e.Reason)
+			}
+			assert.Equal(t, tc.events, events)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReasonRateLimited is the reason of the events recorded if Grafana rate limits a managed resource.
+const ReasonRateLimited event.Reason = "RateLimited"
+
+// DefaultRateLimitRequeue is how long a rate limited managed resource waits before it is reconciled again, if Grafana
+// didn't ask for a delay.
+const DefaultRateLimitRequeue = 10 * time.Second
+
+// RateLimitRequeues requeues the managed resources of a controller that were rate limited by Grafana after the delay
+// Grafana asked for. Its external clients record the delay, and its reconciler requeues the resource after it once the
+// reconcile returned. It is safe for concurrent use.
+type RateLimitRequeues struct {
+	// requeues holds the delays by the name of the resource, only while it is reconciled
+	requeues sync.Map
+}
+
+// NewRateLimitRequeues returns the RateLimitRequeues of a controller. Each controller needs its own, as resources of
+// different kinds may have the same name.
+func NewRateLimitRequeues() *RateLimitRequeues {
+	return &RateLimitRequeues{}
+}
+
+// WithEvents returns an ExternalConnecter whose external clients record a warning event with the delay asked for by
+// Grafana, if an operation fails with a RateLimitError. The error is still returned, so the managed reconciler reports
+// a ReconcileError and doesn't act on an observation it didn't get. The resource is then requeued by Reconciler.
+func (r *RateLimitRequeues) WithEvents(recorder event.Recorder, c managed.ExternalConnecter) managed.ExternalConnecter {
+	return &rateLimitEventConnecter{ExternalConnecter: c, recorder: recorder, requeues: r}
+}
+
+// Reconciler returns a reconciler that requeues a managed resource rate limited during the reconcile after the delay
+// Grafana asked for, or DefaultRateLimitRequeue, instead of backing off. The delay is forgotten once the reconcile
+// returned, so it is never used by a later one.
+func (r *RateLimitRequeues) Reconciler(rec reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		result, err := rec.Reconcile(ctx, req)
+		requeue, ok := r.requeues.LoadAndDelete(req.NamespacedName)
+		if !ok || err != nil {
+			return result, err
+		}
+		return reconcile.Result{RequeueAfter: requeue.(time.Duration)}, nil
+	})
+}
+
+// record records an event and the delay to requeue mg after, if err is a RateLimitError.
+func (r *RateLimitRequeues) record(recorder event.Recorder, mg resource.Managed, err error) {
+	rateLimitErr, ok := AsRateLimitError(err)
+	if !ok {
+		return
+	}
+	if recorder != nil {
+		recorder.Event(mg, event.Warning(ReasonRateLimited, rateLimitErr))
+	}
+	requeue := rateLimitErr.RetryAfter
+	if requeue <= 0 {
+		requeue = DefaultRateLimitRequeue
+	}
+	r.requeues.Store(types.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetName()}, requeue)
+}
+
+type rateLimitEventConnecter struct {
+	managed.ExternalConnecter
+	recorder event.Recorder
+	requeues *RateLimitRequeues
+}
+
+func (c *rateLimitEventConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.ExternalConnecter.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &rateLimitEventClient{ExternalClient: ec, recorder: c.recorder, requeues: c.requeues}, nil
+}
+
+type rateLimitEventClient struct {
+	managed.ExternalClient
+	recorder event.Recorder
+	requeues *RateLimitRequeues
+}
+
+func (c *rateLimitEventClient) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := c.ExternalClient.Observe(ctx, mg)
+	c.requeues.record(c.recorder, mg, err)
+	return o, err
+}
+
+func (c *rateLimitEventClient) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, err := c.ExternalClient.Create(ctx, mg)
+	c.requeues.record(c.recorder, mg, err)
+	return cr, err
+}
+
+func (c *rateLimitEventClient) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := c.ExternalClient.Update(ctx, mg)
+	c.requeues.record(c.recorder, mg, err)
+	return u, err
+}
+
+func (c *rateLimitEventClient) Delete(ctx context.Context, mg resource.Managed) error {
+	err := c.ExternalClient.Delete(ctx, mg)
+	c.requeues.record(c.recorder, mg, err)
+	return err
+}
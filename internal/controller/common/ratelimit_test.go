@@ -0,0 +1,116 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+)
+
+// eventRecorder records the events of all objects.
+type eventRecorder struct {
+	events []event.Event
+}
+
+func (r *eventRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *eventRecorder) WithAnnotations(...string) event.Recorder {
+	return r
+}
+
+func Test_RateLimitRequeues(t *testing.T) {
+	errRateLimited := errors.Wrap(&RateLimitError{RetryAfter: 3 * time.Second}, "cannot get Folder")
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		err        error
+		wantEvents []event.Event
+		want       reconcile.Result
+	}{
+		"RateLimited": {
+			err:        errRateLimited,
+			wantEvents: []event.Event{event.Warning(ReasonRateLimited, &RateLimitError{RetryAfter: 3 * time.Second})},
+			want:       reconcile.Result{RequeueAfter: 3 * time.Second},
+		},
+		"RateLimitedWithoutRetryAfter": {
+			err:        &RateLimitError{},
+			wantEvents: []event.Event{event.Warning(ReasonRateLimited, &RateLimitError{})},
+			want:       reconcile.Result{RequeueAfter: DefaultRateLimitRequeue},
+		},
+		"OtherError": {
+			err:  errBoom,
+			want: reconcile.Result{Requeue: true},
+		},
+		"Success": {
+			want: reconcile.Result{Requeue: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &eventRecorder{}
+			rateLimits := NewRateLimitRequeues()
+			c := rateLimits.WithEvents(recorder, managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+				return &managed.ExternalClientFns{
+					ObserveFn: func(context.Context, resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, tc.err
+					},
+				}, nil
+			}))
+
+			folder := &v1alpha1.Folder{}
+			folder.SetName("folder")
+			observe := reconcile.Func(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				ec, err := c.Connect(ctx, folder)
+				assert.Nil(t, err)
+				o, err := ec.Observe(ctx, folder)
+				assert.Equal(t, tc.err, err, "the error should be returned")
+				assert.Equal(t, managed.ExternalObservation{}, o, "the observation should not be made up")
+				// like the managed reconciler after it reported the error
+				return reconcile.Result{Requeue: true}, nil
+			})
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "folder"}}
+			got, err := rateLimits.Reconciler(observe).Reconcile(context.Background(), req)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, got, "a rate limited resource should be requeued after the delay")
+			assert.Equal(t, tc.wantEvents, recorder.events)
+
+			succeed := reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			})
+			got, _ = rateLimits.Reconciler(succeed).Reconcile(context.Background(), req)
+			assert.Equal(t, reconcile.Result{RequeueAfter: time.Minute}, got, "the delay should only be used by the reconcile that was rate limited")
+		})
+	}
+}
+
+func Test_RateLimitRequeuesDelete(t *testing.T) {
+	rateLimits := NewRateLimitRequeues()
+	c := rateLimits.WithEvents(nil, managed.ExternalConnectorFn(func(context.Context, resource.Managed) (managed.ExternalClient, error) {
+		return &managed.ExternalClientFns{
+			DeleteFn: func(context.Context, resource.Managed) error {
+				return &RateLimitError{}
+			},
+		}, nil
+	}))
+
+	folder := &v1alpha1.Folder{}
+	folder.SetName("deleted")
+	ec, err := c.Connect(context.Background(), folder)
+	assert.Nil(t, err)
+	_, ok := AsRateLimitError(ec.Delete(context.Background(), folder))
+	assert.True(t, ok, "a RateLimitError should fail the deletion")
+}
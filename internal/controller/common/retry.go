@@ -0,0 +1,133 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the number of attempts per request unless the ProviderConfig configures otherwise.
+	DefaultRetryMaxAttempts = 5
+	// DefaultRetryBaseDelay is the delay before the first retry unless the ProviderConfig configures otherwise.
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	// DefaultRetryMaxDelay caps the delay between two attempts unless the ProviderConfig configures otherwise.
+	DefaultRetryMaxDelay = 10 * time.Second
+)
+
+// A RetryTransport retries requests that Grafana rejects with 429 Too Many Requests. If the attempts are used up, a
+// RateLimitError is returned instead of the response. Other responses, including all other errors, are returned right
+// away, so requests that can't succeed still fail fast.
+type RetryTransport struct {
+	Transport   http.RoundTripper
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// NewRetryTransport returns a RetryTransport around the default transport, configured by the policy of a
+// ProviderConfig. Unset fields of the policy take their defaults.
+func NewRetryTransport(policy *apisv1beta1.RetryPolicy) *RetryTransport {
+	t := &RetryTransport{
+		Transport:   http.DefaultTransport,
+		MaxAttempts: DefaultRetryMaxAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+	}
+	if policy == nil {
+		return t
+	}
+	if policy.MaxAttempts != nil {
+		t.MaxAttempts = *policy.MaxAttempts
+	}
+	if policy.BaseDelay != nil {
+		t.BaseDelay = policy.BaseDelay.Duration
+	}
+	if policy.MaxDelay != nil {
+		t.MaxDelay = policy.MaxDelay.Duration
+	}
+	return t
+}
+
+// RoundTrip sends the request until it is not rejected with 429 or the attempts are used up. The body of the request
+// is buffered, so it can be sent again.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+	}
+
+	for attempt := 1; ; attempt++ {
+		if req.Body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := t.Transport.RoundTrip(req)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests {
+			return resp, err
+		}
+		if attempt >= t.MaxAttempts {
+			retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"))
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+			return nil, &RateLimitError{RetryAfter: retryAfter}
+		}
+
+		delay := t.delay(attempt, resp.Header.Get("Retry-After"))
+		// the response is discarded, so the connection can be reused for the retry
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay returns how long to wait before the next attempt. Grafana's Retry-After is honored, otherwise the base delay
+// is doubled for every attempt and jittered, so clients that were rejected together don't retry together. Both are
+// capped at the max delay.
+func (t *RetryTransport) delay(attempt int, retryAfter string) time.Duration {
+	if d, ok := parseRetryAfter(retryAfter); ok {
+		if d > t.MaxDelay {
+			return t.MaxDelay
+		}
+		return d
+	}
+	backoff := t.BaseDelay << (attempt - 1)
+	if backoff <= 0 || backoff > t.MaxDelay {
+		backoff = t.MaxDelay
+	}
+	// full jitter in [backoff/2, backoff)
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1)) //nolint:gosec // no need for a secure random number
+}
+
+// parseRetryAfter parses the Retry-After header, which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,148 @@
+package common
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+// statusServer responds with the given status codes in order, and with 200 once they are used up.
+func statusServer(bodies *[]string, codes ...int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		if len(*bodies) > len(codes) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if codes[len(*bodies)-1] == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(codes[len(*bodies)-1])
+	}))
+}
+
+func Test_RetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		codes    []int
+		attempts int
+		want     int
+	}{
+		"Success": {
+			attempts: 1,
+			want:     http.StatusOK,
+		},
+		"RetriedUntilSuccess": {
+			codes:    []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			attempts: 3,
+			want:     http.StatusOK,
+		},
+		"NotRetryable": {
+			codes:    []int{http.StatusNotFound},
+			attempts: 1,
+			want:     http.StatusNotFound,
+		},
+		"ServerErrorNotRetried": {
+			codes:    []int{http.StatusInternalServerError},
+			attempts: 1,
+			want:     http.StatusInternalServerError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var bodies []string
+			server := statusServer(&bodies, tc.codes...)
+			defer server.Close()
+
+			client := &http.Client{Transport: &RetryTransport{Transport: http.DefaultTransport, MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader(`{"title": "test"}`))
+			assert.Nil(t, err)
+			assert.Equal(t, tc.want, resp.StatusCode)
+			assert.Len(t, bodies, tc.attempts)
+			for _, body := range bodies {
+				assert.Equal(t, `{"title": "test"}`, body)
+			}
+		})
+	}
+}
+
+func Test_RetryTransportReturnsRateLimitError(t *testing.T) {
+	var bodies []string
+	server := statusServer(&bodies, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests)
+	defer server.Close()
+
+	client := &http.Client{Transport: &RetryTransport{Transport: http.DefaultTransport, MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}}
+	_, err := client.Get(server.URL)
+	assert.Len(t, bodies, 3)
+	rateLimitErr, ok := AsRateLimitError(err)
+	assert.True(t, ok, "the error should be a RateLimitError once the attempts are used up")
+	assert.Equal(t, &RateLimitError{}, rateLimitErr)
+	assert.True(t, IsCode(err, http.StatusTooManyRequests))
+}
+
+func Test_RateLimitErrorRetryAfter(t *testing.T) {
+	transport := &RetryTransport{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"120"}}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), MaxAttempts: 1}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://grafana/api/search", nil)
+	_, err := transport.RoundTrip(req)
+	assert.Equal(t, &RateLimitError{RetryAfter: 2 * time.Minute}, err)
+	assert.Equal(t, "rate limited by Grafana, retry after 2m0s", err.Error())
+}
+
+func Test_RetryTransportStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://grafana/api/search", nil)
+	// the wrapped transport ignores the context, so only the wait for the retry can be canceled
+	transport := &RetryTransport{Transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	_, err := transport.RoundTrip(req)
+	assert.Equal(t, context.Canceled, err)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func Test_RetryTransportDelay(t *testing.T) {
+	transport := &RetryTransport{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 10: 10 * time.Second} {
+		got := transport.delay(attempt, "")
+		assert.GreaterOrEqual(t, got, want/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, got, want, "attempt %d", attempt)
+	}
+
+	assert.Equal(t, 3*time.Second, transport.delay(1, "3"))
+	assert.Equal(t, 10*time.Second, transport.delay(1, "120"), "Retry-After should be capped at the max delay")
+	assert.Equal(t, time.Duration(0), transport.delay(1, time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)))
+}
+
+func Test_NewRetryTransport(t *testing.T) {
+	transport := NewRetryTransport(nil)
+	assert.Equal(t, DefaultRetryMaxAttempts, transport.MaxAttempts)
+	assert.Equal(t, DefaultRetryBaseDelay, transport.BaseDelay)
+	assert.Equal(t, DefaultRetryMaxDelay, transport.MaxDelay)
+
+	attempts := 1
+	transport = NewRetryTransport(&apisv1beta1.RetryPolicy{MaxAttempts: &attempts, MaxDelay: &metav1.Duration{Duration: time.Minute}})
+	assert.Equal(t, 1, transport.MaxAttempts)
+	assert.Equal(t, DefaultRetryBaseDelay, transport.BaseDelay)
+	assert.Equal(t, time.Minute, transport.MaxDelay)
+}
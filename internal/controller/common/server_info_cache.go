@@ -0,0 +1,84 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultServerInfoCacheTTL is how long the server info of a Grafana instance
+// is cached unless configured otherwise.
+const DefaultServerInfoCacheTTL = 10 * time.Minute
+
+// ServerInfos caches the server info of all Grafana instances the provider
+// talks to. It is shared by all reconcilers.
+var ServerInfos = NewServerInfoCache(DefaultServerInfoCacheTTL)
+
+// ServerInfoCache caches the ServerInfo per ProviderConfig, so that the
+// version and feature toggles of Grafana are not requested on every
+// reconcile. It is safe for concurrent use. A nil cache or a TTL <= 0 disables
+// caching.
+type ServerInfoCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*serverInfoCacheEntry
+	now     func() time.Time
+}
+
+type serverInfoCacheEntry struct {
+	mu      sync.Mutex
+	info    *ServerInfo
+	expires time.Time
+}
+
+// NewServerInfoCache returns a ServerInfoCache that keeps server info for the
+// supplied TTL.
+func NewServerInfoCache(ttl time.Duration) *ServerInfoCache {
+	return &ServerInfoCache{
+		ttl:     ttl,
+		entries: make(map[string]*serverInfoCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// SetTTL changes how long server info is cached. Already cached server info
+// keeps its expiry.
+func (c *ServerInfoCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the server info of the supplied ProviderConfig, calling load if
+// it is not cached or expired. Concurrent calls for the same ProviderConfig
+// wait for a single load. Errors are not cached.
+func (c *ServerInfoCache) Get(providerConfig string, load func() (*ServerInfo, error)) (*ServerInfo, error) {
+	if c == nil {
+		return load()
+	}
+
+	c.mu.Lock()
+	ttl := c.ttl
+	entry, ok := c.entries[providerConfig]
+	if !ok {
+		entry = &serverInfoCacheEntry{}
+		c.entries[providerConfig] = entry
+	}
+	c.mu.Unlock()
+
+	if ttl <= 0 {
+		return load()
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.info != nil && c.now().Before(entry.expires) {
+		return entry.info, nil
+	}
+	info, err := load()
+	if err != nil {
+		return nil, err
+	}
+	entry.info = info
+	entry.expires = c.now().Add(ttl)
+	return info, nil
+}
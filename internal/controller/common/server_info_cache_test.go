@@ -0,0 +1,64 @@
+package common
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func countingServerInfoLoader(calls *int32) func() (*ServerInfo, error) {
+	return func() (*ServerInfo, error) {
+		atomic.AddInt32(calls, 1)
+		return &ServerInfo{Version: "10.3.1"}, nil
+	}
+}
+
+func Test_ServerInfoCacheLoadsOncePerTTL(t *testing.T) {
+	var calls int32
+	now := time.Unix(0, 0)
+	cache := NewServerInfoCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	info, err := cache.Get("default", countingServerInfoLoader(&calls))
+	assert.Nil(t, err)
+	assert.Equal(t, "10.3.1", info.Version)
+
+	_, _ = cache.Get("default", countingServerInfoLoader(&calls))
+	assert.Equal(t, int32(1), calls, "server info should be served from the cache within the TTL")
+
+	_, _ = cache.Get("other", countingServerInfoLoader(&calls))
+	assert.Equal(t, int32(2), calls, "server info should be cached per ProviderConfig")
+
+	now = now.Add(time.Minute)
+	_, _ = cache.Get("default", countingServerInfoLoader(&calls))
+	assert.Equal(t, int32(3), calls, "server info should be reloaded after the TTL")
+}
+
+func Test_ServerInfoCacheDisabled(t *testing.T) {
+	var calls int32
+	var nilCache *ServerInfoCache
+	_, _ = nilCache.Get("default", countingServerInfoLoader(&calls))
+
+	cache := NewServerInfoCache(0)
+	_, _ = cache.Get("default", countingServerInfoLoader(&calls))
+	_, _ = cache.Get("default", countingServerInfoLoader(&calls))
+	assert.Equal(t, int32(3), calls)
+}
+
+func Test_ServerInfoCacheDoesNotCacheErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	var calls int32
+	cache := NewServerInfoCache(time.Minute)
+
+	_, err := cache.Get("default", func() (*ServerInfo, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, errBoom
+	})
+	assert.Equal(t, errBoom, err)
+
+	_, _ = cache.Get("default", countingServerInfoLoader(&calls))
+	assert.Equal(t, int32(2), calls, "a failed load should be retried")
+}
@@ -0,0 +1,183 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+)
+
+// DefaultShutdownGracePeriod is how long the requests in flight may take to finish when the provider stops, unless
+// configured otherwise. It is shorter than the 30s the controller manager waits for running reconciles, so that they
+// can still return.
+const DefaultShutdownGracePeriod = 20 * time.Second
+
+// Clients tracks the clients created by NewGrafanaAPI while they have requests in flight, so that they can be shut
+// down when the provider stops.
+var Clients = NewClientRegistry()
+
+// A ClientRegistry tracks clients while they have requests in flight. Idle clients are not referenced, as every
+// Connect creates a new client that is dropped after the reconcile. It is safe for concurrent use.
+type ClientRegistry struct {
+	mu       sync.Mutex
+	busy     map[*inFlightRequests]struct{}
+	shutDown bool
+}
+
+// NewClientRegistry returns an empty ClientRegistry.
+func NewClientRegistry() *ClientRegistry {
+	return &ClientRegistry{busy: make(map[*inFlightRequests]struct{})}
+}
+
+// Shutdown shuts down the clients with requests in flight like GrafanaAPI.Shutdown, and makes the requests of all
+// other clients fail with context.Canceled from now on. It returns the error of ctx if requests were cancelled.
+func (r *ClientRegistry) Shutdown(ctx context.Context) error {
+	r.mu.Lock()
+	r.shutDown = true
+	busy := make([]*inFlightRequests, 0, len(r.busy))
+	for requests := range r.busy {
+		busy = append(busy, requests)
+	}
+	r.mu.Unlock()
+
+	var err error
+	for _, requests := range busy {
+		if shutdownErr := requests.shutdown(ctx); shutdownErr != nil {
+			err = shutdownErr
+		}
+	}
+	return err
+}
+
+// add tracks the requests of a client. It returns false without tracking them if the registry was shut down.
+func (r *ClientRegistry) add(requests *inFlightRequests) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shutDown {
+		return false
+	}
+	r.busy[requests] = struct{}{}
+	return true
+}
+
+func (r *ClientRegistry) remove(requests *inFlightRequests) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.busy, requests)
+}
+
+// Shutdown waits until no request of the client is in flight, but at most until ctx is done, and then cancels the
+// requests still in flight. Requests with a context of their own, like the paginated ones, are left to their context,
+// but their remaining pages are not requested.
+func (g *grafanaAPIClient) Shutdown(ctx context.Context) error {
+	return g.requests.shutdown(ctx)
+}
+
+// inFlightRequests counts the requests of a client in flight, so that a shutdown can wait for them instead of
+// interrupting a change halfway. It is safe for concurrent use.
+type inFlightRequests struct {
+	// ctx is passed to the requests without a context of their own and cancelled by shutdown
+	ctx    context.Context
+	cancel context.CancelFunc
+	// registry tracks the client while requests are in flight
+	registry *ClientRegistry
+
+	mu       sync.Mutex
+	inFlight int
+	// idle is closed whenever no request is in flight
+	idle chan struct{}
+}
+
+func newInFlightRequests(registry *ClientRegistry) *inFlightRequests {
+	ctx, cancel := context.WithCancel(context.Background())
+	idle := make(chan struct{})
+	close(idle)
+	return &inFlightRequests{ctx: ctx, cancel: cancel, registry: registry, idle: idle}
+}
+
+// start counts a request as in flight. It returns false without counting it if the client or the registry has been
+// shut down.
+func (r *inFlightRequests) start() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.ctx.Err() != nil {
+		return false
+	}
+	if r.inFlight == 0 {
+		if !r.registry.add(r) {
+			r.cancel()
+			return false
+		}
+		r.idle = make(chan struct{})
+	}
+	r.inFlight++
+	return true
+}
+
+// done counts a request that was started as finished.
+func (r *inFlightRequests) done() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inFlight--
+	if r.inFlight == 0 {
+		close(r.idle)
+		r.registry.remove(r)
+	}
+}
+
+// shutdown cancels ctx once no request is in flight, or once the ctx passed is done at the latest. It returns the
+// error of the ctx passed if requests were cancelled.
+func (r *inFlightRequests) shutdown(ctx context.Context) error {
+	for {
+		r.mu.Lock()
+		if r.inFlight == 0 {
+			// cancelled while holding the lock, so no request can start in between
+			r.cancel()
+			r.mu.Unlock()
+			return nil
+		}
+		idle := r.idle
+		r.mu.Unlock()
+
+		select {
+		case <-idle:
+			// another request may have started meanwhile, which is checked again
+		case <-ctx.Done():
+			r.cancel()
+			return ctx.Err()
+		}
+	}
+}
+
+// shutdownTransport submits the operations without a context of their own with the context of requests, and counts
+// them as in flight until they returned.
+type shutdownTransport struct {
+	runtime.ClientTransport
+	requests *inFlightRequests
+}
+
+func (t *shutdownTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	if !t.requests.start() {
+		return nil, t.requests.ctx.Err()
+	}
+	defer t.requests.done()
+
+	if op.Context == nil {
+		op.Context = t.requests.ctx
+	}
+	return t.ClientTransport.Submit(op)
+}
+
+// withShutdown makes the requests of the client wait for and be cancelled by Shutdown. It must be called after
+// WithOrgID, as that replaces the transport of the client.
+func (g *grafanaAPIClient) withShutdown(client *grafana.GrafanaHTTPAPI) *grafana.GrafanaHTTPAPI {
+	client.SetTransport(&shutdownTransport{ClientTransport: client.Transport, requests: g.requests})
+	return client
+}
+
+// withOrgID returns a copy of the client that sends its requests to the organization.
+func (g *grafanaAPIClient) withOrgID(orgId int64) *grafana.GrafanaHTTPAPI {
+	return g.withShutdown(g.service.Clone().WithOrgID(orgId))
+}
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// newShutdownTestAPI returns a client for the server that is tracked by the registry.
+func newShutdownTestAPI(server *httptest.Server, registry *ClientRegistry) GrafanaAPI {
+	u, _ := url.Parse(server.URL)
+	return newGrafanaAPIClient(*grafana.NewHTTPClientWithConfig(nil, &grafana.TransportConfig{
+		Host:     u.Host,
+		BasePath: "/api",
+		Schemes:  []string{"http"},
+	}), DefaultPerPage, DefaultMaxPages, registry)
+}
+
+// hangingServer returns a server that signals each request on started and hangs until the client gives up.
+func hangingServer(started chan<- struct{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-r.Context().Done()
+	}))
+}
+
+func Test_ShutdownCancelsRequestsInFlight(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := hangingServer(started)
+	defer server.Close()
+	api := newShutdownTestAPI(server, NewClientRegistry())
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := api.GetDataSourceById(1, "1")
+		result <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, api.Shutdown(ctx), "Shutdown should report that it cancelled requests")
+	select {
+	case err := <-result:
+		assert.True(t, errors.Is(err, context.Canceled), "want context.Canceled, got %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetDataSourceById was not cancelled by Shutdown")
+	}
+}
+
+func Test_ShutdownWaitsForRequestsInFlight(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": 1, "uid": "abc"}`))
+	}))
+	defer server.Close()
+	api := newShutdownTestAPI(server, NewClientRegistry())
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := api.GetDataSourceById(1, "1")
+		result <- err
+	}()
+	<-started
+
+	shutdown := make(chan error, 1)
+	go func() {
+		shutdown <- api.Shutdown(context.Background())
+	}()
+	select {
+	case <-shutdown:
+		t.Fatal("Shutdown returned while a request was in flight")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	assert.Nil(t, <-result, "the request in flight should finish")
+	select {
+	case err := <-shutdown:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown did not return once no request was in flight")
+	}
+
+	// requests after the shutdown fail without reaching Grafana
+	_, err := api.GetDataSourceById(1, "1")
+	assert.True(t, errors.Is(err, context.Canceled), "want context.Canceled, got %v", err)
+}
+
+func Test_ClientRegistryShutdown(t *testing.T) {
+	started := make(chan struct{}, 1)
+	server := hangingServer(started)
+	defer server.Close()
+	registry := NewClientRegistry()
+	busy := newShutdownTestAPI(server, registry)
+	idle := newShutdownTestAPI(server, registry)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := busy.GetDataSourceById(1, "1")
+		result <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, registry.Shutdown(ctx), "Shutdown should report that it cancelled requests")
+	select {
+	case err := <-result:
+		assert.True(t, errors.Is(err, context.Canceled), "want context.Canceled, got %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetDataSourceById was not cancelled after the grace period")
+	}
+	assert.Empty(t, registry.busy, "clients without requests in flight should not be tracked")
+
+	// clients that were idle during the shutdown fail without reaching Grafana as well
+	_, err := idle.GetDataSourceById(1, "1")
+	assert.True(t, errors.Is(err, context.Canceled), "want context.Canceled, got %v", err)
+}
+
+func Test_SearchDashboardsHonorsContext(t *testing.T) {
+	var pages []string
+	server := pagedServer("/api/search", 10, func(i int) string { return `{"uid": "abc"}` }, &pages)
+	defer server.Close()
+	api := newShutdownTestAPI(server, NewClientRegistry())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := api.SearchDashboards(ctx, 1)
+	assert.True(t, errors.Is(err, context.Canceled), "want context.Canceled, got %v", err)
+	assert.Empty(t, pages, "no page should be requested once the context is done")
+}
@@ -0,0 +1,260 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+const (
+	errTokenMissing  = "token is required if the source of the credentials is Token"
+	errReadToken     = "cannot read token file"
+	errTokenEmpty    = "token file %q is empty"
+	errExchangeToken = "cannot exchange token"
+	errTokenStatus   = "token endpoint responded with %d: %s"
+	errNoAccessToken = "token endpoint responded without access_token"
+
+	// defaultTokenLifetime is assumed for tokens that don't tell when they expire, so that they are still read again
+	// regularly.
+	defaultTokenLifetime = 5 * time.Minute
+	// tokenRefreshRatio is the share of the lifetime of a token after which it is refreshed, like the kubelet does for
+	// projected service account tokens.
+	tokenRefreshRatio = 0.8
+
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	tokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+	tokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// A TokenProvider obtains a bearer token and tells when it expires.
+type TokenProvider interface {
+	Token(ctx context.Context) (token string, expiry time.Time, err error)
+}
+
+// NewTokenProvider returns the TokenProvider of the token credentials. Tokens are exchanged via client.
+func NewTokenProvider(cfg apisv1beta1.TokenCredentials, client *http.Client) TokenProvider {
+	var provider TokenProvider = &fileTokenProvider{path: cfg.Path, now: time.Now}
+	if cfg.Exchange != nil {
+		provider = &exchangeTokenProvider{subject: provider, exchange: *cfg.Exchange, client: client, now: time.Now}
+	}
+	return provider
+}
+
+// fileTokenProvider reads the token from a file. JWTs expire at their exp claim, other tokens after
+// defaultTokenLifetime.
+type fileTokenProvider struct {
+	path string
+	now  func() time.Time
+}
+
+func (p *fileTokenProvider) Token(_ context.Context) (string, time.Time, error) {
+	raw, err := os.ReadFile(p.path)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errReadToken)
+	}
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return "", time.Time{}, errors.Errorf(errTokenEmpty, p.path)
+	}
+	if expiry, ok := jwtExpiry(token); ok {
+		return token, expiry, nil
+	}
+	return token, p.now().Add(defaultTokenLifetime), nil
+}
+
+// jwtExpiry returns the exp claim of a JWT. The signature is not verified, the token is only passed on.
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
+
+// exchangeTokenProvider exchanges the token of its subject for another one via OAuth 2.0 token exchange (RFC 8693).
+type exchangeTokenProvider struct {
+	subject  TokenProvider
+	exchange apisv1beta1.TokenExchange
+	client   *http.Client
+	now      func() time.Time
+}
+
+type tokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func (p *exchangeTokenProvider) Token(ctx context.Context) (string, time.Time, error) {
+	subject, _, err := p.subject.Token(ctx)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	form := url.Values{
+		"grant_type":           {grantTypeTokenExchange},
+		"subject_token":        {subject},
+		"subject_token_type":   {tokenTypeJWT},
+		"requested_token_type": {tokenTypeAccessToken},
+	}
+	if p.exchange.ClientID != "" {
+		form.Set("client_id", p.exchange.ClientID)
+	}
+	if p.exchange.Audience != "" {
+		form.Set("audience", p.exchange.Audience)
+	}
+	if len(p.exchange.Scopes) > 0 {
+		form.Set("scope", strings.Join(p.exchange.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.exchange.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errExchangeToken)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errExchangeToken)
+	}
+	defer resp.Body.Close() //nolint:errcheck // nothing left to read
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errExchangeToken)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, errors.Errorf(errTokenStatus, resp.StatusCode, truncate(string(body), maxDiffMessageLength))
+	}
+
+	var result tokenExchangeResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", time.Time{}, errors.Wrap(err, errExchangeToken)
+	}
+	if result.AccessToken == "" {
+		return "", time.Time{}, errors.New(errNoAccessToken)
+	}
+	lifetime := defaultTokenLifetime
+	if result.ExpiresIn > 0 {
+		lifetime = time.Duration(result.ExpiresIn) * time.Second
+	}
+	return result.AccessToken, p.now().Add(lifetime), nil
+}
+
+// A TokenSource caches the token of a TokenProvider and refreshes it once most of its lifetime has passed. If the
+// refresh fails, the cached token is used until it expires. It is safe for concurrent use.
+type TokenSource struct {
+	provider TokenProvider
+	now      func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	expiry    time.Time
+	refreshAt time.Time
+}
+
+// NewTokenSource returns a TokenSource for the supplied provider.
+func NewTokenSource(provider TokenProvider) *TokenSource {
+	return &TokenSource{provider: provider, now: time.Now}
+}
+
+// Token returns the cached token, refreshing it if it is near expiry.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Before(s.refreshAt) {
+		return s.token, nil
+	}
+	token, expiry, err := s.provider.Token(ctx)
+	if err != nil {
+		if s.token != "" && now.Before(s.expiry) {
+			return s.token, nil
+		}
+		return "", err
+	}
+	s.token = token
+	s.expiry = expiry
+	s.refreshAt = now.Add(time.Duration(float64(expiry.Sub(now)) * tokenRefreshRatio))
+	return token, nil
+}
+
+// Tokens holds the TokenSources of all ProviderConfigs with the Token source. It is shared by all reconcilers, so that
+// tokens outlive the clients of a single reconcile.
+var Tokens = NewTokenSources()
+
+// TokenSources keeps a TokenSource per ProviderConfig. The source is replaced if the token credentials of the
+// ProviderConfig change. It is safe for concurrent use.
+type TokenSources struct {
+	mu      sync.Mutex
+	entries map[string]*tokenSourcesEntry
+	client  *http.Client
+}
+
+type tokenSourcesEntry struct {
+	credentials apisv1beta1.TokenCredentials
+	source      *TokenSource
+}
+
+// NewTokenSources returns an empty TokenSources.
+func NewTokenSources() *TokenSources {
+	return &TokenSources{
+		entries: make(map[string]*tokenSourcesEntry),
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// For returns the TokenSource of the ProviderConfig.
+func (t *TokenSources) For(providerConfig string, credentials apisv1beta1.TokenCredentials) *TokenSource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.entries[providerConfig]
+	if !ok || !reflect.DeepEqual(entry.credentials, credentials) {
+		entry = &tokenSourcesEntry{
+			credentials: *credentials.DeepCopy(),
+			source:      NewTokenSource(NewTokenProvider(credentials, t.client)),
+		}
+		t.entries[providerConfig] = entry
+	}
+	return entry.source
+}
+
+// TokenTransport sends the token of its source as bearer token with every request, so that long-running clients pick
+// up refreshed tokens.
+type TokenTransport struct {
+	Transport http.RoundTripper
+	Source    *TokenSource
+}
+
+func (t *TokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.Transport.RoundTrip(req)
+}
@@ -0,0 +1,209 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+// jwt returns an unsigned JWT that expires at exp.
+func jwt(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"provider","exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJub25lIn0." + payload + ".c2ln"
+}
+
+// writeToken writes the token to a file in a temporary directory and returns its path.
+func writeToken(t *testing.T, path string, token string) string {
+	if path == "" {
+		path = filepath.Join(t.TempDir(), "token")
+	}
+	assert.Nil(t, os.WriteFile(path, []byte(token+"\n"), 0o600))
+	return path
+}
+
+// fakeTokenProvider returns the next of its tokens on every call.
+type fakeTokenProvider struct {
+	tokens []string
+	expiry time.Time
+	err    error
+	calls  int
+}
+
+func (p *fakeTokenProvider) Token(_ context.Context) (string, time.Time, error) {
+	p.calls++
+	if p.err != nil {
+		return "", time.Time{}, p.err
+	}
+	return p.tokens[p.calls-1], p.expiry, nil
+}
+
+func Test_FileTokenProvider(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	exp := now.Add(time.Hour)
+
+	cases := map[string]struct {
+		token      string
+		wantExpiry time.Time
+	}{
+		"JWT": {
+			token:      jwt(exp),
+			wantExpiry: exp,
+		},
+		"Opaque": {
+			token:      "glsa_opaque",
+			wantExpiry: now.Add(defaultTokenLifetime),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &fileTokenProvider{path: writeToken(t, "", tc.token), now: func() time.Time { return now }}
+			token, expiry, err := p.Token(context.Background())
+			assert.Nil(t, err)
+			assert.Equal(t, tc.token, token)
+			assert.Equal(t, tc.wantExpiry, expiry)
+		})
+	}
+}
+
+func Test_FileTokenProviderFails(t *testing.T) {
+	p := &fileTokenProvider{path: filepath.Join(t.TempDir(), "missing"), now: time.Now}
+	_, _, err := p.Token(context.Background())
+	assert.ErrorContains(t, err, errReadToken)
+
+	p.path = writeToken(t, "", "  ")
+	_, _, err = p.Token(context.Background())
+	assert.EqualError(t, err, fmt.Sprintf(errTokenEmpty, p.path))
+}
+
+func Test_ExchangeTokenProvider(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	subject := jwt(now.Add(time.Hour))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, grantTypeTokenExchange, r.PostForm.Get("grant_type"))
+		assert.Equal(t, subject, r.PostForm.Get("subject_token"))
+		assert.Equal(t, tokenTypeJWT, r.PostForm.Get("subject_token_type"))
+		assert.Equal(t, "provider-grafana", r.PostForm.Get("client_id"))
+		assert.Equal(t, "grafana", r.PostForm.Get("audience"))
+		assert.Equal(t, "openid grafana", r.PostForm.Get("scope"))
+		_, _ = w.Write([]byte(`{"access_token":"exchanged","token_type":"Bearer","expires_in":600}`))
+	}))
+	defer server.Close()
+
+	p := NewTokenProvider(apisv1beta1.TokenCredentials{
+		Path: writeToken(t, "", subject),
+		Exchange: &apisv1beta1.TokenExchange{
+			TokenURL: server.URL,
+			ClientID: "provider-grafana",
+			Audience: "grafana",
+			Scopes:   []string{"openid", "grafana"},
+		},
+	}, server.Client())
+	p.(*exchangeTokenProvider).now = func() time.Time { return now }
+
+	token, expiry, err := p.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "exchanged", token)
+	assert.Equal(t, now.Add(10*time.Minute), expiry)
+}
+
+func Test_ExchangeTokenProviderRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer server.Close()
+
+	p := NewTokenProvider(apisv1beta1.TokenCredentials{
+		Path:     writeToken(t, "", "subject"),
+		Exchange: &apisv1beta1.TokenExchange{TokenURL: server.URL},
+	}, server.Client())
+
+	_, _, err := p.Token(context.Background())
+	assert.EqualError(t, err, fmt.Sprintf(errTokenStatus, http.StatusBadRequest, `{"error":"invalid_grant"}`))
+}
+
+func Test_TokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	provider := &fakeTokenProvider{tokens: []string{"first", "second"}, expiry: now.Add(10 * time.Minute)}
+	source := NewTokenSource(provider)
+	source.now = func() time.Time { return now }
+
+	token, err := source.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "first", token)
+
+	// the token is cached for most of its lifetime
+	now = now.Add(7 * time.Minute)
+	token, err = source.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "first", token)
+	assert.Equal(t, 1, provider.calls)
+
+	// and refreshed before it expires
+	now = now.Add(time.Minute)
+	token, err = source.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "second", token)
+	assert.Equal(t, 2, provider.calls)
+}
+
+func Test_TokenSourceKeepsTokenIfRefreshFails(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	provider := &fakeTokenProvider{tokens: []string{"first"}, expiry: now.Add(10 * time.Minute)}
+	source := NewTokenSource(provider)
+	source.now = func() time.Time { return now }
+
+	_, err := source.Token(context.Background())
+	assert.Nil(t, err)
+
+	provider.err = errors.New("boom")
+	now = now.Add(9 * time.Minute)
+	token, err := source.Token(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "first", token)
+
+	now = now.Add(time.Minute)
+	_, err = source.Token(context.Background())
+	assert.EqualError(t, err, "boom")
+}
+
+func Test_TokenSourcesReplaceChangedCredentials(t *testing.T) {
+	sources := NewTokenSources()
+	credentials := apisv1beta1.TokenCredentials{Path: "/var/run/secrets/tokens/grafana"}
+
+	first := sources.For("default", credentials)
+	assert.Same(t, first, sources.For("default", credentials))
+	assert.NotSame(t, first, sources.For("other", credentials))
+
+	credentials.Path = "/var/run/secrets/tokens/other"
+	assert.NotSame(t, first, sources.For("default", credentials))
+}
+
+func Test_TokenTransportSetsBearerToken(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	source := NewTokenSource(&fakeTokenProvider{tokens: []string{"token"}, expiry: time.Now().Add(time.Hour)})
+	client := &http.Client{Transport: &TokenTransport{Transport: http.DefaultTransport, Source: source}}
+	resp, err := client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+	assert.Equal(t, "Bearer token", authorization)
+}
@@ -0,0 +1,200 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+const (
+	errGetCreds            = "cannot get credentials"
+	errCredsFormat         = "credentials are not formatted as base64 encoded 'username:password' pair"
+	errFromEnvMissing      = "fromEnvironment is required if the source of the credentials is EnvironmentVariable"
+	errEnvVarEmpty         = "environment variable %q is not set"
+	errGetCloudAPIKey      = "cannot get Grafana Cloud API key"
+	errCloudOrgSlugMissing = "cloudOrgSlug or cloudStackSlug is required if cloudApiKey is set"
+
+	// cloudDomain is the domain of the Grafana Cloud stacks, which are served at {slug}.grafana.net.
+	cloudDomain = "grafana.net"
+)
+
+// NewTransportConfig builds the transport of the Grafana client for the ProviderConfig. If the ProviderConfig
+// references a Grafana Cloud API key, it is sent as Bearer token to the stack of the cloudOrgSlug. If the source of the
+// credentials is Token, the token of its token provider is sent as Bearer token to host and port, otherwise the
+// 'username:password' pair of the credentials is sent as basic auth to host and port. Requests rejected with 429 are
+// retried according to the retry policy of the ProviderConfig, and requests to a host that is unavailable are stopped by
+// its circuit breaker. Host and port are looked up from the cloudStackSlug, unless host is set.
+func NewTransportConfig(ctx context.Context, kube client.Client, pc *apisv1beta1.ProviderConfig) (*grafana.TransportConfig, error) {
+	pc, err := resolveCloudStack(ctx, kube, pc)
+	if err != nil {
+		return nil, err
+	}
+	if pc.Spec.CloudAPIKey != nil {
+		return newCloudTransportConfig(ctx, kube, pc)
+	}
+	if pc.Spec.Credentials.Source == apisv1beta1.CredentialsSourceToken {
+		return newTokenTransportConfig(ctx, pc)
+	}
+
+	data, err := extractCredentials(ctx, kube, pc.Spec.Credentials)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	decoder := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data))
+	decodedCredentials, err := io.ReadAll(decoder)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+	parts := strings.Split(string(decodedCredentials), ":")
+	if len(parts) != 2 {
+		return nil, errors.New(errCredsFormat)
+	}
+
+	clientCfg := newHostTransportConfig(pc)
+	clientCfg.BasicAuth = url.UserPassword(parts[0], parts[1])
+	return clientCfg, nil
+}
+
+// resolveCloudStack returns a copy of the ProviderConfig whose host, port and schemes are the ones of its
+// cloudStackSlug. The ProviderConfig is returned as is if it has no cloudStackSlug or an explicit host.
+func resolveCloudStack(ctx context.Context, kube client.Client, pc *apisv1beta1.ProviderConfig) (*apisv1beta1.ProviderConfig, error) {
+	if pc.Spec.CloudStackSlug == nil || pc.Spec.Host != "" {
+		return pc, nil
+	}
+
+	token, err := resource.ExtractSecret(ctx, kube, v1.CommonCredentialSelectors{SecretRef: pc.Spec.CloudAccessPolicyToken})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetStackToken)
+	}
+	if len(token) == 0 {
+		return nil, errors.New(errGetStackToken)
+	}
+	stack, err := CloudStacks.Resolve(ctx, *pc.Spec.CloudStackSlug, strings.TrimSpace(string(token)))
+	if err != nil {
+		return nil, err
+	}
+
+	pc = pc.DeepCopy()
+	pc.Spec.Host = stack.Host
+	pc.Spec.Port = stack.Port
+	if len(pc.Spec.Schemes) == 0 {
+		pc.Spec.Schemes = []string{stack.Scheme}
+	}
+	return pc, nil
+}
+
+// newHostTransportConfig builds the transport for host and port of the ProviderConfig, without credentials.
+func newHostTransportConfig(pc *apisv1beta1.ProviderConfig) *grafana.TransportConfig {
+	clientCfg := grafana.DefaultTransportConfig()
+	clientCfg = clientCfg.WithHost(fmt.Sprintf("%s:%d", pc.Spec.Host, pc.Spec.Port))
+	if len(pc.Spec.Schemes) > 0 {
+		clientCfg = clientCfg.WithSchemes(pc.Spec.Schemes)
+	}
+	clientCfg.Client = newHTTPClient(clientCfg.Host, pc)
+	return clientCfg
+}
+
+// newTokenTransportConfig builds the transport that sends the token of the token provider of the ProviderConfig. The
+// token is obtained once here, so that invalid credentials fail the connect rather than the first request, and is
+// refreshed by the transport before it expires.
+func newTokenTransportConfig(ctx context.Context, pc *apisv1beta1.ProviderConfig) (*grafana.TransportConfig, error) {
+	if pc.Spec.Credentials.Token == nil {
+		return nil, errors.New(errTokenMissing)
+	}
+	source := Tokens.For(pc.Name, *pc.Spec.Credentials.Token)
+	if _, err := source.Token(ctx); err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	clientCfg := newHostTransportConfig(pc)
+	clientCfg.Client.Transport = &TokenTransport{Transport: clientCfg.Client.Transport, Source: source}
+	return clientCfg, nil
+}
+
+// extractCredentials returns the base64 encoded 'username:password' pair of the credentials. The credential sources of
+// crossplane-runtime already hold them in that format, the ones read from environment variables are encoded the same way.
+func extractCredentials(ctx context.Context, kube client.Client, cd apisv1beta1.ProviderCredentials) ([]byte, error) {
+	if cd.Source != apisv1beta1.CredentialsSourceEnvironmentVariable {
+		return resource.CommonCredentialExtractor(ctx, cd.Source, kube, cd.CommonCredentialSelectors)
+	}
+	if cd.FromEnvironment == nil {
+		return nil, errors.New(errFromEnvMissing)
+	}
+	username, err := getenv(cd.FromEnvironment.UsernameVar)
+	if err != nil {
+		return nil, err
+	}
+	password, err := getenv(cd.FromEnvironment.PasswordVar)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString([]byte(username + ":" + password))), nil
+}
+
+// getenv returns the value of the environment variable, which must not be empty.
+func getenv(name string) (string, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return "", errors.Errorf(errEnvVarEmpty, name)
+	}
+	return value, nil
+}
+
+// newCloudTransportConfig builds the transport for a Grafana Cloud stack. The schemes default to https, since the
+// stacks are not served via http. The stack is the one at host and port if the cloudStackSlug was resolved, otherwise
+// the one of the cloudOrgSlug.
+func newCloudTransportConfig(ctx context.Context, kube client.Client, pc *apisv1beta1.ProviderConfig) (*grafana.TransportConfig, error) {
+	host := ""
+	switch {
+	case pc.Spec.CloudStackSlug != nil:
+		host = fmt.Sprintf("%s:%d", pc.Spec.Host, pc.Spec.Port)
+	case pc.Spec.CloudOrgSlug != nil && *pc.Spec.CloudOrgSlug != "":
+		host = *pc.Spec.CloudOrgSlug + "." + cloudDomain
+	default:
+		return nil, errors.New(errCloudOrgSlugMissing)
+	}
+
+	key, err := resource.ExtractSecret(ctx, kube, v1.CommonCredentialSelectors{SecretRef: pc.Spec.CloudAPIKey})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCloudAPIKey)
+	}
+	if len(key) == 0 {
+		return nil, errors.New(errGetCloudAPIKey)
+	}
+
+	schemes := pc.Spec.Schemes
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	clientCfg := grafana.DefaultTransportConfig()
+	clientCfg = clientCfg.WithHost(host)
+	clientCfg = clientCfg.WithSchemes(schemes)
+	clientCfg.APIKey = strings.TrimSpace(string(key))
+	clientCfg.Client = newHTTPClient(clientCfg.Host, pc)
+	return clientCfg, nil
+}
+
+// newHTTPClient returns the client that sends the requests to the host. Retries happen inside the circuit breaker, so a
+// request counts as a single failure no matter how often it was retried.
+func newHTTPClient(host string, pc *apisv1beta1.ProviderConfig) *http.Client {
+	return &http.Client{Transport: &CircuitBreakerTransport{
+		Transport: NewRetryTransport(pc.Spec.Retry),
+		Breaker:   CircuitBreakers.For(host, pc.Spec.CircuitBreaker),
+	}}
+}
@@ -0,0 +1,229 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+)
+
+// secretClient serves secrets that hold value at every key.
+func secretClient(key string, value string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{key: []byte(value)}
+			return nil
+		},
+	}
+}
+
+func providerConfig() *apisv1beta1.ProviderConfig {
+	return &apisv1beta1.ProviderConfig{
+		Spec: apisv1beta1.ProviderConfigSpec{
+			Credentials: apisv1beta1.ProviderCredentials{
+				Source: v1.CredentialsSourceSecret,
+				CommonCredentialSelectors: v1.CommonCredentialSelectors{
+					SecretRef: &v1.SecretKeySelector{
+						SecretReference: v1.SecretReference{Name: "grafana", Namespace: "crossplane-system"},
+						Key:             "credentials",
+					},
+				},
+			},
+			Host:    "grafana",
+			Port:    3000,
+			Schemes: []string{"http"},
+		},
+	}
+}
+
+func cloudProviderConfig() *apisv1beta1.ProviderConfig {
+	slug := "mystack"
+	return &apisv1beta1.ProviderConfig{
+		Spec: apisv1beta1.ProviderConfigSpec{
+			CloudAPIKey: &v1.SecretKeySelector{
+				SecretReference: v1.SecretReference{Name: "grafana-cloud", Namespace: "crossplane-system"},
+				Key:             "token",
+			},
+			CloudOrgSlug: &slug,
+		},
+	}
+}
+
+func Test_NewTransportConfig(t *testing.T) {
+	// YWRtaW46YWRtaW4= is admin:admin
+	cfg, err := NewTransportConfig(context.Background(), secretClient("credentials", "YWRtaW46YWRtaW4="), providerConfig())
+	assert.Nil(t, err)
+	assert.Equal(t, "grafana:3000", cfg.Host)
+	assert.Equal(t, []string{"http"}, cfg.Schemes)
+	assert.Equal(t, url.UserPassword("admin", "admin"), cfg.BasicAuth)
+	assert.Equal(t, "", cfg.APIKey)
+
+	// YWRtaW4= is admin
+	_, err = NewTransportConfig(context.Background(), secretClient("credentials", "YWRtaW4="), providerConfig())
+	assert.EqualError(t, err, errCredsFormat)
+}
+
+func environmentProviderConfig() *apisv1beta1.ProviderConfig {
+	pc := providerConfig()
+	pc.Spec.Credentials = apisv1beta1.ProviderCredentials{
+		Source:          apisv1beta1.CredentialsSourceEnvironmentVariable,
+		FromEnvironment: &apisv1beta1.EnvironmentCredentials{UsernameVar: "GRAFANA_USERNAME", PasswordVar: "GRAFANA_PASSWORD"},
+	}
+	return pc
+}
+
+func Test_NewTransportConfig_Environment(t *testing.T) {
+	t.Setenv("GRAFANA_USERNAME", "admin")
+	t.Setenv("GRAFANA_PASSWORD", "secret")
+
+	// the secrets must not be read
+	cfg, err := NewTransportConfig(context.Background(), &test.MockClient{}, environmentProviderConfig())
+	assert.Nil(t, err)
+	assert.Equal(t, "grafana:3000", cfg.Host)
+	assert.Equal(t, url.UserPassword("admin", "secret"), cfg.BasicAuth)
+}
+
+func Test_NewTransportConfig_EnvironmentNotSet(t *testing.T) {
+	t.Setenv("GRAFANA_USERNAME", "admin")
+	t.Setenv("GRAFANA_PASSWORD", "")
+
+	_, err := NewTransportConfig(context.Background(), &test.MockClient{}, environmentProviderConfig())
+	assert.EqualError(t, err, errGetCreds+`: environment variable "GRAFANA_PASSWORD" is not set`)
+
+	pc := environmentProviderConfig()
+	pc.Spec.Credentials.FromEnvironment = nil
+	_, err = NewTransportConfig(context.Background(), &test.MockClient{}, pc)
+	assert.EqualError(t, err, errGetCreds+": "+errFromEnvMissing)
+}
+
+func Test_NewTransportConfig_Cloud(t *testing.T) {
+	cfg, err := NewTransportConfig(context.Background(), secretClient("token", "glsa_abc\n"), cloudProviderConfig())
+	assert.Nil(t, err)
+	assert.Equal(t, "mystack.grafana.net", cfg.Host)
+	assert.Equal(t, []string{"https"}, cfg.Schemes)
+	assert.Equal(t, "glsa_abc", cfg.APIKey)
+	assert.Nil(t, cfg.BasicAuth)
+
+	pc := cloudProviderConfig()
+	pc.Spec.CloudOrgSlug = nil
+	_, err = NewTransportConfig(context.Background(), secretClient("token", "glsa_abc"), pc)
+	assert.EqualError(t, err, errCloudOrgSlugMissing)
+
+	_, err = NewTransportConfig(context.Background(), secretClient("other", "glsa_abc"), cloudProviderConfig())
+	assert.EqualError(t, err, errGetCloudAPIKey)
+}
+
+func tokenProviderConfig(path string) *apisv1beta1.ProviderConfig {
+	pc := providerConfig()
+	pc.Name = "token"
+	pc.Spec.Credentials = apisv1beta1.ProviderCredentials{
+		Source: apisv1beta1.CredentialsSourceToken,
+		Token:  &apisv1beta1.TokenCredentials{Path: path},
+	}
+	return pc
+}
+
+func Test_NewTransportConfig_Token(t *testing.T) {
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	path := writeToken(t, "", "first")
+	// the secrets must not be read
+	cfg, err := NewTransportConfig(context.Background(), &test.MockClient{}, tokenProviderConfig(path))
+	assert.Nil(t, err)
+	assert.Equal(t, "grafana:3000", cfg.Host)
+	assert.Nil(t, cfg.BasicAuth)
+	assert.Equal(t, "", cfg.APIKey)
+
+	resp, err := cfg.Client.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+	assert.Equal(t, "Bearer first", authorization)
+
+	_, err = NewTransportConfig(context.Background(), &test.MockClient{}, tokenProviderConfig(filepath.Join(t.TempDir(), "missing")))
+	assert.ErrorContains(t, err, errGetCreds+": "+errReadToken)
+
+	pc := tokenProviderConfig(path)
+	pc.Spec.Credentials.Token = nil
+	_, err = NewTransportConfig(context.Background(), &test.MockClient{}, pc)
+	assert.EqualError(t, err, errTokenMissing)
+}
+
+func stackProviderConfig() *apisv1beta1.ProviderConfig {
+	pc := providerConfig()
+	slug := "mystack"
+	pc.Spec.Host = ""
+	pc.Spec.Port = 0
+	pc.Spec.Schemes = nil
+	pc.Spec.CloudStackSlug = &slug
+	pc.Spec.CloudAccessPolicyToken = &v1.SecretKeySelector{
+		SecretReference: v1.SecretReference{Name: "grafana-cloud", Namespace: "crossplane-system"},
+		Key:             "token",
+	}
+	return pc
+}
+
+// secretsClient serves secrets that hold the data.
+func secretsClient(data map[string]string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{}
+			for k, v := range data {
+				obj.(*corev1.Secret).Data[k] = []byte(v)
+			}
+			return nil
+		},
+	}
+}
+
+func Test_NewTransportConfig_CloudStack(t *testing.T) {
+	var calls int32
+	server := cloudAPI(t, "https://mystack.grafana.net", &calls)
+	stacks := CloudStacks
+	CloudStacks = NewCloudStackResolver(server.URL, time.Hour)
+	defer func() { CloudStacks = stacks }()
+
+	// YWRtaW46YWRtaW4= is admin:admin
+	kube := secretsClient(map[string]string{"token": "glc_abc\n", "credentials": "YWRtaW46YWRtaW4=", "key": "glsa_abc"})
+	cfg, err := NewTransportConfig(context.Background(), kube, stackProviderConfig())
+	assert.Nil(t, err)
+	assert.Equal(t, "mystack.grafana.net:443", cfg.Host)
+	assert.Equal(t, []string{"https"}, cfg.Schemes)
+	assert.Equal(t, url.UserPassword("admin", "admin"), cfg.BasicAuth)
+
+	cloud := stackProviderConfig()
+	cloud.Spec.CloudAPIKey = &v1.SecretKeySelector{Key: "key"}
+	cfg, err = NewTransportConfig(context.Background(), kube, cloud)
+	assert.Nil(t, err)
+	assert.Equal(t, "mystack.grafana.net:443", cfg.Host)
+	assert.Equal(t, "glsa_abc", cfg.APIKey)
+	assert.Equal(t, int32(1), calls, "the stack should be looked up once")
+
+	// an explicit host overrides the stack
+	override := stackProviderConfig()
+	override.Spec.Host = "grafana"
+	override.Spec.Port = 3000
+	cfg, err = NewTransportConfig(context.Background(), kube, override)
+	assert.Nil(t, err)
+	assert.Equal(t, "grafana:3000", cfg.Host)
+	assert.Equal(t, int32(1), calls, "the stack should not be looked up if host is set")
+
+	missing := stackProviderConfig()
+	missing.Spec.CloudAccessPolicyToken = nil
+	_, err = NewTransportConfig(context.Background(), kube, missing)
+	assert.ErrorContains(t, err, errGetStackToken)
+}
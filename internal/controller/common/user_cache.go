@@ -0,0 +1,107 @@
+package common
+
+import (
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+)
+
+// DefaultUserCacheTTL is how long the users of a Grafana instance are cached
+// unless configured otherwise.
+const DefaultUserCacheTTL = time.Minute
+
+// Users caches the users of all Grafana instances the provider talks to. It is
+// shared by all reconcilers.
+var Users = NewUserCache(DefaultUserCacheTTL)
+
+// UserCache caches the mapping of user emails to user IDs per Grafana host, so
+// that not every reconcile has to page through all users of the instance. It
+// is safe for concurrent use. A nil cache or a TTL <= 0 disables caching.
+type UserCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*userCacheEntry
+	now     func() time.Time
+}
+
+type userCacheEntry struct {
+	mu      sync.Mutex
+	ids     map[string]int64
+	expires time.Time
+}
+
+// NewUserCache returns a UserCache that keeps users for the supplied TTL.
+func NewUserCache(ttl time.Duration) *UserCache {
+	return &UserCache{
+		ttl:     ttl,
+		entries: make(map[string]*userCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// SetTTL changes how long users are cached. Already cached users keep their
+// expiry.
+func (c *UserCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Get returns the user IDs by email of the supplied host, calling load if they
+// are not cached or expired. Concurrent calls for the same host wait for a
+// single load. The returned map is shared and must not be modified.
+func (c *UserCache) Get(host string, load func() ([]*models.UserSearchHitDTO, error)) (map[string]int64, error) {
+	if c == nil {
+		return loadUserIds(load)
+	}
+
+	c.mu.Lock()
+	ttl := c.ttl
+	entry, ok := c.entries[host]
+	if !ok {
+		entry = &userCacheEntry{}
+		c.entries[host] = entry
+	}
+	c.mu.Unlock()
+
+	if ttl <= 0 {
+		return loadUserIds(load)
+	}
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+	if entry.ids != nil && c.now().Before(entry.expires) {
+		return entry.ids, nil
+	}
+	ids, err := loadUserIds(load)
+	if err != nil {
+		return nil, err
+	}
+	entry.ids = ids
+	entry.expires = c.now().Add(ttl)
+	return ids, nil
+}
+
+// Invalidate drops the cached users of the supplied host, e.g. after a user
+// was created.
+func (c *UserCache) Invalidate(host string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, host)
+}
+
+func loadUserIds(load func() ([]*models.UserSearchHitDTO, error)) (map[string]int64, error) {
+	users, err := load()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]int64, len(users))
+	for _, u := range users {
+		ids[u.Email] = u.ID
+	}
+	return ids, nil
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func countingLoader(calls *int32) func() ([]*models.UserSearchHitDTO, error) {
+	return func() ([]*models.UserSearchHitDTO, error) {
+		atomic.AddInt32(calls, 1)
+		return []*models.UserSearchHitDTO{{ID: 2, Email: "user@example.com"}}, nil
+	}
+}
+
+func Test_UserCacheLoadsOncePerTTL(t *testing.T) {
+	var calls int32
+	now := time.Unix(0, 0)
+	cache := NewUserCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	ids, err := cache.Get("grafana:3000", countingLoader(&calls))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int64{"user@example.com": 2}, ids)
+
+	_, _ = cache.Get("grafana:3000", countingLoader(&calls))
+	assert.Equal(t, int32(1), calls, "users should be served from the cache within the TTL")
+
+	_, _ = cache.Get("other:3000", countingLoader(&calls))
+	assert.Equal(t, int32(2), calls, "users should be cached per host")
+
+	now = now.Add(time.Minute)
+	_, _ = cache.Get("grafana:3000", countingLoader(&calls))
+	assert.Equal(t, int32(3), calls, "users should be reloaded after the TTL")
+
+	cache.Invalidate("grafana:3000")
+	_, _ = cache.Get("grafana:3000", countingLoader(&calls))
+	assert.Equal(t, int32(4), calls, "users should be reloaded after invalidation")
+}
+
+func Test_UserCacheDisabled(t *testing.T) {
+	var calls int32
+	var nilCache *UserCache
+	_, _ = nilCache.Get("grafana:3000", countingLoader(&calls))
+	nilCache.Invalidate("grafana:3000")
+
+	cache := NewUserCache(0)
+	_, _ = cache.Get("grafana:3000", countingLoader(&calls))
+	_, _ = cache.Get("grafana:3000", countingLoader(&calls))
+	assert.Equal(t, int32(3), calls)
+}
+
+func Test_UserCacheDoesNotCacheErrors(t *testing.T) {
+	errBoom := errors.New("boom")
+	cache := NewUserCache(time.Minute)
+
+	_, err := cache.Get("grafana:3000", func() ([]*models.UserSearchHitDTO, error) { return nil, errBoom })
+	assert.Equal(t, errBoom, err)
+
+	var calls int32
+	_, err = cache.Get("grafana:3000", countingLoader(&calls))
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), calls)
+}
+
+func Test_UserCacheConcurrentGet(t *testing.T) {
+	var calls int32
+	cache := NewUserCache(time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = cache.Get("grafana:3000", countingLoader(&calls))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls)
+}
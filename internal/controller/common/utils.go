@@ -3,10 +3,47 @@ package common
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
 	kubeV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
 )
 
+// maxDiffMessageLength is the maximum length in bytes of the message of a DiffCondition.
+const maxDiffMessageLength = 512
+
+// ErrOrgIdRequired is returned for resources that neither set an orgId nor use a ProviderConfig with a defaultOrgId.
+const ErrOrgIdRequired = "orgId is not set and the ProviderConfig has no defaultOrgId"
+
+// AnnotationKeyAdoptExisting marks a managed resource that may already exist in Grafana, e.g. because it was created
+// by Terraform. Such resources are looked up by their UID or email before they are created, and adopted if found.
+const AnnotationKeyAdoptExisting = "grafana.crossplane.io/adopt-existing"
+
+// AnnotationKeyAdoptByUID is an alias of AnnotationKeyAdoptExisting, named after the UID the resources are looked up by.
+const AnnotationKeyAdoptByUID = "grafana.crossplane.io/adopt-by-uid"
+
+// AnnotationKeyLastReconcileTime holds the time in RFC3339 a managed resource was last observed, created or updated
+// successfully.
+const AnnotationKeyLastReconcileTime = "grafana.crossplane.io/last-reconcile-time"
+
+// SetLastReconcileAnnotation sets the AnnotationKeyLastReconcileTime annotation of the managed resource to t. Like other
+// metadata changed by an ExternalClient, it is persisted whenever the managed reconciler updates the resource, e.g.
+// after a create or a late initialization, not with the status alone. Persisting it after every observation would
+// trigger another reconcile with every write.
+func SetLastReconcileAnnotation(mg resource.Managed, t time.Time) {
+	meta.AddAnnotations(mg, map[string]string{AnnotationKeyLastReconcileTime: t.UTC().Format(time.RFC3339)})
+}
+
 func SecretToStringMap(secret *kubeV1.Secret) map[string]string {
 	sjd := make(map[string]string)
 	if secret == nil {
@@ -29,16 +66,89 @@ func JsonDataWithHeaders(inputJSONData map[string]interface{}, inputSecureJSONDa
 		secureJSONData[name] = value
 	}
 
-	idx := 1
-	for name, value := range headers {
-		jsonData[fmt.Sprintf("httpHeaderName%d", idx)] = name
-		secureJSONData[fmt.Sprintf("httpHeaderValue%d", idx)] = value
-		idx++
+	// the indices must not depend on the iteration order of the map, otherwise they change between reconciles
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// headers set in the JSON data directly keep their indices, the ones of the secret are numbered after them
+	offset := highestHeaderIndex(jsonData, secureJSONData)
+	for i, name := range names {
+		jsonData[fmt.Sprintf(httpHeaderNameKey+"%d", offset+i+1)] = name
+		secureJSONData[fmt.Sprintf(httpHeaderValueKey+"%d", offset+i+1)] = headers[name]
 	}
 
 	return jsonData, secureJSONData
 }
 
+// The prefixes of the numbered keys of the HTTP headers of a data source in its JSON and secure JSON data.
+const (
+	httpHeaderNameKey  = "httpHeaderName"
+	httpHeaderValueKey = "httpHeaderValue"
+)
+
+// highestHeaderIndex returns the highest index of the HTTP headers in the JSON or secure JSON data, or 0 if there are
+// none.
+func highestHeaderIndex(jsonData map[string]interface{}, secureJSONData map[string]string) int {
+	highest := 0
+	update := func(key string, prefix string) {
+		if !strings.HasPrefix(key, prefix) {
+			return
+		}
+		if i, err := strconv.Atoi(strings.TrimPrefix(key, prefix)); err == nil && i > highest {
+			highest = i
+		}
+	}
+	for key := range jsonData {
+		update(key, httpHeaderNameKey)
+	}
+	for key := range secureJSONData {
+		update(key, httpHeaderValueKey)
+	}
+	return highest
+}
+
+// The keys of a kubernetes.io/tls secret, the CA certificate is optional.
+const (
+	TLSCertKey = "tls.crt"
+	TLSKeyKey  = "tls.key"
+	TLSCAKey   = "ca.crt"
+)
+
+const errTLSKeyPair = "the TLS secret must contain either both or none of tls.crt and tls.key"
+
+// JsonDataWithTLS adds the client certificate, client key and CA certificate of a TLS secret to the secure JSON data
+// and enables them in the JSON data. The secret values take precedence over the TLS settings of the JSON data.
+func JsonDataWithTLS(inputJSONData map[string]interface{}, inputSecureJSONData map[string]string, tls map[string]string) (map[string]interface{}, map[string]string, error) {
+	jsonData := make(map[string]interface{})
+	for name, value := range inputJSONData {
+		jsonData[name] = value
+	}
+
+	secureJSONData := make(map[string]string)
+	for name, value := range inputSecureJSONData {
+		secureJSONData[name] = value
+	}
+
+	cert, hasCert := tls[TLSCertKey]
+	key, hasKey := tls[TLSKeyKey]
+	if hasCert != hasKey {
+		return nil, nil, errors.New(errTLSKeyPair)
+	}
+	if hasCert {
+		jsonData["tlsAuth"] = true
+		secureJSONData["tlsClientCert"] = cert
+		secureJSONData["tlsClientKey"] = key
+	}
+	if ca, ok := tls[TLSCAKey]; ok {
+		jsonData["tlsAuthWithCACert"] = true
+		secureJSONData["tlsCACert"] = ca
+	}
+
+	return jsonData, secureJSONData, nil
+}
+
 func DefaultString(s *string, def string) string {
 	if s == nil {
 		return def
@@ -54,6 +164,63 @@ func DefaultBool(b *bool, def bool) bool {
 	return *b
 }
 
+func DefaultInt64(i *int64, def int64) int64 {
+	if i == nil {
+		return def
+	}
+	return *i
+}
+
+// DefaultOrgID sets orgID to defaultOrgID, the defaultOrgId of the ProviderConfig, if it is not set. It returns true
+// if orgID was defaulted, so that Observe can report the resource as late initialized, and an error if neither is set.
+func DefaultOrgID(orgID **string, defaultOrgID *int64) (bool, error) {
+	if *orgID != nil {
+		return false, nil
+	}
+	if defaultOrgID == nil {
+		return false, errors.New(ErrOrgIdRequired)
+	}
+	defaulted := strconv.FormatInt(*defaultOrgID, 10)
+	*orgID = &defaulted
+	return true, nil
+}
+
+// ShouldAdopt returns true if the managed resource is annotated to adopt an existing resource in Grafana.
+func ShouldAdopt(o metav1.Object) bool {
+	annotations := o.GetAnnotations()
+	return annotations[AnnotationKeyAdoptExisting] == "true" || annotations[AnnotationKeyAdoptByUID] == "true"
+}
+
+// AnnotationKeyDeletionProtection marks a managed resource whose counterpart in Grafana must not be deleted. Deleting
+// the managed resource is refused until the annotation is removed.
+const AnnotationKeyDeletionProtection = "grafana.crossplane.io/deletion-protection"
+
+// ErrDeletionProtected is returned by Delete for managed resources that are annotated with deletion protection.
+const ErrDeletionProtected = "refusing to delete the resource in Grafana, remove the " + AnnotationKeyDeletionProtection +
+	" annotation to delete it"
+
+// TypeDeletionProtected indicates that the deletion of a managed resource was refused, because it is protected.
+const TypeDeletionProtected v1.ConditionType = "DeletionProtected"
+
+// ReasonDeletionRefused indicates that a managed resource was deleted, but its deletion protection kept it in Grafana.
+const ReasonDeletionRefused v1.ConditionReason = "DeletionRefused"
+
+// CheckDeletionProtection returns an error if the managed resource is annotated with deletion protection, and sets the
+// DeletionProtected condition to tell why it is not deleted.
+func CheckDeletionProtection(mg resource.Managed) error {
+	if mg.GetAnnotations()[AnnotationKeyDeletionProtection] != "true" {
+		return nil
+	}
+	mg.SetConditions(v1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             kubeV1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionRefused,
+		Message:            ErrDeletionProtected,
+	})
+	return errors.New(ErrDeletionProtected)
+}
+
 func CompareOptional[K comparable](desired *K, actual K, defaultValue K) bool {
 	var expected K
 	if desired == nil {
@@ -64,71 +231,77 @@ func CompareOptional[K comparable](desired *K, actual K, defaultValue K) bool {
 	return actual == expected
 }
 
+// CompareMap compares two objects decoded from JSON, descending into nested objects and arrays at any depth. Numbers
+// are compared by value, no matter if they were decoded as integers or as float64.
 func CompareMap(desired map[string]interface{}, actual map[string]interface{}) (bool, error) {
 	if len(desired) != len(actual) {
 		return false, nil
 	}
 	for key, value := range desired {
-		if _, ok := actual[key]; !ok {
+		actualValue, ok := actual[key]
+		if !ok {
 			return false, nil
 		}
-		equal, ok := compareComparable(value, actual[key])
-		if ok {
-			if !equal {
-				return false, nil
-			}
-			continue
-		}
-		typeA := reflect.TypeOf(desired)
-		if typeA == reflect.TypeOf(map[string]interface{}{}) {
-			desiredValueType := reflect.TypeOf(value)
-			actualValueType := reflect.TypeOf(actual[key])
-			if desiredValueType != actualValueType {
-				return false, nil
-			}
-			switch desiredValueType {
-			case reflect.TypeOf(map[string]interface{}{}):
-				return CompareMap(value.(map[string]interface{}), actual[key].(map[string]interface{}))
-			case reflect.TypeOf([]interface{}{}):
-				return CompareSlice(value.([]interface{}), actual[key].([]interface{}))
-			default:
-				return false, fmt.Errorf("Unsupported map type %s of value %v", desiredValueType, value)
-			}
+		if equal, err := compareValues(value, actualValue); err != nil || !equal {
+			return false, err
 		}
-		return false, fmt.Errorf("Unsupported type %s of value %v", typeA, value)
 	}
 	return true, nil
 }
 
+// CompareSlice compares two arrays decoded from JSON element by element, like CompareMap.
 func CompareSlice(desired []interface{}, actual []interface{}) (bool, error) {
 	if len(desired) != len(actual) {
 		return false, nil
 	}
 	for i, value := range desired {
-		equal, ok := compareComparable(value, actual[i])
-		if ok {
-			if !equal {
-				return false, nil
-			}
-			continue
-		}
-		typeA := reflect.TypeOf(value)
-		switch typeA {
-		case reflect.TypeOf(map[string]interface{}{}):
-			return CompareMap(value.(map[string]interface{}), actual[i].(map[string]interface{}))
-		case reflect.TypeOf([]interface{}{}):
-			return CompareSlice(value.([]interface{}), actual[i].([]interface{}))
-		default:
-			return false, fmt.Errorf("Unsupported type %s of value %v", typeA, value)
+		if equal, err := compareValues(value, actual[i]); err != nil || !equal {
+			return false, err
 		}
 	}
 	return true, nil
+}
 
+// compareValues compares two values of any depth. Values of different types are not equal, unless both are numbers or
+// can be converted into each other.
+func compareValues(desired interface{}, actual interface{}) (bool, error) {
+	if equal, ok := compareComparable(desired, actual); ok {
+		return equal, nil
+	}
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return false, nil
+		}
+		return CompareMap(desiredValue, actualValue)
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok {
+			return false, nil
+		}
+		return CompareSlice(desiredValue, actualValue)
+	default:
+		if reflect.TypeOf(desired) != reflect.TypeOf(actual) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Unsupported type %s of value %v", reflect.TypeOf(desired), desired)
+	}
 }
 
 // compareComparable tries to compare to values of different types. It returns a boolean indicating if the values are
 // equal and a boolean indicating if the comparison was successful
 func compareComparable(desired interface{}, actual interface{}) (bool, bool) {
+	if desired == nil || actual == nil {
+		return desired == nil && actual == nil, true
+	}
+	numberA, isNumberA := asFloat64(desired)
+	numberB, isNumberB := asFloat64(actual)
+	if isNumberA || isNumberB {
+		// JSON numbers are float64 when decoded by the Grafana client, but integers in the desired state, so both
+		// are compared as float64 rather than converting one into the type of the other, which would truncate
+		return isNumberA && isNumberB && numberA == numberB, true
+	}
 	typeA := reflect.TypeOf(desired)
 	typeB := reflect.TypeOf(actual)
 	if typeA.Comparable() && typeB.Comparable() && typeA.ConvertibleTo(typeB) {
@@ -138,6 +311,83 @@ func compareComparable(desired interface{}, actual interface{}) (bool, bool) {
 	return false, false
 }
 
+// asFloat64 converts any integer or floating point number to float64. It returns false for all other values.
+func asFloat64(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
+
+// DiffCondition returns a Drifted condition that reports the differences between the spec and Grafana, as returned by
+// cmp.Diff(desired, actual). The message is truncated to maxDiffMessageLength bytes.
+func DiffCondition(diff string) v1.Condition {
+	return v1alpha1.Drifted(DiffMessage(diff))
+}
+
+// SetDiffCondition sets the DiffCondition of the diff on mg, or marks it as NotDrifted if the diff is empty and it
+// drifted before.
+func SetDiffCondition(mg resource.Conditioned, diff string) {
+	if diff != "" {
+		mg.SetConditions(DiffCondition(diff))
+	} else if mg.GetCondition(v1alpha1.TypeDrifted).Status == kubeV1.ConditionTrue {
+		mg.SetConditions(v1alpha1.NotDrifted())
+	}
+}
+
+// Reasons a managed resource is not available.
+const (
+	// ReasonResourceDrifted indicates that the resource exists in Grafana, but differs from its spec.
+	ReasonResourceDrifted v1.ConditionReason = "ResourceDrifted"
+	// ReasonAPIError indicates that the resource could not be observed, because a request to Grafana failed.
+	ReasonAPIError v1.ConditionReason = "APIError"
+)
+
+// AvailableCondition returns the Ready condition of a resource that exists in Grafana. It is Available if the resource
+// is up to date with its spec, and Unavailable with reason ResourceDrifted otherwise.
+func AvailableCondition(upToDate bool) v1.Condition {
+	if upToDate {
+		return v1.Available()
+	}
+	condition := v1.Unavailable()
+	condition.Reason = ReasonResourceDrifted
+	return condition
+}
+
+// SetAPIError marks the managed resource as Unavailable with reason APIError and the message of err, and returns err.
+// It is used for requests to Grafana that fail while a resource is observed.
+func SetAPIError(mg resource.Conditioned, err error) error {
+	condition := v1.Unavailable().WithMessage(err.Error())
+	condition.Reason = ReasonAPIError
+	mg.SetConditions(condition)
+	return err
+}
+
+// DiffMessage returns the message of a condition that reports the differences between the spec and Grafana, truncated
+// to fit into the status.
+func DiffMessage(diff string) string {
+	return truncate("spec differs from Grafana (-spec +grafana):\n"+diff, maxDiffMessageLength)
+}
+
+// truncate cuts s to at most n bytes without splitting a multi-byte character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	s = s[:n]
+	for !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
 func CompareMapKeys[T1, T2 comparable](desired map[string]T1, actual map[string]T2) bool {
 	if len(desired) != len(actual) {
 		return false
@@ -2,9 +2,14 @@ package common
 
 import (
 	_ "embed"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
 )
 
 func Test_CompareMap(t *testing.T) {
@@ -57,6 +62,141 @@ func Test_CompareMap(t *testing.T) {
 	assert.True(t, probe)
 }
 
+func Test_CompareMapDetectsChangesAfterNestedValues(t *testing.T) {
+	desired := map[string]interface{}{
+		"a": map[string]interface{}{"value": 1},
+		"b": []interface{}{map[string]interface{}{"value": 1}, map[string]interface{}{"value": 2}},
+		"c": nil,
+	}
+	actual := map[string]interface{}{
+		"a": map[string]interface{}{"value": 1},
+		"b": []interface{}{map[string]interface{}{"value": 1}, map[string]interface{}{"value": 3}},
+		"c": nil,
+	}
+	probe, err := CompareMap(desired, actual)
+	assert.Nil(t, err)
+	assert.False(t, probe)
+
+	actual["b"] = []interface{}{map[string]interface{}{"value": 1}, map[string]interface{}{"value": 2}}
+	probe, err = CompareMap(desired, actual)
+	assert.Nil(t, err)
+	assert.True(t, probe)
+}
+
+func Test_CompareMapNormalizesNumbers(t *testing.T) {
+	desired := map[string]interface{}{
+		"value": int(1),
+		"a":     map[string]interface{}{"timeout": int64(30)},
+		"b":     []interface{}{map[string]interface{}{"value": int32(2)}, int(3)},
+	}
+	actual := map[string]interface{}{
+		"value": float64(1),
+		"a":     map[string]interface{}{"timeout": float64(30)},
+		"b":     []interface{}{map[string]interface{}{"value": float64(2)}, float64(3)},
+	}
+	probe, err := CompareMap(desired, actual)
+	assert.Nil(t, err)
+	assert.True(t, probe)
+
+	// fractions must not be truncated to the integer in the desired state
+	actual["b"] = []interface{}{map[string]interface{}{"value": float64(2.5)}, float64(3)}
+	probe, err = CompareMap(desired, actual)
+	assert.Nil(t, err)
+	assert.False(t, probe)
+
+	// numbers are never equal to strings
+	probe, err = CompareMap(map[string]interface{}{"value": "a"}, map[string]interface{}{"value": int(97)})
+	assert.Nil(t, err)
+	assert.False(t, probe)
+}
+
+func Test_CompareMapNormalizesNumbersAtAnyDepth(t *testing.T) {
+	desired := map[string]interface{}{
+		"a": []interface{}{[]interface{}{map[string]interface{}{"b": map[string]interface{}{"c": int64(5)}}}},
+	}
+	actual := map[string]interface{}{
+		"a": []interface{}{[]interface{}{map[string]interface{}{"b": map[string]interface{}{"c": float64(5)}}}},
+	}
+	probe, err := CompareMap(desired, actual)
+	assert.Nil(t, err)
+	assert.True(t, probe)
+
+	// an array in place of an object is a change, not an error
+	actual["a"] = []interface{}{[]interface{}{[]interface{}{}}}
+	probe, err = CompareMap(desired, actual)
+	assert.Nil(t, err)
+	assert.False(t, probe)
+}
+
+func Test_JsonDataWithHeadersContinuesNumbering(t *testing.T) {
+	cases := map[string]struct {
+		jsonData       map[string]interface{}
+		secureJsonData map[string]string
+		want           string
+	}{
+		"NoHeaders": {
+			want: "1",
+		},
+		"HeaderName": {
+			jsonData: map[string]interface{}{"httpHeaderName1": "X-Custom"},
+			want:     "2",
+		},
+		"HeaderValue": {
+			secureJsonData: map[string]string{"httpHeaderValue3": "value"},
+			want:           "4",
+		},
+		"NotNumbered": {
+			jsonData: map[string]interface{}{"httpHeaderNameX": "X-Custom", "httpHeaderNames": "X-Custom"},
+			want:     "1",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			jsonData, secureJsonData := JsonDataWithHeaders(tc.jsonData, tc.secureJsonData, map[string]string{"X-Scope-OrgID": "tenant"})
+			assert.Equal(t, "X-Scope-OrgID", jsonData["httpHeaderName"+tc.want])
+			assert.Equal(t, "tenant", secureJsonData["httpHeaderValue"+tc.want])
+			for key, value := range tc.jsonData {
+				assert.Equal(t, value, jsonData[key])
+			}
+			for key, value := range tc.secureJsonData {
+				assert.Equal(t, value, secureJsonData[key])
+			}
+		})
+	}
+}
+
+func Test_DiffConditionIsTruncated(t *testing.T) {
+	condition := DiffCondition(strings.Repeat("ä", 600))
+	assert.Equal(t, v1alpha1.TypeDrifted, condition.Type)
+	assert.Equal(t, v1alpha1.ReasonDriftDetected, condition.Reason)
+	assert.LessOrEqual(t, len(condition.Message), 512)
+	assert.True(t, utf8.ValidString(condition.Message))
+
+	condition = DiffCondition("-\tTitle: \"test\"\n+\tTitle: \"other\"\n")
+	assert.Contains(t, condition.Message, "+\tTitle: \"other\"\n")
+}
+
+func Test_DefaultOrgID(t *testing.T) {
+	orgID := "2"
+	set := &orgID
+	defaulted, err := DefaultOrgID(&set, nil)
+	assert.NoError(t, err)
+	assert.False(t, defaulted)
+	assert.Equal(t, "2", *set)
+
+	var defaultOrgID int64 = 1
+	var unset *string
+	defaulted, err = DefaultOrgID(&unset, &defaultOrgID)
+	assert.NoError(t, err)
+	assert.True(t, defaulted)
+	assert.Equal(t, "1", *unset)
+
+	var missing *string
+	_, err = DefaultOrgID(&missing, nil)
+	assert.EqualError(t, err, ErrOrgIdRequired)
+}
+
 func Test_CompareOptional(t *testing.T) {
 	desired := "Test"
 	assert.True(t, CompareOptional(&desired, "Test", ""))
@@ -66,3 +206,108 @@ func Test_CompareOptional(t *testing.T) {
 	assert.True(t, CompareOptional(nil, "default", "default"))
 	assert.False(t, CompareOptional(nil, "non-default", "default"))
 }
+
+func Test_JsonDataWithHeadersIsDeterministic(t *testing.T) {
+	headers := map[string]string{
+		"X-Scope-OrgID": "tenant",
+		"Authorization": "Bearer token",
+		"X-Custom":      "value",
+	}
+	firstJsonData, firstSecureJsonData := JsonDataWithHeaders(map[string]interface{}{"a": 1}, map[string]string{"b": "2"}, headers)
+	for i := 0; i < 20; i++ {
+		jsonData, secureJsonData := JsonDataWithHeaders(map[string]interface{}{"a": 1}, map[string]string{"b": "2"}, headers)
+		assert.Equal(t, firstJsonData, jsonData)
+		assert.Equal(t, firstSecureJsonData, secureJsonData)
+	}
+	assert.Equal(t, "Authorization", firstJsonData["httpHeaderName1"])
+	assert.Equal(t, "Bearer token", firstSecureJsonData["httpHeaderValue1"])
+	assert.Equal(t, "X-Custom", firstJsonData["httpHeaderName2"])
+	assert.Equal(t, "X-Scope-OrgID", firstJsonData["httpHeaderName3"])
+	assert.Equal(t, "tenant", firstSecureJsonData["httpHeaderValue3"])
+}
+
+func Test_JsonDataWithTLS(t *testing.T) {
+	cases := map[string]struct {
+		jsonData           map[string]interface{}
+		tls                map[string]string
+		wantJsonData       map[string]interface{}
+		wantSecureJsonData map[string]string
+		wantErr            bool
+	}{
+		"NoSecret": {
+			jsonData:           map[string]interface{}{"a": 1},
+			wantJsonData:       map[string]interface{}{"a": 1},
+			wantSecureJsonData: map[string]string{"b": "2"},
+		},
+		"ClientCertificate": {
+			jsonData:           map[string]interface{}{"a": 1},
+			tls:                map[string]string{TLSCertKey: "cert", TLSKeyKey: "key"},
+			wantJsonData:       map[string]interface{}{"a": 1, "tlsAuth": true},
+			wantSecureJsonData: map[string]string{"b": "2", "tlsClientCert": "cert", "tlsClientKey": "key"},
+		},
+		"ClientCertificateAndCA": {
+			jsonData:           map[string]interface{}{"a": 1},
+			tls:                map[string]string{TLSCertKey: "cert", TLSKeyKey: "key", TLSCAKey: "ca"},
+			wantJsonData:       map[string]interface{}{"a": 1, "tlsAuth": true, "tlsAuthWithCACert": true},
+			wantSecureJsonData: map[string]string{"b": "2", "tlsClientCert": "cert", "tlsClientKey": "key", "tlsCACert": "ca"},
+		},
+		"OnlyCA": {
+			jsonData:           map[string]interface{}{"a": 1},
+			tls:                map[string]string{TLSCAKey: "ca"},
+			wantJsonData:       map[string]interface{}{"a": 1, "tlsAuthWithCACert": true},
+			wantSecureJsonData: map[string]string{"b": "2", "tlsCACert": "ca"},
+		},
+		"OverridesJsonData": {
+			jsonData:           map[string]interface{}{"tlsAuth": false, "tlsAuthWithCACert": false},
+			tls:                map[string]string{TLSCertKey: "cert", TLSKeyKey: "key", TLSCAKey: "ca"},
+			wantJsonData:       map[string]interface{}{"tlsAuth": true, "tlsAuthWithCACert": true},
+			wantSecureJsonData: map[string]string{"b": "2", "tlsClientCert": "cert", "tlsClientKey": "key", "tlsCACert": "ca"},
+		},
+		"IgnoresOtherKeys": {
+			jsonData:           map[string]interface{}{"a": 1},
+			tls:                map[string]string{"other": "value"},
+			wantJsonData:       map[string]interface{}{"a": 1},
+			wantSecureJsonData: map[string]string{"b": "2"},
+		},
+		"CertificateWithoutKey": {
+			jsonData: map[string]interface{}{"a": 1},
+			tls:      map[string]string{TLSCertKey: "cert", TLSCAKey: "ca"},
+			wantErr:  true,
+		},
+		"KeyWithoutCertificate": {
+			jsonData: map[string]interface{}{"a": 1},
+			tls:      map[string]string{TLSKeyKey: "key"},
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			secureJsonData := map[string]string{"b": "2"}
+			jsonData, gotSecureJsonData, err := JsonDataWithTLS(tc.jsonData, secureJsonData, tc.tls)
+			if tc.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.wantJsonData, jsonData)
+			assert.Equal(t, tc.wantSecureJsonData, gotSecureJsonData)
+			// the input maps are left untouched
+			assert.Equal(t, map[string]string{"b": "2"}, secureJsonData)
+		})
+	}
+}
+
+func Test_ShouldAdopt(t *testing.T) {
+	o := &metav1.ObjectMeta{}
+	assert.False(t, ShouldAdopt(o))
+
+	o.SetAnnotations(map[string]string{AnnotationKeyAdoptExisting: "false"})
+	assert.False(t, ShouldAdopt(o))
+
+	o.SetAnnotations(map[string]string{AnnotationKeyAdoptExisting: "true"})
+	assert.True(t, ShouldAdopt(o))
+
+	o.SetAnnotations(map[string]string{AnnotationKeyAdoptByUID: "true"})
+	assert.True(t, ShouldAdopt(o))
+}
@@ -17,27 +17,29 @@ limitations under the License.
 package dashboard
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"fmt"
-	"io"
-	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
 	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/uuid"
 	"k8s.io/apimachinery/pkg/util/json"
 
 	"github.com/argannor/provider-grafana/internal/controller/common"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,17 +60,42 @@ const (
 	errNotDashboard = "managed resource is not a Dashboard custom resource"
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
-	errCredsFormat  = "credentials are not formatted as base64 encoded 'username:password' pair"
 	errOrgIdNotInt  = "orgId is not an integer"
 	errNoTitle      = "configJson does not contain a title for the dashboard"
 
+	errResolveFolder  = "cannot resolve folder %q"
+	errFolderNotFound = "no folder is titled %q"
+
+	errGetConfigMap   = "cannot get ConfigMap %s/%s"
+	errConfigMapNoKey = "ConfigMap %s/%s has no key %q"
+
+	errNoDataSourceVariable = "configJson has no template variable %q of type datasource"
+	errDataSourceUnresolved = "the data source of template variable %q is not resolved"
+	errMarshalJson          = "cannot marshal JSON data"
+
 	errNewClient             = "cannot create new Service"
 	errFailedGetDashboard    = "cannot get Dashboard from Grafana API"
 	errFailedCreateDashboard = "cannot create Dashboard"
 	errFailedUpdateDashboard = "cannot update Dashboard"
 	errFailedDeleteDashboard = "cannot delete Dashboard"
 
+	errVersionConflict         = "dashboard %q was modified in Grafana and overwrite is disabled"
+	errVersionConflictVersions = "dashboard %q was modified in Grafana (version %d at Grafana, last applied version %d) and overwrite is disabled"
+
+	msgModifiedInGrafana = "dashboard %q was modified in Grafana (version %d at Grafana, last applied version %d)"
+
+	// reasonModifiedInGrafana is the reason of the event recorded when a dashboard was modified outside the provider.
+	reasonModifiedInGrafana event.Reason = "ModifiedInGrafana"
+
+	msgCreated = "created dashboard %q in version %d"
+	msgUpdated = "updated dashboard %q to version %d"
+	msgDeleted = "deleted dashboard %q"
+
+	// reasons of the events recorded when the provider changed a dashboard in Grafana
+	reasonCreated event.Reason = "CreatedDashboard"
+	reasonUpdated event.Reason = "UpdatedDashboard"
+	reasonDeleted event.Reason = "DeletedDashboard"
+
 	errUnmarshalJson            = "cannot unmarshal JSON data"
 	errInvalidDashboardResponse = "cannot parse dashboard response"
 )
@@ -83,22 +110,26 @@ var (
 // Setup adds a controller that reconciles Dashboard managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.DashboardGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
 	}
 
-	r := managed.NewReconciler(mgr,
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
 		resource.ManagedKind(v1alpha1.DashboardGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
 			newServiceFn: newService,
-			logger:       o.Logger}),
+			logger:       o.Logger,
+			recorder:     recorder}))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -106,7 +137,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.Dashboard{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -115,6 +146,7 @@ type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	logger       logging.Logger
+	recorder     event.Recorder
 	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
 }
 
@@ -138,41 +170,37 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
 	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
+		return nil, err
 	}
 
-	decoder := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data))
-	decodedCredentials, err := io.ReadAll(decoder)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-	parts := strings.Split(string(decodedCredentials), ":")
-	if len(parts) != 2 {
-		return nil, errors.New(errCredsFormat)
-	}
-
-	clientCfg := grafana.DefaultTransportConfig()
-	clientCfg = clientCfg.WithHost(fmt.Sprintf("%s:%d", pc.Spec.Host, pc.Spec.Port))
-	clientCfg = clientCfg.WithSchemes(pc.Spec.Schemes)
-	clientCfg.BasicAuth = url.UserPassword(parts[0], parts[1])
-
 	svc, err := c.newServiceFn(clientCfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc, logger: c.logger, kube: c.kube}, nil
+	return &external{service: svc, logger: c.logger, recorder: c.recorder, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID,
+		index: common.Dashboards, host: clientCfg.Host}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service common.GrafanaAPI
-	logger  logging.Logger
-	kube    client.Client
+	service  common.GrafanaAPI
+	logger   logging.Logger
+	recorder event.Recorder
+	kube     client.Client
+
+	// folderUIDs caches the UIDs of folders referenced by title. The external client only lives for a single
+	// reconcile, so changes to the folders in Grafana are picked up by the next one.
+	folderUIDs   map[string]string
+	defaultOrgID *int64
+
+	// index lists the dashboards of the organizations at host, so that dashboards which don't exist are not requested
+	// and dashboards are found by title without searching. A nil index looks up every dashboard on its own.
+	index *common.DashboardIndex
+	host  string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -181,46 +209,111 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotDashboard)
 	}
 
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	// orgId as int64
 	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	atGrafana, err := c.GetDashboard(orgId, cr)
+	folder, err := c.resolveFolder(ctx, orgId, cr.Spec.ForProvider.Folder)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
 
+	configJSON, err := c.getConfigJSON(ctx, cr)
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetDashboard)
+		return managed.ExternalObservation{}, err
+	}
+
+	atGrafana, err := c.GetDashboard(ctx, orgId, cr, folder, configJSON)
+
+	if err != nil {
+		return managed.ExternalObservation{}, common.SetAPIError(cr, errors.Wrap(err, errFailedGetDashboard))
 	}
 
 	if atGrafana == nil {
+		adopted, err := c.tryAdopt(ctx, orgId, cr, configJSON)
+		if err != nil {
+			return managed.ExternalObservation{}, common.SetAPIError(cr, errors.Wrap(err, errFailedGetDashboard))
+		}
+		if adopted == nil {
+			c.log(cr).Debug("Dashboard does not exist")
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		// the adopted dashboard is updated to match the spec instead of creating a conflicting one
+		if err := adopt(adopted, cr); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		c.log(cr).Debug("Adopting existing dashboard", "uid", common.DefaultString(cr.Status.AtProvider.UID, ""))
 		return managed.ExternalObservation{
-			ResourceExists: false,
+			ResourceExists:          true,
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        false,
+			ConnectionDetails:       connectionDetails(cr),
 		}, nil
 	}
 
-	cr.SetConditions(v1.Available())
-	upToDate := isUpToDate(cr, atGrafana)
+	if modifiedInGrafana(cr, atGrafana.Meta.Version) {
+		c.recordEvent(cr, event.Warning(reasonModifiedInGrafana, errors.Errorf(msgModifiedInGrafana,
+			common.DefaultString(cr.Status.AtProvider.UID, ""), atGrafana.Meta.Version, *cr.Status.AtProvider.ManagedVersion)))
+	}
+	var upToDate bool
+	delta := ""
+	if updatesAllowed(cr) {
+		upToDate = isUpToDate(c.log(cr), cr, atGrafana, folder, configJSON)
+		if !upToDate {
+			delta = Diff(cr, atGrafana, folder, configJSON)
+		}
+		common.SetDiffCondition(cr, delta)
+	} else {
+		// the reconciler won't update the dashboard, so the drift is compared with the dashboard model as it is
+		delta, err = DriftDiff(atGrafana, folder, configJSON)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		upToDate = delta == ""
+		if upToDate {
+			cr.SetConditions(v1alpha1.NotDrifted())
+		} else {
+			cr.SetConditions(common.DiffCondition(delta))
+		}
+	}
+
+	cr.SetConditions(common.AvailableCondition(upToDate))
 
 	err = copyToStatusFromMeta(atGrafana, cr, *cr.Spec.ForProvider.OrgID)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	c.log(cr).Debug("Observed dashboard", "uid", common.DefaultString(cr.Status.AtProvider.UID, ""), "version", atGrafana.Meta.Version, "upToDate", upToDate)
 
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
 		// (re)create the resource, or that it has successfully been deleted.
 		ResourceExists: true,
 
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
 		ResourceUpToDate: upToDate,
 
+		Diff: delta,
+
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -230,6 +323,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotDashboard)
 	}
 
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	cr.SetConditions(v1.Creating())
 
 	// orgId as int64
@@ -239,50 +336,212 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	configJson, err := parseConfigJson(spec.ConfigJSON)
+	configJSON, err := c.getConfigJSON(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	configJson, err := parseConfigJson(configJSON)
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errUnmarshalJson)
 	}
 
+	adopted, err := c.tryAdopt(ctx, orgId, cr, configJSON)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedGetDashboard)
+	}
+	if adopted != nil {
+		// the dashboard appeared since it was observed, it is updated on the next reconcile
+		if err := adopt(adopted, cr); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+		c.log(cr).Debug("Adopting existing dashboard instead of creating it", "uid", common.DefaultString(cr.Status.AtProvider.UID, ""))
+		return managed.ExternalCreation{
+			ConnectionDetails: connectionDetails(cr),
+		}, nil
+	}
+
+	folder, err := c.resolveFolder(ctx, orgId, spec.Folder)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	command := &models.SaveDashboardCommand{
 		Dashboard: configJson,
 		IsFolder:  false,
 		Message:   common.DefaultString(spec.Message, ""),
 		Overwrite: common.DefaultBool(spec.Overwrite, false),
 	}
-	setFolderId(spec.Folder, command)
+	setFolderId(folder, command)
 
+	c.log(cr).Debug("Creating dashboard", "orgId", orgId, "folder", common.DefaultString(folder, ""))
 	result, err := c.service.CreateOrUpdateDashboard(orgId, command)
+	c.index.Invalidate(c.host, orgId)
 
+	if isVersionMismatch(err) {
+		uid, _ := configJson["uid"].(string)
+		return managed.ExternalCreation{}, c.versionConflict(orgId, uid, cr)
+	}
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateDashboard)
 	}
 
+	cr.SetConditions(v1alpha1.NoVersionConflict())
+	copyToStatus(result, cr, *spec.OrgID, configJson)
 	cr.Status.AtProvider.ManagedVersion = result.Version
-	cr.Status.AtProvider.ConfigJSON = cr.Spec.ForProvider.ConfigJSON
+	cr.Status.AtProvider.ConfigJSON = configJSON
+	c.log(cr).Debug("Created dashboard", "uid", common.DefaultString(result.UID, ""), "version", common.DefaultInt64(result.Version, 0))
+	c.recordEvent(cr, event.Normal(reasonCreated, fmt.Sprintf(msgCreated, common.DefaultString(result.UID, ""), common.DefaultInt64(result.Version, 0))))
 
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
+// tryAdopt looks up a dashboard that was created outside the provider by the uid of its model, if the resource is
+// annotated to adopt existing dashboards. GetDashboard only finds such dashboards if their title matches the model.
+func (c *external) tryAdopt(ctx context.Context, orgId int64, cr *v1alpha1.Dashboard, configJSON *string) (*models.DashboardFullWithMeta, error) {
+	if !common.ShouldAdopt(cr) {
+		return nil, nil
+	}
+	configJson, err := parseConfigJson(configJSON)
+	if err != nil {
+		return nil, err
+	}
+	uid, _ := configJson["uid"].(string)
+	if uid == "" {
+		return nil, nil
+	}
+	return c.getDashboardByUid(ctx, orgId, uid)
+}
+
+// adopt copies a dashboard found by tryAdopt to the status and claims its current version, as the update that
+// follows the adoption would otherwise be rejected as version mismatch.
+func adopt(atGrafana *models.DashboardFullWithMeta, cr *v1alpha1.Dashboard) error {
+	if err := copyToStatusFromMeta(atGrafana, cr, *cr.Spec.ForProvider.OrgID); err != nil {
+		return err
+	}
+	cr.Status.AtProvider.ManagedVersion = cr.Status.AtProvider.Version
+	return nil
+}
+
+// setFolderId sets the folder resolved by resolveFolder, which is either a numeric ID or an UID.
 func setFolderId(folder *string, command *models.SaveDashboardCommand) {
 	if folder == nil {
 		return
 	}
-	// if folder matches uuid regex, set as FolderUID
-	if _, err := uuid.Parse(*folder); err == nil {
-		command.FolderUID = *folder
+	if folderId, err := strconv.ParseInt(*folder, 10, 64); err == nil {
+		// nolint: staticcheck
+		command.FolderID = folderId
 	} else {
-		// else set as FolderID
-		folderId, err := strconv.ParseInt(*folder, 10, 64)
-		if err == nil {
-			// nolint: staticcheck
-			command.FolderID = folderId
+		command.FolderUID = *folder
+	}
+}
+
+// resolveFolder returns the folder as it can be passed to Grafana. UUIDs and numeric IDs are used as they are,
+// anything else is looked up as the title of a folder. If no folder has that title, the folder is tried as UID, as
+// folders created by Grafana have UIDs that are no UUIDs.
+func (c *external) resolveFolder(ctx context.Context, orgId int64, folder *string) (*string, error) {
+	if folder == nil {
+		return nil, nil
+	}
+	if _, err := uuid.Parse(*folder); err == nil {
+		return folder, nil
+	}
+	if _, err := strconv.ParseInt(*folder, 10, 64); err == nil {
+		return folder, nil
+	}
+	if uid, ok := c.folderUIDs[*folder]; ok {
+		return &uid, nil
+	}
+
+	found, err := c.service.GetFolderByName(ctx, orgId, *folder, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, errResolveFolder, *folder)
+	}
+	if found == nil {
+		found, err = c.service.GetFolderByUid(orgId, *folder)
+		if err != nil {
+			return nil, errors.Wrapf(err, errResolveFolder, *folder)
+		}
+	}
+	if found == nil {
+		return nil, errors.Errorf(errFolderNotFound, *folder)
+	}
+
+	if c.folderUIDs == nil {
+		c.folderUIDs = make(map[string]string)
+	}
+	c.folderUIDs[*folder] = found.UID
+	return &found.UID, nil
+}
+
+// getConfigJSON returns the dashboard model JSON, which is read from the key selected by configMapRef if it is set and
+// taken from configJson otherwise. The UIDs of the DataSources bound by dataSourceRef are set in the model.
+func (c *external) getConfigJSON(ctx context.Context, cr *v1alpha1.Dashboard) (*string, error) {
+	ref := cr.Spec.ForProvider.ConfigMapRef
+	if ref == nil {
+		return bindDataSources(cr.Spec.ForProvider.ConfigJSON, cr.Spec.ForProvider.DataSourceRef)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cm); err != nil {
+		return nil, errors.Wrapf(err, errGetConfigMap, ref.Namespace, ref.Name)
+	}
+	configJSON, ok := cm.Data[ref.Key]
+	if !ok {
+		return nil, errors.Errorf(errConfigMapNoKey, ref.Namespace, ref.Name, ref.Key)
+	}
+	return bindDataSources(&configJSON, cr.Spec.ForProvider.DataSourceRef)
+}
+
+// bindDataSources sets the UID of the bound DataSource as query of each datasource template variable in bindings.
+// The model is returned unchanged if there are no bindings. Because the bound model is also what Observe compares, a
+// DataSource that gets a new UID updates the dashboard.
+func bindDataSources(configJSON *string, bindings []v1alpha1.DataSourceVariableRef) (*string, error) {
+	if configJSON == nil || len(bindings) == 0 {
+		return configJSON, nil
+	}
+	model, err := parseConfigJson(configJSON)
+	if err != nil {
+		return nil, err
+	}
+	variables := dataSourceVariables(model)
+	for _, binding := range bindings {
+		name := common.DefaultString(binding.VariableName, "")
+		if binding.DataSourceUID == nil {
+			return nil, errors.Errorf(errDataSourceUnresolved, name)
 		}
+		variable, ok := variables[name]
+		if !ok {
+			return nil, errors.Errorf(errNoDataSourceVariable, name)
+		}
+		variable["query"] = *binding.DataSourceUID
+	}
+	bound, err := json.Marshal(model)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalJson)
 	}
+	boundJSON := string(bound)
+	return &boundJSON, nil
+}
+
+// dataSourceVariables returns the template variables of type datasource of the model by name.
+func dataSourceVariables(model map[string]interface{}) map[string]map[string]interface{} {
+	variables := map[string]map[string]interface{}{}
+	templating, _ := model["templating"].(map[string]interface{})
+	list, _ := templating["list"].([]interface{})
+	for _, item := range list {
+		variable, ok := item.(map[string]interface{})
+		if !ok || variable["type"] != "datasource" {
+			continue
+		}
+		if name, ok := variable["name"].(string); ok {
+			variables[name] = variable
+		}
+	}
+	return variables
 }
 
 func parseConfigJson(configJson *string) (map[string]interface{}, error) {
@@ -303,6 +562,10 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotDashboard)
 	}
 
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	// orgId as int64
 	spec := cr.Spec.ForProvider
 	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
@@ -310,15 +573,31 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	configJson, err := parseConfigJson(spec.ConfigJSON)
+	configJSON, err := c.getConfigJSON(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	configJson, err := parseConfigJson(configJSON)
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errUnmarshalJson)
 	}
+
+	folder, err := c.resolveFolder(ctx, orgId, spec.Folder)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 	configJson["id"] = cr.Status.AtProvider.DashboardID
 	configJson["uid"] = cr.Status.AtProvider.UID
-	if spec.Overwrite != nil && *spec.Overwrite {
+	if conflictStrategy(spec) == v1alpha1.ConflictStrategyOverwrite {
 		// ensure that the version is set to the current version if we are overwriting, so that Grafana won't reject
 		configJson["version"] = cr.Status.AtProvider.Version
+	} else if cr.Status.AtProvider.ManagedVersion != nil {
+		if modifiedInGrafana(cr, common.DefaultInt64(cr.Status.AtProvider.Version, 0)) {
+			// leave the modifications in place until the user resolves the conflict
+			return managed.ExternalUpdate{}, c.versionConflict(orgId, common.DefaultString(cr.Status.AtProvider.UID, ""), cr)
+		}
+		// claim the version we applied last, so that Grafana rejects the update if the dashboard was modified since
+		configJson["version"] = cr.Status.AtProvider.ManagedVersion
 	}
 	command := &models.SaveDashboardCommand{
 		Dashboard: configJson,
@@ -326,31 +605,100 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		Message:   common.DefaultString(spec.Message, ""),
 		Overwrite: common.DefaultBool(spec.Overwrite, false),
 	}
-	setFolderId(spec.Folder, command)
+	setFolderId(folder, command)
 
+	c.log(cr).Debug("Updating dashboard", "orgId", orgId, "uid", common.DefaultString(cr.Status.AtProvider.UID, ""), "version", configJson["version"])
 	response, err := c.service.CreateOrUpdateDashboard(orgId, command)
+	c.index.Invalidate(c.host, orgId)
 
+	if isVersionMismatch(err) {
+		return managed.ExternalUpdate{}, c.versionConflict(orgId, common.DefaultString(cr.Status.AtProvider.UID, ""), cr)
+	}
 	if err != nil {
 		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateDashboard)
 	}
 
-	copyToStatus(response, cr, *spec.OrgID)
-	cr.Status.AtProvider.ConfigJSON = cr.Spec.ForProvider.ConfigJSON
+	cr.SetConditions(v1alpha1.NoVersionConflict())
+	copyToStatus(response, cr, *spec.OrgID, configJson)
+	cr.Status.AtProvider.ConfigJSON = configJSON
 	cr.Status.AtProvider.ManagedVersion = response.Version
+	c.log(cr).Debug("Updated dashboard", "uid", common.DefaultString(response.UID, ""), "version", common.DefaultInt64(response.Version, 0))
+	c.recordEvent(cr, event.Normal(reasonUpdated, fmt.Sprintf(msgUpdated, common.DefaultString(response.UID, ""), common.DefaultInt64(response.Version, 0))))
 
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
+// conflictStrategy returns how to handle a dashboard that was modified in Grafana, which follows overwrite unless a
+// strategy is set explicitly.
+func conflictStrategy(spec v1alpha1.DashboardParameters) string {
+	if spec.ConflictStrategy != nil {
+		return *spec.ConflictStrategy
+	}
+	if common.DefaultBool(spec.Overwrite, false) {
+		return v1alpha1.ConflictStrategyOverwrite
+	}
+	return v1alpha1.ConflictStrategyReject
+}
+
+// modifiedInGrafana returns true if the given version of the dashboard was saved after the one written last by the
+// provider, e.g. in the UI.
+func modifiedInGrafana(cr *v1alpha1.Dashboard, version int64) bool {
+	managedVersion := cr.Status.AtProvider.ManagedVersion
+	return managedVersion != nil && version > *managedVersion
+}
+
+// log returns the logger of the dashboard.
+func (c *external) log(cr *v1alpha1.Dashboard) logging.Logger {
+	return common.ResourceLogger(c.logger, v1alpha1.DashboardKind, cr)
+}
+
+// recordEvent records an event for the dashboard, if the external client was created with a recorder.
+func (c *external) recordEvent(cr *v1alpha1.Dashboard, e event.Event) {
+	if c.recorder != nil {
+		c.recorder.Event(cr, e)
+	}
+}
+
+// isVersionMismatch returns true if Grafana rejected a dashboard, because it was modified since the version it claims.
+func isVersionMismatch(err error) bool {
+	var precondition *dashboards.PostDashboardPreconditionFailed
+	return errors.As(err, &precondition) && precondition.Payload != nil && precondition.Payload.Status == "version-mismatch"
+}
+
+// versionConflict sets the VersionConflict condition and returns the matching error. The dashboard is observed again
+// to include its current version, but the conflict is reported either way.
+func (c *external) versionConflict(orgId int64, uid string, cr *v1alpha1.Dashboard) error {
+	err := errors.Errorf(errVersionConflict, uid)
+	if uid != "" {
+		current, getErr := c.service.GetDashboardByUid(orgId, uid)
+		if getErr == nil && current != nil && current.Meta != nil {
+			managedVersion := common.DefaultInt64(cr.Status.AtProvider.ManagedVersion, 0)
+			err = errors.Errorf(errVersionConflictVersions, uid, current.Meta.Version, managedVersion)
+		}
+	}
+	cr.SetConditions(v1alpha1.VersionConflict(err.Error()))
+	return err
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 	cr, ok := mg.(*v1alpha1.Dashboard)
 	if !ok {
 		return errors.New(errNotDashboard)
 	}
 
+	if err := common.CheckDeletionProtection(cr); err != nil {
+		return err
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
 	cr.SetConditions(v1.Deleting())
 
 	// orgId as int64
@@ -360,12 +708,20 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.Wrap(err, errOrgIdNotInt)
 	}
 
+	c.log(cr).Debug("Deleting dashboard", "orgId", orgId, "uid", *cr.Status.AtProvider.UID)
 	_, err = c.service.DeleteDashboard(orgId, *cr.Status.AtProvider.UID)
+	c.index.Invalidate(c.host, orgId)
+	if err != nil {
+		return errors.Wrap(err, errFailedDeleteDashboard)
+	}
 
-	return errors.Wrap(err, errFailedDeleteDashboard)
+	c.recordEvent(cr, event.Normal(reasonDeleted, fmt.Sprintf(msgDeleted, *cr.Status.AtProvider.UID)))
+	return nil
 }
 
-func copyToStatus(response *models.PostDashboardOKBody, cr *v1alpha1.Dashboard, orgId string) {
+// copyToStatus copies the response to writing the model to Grafana to the status.
+func copyToStatus(response *models.PostDashboardOKBody, cr *v1alpha1.Dashboard, orgId string, model map[string]interface{}) {
+	setDataSourceUIDs(cr, model)
 	id := fmt.Sprintf("%s:%s", orgId, *response.UID)
 	cr.Status.AtProvider.ID = &id
 	cr.Status.AtProvider.OrgID = &orgId
@@ -381,6 +737,7 @@ func copyToStatusFromMeta(response *models.DashboardFullWithMeta, cr *v1alpha1.D
 	if err != nil {
 		return err
 	}
+	setDataSourceUIDs(cr, response.Dashboard)
 	id := fmt.Sprintf("%s:%s", orgId, dashboard.UID)
 	cr.Status.AtProvider.ID = &id
 	cr.Status.AtProvider.OrgID = &orgId
@@ -392,6 +749,47 @@ func copyToStatusFromMeta(response *models.DashboardFullWithMeta, cr *v1alpha1.D
 	return nil
 }
 
+// setDataSourceUIDs reports the data sources the panels of the model reference in the status. It is only informational.
+func setDataSourceUIDs(cr *v1alpha1.Dashboard, model interface{}) {
+	dashboard, _ := model.(map[string]interface{})
+	uids := map[string]bool{}
+	collectDataSourceUIDs(dashboard["panels"], uids)
+	cr.Status.AtProvider.DataSourceUIDs = nil
+	for uid := range uids {
+		cr.Status.AtProvider.DataSourceUIDs = append(cr.Status.AtProvider.DataSourceUIDs, uid)
+	}
+	sort.Strings(cr.Status.AtProvider.DataSourceUIDs)
+	count := int64(len(uids))
+	cr.Status.AtProvider.DataSourceCount = &count
+}
+
+// collectDataSourceUIDs walks the JSON tree and adds the uid of every datasource object to uids. This covers the data
+// sources of panels, of their targets and of panels nested in rows.
+func collectDataSourceUIDs(node interface{}, uids map[string]bool) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, item := range n {
+			collectDataSourceUIDs(item, uids)
+		}
+	case map[string]interface{}:
+		for key, value := range n {
+			if ds, ok := value.(map[string]interface{}); ok && key == "datasource" {
+				if uid, ok := ds["uid"].(string); ok && isDataSourceUID(uid) {
+					uids[uid] = true
+				}
+				continue
+			}
+			collectDataSourceUIDs(value, uids)
+		}
+	}
+}
+
+// isDataSourceUID returns false for the built-in data sources of Grafana, like "-- Dashboard --", and for template
+// variables, which are not the UID of a data source.
+func isDataSourceUID(uid string) bool {
+	return uid != "" && !strings.HasPrefix(uid, "--") && !strings.Contains(uid, "$")
+}
+
 type dashboardInDashboardFullWithMeta struct {
 	UID     string `json:"uid,omitempty"`
 	ID      int64  `json:"id,omitempty"`
@@ -413,26 +811,168 @@ func dashboardInDashboardFullWithMetaFromJSON(dashboard *models.JSON) (*dashboar
 	}, nil
 }
 
-func isUpToDate(cr *v1alpha1.Dashboard, atGrafana *models.DashboardFullWithMeta) bool {
-	// These fmt statements should be removed in the real implementation.
-	spec := cr.Spec.ForProvider
-	upToDate := true
+// connectionDetails exposes the computed outputs of the dashboard, so that they can be consumed by other resources.
+func connectionDetails(cr *v1alpha1.Dashboard) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{}
+	if cr.Status.AtProvider.UID != nil {
+		details["uid"] = []byte(*cr.Status.AtProvider.UID)
+	}
+	if cr.Status.AtProvider.URL != nil {
+		details["url"] = []byte(*cr.Status.AtProvider.URL)
+	}
+	if cr.Status.AtProvider.DashboardID != nil {
+		details["dashboardId"] = []byte(strconv.FormatInt(*cr.Status.AtProvider.DashboardID, 10))
+	}
+	if cr.Status.AtProvider.Version != nil {
+		details["version"] = []byte(strconv.FormatInt(*cr.Status.AtProvider.Version, 10))
+	}
+	return details
+}
+
+// isUpToDate compares the dashboard in Grafana with the spec and logs the fields that differ.
+func isUpToDate(log logging.Logger, cr *v1alpha1.Dashboard, atGrafana *models.DashboardFullWithMeta, folder *string, configJSON *string) bool {
+	comparison := &common.Comparison{}
 
-	upToDate = upToDate && common.CompareOptional(spec.Folder, atGrafana.Meta.FolderUID, "")
+	comparison.Equal("folder", common.CompareOptional(folder, atGrafana.Meta.FolderUID, ""))
 
-	// identify changes to spec.ConfigJSON
-	upToDate = upToDate && common.CompareOptional(cr.Status.AtProvider.ConfigJSON, *spec.ConfigJSON, "")
+	// identify changes to spec.ConfigJSON or the ConfigMap it is read from
+	comparison.Equal("configJson", configJSONUpToDate(configJSON, cr.Status.AtProvider.ConfigJSON))
 	// identify external changes by comparing the version
-	upToDate = upToDate && common.CompareOptional(cr.Status.AtProvider.Version, atGrafana.Meta.Version, 1)
+	if cr.Status.AtProvider.ManagedVersion != nil {
+		// any version after the one we wrote last was saved by someone else, e.g. in the UI
+		comparison.Equal("version", !modifiedInGrafana(cr, atGrafana.Meta.Version))
+	} else {
+		comparison.Equal("version", common.CompareOptional(cr.Status.AtProvider.Version, atGrafana.Meta.Version, 1))
+	}
+
+	comparison.Log(log)
+	return comparison.UpToDate()
+}
+
+// grafanaManagedFields are the fields of the dashboard model that are assigned by Grafana. They are ignored when the
+// configJson is compared, as Create and Update replace them anyway.
+var grafanaManagedFields = []string{"id", "uid", "version"}
+
+// configJSONUpToDate compares the configJson with the one applied last as JSON, so that changes of whitespace or of the
+// order of keys don't cause updates. If either of them is no valid JSON, they are compared as strings.
+func configJSONUpToDate(configJSON *string, applied *string) bool {
+	desiredJSON, appliedJSON := common.DefaultString(configJSON, ""), common.DefaultString(applied, "")
+	if desiredJSON == appliedJSON {
+		return true
+	}
+	desired, err := parseConfigJson(&desiredJSON)
+	if err != nil {
+		return false
+	}
+	actual, err := parseConfigJson(&appliedJSON)
+	if err != nil {
+		return false
+	}
+	for _, field := range grafanaManagedFields {
+		delete(desired, field)
+		delete(actual, field)
+	}
+	equal, err := common.CompareMap(desired, actual)
+	return err == nil && equal
+}
+
+// knownVersion returns the version the dashboard is expected to have in Grafana, which is the version returned by the
+// last write of the provider and the version observed last for dashboards that were not written yet.
+func knownVersion(cr *v1alpha1.Dashboard) int64 {
+	if cr.Status.AtProvider.ManagedVersion != nil {
+		return *cr.Status.AtProvider.ManagedVersion
+	}
+	return common.DefaultInt64(cr.Status.AtProvider.Version, 1)
+}
+
+// dashboardState holds the fields of a dashboard that are compared by isUpToDate. The configJson is compared with the
+// one last applied, as Grafana adds fields to the dashboard model, and the version detects changes made in Grafana.
+type dashboardState struct {
+	FolderUID  string
+	ConfigJSON string
+	Version    int64
+}
+
+// Diff describes how the dashboard in Grafana differs from the spec, in the format of cmp.Diff.
+func Diff(cr *v1alpha1.Dashboard, atGrafana *models.DashboardFullWithMeta, folder *string, configJSON *string) string {
+	desired := dashboardState{
+		FolderUID:  common.DefaultString(folder, ""),
+		ConfigJSON: common.DefaultString(configJSON, ""),
+		Version:    knownVersion(cr),
+	}
+	actual := dashboardState{
+		FolderUID:  atGrafana.Meta.FolderUID,
+		ConfigJSON: common.DefaultString(cr.Status.AtProvider.ConfigJSON, ""),
+		Version:    atGrafana.Meta.Version,
+	}
+	return cmp.Diff(desired, actual)
+}
+
+// updatesAllowed returns false if the management policies of the dashboard don't allow the provider to update it.
+// Without management policies, all actions are allowed.
+func updatesAllowed(cr *v1alpha1.Dashboard) bool {
+	policies := cr.GetManagementPolicies()
+	if len(policies) == 0 {
+		return true
+	}
+	for _, policy := range policies {
+		if policy == v1.ManagementActionAll || policy == v1.ManagementActionUpdate {
+			return true
+		}
+	}
+	return false
+}
 
-	return upToDate
+// dashboardModel holds the fields of a dashboard that are compared by DriftDiff.
+type dashboardModel struct {
+	FolderUID  string
+	ConfigJSON map[string]interface{}
 }
 
-func (c *external) GetDashboard(orgId int64, cr *v1alpha1.Dashboard) (*models.DashboardFullWithMeta, error) {
+// DriftDiff describes how the dashboard in Grafana differs from the spec, in the format of cmp.Diff, for dashboards the
+// provider may not update. As no configJson was applied, it is compared with the dashboard model in Grafana as JSON,
+// ignoring the fields assigned by Grafana. An empty string means the dashboard has not drifted.
+func DriftDiff(atGrafana *models.DashboardFullWithMeta, folder *string, configJSON *string) (string, error) {
+	desired, err := parseConfigJson(configJSON)
+	if err != nil {
+		return "", err
+	}
+	// the model in Grafana is parsed like the configJson, so that numbers are compared with the same types
+	model, err := json.Marshal(atGrafana.Dashboard)
+	if err != nil {
+		return "", errors.Wrap(err, errInvalidDashboardResponse)
+	}
+	modelJSON := string(model)
+	actual, err := parseConfigJson(&modelJSON)
+	if err != nil {
+		return "", errors.Wrap(err, errInvalidDashboardResponse)
+	}
+	for _, field := range grafanaManagedFields {
+		delete(desired, field)
+		delete(actual, field)
+	}
+	return cmp.Diff(
+		dashboardModel{FolderUID: common.DefaultString(folder, ""), ConfigJSON: desired},
+		dashboardModel{FolderUID: atGrafana.Meta.FolderUID, ConfigJSON: actual},
+	), nil
+}
+
+// GetDashboard looks up the dashboard by the UID in status. Without one, the
+// external-name annotation is tried as UID to allow importing existing
+// dashboards, before falling back to the title in configJson within the resolved
+// folder. The external-name defaults to the name of the resource, so a miss
+// there is not conclusive.
+func (c *external) GetDashboard(ctx context.Context, orgId int64, cr *v1alpha1.Dashboard, folder *string, configJSON *string) (*models.DashboardFullWithMeta, error) {
 	if cr.Status.AtProvider.UID != nil {
-		return c.service.GetDashboardByUid(orgId, *cr.Status.AtProvider.UID)
+		return c.getDashboardByUid(ctx, orgId, *cr.Status.AtProvider.UID)
 	} else {
-		configJson, err := parseConfigJson(cr.Spec.ForProvider.ConfigJSON)
+		if externalName := meta.GetExternalName(cr); externalName != "" {
+			dashboard, err := c.getDashboardByUid(ctx, orgId, externalName)
+			if err != nil || dashboard != nil {
+				return dashboard, err
+			}
+		}
+		configJson, err := parseConfigJson(configJSON)
 		if err != nil {
 			return nil, err
 		}
@@ -440,6 +980,44 @@ func (c *external) GetDashboard(orgId int64, cr *v1alpha1.Dashboard) (*models.Da
 		if !found {
 			return nil, errors.New(errNoTitle)
 		}
-		return c.service.GetDashboardByName(orgId, title.(string), cr.Spec.ForProvider.Folder)
+		return c.getDashboardByName(ctx, orgId, title.(string), folder)
+	}
+}
+
+// dashboards returns the indexed dashboards of the organization, or nil if the index is disabled or the dashboards
+// can't be listed. In that case, the dashboards are looked up one by one.
+func (c *external) dashboards(ctx context.Context, orgId int64) *common.DashboardList {
+	list, err := c.index.Get(c.host, orgId, func() ([]*models.Hit, error) {
+		return c.service.SearchDashboards(ctx, orgId)
+	})
+	if err != nil {
+		c.logger.Debug("Cannot list dashboards, looking them up one by one", "error", err)
+		return nil
+	}
+	return list
+}
+
+// getDashboardByUid returns nil without asking Grafana if the index does not contain the dashboard.
+func (c *external) getDashboardByUid(ctx context.Context, orgId int64, uid string) (*models.DashboardFullWithMeta, error) {
+	if list := c.dashboards(ctx, orgId); list != nil && !list.Contains(uid) {
+		return nil, nil
+	}
+	return c.service.GetDashboardByUid(orgId, uid)
+}
+
+// getDashboardByName looks up the UID of the dashboard with the title in the index instead of searching Grafana.
+func (c *external) getDashboardByName(ctx context.Context, orgId int64, title string, folder *string) (*models.DashboardFullWithMeta, error) {
+	list := c.dashboards(ctx, orgId)
+	if list == nil {
+		return c.service.GetDashboardByName(ctx, orgId, title, folder)
+	}
+	uids := list.UIDsByTitle(title, folder)
+	switch len(uids) {
+	case 0:
+		return nil, nil
+	case 1:
+		return c.service.GetDashboardByUid(orgId, uids[0])
+	default:
+		return nil, common.ErrAmbiguousDashboardTitle
 	}
 }
@@ -19,10 +19,25 @@ package dashboard
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
 	"github.com/argannor/provider-grafana/internal/controller/common"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/grafana/grafana-openapi-client-go/client/dashboards"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -37,10 +52,105 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func dashboard() *v1alpha1.Dashboard {
+	orgId := "1"
+	configJson := `{"title":"test"}`
+	uid := "abc"
+	var version int64 = 1
+	return &v1alpha1.Dashboard{
+		Spec: v1alpha1.DashboardSpec{
+			ForProvider: v1alpha1.DashboardParameters{
+				OrgID:      &orgId,
+				ConfigJSON: &configJson,
+			},
+		},
+		Status: v1alpha1.DashboardStatus{
+			AtProvider: v1alpha1.DashboardObservation{
+				ConfigJSON: &configJson,
+				UID:        &uid,
+				Version:    &version,
+			},
+		},
+	}
+}
+
+func importedDashboard(externalName string) *v1alpha1.Dashboard {
+	cr := dashboard()
+	cr.Status.AtProvider = v1alpha1.DashboardObservation{}
+	meta.SetExternalName(cr, externalName)
+	return cr
+}
+
+func inFolder(cr *v1alpha1.Dashboard, folder string) *v1alpha1.Dashboard {
+	cr.Spec.ForProvider.Folder = &folder
+	return cr
+}
+
+func managedAt(cr *v1alpha1.Dashboard, version int64) *v1alpha1.Dashboard {
+	cr.Status.AtProvider.ManagedVersion = &version
+	return cr
+}
+
+func observeOnly(cr *v1alpha1.Dashboard) *v1alpha1.Dashboard {
+	cr.SetManagementPolicies(xpv1.ManagementPolicies{xpv1.ManagementActionObserve})
+	// the provider never wrote the dashboard
+	cr.Status.AtProvider.ConfigJSON = nil
+	return cr
+}
+
+func fromConfigMap(cr *v1alpha1.Dashboard) *v1alpha1.Dashboard {
+	cr.Spec.ForProvider.ConfigJSON = nil
+	cr.Spec.ForProvider.ConfigMapRef = &v1alpha1.ConfigMapKeySelector{Name: "dashboards", Namespace: "grafana", Key: "test.json"}
+	return cr
+}
+
+func configMapClient(configJson string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Namespace != "grafana" || key.Name != "dashboards" {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+			}
+			obj.(*corev1.ConfigMap).Data = map[string]string{"test.json": configJson}
+			return nil
+		},
+	}
+}
+
+func grafanaDashboard(version int64) *models.DashboardFullWithMeta {
+	return &models.DashboardFullWithMeta{
+		Dashboard: map[string]interface{}{
+			"id":      float64(2),
+			"title":   "test",
+			"uid":     "abc",
+			"version": float64(version),
+		},
+		Meta: &models.DashboardMeta{
+			URL:     "/d/abc/test",
+			Version: version,
+		},
+	}
+}
+
+func dashboardConnectionDetails(version string) managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		"dashboardId": []byte("2"),
+		"uid":         []byte("abc"),
+		"url":         []byte("/d/abc/test"),
+		"version":     []byte(version),
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		service common.GrafanaAPI
 		logger  logging.Logger
+		kube    client.Client
 	}
 
 	type args struct {
@@ -59,19 +169,1358 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotDashboard": {
+			reason: "An error should be returned if the managed resource is not a Dashboard",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg:  &v1alpha1.Folder{},
+			},
+			want: want{
+				err: errors.New(errNotDashboard),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the Dashboard cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dashboard(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetDashboard),
+			},
+		},
+		"NotFound": {
+			reason: "The Dashboard should be reported as missing if Grafana does not know it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dashboard(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The Dashboard should be reported as up to date if neither the spec nor the version changed",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dashboard(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: dashboardConnectionDetails("1"),
+				},
+			},
+		},
+		"ChangedOutsideOfProvider": {
+			reason: "The Dashboard should be reported as outdated if its version was changed in Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(2), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dashboard(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: dashboardConnectionDetails("2"),
+				},
+			},
+		},
+		"ManagedVersionCurrent": {
+			reason: "The Dashboard should be reported as up to date if Grafana has the version written last by the provider",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(2), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  managedAt(dashboard(), 2),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: dashboardConnectionDetails("2"),
+				},
+			},
+		},
+		"EditedAfterManagedVersion": {
+			reason: "The Dashboard should be reported as outdated as long as Grafana has a version after the one written last by the provider, even if that version was observed before",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(3), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg: func() resource.Managed {
+					cr := managedAt(dashboard(), 2)
+					var observed int64 = 3
+					cr.Status.AtProvider.Version = &observed
+					return cr
+				}(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: dashboardConnectionDetails("3"),
+				},
+			},
+		},
+		"ImportedByExternalName": {
+			reason: "A Dashboard without UID in status should be looked up by its external-name and updated to the desired state",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  importedDashboard("abc"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: dashboardConnectionDetails("1"),
+				},
+			},
+		},
+		"ExternalNameNotFound": {
+			reason: "A Dashboard should be looked up by its title if no dashboard has the external-name as UID",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "example").Return(nil, nil)
+				m.On("GetDashboardByName", mock.Anything, int64(1), "test", (*string)(nil)).Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  importedDashboard("example"),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"FolderByTitle": {
+			reason: "A folder given by title should be resolved to its UID before comparing it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByName", mock.Anything, int64(1), "Team", (*string)(nil)).Return(&models.Folder{UID: "team-uid", Title: "Team"}, nil)
+				d := grafanaDashboard(1)
+				d.Meta.FolderUID = "team-uid"
+				m.On("GetDashboardByUid", int64(1), "abc").Return(d, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  inFolder(dashboard(), "Team"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: dashboardConnectionDetails("1"),
+				},
+			},
+		},
+		"FolderByGrafanaUID": {
+			reason: "A folder that is neither a title nor an UUID should be tried as UID, as Grafana generates shorter UIDs",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByName", mock.Anything, int64(1), "fdk2x9a", (*string)(nil)).Return(nil, nil)
+				m.On("GetFolderByUid", int64(1), "fdk2x9a").Return(&models.Folder{UID: "fdk2x9a", Title: "Team"}, nil)
+				d := grafanaDashboard(1)
+				d.Meta.FolderUID = "fdk2x9a"
+				m.On("GetDashboardByUid", int64(1), "abc").Return(d, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  inFolder(dashboard(), "fdk2x9a"),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: dashboardConnectionDetails("1"),
+				},
+			},
+		},
+		"FolderTitleNotFound": {
+			reason: "An error should be returned if no folder has the given title",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByName", mock.Anything, int64(1), "Team", (*string)(nil)).Return(nil, nil)
+				m.On("GetFolderByUid", int64(1), "Team").Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  inFolder(dashboard(), "Team"),
+			},
+			want: want{
+				err: errors.Errorf(errFolderNotFound, "Team"),
+			},
+		},
+		"FolderTitleAmbiguous": {
+			reason: "An error should be returned if multiple folders have the given title",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByName", mock.Anything, int64(1), "Team", (*string)(nil)).Return(nil, common.ErrAmbiguousFolderTitle)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  inFolder(dashboard(), "Team"),
+			},
+			want: want{
+				err: errors.Wrapf(common.ErrAmbiguousFolderTitle, errResolveFolder, "Team"),
+			},
+		},
+		"ConfigMapUpToDate": {
+			reason: "The dashboard should be reported as up to date if the ConfigMap holds the applied configJson",
+			fields: fields{
+				service: func() common.GrafanaAPI {
+					m := &common.MockGrafanaAPI{}
+					m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+					return m
+				}(),
+				kube: configMapClient(`{"title":"test"}`),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  fromConfigMap(dashboard()),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: dashboardConnectionDetails("1"),
+				},
+			},
+		},
+		"ConfigMapChanged": {
+			reason: "The dashboard should be reported as outdated if the content of the ConfigMap changed",
+			fields: fields{
+				service: func() common.GrafanaAPI {
+					m := &common.MockGrafanaAPI{}
+					m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+					return m
+				}(),
+				kube: configMapClient(`{"title":"changed"}`),
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  fromConfigMap(dashboard()),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: dashboardConnectionDetails("1"),
+				},
+			},
+		},
+		"ConfigMapKeyMissing": {
+			reason: "An error should be returned if the ConfigMap does not contain the selected key",
+			fields: fields{
+				service: &common.MockGrafanaAPI{},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  fromConfigMap(dashboard()),
+			},
+			want: want{
+				err: errors.Errorf(errConfigMapNoKey, "grafana", "dashboards", "test.json"),
+			},
+		},
+		"ConfigMapGetFailed": {
+			reason: "An error should be returned if the ConfigMap cannot be read",
+			fields: fields{
+				service: &common.MockGrafanaAPI{},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				},
+			},
+			args: args{
+				ctx: context.Background(),
+				mg:  fromConfigMap(dashboard()),
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errGetConfigMap, "grafana", "dashboards"),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{service: tc.fields.service, logger: tc.fields.logger}
+			e := external{service: tc.fields.service, logger: tc.fields.logger, kube: tc.fields.kube}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if got.ResourceExists && (got.Diff == "") != got.ResourceUpToDate {
+				t.Errorf("\n%s\ne.Observe(...): want a diff if and only if the resource is outdated, got %q\n", tc.reason, got.Diff)
+			}
+		})
+	}
+}
+
+func TestGetDashboardFromIndex(t *testing.T) {
+	type want struct {
+		dashboard *models.DashboardFullWithMeta
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		cr      *v1alpha1.Dashboard
+		hits    []*models.Hit
+		service func() *common.MockGrafanaAPI
+		want    want
+	}{
+		"ByUIDInStatus": {
+			reason: "A dashboard in the index should be requested by the UID in status",
+			cr:     dashboard(),
+			hits:   []*models.Hit{{UID: "abc", Title: "test"}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+				return m
+			},
+			want: want{dashboard: grafanaDashboard(1)},
+		},
+		"MissingUIDInStatus": {
+			reason:  "A dashboard that is not in the index should not be requested",
+			cr:      dashboard(),
+			service: func() *common.MockGrafanaAPI { return &common.MockGrafanaAPI{} },
+		},
+		"ByTitle": {
+			reason: "The UID of a dashboard without UID in status should be looked up by its title in the index",
+			cr:     importedDashboard("test"),
+			hits:   []*models.Hit{{UID: "abc", Title: "test"}, {UID: "other", Title: "test", FolderUID: "team"}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+				return m
+			},
+			want: want{dashboard: grafanaDashboard(1)},
+		},
+		"AmbiguousTitle": {
+			reason:  "An error should be returned if the index has more than one dashboard with the title in the folder",
+			cr:      importedDashboard("test"),
+			hits:    []*models.Hit{{UID: "abc", Title: "test"}, {UID: "other", Title: "test"}},
+			service: func() *common.MockGrafanaAPI { return &common.MockGrafanaAPI{} },
+			want:    want{err: common.ErrAmbiguousDashboardTitle},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			m.On("SearchDashboards", mock.Anything, int64(1)).Return(tc.hits, nil).Once()
+			e := external{service: m, index: common.NewDashboardIndex(time.Minute), host: "grafana:3000"}
+			got, err := e.GetDashboard(context.Background(), 1, tc.cr, nil, strRef(`{"title":"test"}`))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.GetDashboard(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.dashboard, got); diff != "" {
+				t.Errorf("\n%s\ne.GetDashboard(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGetDashboardWithoutIndex(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("SearchDashboards", mock.Anything, int64(1)).Return(nil, errBoom)
+	m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+
+	e := external{service: m, logger: logging.NewNopLogger(), index: common.NewDashboardIndex(time.Minute), host: "grafana:3000"}
+	got, err := e.GetDashboard(context.Background(), 1, dashboard(), nil, strRef(`{"title":"test"}`))
+	if err != nil {
+		t.Fatalf("e.GetDashboard(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(grafanaDashboard(1), got); diff != "" {
+		t.Errorf("e.GetDashboard(...): dashboards should be looked up one by one if they can't be listed: -want, +got:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateInvalidatesIndex(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("SearchDashboards", mock.Anything, int64(1)).Return([]*models.Hit{}, nil).Once()
+	var id int64 = 2
+	uid, url := "abc", "/d/abc/test"
+	var version int64 = 1
+	m.On("CreateOrUpdateDashboard", int64(1), mock.Anything).Return(&models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version}, nil)
+
+	cr := dashboard()
+	cr.Status.AtProvider = v1alpha1.DashboardObservation{}
+	e := external{service: m, index: common.NewDashboardIndex(time.Minute), host: "grafana:3000"}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil || o.ResourceExists {
+		t.Fatalf("e.Observe(...): want missing dashboard, got %v, %v", o, err)
+	}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+
+	// the created dashboard must be found by the next reconcile
+	m.On("SearchDashboards", mock.Anything, int64(1)).Return([]*models.Hit{{UID: "abc", Title: "test"}}, nil).Once()
+	m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+	o, err = e.Observe(context.Background(), cr)
+	if err != nil || !o.ResourceExists {
+		t.Fatalf("e.Observe(...): want existing dashboard, got %v, %v", o, err)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestFolderTitleIsResolvedOncePerReconcile(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetFolderByName", mock.Anything, int64(1), "Team", (*string)(nil)).Return(&models.Folder{UID: "team-uid", Title: "Team"}, nil).Once()
+	m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+	var id int64 = 2
+	uid, url := "abc", "/d/abc/test"
+	var version int64 = 2
+	m.On("CreateOrUpdateDashboard", int64(1), mock.MatchedBy(func(command *models.SaveDashboardCommand) bool {
+		return command.FolderUID == "team-uid"
+	})).Return(&models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version, FolderUID: "team-uid"}, nil)
+
+	cr := inFolder(dashboard(), "Team")
+	e := external{service: m}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error %v", err)
+	}
+	if o.ResourceUpToDate {
+		t.Errorf("e.Observe(...): expected the dashboard to be outdated, as it is not in the folder yet")
+	}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error %v", err)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateFromConfigMap(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	var id int64 = 2
+	uid, url := "abc", "/d/abc/test"
+	var version int64 = 1
+	m.On("CreateOrUpdateDashboard", int64(1), mock.MatchedBy(func(command *models.SaveDashboardCommand) bool {
+		return command.Dashboard.(map[string]interface{})["title"] == "from-configmap"
+	})).Return(&models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version}, nil)
+
+	cr := fromConfigMap(dashboard())
+	cr.Status.AtProvider = v1alpha1.DashboardObservation{}
+	e := external{service: m, kube: configMapClient(`{"title":"from-configmap"}`)}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(`{"title":"from-configmap"}`, common.DefaultString(cr.Status.AtProvider.ConfigJSON, "")); diff != "" {
+		t.Errorf("e.Create(...): -want configJson in status, +got configJson in status:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateBindsDataSources(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	var id int64 = 2
+	uid, url := "abc", "/d/abc/test"
+	var version int64 = 1
+	m.On("CreateOrUpdateDashboard", int64(1), mock.MatchedBy(func(command *models.SaveDashboardCommand) bool {
+		variables := dataSourceVariables(command.Dashboard.(map[string]interface{}))
+		return variables["metrics"]["query"] == "prometheus-uid"
+	})).Return(&models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version}, nil)
+
+	cr := dashboard()
+	configJson := `{"title":"test","templating":{"list":[{"name":"metrics","type":"datasource","query":"prometheus"}]}}`
+	cr.Spec.ForProvider.ConfigJSON = &configJson
+	name, dataSourceUID := "metrics", "prometheus-uid"
+	cr.Spec.ForProvider.DataSourceRef = []v1alpha1.DataSourceVariableRef{{VariableName: &name, DataSourceUID: &dataSourceUID}}
+	cr.Status.AtProvider = v1alpha1.DashboardObservation{}
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestBindDataSources(t *testing.T) {
+	configJson := `{"title":"test","templating":{"list":[{"name":"metrics","type":"datasource","query":"prometheus"},{"name":"env","type":"custom","query":"dev,prod"}]}}`
+	metrics, env, dataSourceUID := "metrics", "env", "prometheus-uid"
+	type want struct {
+		configJson string
+		err        error
+	}
+	cases := map[string]struct {
+		reason   string
+		bindings []v1alpha1.DataSourceVariableRef
+		want     want
+	}{
+		"NoBindings": {
+			reason: "The model should be returned unchanged if there are no bindings",
+			want:   want{configJson: configJson},
+		},
+		"Bound": {
+			reason:   "The UID of the DataSource should be set as query of the variable",
+			bindings: []v1alpha1.DataSourceVariableRef{{VariableName: &metrics, DataSourceUID: &dataSourceUID}},
+			want: want{
+				configJson: `{"title":"test","templating":{"list":[{"name":"metrics","type":"datasource","query":"prometheus-uid"},{"name":"env","type":"custom","query":"dev,prod"}]}}`,
+			},
+		},
+		"NotADataSourceVariable": {
+			reason:   "Variables of other types should not be bound",
+			bindings: []v1alpha1.DataSourceVariableRef{{VariableName: &env, DataSourceUID: &dataSourceUID}},
+			want:     want{err: errors.Errorf(errNoDataSourceVariable, env)},
+		},
+		"Unresolved": {
+			reason:   "A binding without the UID of the DataSource should be an error",
+			bindings: []v1alpha1.DataSourceVariableRef{{VariableName: &metrics}},
+			want:     want{err: errors.Errorf(errDataSourceUnresolved, metrics)},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := bindDataSources(&configJson, tc.bindings)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nbindDataSources(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if !configJSONUpToDate(&tc.want.configJson, got) {
+				t.Errorf("\n%s\nbindDataSources(...): want %s, got %s", tc.reason, tc.want.configJson, common.DefaultString(got, ""))
+			}
+		})
+	}
+}
+
+func preconditionFailed(status string) error {
+	return &dashboards.PostDashboardPreconditionFailed{Payload: &models.ErrorResponseBody{Status: status}}
+}
+
+func TestUpdateVersionConflict(t *testing.T) {
+	type want struct {
+		err       error
+		condition corev1.ConditionStatus
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service func() *common.MockGrafanaAPI
+		want    want
+	}{
+		"ReportsCurrentVersion": {
+			reason: "A version mismatch should be reported with the version at Grafana and the version applied last",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateOrUpdateDashboard", int64(1), mock.Anything).Return(nil, preconditionFailed("version-mismatch"))
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(3), nil)
+				return m
+			},
+			want: want{
+				err:       errors.Errorf(errVersionConflictVersions, "abc", 3, 1),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"DashboardGone": {
+			reason: "A version mismatch should be reported even if the dashboard cannot be observed again",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateOrUpdateDashboard", int64(1), mock.Anything).Return(nil, preconditionFailed("version-mismatch"))
+				m.On("GetDashboardByUid", int64(1), "abc").Return(nil, errBoom)
+				return m
+			},
+			want: want{
+				err:       errors.Errorf(errVersionConflict, "abc"),
+				condition: corev1.ConditionTrue,
+			},
+		},
+		"OtherPreconditionFailed": {
+			reason: "Other failed preconditions should not be reported as version conflict",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateOrUpdateDashboard", int64(1), mock.Anything).Return(nil, preconditionFailed("name-exists"))
+				return m
+			},
+			want: want{
+				err:       errors.Wrap(preconditionFailed("name-exists"), errFailedUpdateDashboard),
+				condition: corev1.ConditionUnknown,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := dashboard()
+			var managedVersion int64 = 1
+			cr.Status.AtProvider.ManagedVersion = &managedVersion
+			m := tc.service()
+			e := external{service: m}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.GetCondition(v1alpha1.TypeVersionConflict).Status); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want condition status, +got condition status:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateClaimsManagedVersion(t *testing.T) {
+	var id int64 = 2
+	uid, url := "abc", "/d/abc/test"
+	var version int64 = 2
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateOrUpdateDashboard", int64(1), mock.MatchedBy(func(command *models.SaveDashboardCommand) bool {
+		claimed, ok := command.Dashboard.(map[string]interface{})["version"].(*int64)
+		return !command.Overwrite && ok && *claimed == 1
+	})).Return(&models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version}, nil)
+
+	cr := dashboard()
+	var managedVersion int64 = 1
+	cr.Status.AtProvider.ManagedVersion = &managedVersion
+	e := external{service: m}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(corev1.ConditionFalse, cr.GetCondition(v1alpha1.TypeVersionConflict).Status); diff != "" {
+		t.Errorf("e.Update(...): -want condition status, +got condition status:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(int64(2), common.DefaultInt64(cr.Status.AtProvider.ManagedVersion, 0)); diff != "" {
+		t.Errorf("e.Update(...): -want managed version, +got managed version:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+// recorder records the events of a test.
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestObserveRecordsModificationInGrafana(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		version int64
+		want    []event.Reason
+	}{
+		"Modified": {
+			reason:  "An event should be recorded if Grafana has a version after the one written last by the provider",
+			version: 3,
+			want:    []event.Reason{reasonModifiedInGrafana},
+		},
+		"NotModified": {
+			reason:  "No event should be recorded if Grafana has the version written last by the provider",
+			version: 2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(tc.version), nil)
+
+			r := &recorder{}
+			e := external{service: m, recorder: r}
+			if _, err := e.Observe(context.Background(), managedAt(dashboard(), 2)); err != nil {
+				t.Fatalf("e.Observe(...): unexpected error %v", err)
+			}
+			var got []event.Reason
+			for _, e := range r.events {
+				got = append(got, e.Reason)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want event reasons, +got event reasons:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveReportsDrift(t *testing.T) {
+	type want struct {
+		upToDate  bool
+		condition xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		reason    string
+		cr        *v1alpha1.Dashboard
+		dashboard func() *models.DashboardFullWithMeta
+		want      want
+	}{
+		"NotDrifted": {
+			reason:    "A dashboard that matches the spec apart from the fields assigned by Grafana should not have drifted",
+			cr:        observeOnly(dashboard()),
+			dashboard: func() *models.DashboardFullWithMeta { return grafanaDashboard(5) },
+			want:      want{upToDate: true, condition: v1alpha1.NotDrifted()},
+		},
+		"EditedInGrafana": {
+			reason: "A dashboard that was edited in Grafana should have drifted",
+			cr:     observeOnly(dashboard()),
+			dashboard: func() *models.DashboardFullWithMeta {
+				d := grafanaDashboard(5)
+				d.Dashboard.(map[string]interface{})["title"] = "edited"
+				return d
+			},
+			want: want{condition: v1alpha1.Drifted("")},
+		},
+		"MovedInGrafana": {
+			reason: "A dashboard that was moved to another folder in Grafana should have drifted",
+			cr:     observeOnly(dashboard()),
+			dashboard: func() *models.DashboardFullWithMeta {
+				d := grafanaDashboard(5)
+				d.Meta.FolderUID = "team"
+				return d
+			},
+			want: want{condition: v1alpha1.Drifted("")},
+		},
+		"UpdatesAllowed": {
+			reason: "A dashboard the provider may update should not report drift",
+			cr:     dashboard(),
+			dashboard: func() *models.DashboardFullWithMeta {
+				d := grafanaDashboard(1)
+				d.Dashboard.(map[string]interface{})["title"] = "edited"
+				return d
+			},
+			want: want{upToDate: true, condition: xpv1.Condition{Type: v1alpha1.TypeDrifted, Status: corev1.ConditionUnknown}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetDashboardByUid", int64(1), "abc").Return(tc.dashboard(), nil)
+
+			e := external{service: m}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("e.Observe(...): unexpected error %v", err)
+			}
+			if got.ResourceUpToDate != tc.want.upToDate {
+				t.Errorf("\n%s\ne.Observe(...): want ResourceUpToDate %t, got %t\n", tc.reason, tc.want.upToDate, got.ResourceUpToDate)
+			}
+			if !tc.want.upToDate && got.Diff == "" {
+				t.Errorf("\n%s\ne.Observe(...): want a diff, got none\n", tc.reason)
+			}
+			want := tc.want.condition
+			if want.Status == corev1.ConditionTrue {
+				want.Message = common.DiffMessage(got.Diff)
+			}
+			if diff := cmp.Diff(want, tc.cr.GetCondition(v1alpha1.TypeDrifted)); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
 		})
 	}
 }
+
+func TestUpdateConflictStrategy(t *testing.T) {
+	var id int64 = 2
+	uid, url := "abc", "/d/abc/test"
+	var version int64 = 4
+
+	cases := map[string]struct {
+		reason    string
+		strategy  *string
+		overwrite *bool
+		claimed   int64
+		err       error
+	}{
+		"Overwrite": {
+			reason:   "The modifications in Grafana should be overwritten by claiming the version observed last",
+			strategy: strRef(v1alpha1.ConflictStrategyOverwrite),
+			claimed:  3,
+		},
+		"OverwriteByDefault": {
+			reason:    "The modifications in Grafana should be overwritten if overwrite is set and no strategy is",
+			overwrite: func() *bool { b := true; return &b }(),
+			claimed:   3,
+		},
+		"Reject": {
+			reason:   "The dashboard should not be written if it was modified in Grafana and the strategy is reject",
+			strategy: strRef(v1alpha1.ConflictStrategyReject),
+			err:      errors.Errorf(errVersionConflictVersions, "abc", 3, 2),
+		},
+		"RejectByDefault": {
+			reason: "The dashboard should not be written if it was modified in Grafana and neither strategy nor overwrite is set",
+			err:    errors.Errorf(errVersionConflictVersions, "abc", 3, 2),
+		},
+		"RejectDespiteOverwrite": {
+			reason:    "The strategy should take precedence over overwrite",
+			strategy:  strRef(v1alpha1.ConflictStrategyReject),
+			overwrite: func() *bool { b := true; return &b }(),
+			err:       errors.Errorf(errVersionConflictVersions, "abc", 3, 2),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			if tc.err == nil {
+				m.On("CreateOrUpdateDashboard", int64(1), mock.MatchedBy(func(command *models.SaveDashboardCommand) bool {
+					claimed, ok := command.Dashboard.(map[string]interface{})["version"].(*int64)
+					return ok && *claimed == tc.claimed
+				})).Return(&models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version}, nil)
+			} else {
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(3), nil)
+			}
+
+			// the dashboard was observed at version 3, after the provider wrote version 2
+			cr := managedAt(dashboard(), 2)
+			var observed int64 = 3
+			cr.Status.AtProvider.Version = &observed
+			cr.Spec.ForProvider.ConflictStrategy = tc.strategy
+			cr.Spec.ForProvider.Overwrite = tc.overwrite
+
+			e := external{service: m}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			wantCondition := corev1.ConditionFalse
+			if tc.err != nil {
+				wantCondition = corev1.ConditionTrue
+			}
+			if diff := cmp.Diff(wantCondition, cr.GetCondition(v1alpha1.TypeVersionConflict).Status); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want condition status, +got condition status:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+// appliedDashboard returns a dashboard that was written by the provider with the given configJson.
+func appliedDashboard(configJSON string) *v1alpha1.Dashboard {
+	cr := dashboard()
+	cr.Status.AtProvider.ConfigJSON = &configJSON
+	return cr
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		cr         *v1alpha1.Dashboard
+		configJSON string
+		want       bool
+	}{
+		"ConfigJSONMatches": {
+			reason:     "A dashboard should be up to date if the configJson was applied and the version is unchanged",
+			cr:         dashboard(),
+			configJSON: `{"title":"test"}`,
+			want:       true,
+		},
+		"ConfigJSONDiffers": {
+			reason:     "A dashboard should be outdated if the configJson differs from the one applied last",
+			cr:         dashboard(),
+			configJSON: `{"title":"other"}`,
+			want:       false,
+		},
+		"WhitespaceDiffers": {
+			reason:     "A dashboard should be up to date if the configJson differs from the one applied last only in whitespace",
+			cr:         appliedDashboard(`{"title":"test","panels":[{"id":1}]}`),
+			configJSON: "{\n  \"title\": \"test\",\n  \"panels\": [ { \"id\": 1 } ]\n}",
+			want:       true,
+		},
+		"KeysReordered": {
+			reason:     "A dashboard should be up to date if the configJson differs from the one applied last only in the order of keys",
+			cr:         appliedDashboard(`{"title":"test","tags":["a"],"time":{"from":"now-6h","to":"now"}}`),
+			configJSON: `{"time":{"to":"now","from":"now-6h"},"tags":["a"],"title":"test"}`,
+			want:       true,
+		},
+		"GrafanaManagedFields": {
+			reason:     "The fields assigned by Grafana should be ignored, as they are replaced when the dashboard is written",
+			cr:         appliedDashboard(`{"title":"test","id":12,"uid":"abc","version":3}`),
+			configJSON: `{"title":"test"}`,
+			want:       true,
+		},
+		"NestedValueDiffers": {
+			reason:     "A dashboard should be outdated if a nested value differs from the one applied last",
+			cr:         appliedDashboard(`{"title":"test","panels":[{"id":1,"type":"graph"}]}`),
+			configJSON: `{"title":"test","panels":[{"id":1,"type":"table"}]}`,
+			want:       false,
+		},
+		"FieldRemoved": {
+			reason:     "A dashboard should be outdated if a field that is not assigned by Grafana was removed from the configJson",
+			cr:         appliedDashboard(`{"title":"test","tags":["a"]}`),
+			configJSON: `{"title":"test"}`,
+			want:       false,
+		},
+		"NotAppliedYet": {
+			reason:     "A dashboard without UID in status was never written by the provider and should be outdated",
+			cr:         importedDashboard("abc"),
+			configJSON: `{"title":"test"}`,
+			want:       false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isUpToDate(logging.NewNopLogger(), tc.cr, grafanaDashboard(1), nil, &tc.configJSON); got != tc.want {
+				t.Errorf("\n%s\nisUpToDate(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseConfigJson(t *testing.T) {
+	valid := `{"title":"test","panels":[]}`
+	invalid := `{"title":`
+
+	cases := map[string]struct {
+		reason     string
+		configJson *string
+		want       map[string]interface{}
+		wantErr    bool
+	}{
+		"Valid": {
+			reason:     "Valid JSON should be parsed into a map",
+			configJson: &valid,
+			want:       map[string]interface{}{"title": "test", "panels": []interface{}{}},
+		},
+		"Invalid": {
+			reason:     "An error should be returned for invalid JSON",
+			configJson: &invalid,
+			wantErr:    true,
+		},
+		"Nil": {
+			reason: "No configJson should be parsed into no map",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseConfigJson(tc.configJson)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nparseConfigJson(...): want error %t, got %v\n", tc.reason, tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nparseConfigJson(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetFolderId(t *testing.T) {
+	uuid := "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+	numeric := "42"
+	grafanaUID := "team-uid"
+
+	cases := map[string]struct {
+		reason string
+		folder *string
+		want   *models.SaveDashboardCommand
+	}{
+		"UUID": {
+			reason: "A UUID should be set as folder UID",
+			folder: &uuid,
+			want:   &models.SaveDashboardCommand{FolderUID: uuid},
+		},
+		"Numeric": {
+			reason: "A numeric folder should be set as folder ID",
+			folder: &numeric,
+			want:   &models.SaveDashboardCommand{FolderID: 42},
+		},
+		"GrafanaUID": {
+			reason: "A non-numeric folder that is no UUID should be set as folder UID",
+			folder: &grafanaUID,
+			want:   &models.SaveDashboardCommand{FolderUID: grafanaUID},
+		},
+		"Nil": {
+			reason: "No folder should leave the command in the General folder",
+			want:   &models.SaveDashboardCommand{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := &models.SaveDashboardCommand{}
+			setFolderId(tc.folder, got)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nsetFolderId(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCopyToStatus(t *testing.T) {
+	var id, version int64 = 2, 3
+	uid, url := "abc", "/d/abc/test"
+	response := &models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version, FolderUID: "team-uid"}
+
+	cr := &v1alpha1.Dashboard{}
+	copyToStatus(response, cr, "1", map[string]interface{}{"title": "test"})
+
+	var dataSourceCount int64
+	want := v1alpha1.DashboardObservation{
+		ID:              strRef("1:abc"),
+		OrgID:           strRef("1"),
+		UID:             &uid,
+		Folder:          strRef("team-uid"),
+		DashboardID:     &id,
+		DataSourceCount: &dataSourceCount,
+		URL:             &url,
+		Version:         &version,
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("copyToStatus(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestSetDataSourceUIDs(t *testing.T) {
+	configJson := `{
+		"title": "test",
+		"panels": [
+			{"type": "timeseries", "datasource": {"type": "prometheus", "uid": "prometheus"},
+			 "targets": [{"datasource": {"type": "prometheus", "uid": "prometheus"}}, {"datasource": {"type": "loki", "uid": "loki"}}]},
+			{"type": "row", "panels": [{"type": "table", "datasource": {"type": "postgres", "uid": "postgres"}}]},
+			{"type": "text", "datasource": {"type": "datasource", "uid": "-- Dashboard --"}},
+			{"type": "stat", "datasource": {"type": "prometheus", "uid": "${datasource}"}},
+			{"type": "stat", "datasource": "legacy-name"}
+		]
+	}`
+	model, err := parseConfigJson(&configJson)
+	if err != nil {
+		t.Fatalf("parseConfigJson(...): unexpected error %v", err)
+	}
+
+	cr := &v1alpha1.Dashboard{}
+	setDataSourceUIDs(cr, model)
+
+	if diff := cmp.Diff([]string{"loki", "postgres", "prometheus"}, cr.Status.AtProvider.DataSourceUIDs); diff != "" {
+		t.Errorf("setDataSourceUIDs(...): -want, +got:\n%s\n", diff)
+	}
+	if got := common.DefaultInt64(cr.Status.AtProvider.DataSourceCount, 0); got != 3 {
+		t.Errorf("setDataSourceUIDs(...): want 3 data sources, got %d", got)
+	}
+}
+
+func TestGetDashboard(t *testing.T) {
+	type want struct {
+		dashboard *models.DashboardFullWithMeta
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason     string
+		cr         *v1alpha1.Dashboard
+		configJSON string
+		service    func() *common.MockGrafanaAPI
+		want       want
+	}{
+		"ByUIDInStatus": {
+			reason:     "The dashboard should be looked up by the UID in status if it is set",
+			cr:         dashboard(),
+			configJSON: `{"title":"test"}`,
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+				return m
+			},
+			want: want{dashboard: grafanaDashboard(1)},
+		},
+		"ByExternalName": {
+			reason:     "The external-name should be tried as UID if there is no UID in status",
+			cr:         importedDashboard("abc"),
+			configJSON: `{"title":"test"}`,
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+				return m
+			},
+			want: want{dashboard: grafanaDashboard(1)},
+		},
+		"ByTitle": {
+			reason:     "The dashboard should be looked up by the title in configJson if neither finds it",
+			cr:         importedDashboard("test"),
+			configJSON: `{"title":"test"}`,
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "test").Return(nil, nil)
+				m.On("GetDashboardByName", mock.Anything, int64(1), "test", (*string)(nil)).Return(grafanaDashboard(1), nil)
+				return m
+			},
+			want: want{dashboard: grafanaDashboard(1)},
+		},
+		"NoTitle": {
+			reason:     "An error should be returned if the dashboard can only be looked up by a title it does not have",
+			cr:         importedDashboard(""),
+			configJSON: `{}`,
+			service:    func() *common.MockGrafanaAPI { return &common.MockGrafanaAPI{} },
+			want:       want{err: errors.New(errNoTitle)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			e := external{service: m}
+			got, err := e.GetDashboard(context.Background(), 1, tc.cr, nil, &tc.configJSON)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.GetDashboard(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.dashboard, got); diff != "" {
+				t.Errorf("\n%s\ne.GetDashboard(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrgIdRequired(t *testing.T) {
+	// without orgId and defaultOrgId every operation should fail instead of dereferencing the orgId
+	want := errors.New(common.ErrOrgIdRequired)
+	e := external{service: &common.MockGrafanaAPI{}}
+	withoutOrgId := func() *v1alpha1.Dashboard {
+		cr := dashboard()
+		cr.Spec.ForProvider.OrgID = nil
+		return cr
+	}
+
+	_, err := e.Observe(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	_, err = e.Create(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	_, err = e.Update(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	err = e.Delete(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestObserveAdoptsExisting(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDashboardByName", mock.Anything, int64(1), "renamed", (*string)(nil)).Return(nil, nil)
+	m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(3), nil)
+
+	cr := dashboard()
+	cr.Spec.ForProvider.ConfigJSON = strRef(`{"title":"renamed","uid":"abc"}`)
+	cr.Status.AtProvider = v1alpha1.DashboardObservation{}
+	cr.SetAnnotations(map[string]string{common.AnnotationKeyAdoptExisting: "true"})
+
+	e := external{service: m}
+	got, err := e.Observe(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	want := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: dashboardConnectionDetails("3")}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s\n", diff)
+	}
+	// the adopted version is claimed, so that the following update is not rejected as version mismatch
+	var version int64 = 3
+	if diff := cmp.Diff(&version, cr.Status.AtProvider.ManagedVersion); diff != "" {
+		t.Errorf("e.Observe(...): -want managed version, +got managed version:\n%s\n", diff)
+	}
+}
+
+func TestCreateAdoptsExisting(t *testing.T) {
+	// the dashboard was created between Observe and Create, so it is adopted instead of overwritten
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(3), nil)
+
+	cr := dashboard()
+	cr.Spec.ForProvider.ConfigJSON = strRef(`{"title":"test","uid":"abc"}`)
+	cr.Status.AtProvider = v1alpha1.DashboardObservation{}
+	cr.SetAnnotations(map[string]string{common.AnnotationKeyAdoptExisting: "true"})
+
+	e := external{service: m}
+	got, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ConnectionDetails: dashboardConnectionDetails("3")}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	m.AssertNotCalled(t, "CreateOrUpdateDashboard", mock.Anything, mock.Anything)
+}
+
+func TestCreateAdoptsByUID(t *testing.T) {
+	type want struct {
+		o     managed.ExternalCreation
+		uid   string
+		adopt bool
+	}
+
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		statusUID   *string
+		want        want
+	}{
+		"Adopted": {
+			reason:      "An existing dashboard with the uid of the model should be adopted instead of created",
+			annotations: map[string]string{common.AnnotationKeyAdoptByUID: "true"},
+			want:        want{o: managed.ExternalCreation{ConnectionDetails: dashboardConnectionDetails("3")}, uid: "abc", adopt: true},
+		},
+		"StatusHasOtherUID": {
+			reason:      "The dashboard should be adopted by the uid of the model, even if the status still has another uid",
+			annotations: map[string]string{common.AnnotationKeyAdoptByUID: "true"},
+			statusUID:   strRef("old"),
+			want:        want{o: managed.ExternalCreation{ConnectionDetails: dashboardConnectionDetails("3")}, uid: "abc", adopt: true},
+		},
+		"NotAnnotated": {
+			reason: "A dashboard should be created as usual without the annotation",
+			want:   want{o: managed.ExternalCreation{ConnectionDetails: dashboardConnectionDetails("1")}, uid: "abc"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(3), nil)
+			var id int64 = 2
+			uid, url := "abc", "/d/abc/test"
+			var version int64 = 1
+			m.On("CreateOrUpdateDashboard", int64(1), mock.Anything).Return(&models.PostDashboardOKBody{ID: &id, UID: &uid, URL: &url, Version: &version}, nil)
+
+			cr := dashboard()
+			cr.Spec.ForProvider.ConfigJSON = strRef(`{"title":"test","uid":"abc"}`)
+			cr.Status.AtProvider = v1alpha1.DashboardObservation{UID: tc.statusUID}
+			cr.SetAnnotations(tc.annotations)
+
+			e := external{service: m}
+			got, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(&tc.want.uid, cr.Status.AtProvider.UID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want uid, +got uid:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.adopt {
+				m.AssertNotCalled(t, "CreateOrUpdateDashboard", mock.Anything, mock.Anything)
+			} else {
+				m.AssertNotCalled(t, "GetDashboardByUid", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestObserveSetsReadyCondition(t *testing.T) {
+	type want struct {
+		status corev1.ConditionStatus
+		reason xpv1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service func() *common.MockGrafanaAPI
+		want    want
+	}{
+		"UpToDate": {
+			reason: "A Dashboard that is up to date should be available",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(1), nil)
+				return m
+			},
+			want: want{status: corev1.ConditionTrue, reason: xpv1.ReasonAvailable},
+		},
+		"Drifted": {
+			reason: "A Dashboard that differs from its spec should be unavailable, because it drifted",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(grafanaDashboard(2), nil)
+				return m
+			},
+			want: want{status: corev1.ConditionFalse, reason: common.ReasonResourceDrifted},
+		},
+		"APIError": {
+			reason: "A Dashboard that cannot be observed should be unavailable, because of the API error",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDashboardByUid", int64(1), "abc").Return(nil, errBoom)
+				return m
+			},
+			want: want{status: corev1.ConditionFalse, reason: common.ReasonAPIError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := dashboard()
+			// the Dashboard was available before
+			cr.SetConditions(xpv1.Available())
+			e := external{service: tc.service()}
+			_, err := e.Observe(context.Background(), cr)
+
+			ready := cr.GetCondition(xpv1.TypeReady)
+			got := want{status: ready.Status, reason: ready.Reason}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want Ready condition, +got Ready condition:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.reason == common.ReasonAPIError && (err == nil || ready.Message != err.Error()) {
+				t.Errorf("\n%s\ne.Observe(...): want the error %v as message, got %q\n", tc.reason, err, ready.Message)
+			}
+		})
+	}
+}
+
+func TestDeleteRefusedIfProtected(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	cr := dashboard()
+	meta.AddAnnotations(cr, map[string]string{common.AnnotationKeyDeletionProtection: "true"})
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), cr)
+	if diff := cmp.Diff(errors.New(common.ErrDeletionProtected), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	if len(m.Calls) != 0 {
+		t.Errorf("e.Delete(...): want no requests to Grafana, got %v", m.Calls)
+	}
+	if got := cr.GetCondition(common.TypeDeletionProtected); got.Status != corev1.ConditionTrue {
+		t.Errorf("e.Delete(...): want the DeletionProtected condition, got %v", got)
+	}
+}
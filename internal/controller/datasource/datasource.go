@@ -17,25 +17,25 @@ limitations under the License.
 package datasource
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"fmt"
-	"io"
-	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
 
 	"github.com/argannor/provider-grafana/internal/controller/common"
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/google/go-cmp/cmp"
 	grafana "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/pkg/errors"
 	kubeV1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -55,20 +55,37 @@ const (
 	errNotDataSource = "managed resource is not a DataSource custom resource"
 	errTrackPCUsage  = "cannot track ProviderConfig usage"
 	errGetPC         = "cannot get ProviderConfig"
-	errGetCreds      = "cannot get credentials"
-	errCredsFormat   = "credentials are not formatted as base64 encoded 'username:password' pair"
 	errOrgIdNotInt   = "orgId is not an integer"
+	errGetSigningKey = "cannot get signing key"
 
 	errNewClient              = "cannot create new Service"
 	errFailedGetDataSource    = "cannot get DataSource from Grafana API"
 	errFailedGetHeadersSecret = "cannot get referenced HttpHeadersSecret"
+	errFailedGetTLSSecret     = "cannot get referenced TLSConfigSecret"
 	errFailedCreateDataSource = "cannot create DataSource"
 	errFailedUpdateDataSource = "cannot update DataSource"
 	errFailedDeleteDataSource = "cannot delete DataSource"
+	errFailedHealthCheck      = "cannot run health check of DataSource"
 	errGetSecret              = "cannot get Secret"
+	errUIDChanged             = "uid %q differs from uid %q of the DataSource in Grafana: the uid of a data source cannot be changed, delete and re-create the DataSource to use a new uid"
 
 	errUnmarshalJson       = "cannot unmarshal JSON data"
 	errUnmarshalSecureJson = "cannot unmarshal secure JSON data"
+	errHashSecureJson      = "cannot hash secure JSON data"
+
+	msgCreated = "created data source %q"
+	msgUpdated = "updated data source %q"
+	msgDeleted = "deleted data source %q"
+
+	// reasons of the events recorded when the provider changed a data source in Grafana
+	reasonCreated event.Reason = "CreatedDataSource"
+	reasonUpdated event.Reason = "UpdatedDataSource"
+	reasonDeleted event.Reason = "DeletedDataSource"
+
+	msgReadOnlyIgnored = "readOnly is %t, but Grafana reports %t: Grafana only sets readOnly for provisioned data sources and ignores it in API requests"
+
+	// reasonReadOnlyIgnored is the reason of the event recorded when readOnly differs from Grafana after a write.
+	reasonReadOnlyIgnored event.Reason = "ReadOnlyIgnored"
 )
 
 var (
@@ -81,22 +98,26 @@ var (
 // Setup adds a controller that reconciles DataSource managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.DataSourceGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
 	}
 
-	r := managed.NewReconciler(mgr,
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
 		resource.ManagedKind(v1alpha1.DataSourceGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
 			newServiceFn: newService,
-			logger:       o.Logger}),
+			logger:       o.Logger,
+			recorder:     recorder}))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -104,7 +125,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.DataSource{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -113,6 +134,7 @@ type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	logger       logging.Logger
+	recorder     event.Recorder
 	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
 }
 
@@ -136,41 +158,40 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
 	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
+		return nil, err
 	}
 
-	decoder := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data))
-	decodedCredentials, err := io.ReadAll(decoder)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-	parts := strings.Split(string(decodedCredentials), ":")
-	if len(parts) != 2 {
-		return nil, errors.New(errCredsFormat)
+	var signingKey []byte
+	if pc.Spec.SigningKeySecretRef != nil {
+		signingKey, err = resource.ExtractSecret(ctx, c.kube, v1.CommonCredentialSelectors{SecretRef: pc.Spec.SigningKeySecretRef})
+		if err != nil {
+			return nil, errors.Wrap(err, errGetSigningKey)
+		}
+		if len(signingKey) == 0 {
+			return nil, errors.New(errGetSigningKey)
+		}
 	}
 
-	clientCfg := grafana.DefaultTransportConfig()
-	clientCfg = clientCfg.WithHost(fmt.Sprintf("%s:%d", pc.Spec.Host, pc.Spec.Port))
-	clientCfg = clientCfg.WithSchemes(pc.Spec.Schemes)
-	clientCfg.BasicAuth = url.UserPassword(parts[0], parts[1])
-
 	svc, err := c.newServiceFn(clientCfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc, logger: c.logger, kube: c.kube}, nil
+	return &external{service: svc, logger: c.logger, recorder: c.recorder, kube: c.kube, signingKey: signingKey, defaultOrgID: pc.Spec.DefaultOrgID}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service common.GrafanaAPI
-	logger  logging.Logger
-	kube    client.Client
+	service  common.GrafanaAPI
+	logger   logging.Logger
+	recorder event.Recorder
+	kube     client.Client
+	// signingKey is used to hash the secure JSON data, change detection of secret values is disabled if it is nil
+	signingKey   []byte
+	defaultOrgID *int64
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -179,62 +200,114 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotDataSource)
 	}
 
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	// orgId as int64
 	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
+	checkJSONData(cr)
+
 	atGrafana, err := c.GetDataSource(orgId, cr)
 
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetDataSource)
+		return managed.ExternalObservation{}, common.SetAPIError(cr, errors.Wrap(err, errFailedGetDataSource))
 	}
 
 	if atGrafana == nil {
+		adopted, err := c.tryAdopt(orgId, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, common.SetAPIError(cr, errors.Wrap(err, errFailedGetDataSource))
+		}
+		if adopted == nil {
+			c.log(cr).Debug("Data source does not exist")
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		// the adopted data source is updated to match the spec instead of creating a conflicting one
+		copyToStatus(adopted, cr)
+		c.log(cr).Debug("Adopting existing data source", "uid", adopted.UID)
 		return managed.ExternalObservation{
-			ResourceExists: false,
+			ResourceExists:          true,
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        false,
+			ConnectionDetails:       connectionDetails(cr),
 		}, nil
 	}
 
-	var httpHeaderSecret *kubeV1.Secret
-	if cr.Spec.ForProvider.HTTPHeadersSecretRef != nil {
-		httpHeaderSecret, err = c.getSecret(ctx, *cr.Spec.ForProvider.HTTPHeadersSecretRef)
-		if err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetHeadersSecret)
-		}
+	// Grafana identifies data sources by their uid, so it is never updated. Sending a new one would either be
+	// rejected or break every dashboard that references the data source by its old uid.
+	if uid := cr.Spec.ForProvider.UID; uid != nil && *uid != atGrafana.UID {
+		return managed.ExternalObservation{}, errors.Errorf(errUIDChanged, *uid, atGrafana.UID)
 	}
 
-	var secureJsonDataEncoded *string
-	if cr.Spec.ForProvider.SecureJSONDataEncodedSecretRef != nil {
-		secureJsonDataEncoded, err = c.getValueFromSecret(ctx, *cr.Spec.ForProvider.SecureJSONDataEncodedSecretRef)
-		if err != nil {
-			return managed.ExternalObservation{}, errors.Wrap(err, errGetSecret)
-		}
+	httpHeaderSecret, tlsSecret, secureJsonDataEncoded, err := c.getSecrets(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
 
-	upToDate, err := isUpToDate(cr, atGrafana, orgId, httpHeaderSecret, secureJsonDataEncoded)
+	upToDate, err := isUpToDate(c.log(cr), cr, atGrafana, orgId, httpHeaderSecret, tlsSecret, secureJsonDataEncoded, c.signingKey)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	delta := ""
+	if !upToDate {
+		delta, err = Diff(cr, atGrafana, orgId, httpHeaderSecret, tlsSecret, secureJsonDataEncoded, c.signingKey)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+	common.SetDiffCondition(cr, delta)
 
-	cr.SetConditions(v1.Available())
 	copyToStatus(atGrafana, cr)
 
+	healthy := true
+	if upToDate && common.DefaultBool(cr.Spec.ForProvider.EnableHealthCheck, false) {
+		result, err := c.service.TestDataSource(orgId, atGrafana.UID)
+		if err != nil {
+			return managed.ExternalObservation{}, common.SetAPIError(cr, errors.Wrap(err, errFailedHealthCheck))
+		}
+		if result.Healthy {
+			cr.SetConditions(v1alpha1.HealthCheckPassed())
+		} else {
+			cr.SetConditions(v1alpha1.HealthCheckFailed(result.Message), v1.Unavailable())
+			// the update triggers another health check on the next reconcile
+			healthy = false
+			upToDate = false
+		}
+	}
+
+	if healthy {
+		cr.SetConditions(common.AvailableCondition(upToDate))
+	}
+	c.log(cr).Debug("Observed data source", "uid", atGrafana.UID, "upToDate", upToDate, "healthy", healthy)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
 		// (re)create the resource, or that it has successfully been deleted.
 		ResourceExists: true,
 
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
 		ResourceUpToDate: upToDate,
 
+		Diff: delta,
+
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -244,6 +317,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotDataSource)
 	}
 
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	cr.SetConditions(v1.Creating())
 
 	// orgId as int64
@@ -253,12 +330,30 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	jsonData, secureJsonData, err := c.MakeJsonData(ctx, cr)
+	adopted, err := c.tryAdopt(orgId, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedGetDataSource)
+	}
+	if adopted != nil {
+		// the data source appeared since it was observed, it is updated on the next reconcile
+		copyToStatus(adopted, cr)
+		c.log(cr).Debug("Adopting existing data source instead of creating it", "uid", adopted.UID)
+		return managed.ExternalCreation{
+			ConnectionDetails: connectionDetails(cr),
+		}, nil
+	}
+
+	jsonData, secureJsonData, secureJsonDataHash, err := c.MakeJsonData(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	basicAuthPasswordHash, err := hashBasicAuthPassword(spec, *secureJsonData)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
-	_, err = c.service.CreateDataSource(orgId, &models.AddDataSourceCommand{
+	c.log(cr).Debug("Creating data source", "orgId", orgId, "uid", common.DefaultString(spec.UID, ""))
+	response, err := c.service.CreateDataSource(orgId, &models.AddDataSourceCommand{
 		Access:          models.DsAccess(common.DefaultString(spec.AccessMode, "proxy")),
 		BasicAuth:       common.DefaultBool(spec.BasicAuthEnabled, false),
 		BasicAuthUser:   common.DefaultString(spec.BasicAuthUsername, ""),
@@ -271,17 +366,41 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		UID:             common.DefaultString(spec.UID, ""),
 		URL:             common.DefaultString(spec.URL, ""),
 		User:            common.DefaultString(spec.Username, ""),
-		WithCredentials: false,
+		WithCredentials: common.DefaultBool(spec.WithCredentials, false),
 	})
 
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateDataSource)
 	}
 
+	created := response.Datasource
+	if created == nil && response.ID != nil {
+		// older Grafana versions only respond with the id, the uid is looked up so that it can be referenced right away
+		created, err = c.service.GetDataSourceById(orgId, strconv.FormatInt(*response.ID, 10))
+		if err != nil {
+			c.log(cr).Debug("Cannot look up created data source, its uid is reported on the next observation", "id", *response.ID, "err", err)
+		}
+	}
+	if created != nil {
+		copyToStatus(created, cr)
+		// the data source is found by its UID even if the status is lost
+		meta.SetExternalName(cr, created.UID)
+	}
+	c.log(cr).Debug("Created data source", "id", common.DefaultString(cr.Status.AtProvider.ID, ""), "uid", common.DefaultString(cr.Status.AtProvider.UID, ""))
+	cr.Status.AtProvider.SecureJSONDataHash = secureJsonDataHash
+	cr.Status.AtProvider.BasicAuthPasswordHash = basicAuthPasswordHash
+	c.recordEvent(cr, event.Normal(reasonCreated, fmt.Sprintf(msgCreated, common.DefaultString(spec.Name, cr.Name))))
+	c.warnIfReadOnlyIgnored(cr)
+
+	if common.DefaultBool(spec.ValidateOnCreate, false) {
+		c.validate(orgId, cr)
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -291,6 +410,10 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotDataSource)
 	}
 
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	// orgId as int64
 	spec := cr.Spec.ForProvider
 	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
@@ -298,11 +421,16 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	jsonData, secureJsonData, err := c.MakeJsonData(ctx, cr)
+	jsonData, secureJsonData, secureJsonDataHash, err := c.MakeJsonData(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	basicAuthPasswordHash, err := hashBasicAuthPassword(spec, *secureJsonData)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
+	c.log(cr).Debug("Updating data source", "orgId", orgId, "id", getId(cr), "uid", common.DefaultString(cr.Status.AtProvider.UID, ""))
 	response, err := c.service.UpdateDataSource(orgId, getId(cr), &models.UpdateDataSourceCommand{
 		Access:          models.DsAccess(common.DefaultString(spec.AccessMode, "proxy")),
 		BasicAuth:       common.DefaultBool(spec.BasicAuthEnabled, false),
@@ -313,10 +441,10 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		Name:            common.DefaultString(spec.Name, cr.Name),
 		SecureJSONData:  *secureJsonData,
 		Type:            common.DefaultString(spec.Type, ""),
-		UID:             common.DefaultString(spec.UID, ""),
+		UID:             common.DefaultString(cr.Status.AtProvider.UID, ""),
 		URL:             common.DefaultString(spec.URL, ""),
 		User:            common.DefaultString(spec.Username, ""),
-		WithCredentials: false,
+		WithCredentials: common.DefaultBool(spec.WithCredentials, false),
 	})
 
 	if err != nil {
@@ -324,11 +452,20 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	copyToStatus(response.Datasource, cr)
+	cr.Status.AtProvider.SecureJSONDataHash = secureJsonDataHash
+	cr.Status.AtProvider.BasicAuthPasswordHash = basicAuthPasswordHash
+	c.recordEvent(cr, event.Normal(reasonUpdated, fmt.Sprintf(msgUpdated, common.DefaultString(spec.Name, cr.Name))))
+	c.warnIfReadOnlyIgnored(cr)
 
+	if common.DefaultBool(spec.ValidateOnCreate, false) {
+		c.validate(orgId, cr)
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -338,6 +475,14 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotDataSource)
 	}
 
+	if err := common.CheckDeletionProtection(cr); err != nil {
+		return err
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
 	cr.SetConditions(v1.Deleting())
 
 	// orgId as int64
@@ -347,9 +492,23 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.Wrap(err, errOrgIdNotInt)
 	}
 
+	c.log(cr).Debug("Deleting data source", "orgId", orgId, "id", getId(cr))
 	_, err = c.service.DeleteDataSource(orgId, getId(cr))
+	if err != nil {
+		return errors.Wrap(err, errFailedDeleteDataSource)
+	}
+
+	c.recordEvent(cr, event.Normal(reasonDeleted, fmt.Sprintf(msgDeleted, common.DefaultString(spec.Name, cr.Name))))
+	return nil
+}
 
-	return errors.Wrap(err, errFailedDeleteDataSource)
+// tryAdopt looks up a data source that was created outside the provider by the UID of the spec, if the resource is
+// annotated to adopt existing data sources. GetDataSource only finds such data sources if their name matches the spec.
+func (c *external) tryAdopt(orgId int64, cr *v1alpha1.DataSource) (*models.DataSource, error) {
+	if !common.ShouldAdopt(cr) || cr.Spec.ForProvider.UID == nil {
+		return nil, nil
+	}
+	return c.service.GetDataSourceByUid(orgId, *cr.Spec.ForProvider.UID)
 }
 
 func getId(cr *v1alpha1.DataSource) string {
@@ -374,19 +533,37 @@ func copyToStatus(response *models.DataSource, cr *v1alpha1.DataSource) {
 	cr.Status.AtProvider.BasicAuthUsername = &response.BasicAuthUser
 	cr.Status.AtProvider.DatabaseName = &response.Database
 	cr.Status.AtProvider.Type = &response.Type
+	cr.Status.AtProvider.ReadOnly = &response.ReadOnly
 	cr.Status.AtProvider.URL = &response.URL
+	cr.Status.AtProvider.WithCredentials = &response.WithCredentials
+	cr.Status.AtProvider.AccessControl = response.AccessControl
 }
 
+// connectionDetails exposes the identifiers of the data source, so that they can be consumed by other resources.
+func connectionDetails(cr *v1alpha1.DataSource) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{}
+	if cr.Status.AtProvider.UID != nil {
+		details["uid"] = []byte(*cr.Status.AtProvider.UID)
+	}
+	if cr.Status.AtProvider.ID != nil {
+		details["id"] = []byte(*cr.Status.AtProvider.ID)
+	}
+	return details
+}
+
+// isUpToDate compares the data source in Grafana with the spec and logs the fields that differ.
 // nolint: gocyclo
-func isUpToDate(cr *v1alpha1.DataSource, atGrafana *models.DataSource, orgId int64, httpHeaderSecret *kubeV1.Secret, secureJsonDataEncoded *string) (bool, error) {
+func isUpToDate(log logging.Logger, cr *v1alpha1.DataSource, atGrafana *models.DataSource, orgId int64, httpHeaderSecret *kubeV1.Secret, tlsSecret *kubeV1.Secret, secureJsonDataEncoded *string, signingKey []byte) (bool, error) {
 	spec := cr.Spec.ForProvider
-	upToDate := true
+	comparison := &common.Comparison{}
 
-	jd, err := makeJSONData(spec.JSONDataEncoded)
-	if err != nil {
+	jd, jsonDataErr := makeJSONData(spec.JSONDataEncoded)
+	sjd, secureJSONDataErr := makeSecureJSONData(secureJsonDataEncoded)
+	if err := kerrors.NewAggregate([]error{jsonDataErr, secureJSONDataErr}); err != nil {
 		return false, err
 	}
-	sjd, err := makeSecureJSONData(secureJsonDataEncoded)
+	jd = withJSONDataDefaults(spec, jd)
+	jd, sjd, err := common.JsonDataWithTLS(jd, sjd, common.SecretToStringMap(tlsSecret))
 	if err != nil {
 		return false, err
 	}
@@ -400,68 +577,251 @@ func isUpToDate(cr *v1alpha1.DataSource, atGrafana *models.DataSource, orgId int
 		name = *spec.Name
 	}
 
-	upToDate = upToDate && name == atGrafana.Name
-	upToDate = upToDate && *spec.Type == atGrafana.Type
-	upToDate = upToDate && common.CompareOptional(spec.AccessMode, string(atGrafana.Access), "proxy")
-	upToDate = upToDate && common.CompareOptional(spec.BasicAuthEnabled, atGrafana.BasicAuth, false)
-	upToDate = upToDate && common.CompareOptional(spec.BasicAuthUsername, atGrafana.BasicAuthUser, "")
-	upToDate = upToDate && common.CompareOptional(spec.DatabaseName, atGrafana.Database, "")
-	upToDate = upToDate && common.CompareOptional(spec.IsDefault, atGrafana.IsDefault, false)
-	upToDate = upToDate && (spec.UID == nil || (*spec.UID == atGrafana.UID))
-	upToDate = upToDate && common.CompareOptional(spec.URL, atGrafana.URL, "")
-	upToDate = upToDate && common.CompareOptional(spec.Username, atGrafana.User, "")
-	upToDate = upToDate && orgId == atGrafana.OrgID
+	comparison.Equal("name", name == atGrafana.Name)
+	comparison.Equal("type", *spec.Type == atGrafana.Type)
+	comparison.Equal("accessMode", common.CompareOptional(spec.AccessMode, string(atGrafana.Access), "proxy"))
+	comparison.Equal("basicAuthEnabled", common.CompareOptional(spec.BasicAuthEnabled, atGrafana.BasicAuth, false))
+	comparison.Equal("basicAuthUsername", common.CompareOptional(spec.BasicAuthUsername, atGrafana.BasicAuthUser, ""))
+	comparison.Equal("databaseName", common.CompareOptional(spec.DatabaseName, atGrafana.Database, ""))
+	comparison.Equal("isDefault", common.CompareOptional(spec.IsDefault, atGrafana.IsDefault, false))
+	comparison.Equal("readOnly", common.CompareOptional(spec.ReadOnly, atGrafana.ReadOnly, false))
+	comparison.Equal("url", common.CompareOptional(spec.URL, atGrafana.URL, ""))
+	comparison.Equal("username", common.CompareOptional(spec.Username, atGrafana.User, ""))
+	comparison.Equal("withCredentials", common.CompareOptional(spec.WithCredentials, atGrafana.WithCredentials, false))
+	comparison.Equal("orgId", orgId == atGrafana.OrgID)
 	jsonDataUpToDate, err := common.CompareMap(jsonData, atGrafana.JSONData.(map[string]interface{}))
 	if err != nil {
 		return false, fmt.Errorf("failed to compare jsonData field: %w", err)
 	}
-	upToDate = upToDate && jsonDataUpToDate
+	comparison.Equal("jsonData", jsonDataUpToDate)
 	// secure fields are not returned by the API, so we can't compare them
-	upToDate = upToDate && common.CompareMapKeys(secureJSONData, atGrafana.SecureJSONFields)
-	// TODO: since the values are not included in the response, we can't check if they need to be updated. For this we
-	//   would need to store a hash of the secret data in the status and compare against that. It needs to be stable
-	//   against reordering of the keys and the values.
+	comparison.Equal("secureJsonData", common.CompareMapKeys(secureJSONData, atGrafana.SecureJSONFields))
+	// instead we compare against the hash of the values we last sent to Grafana
+	if signingKey != nil {
+		hash, err := hashSecureJSONData(signingKey, sjd, httpHeaderMap)
+		if err != nil {
+			return false, err
+		}
+		stored := cr.Status.AtProvider.SecureJSONDataHash
+		comparison.Equal("secureJsonDataHash", stored != nil && *stored == hash)
+	}
+	// the basic auth password is tracked by a salted hash as well, which does not require a signing key
+	if password, ok := basicAuthPassword(spec, sjd); ok {
+		comparison.Equal("basicAuthPassword", common.PasswordMatches(password, cr.Status.AtProvider.BasicAuthPasswordHash))
+	}
 
-	return upToDate, err
+	comparison.Log(log)
+	return comparison.UpToDate(), err
 }
 
-func (c *external) GetDataSource(orgId int64, cr *v1alpha1.DataSource) (*models.DataSource, error) {
-	if cr.Status.AtProvider.ID != nil {
-		return c.service.GetDataSourceById(orgId, getId(cr))
-	} else {
-		return c.service.GetDataSourceByName(orgId, *cr.Spec.ForProvider.Name)
-	}
+// dataSourceState holds the fields of a data source that are compared by isUpToDate. Secure JSON data is never
+// returned by Grafana, so only its keys and the hash of the values last sent to Grafana are part of it.
+type dataSourceState struct {
+	Name               string
+	Type               string
+	Access             string
+	BasicAuth          bool
+	BasicAuthUser      string
+	Database           string
+	IsDefault          bool
+	ReadOnly           bool
+	UID                string
+	URL                string
+	User               string
+	WithCredentials    bool
+	OrgID              int64
+	JSONData           map[string]interface{}
+	SecureJSONFields   []string
+	SecureJSONDataHash string
+	// BasicAuthPasswordApplied is true if the basic auth password was sent to Grafana, its value is never part of it
+	BasicAuthPasswordApplied bool
 }
 
-func (c *external) MakeJsonData(ctx context.Context, cr *v1alpha1.DataSource) (*map[string]interface{}, *map[string]string, error) {
-	jsonData, err := makeJSONData(cr.Spec.ForProvider.JSONDataEncoded)
+// Diff describes how the data source in Grafana differs from the spec, in the format of cmp.Diff. It never contains
+// secure JSON data.
+func Diff(cr *v1alpha1.DataSource, atGrafana *models.DataSource, orgId int64, httpHeaderSecret *kubeV1.Secret, tlsSecret *kubeV1.Secret, secureJsonDataEncoded *string, signingKey []byte) (string, error) {
+	spec := cr.Spec.ForProvider
+
+	jd, err := makeJSONData(spec.JSONDataEncoded)
 	if err != nil {
-		return nil, nil, err
+		return "", err
 	}
-
-	var httpHeaderSecret *kubeV1.Secret
-	if cr.Spec.ForProvider.HTTPHeadersSecretRef != nil {
-		httpHeaderSecret, err = c.getSecret(ctx, *cr.Spec.ForProvider.HTTPHeadersSecretRef)
+	jd = withJSONDataDefaults(spec, jd)
+	sjd, err := makeSecureJSONData(secureJsonDataEncoded)
+	if err != nil {
+		return "", err
+	}
+	jd, sjd, err = common.JsonDataWithTLS(jd, sjd, common.SecretToStringMap(tlsSecret))
+	if err != nil {
+		return "", err
+	}
+	httpHeaderMap := common.SecretToStringMap(httpHeaderSecret)
+	jsonData, secureJSONData := common.JsonDataWithHeaders(jd, sjd, httpHeaderMap)
+	// the desired jsonData holds integers, while Grafana returns all numbers as float64
+	jsonData, err = normalizeJSONData(jsonData)
+	if err != nil {
+		return "", err
+	}
+
+	desired := dataSourceState{
+		Name:             common.DefaultString(spec.Name, cr.Name),
+		Type:             common.DefaultString(spec.Type, ""),
+		Access:           common.DefaultString(spec.AccessMode, "proxy"),
+		BasicAuth:        common.DefaultBool(spec.BasicAuthEnabled, false),
+		BasicAuthUser:    common.DefaultString(spec.BasicAuthUsername, ""),
+		Database:         common.DefaultString(spec.DatabaseName, ""),
+		IsDefault:        common.DefaultBool(spec.IsDefault, false),
+		ReadOnly:         common.DefaultBool(spec.ReadOnly, false),
+		UID:              common.DefaultString(spec.UID, atGrafana.UID),
+		URL:              common.DefaultString(spec.URL, ""),
+		User:             common.DefaultString(spec.Username, ""),
+		WithCredentials:  common.DefaultBool(spec.WithCredentials, false),
+		OrgID:            orgId,
+		JSONData:         jsonData,
+		SecureJSONFields: sortedKeys(secureJSONData),
+	}
+	actual := dataSourceState{
+		Name:             atGrafana.Name,
+		Type:             atGrafana.Type,
+		Access:           string(atGrafana.Access),
+		BasicAuth:        atGrafana.BasicAuth,
+		BasicAuthUser:    atGrafana.BasicAuthUser,
+		Database:         atGrafana.Database,
+		IsDefault:        atGrafana.IsDefault,
+		ReadOnly:         atGrafana.ReadOnly,
+		UID:              atGrafana.UID,
+		URL:              atGrafana.URL,
+		User:             atGrafana.User,
+		WithCredentials:  atGrafana.WithCredentials,
+		OrgID:            atGrafana.OrgID,
+		JSONData:         atGrafana.JSONData.(map[string]interface{}),
+		SecureJSONFields: sortedKeys(atGrafana.SecureJSONFields),
+	}
+	if signingKey != nil {
+		desired.SecureJSONDataHash, err = hashSecureJSONData(signingKey, sjd, httpHeaderMap)
 		if err != nil {
-			return nil, nil, errors.Wrap(err, errFailedGetHeadersSecret)
+			return "", err
 		}
+		actual.SecureJSONDataHash = common.DefaultString(cr.Status.AtProvider.SecureJSONDataHash, "")
 	}
+	if password, ok := basicAuthPassword(spec, sjd); ok {
+		desired.BasicAuthPasswordApplied = true
+		actual.BasicAuthPasswordApplied = common.PasswordMatches(password, cr.Status.AtProvider.BasicAuthPasswordHash)
+	}
+	return cmp.Diff(desired, actual), nil
+}
 
-	var secureJsonDataEncoded *string
-	if cr.Spec.ForProvider.SecureJSONDataEncodedSecretRef != nil {
-		secureJsonDataEncoded, err = c.getValueFromSecret(ctx, *cr.Spec.ForProvider.SecureJSONDataEncodedSecretRef)
-		if err != nil {
-			return nil, nil, errors.Wrap(err, errGetSecret)
+// validate runs the health check of a freshly created or updated data source
+// and reports the result in the DataSourceHealthy condition. The outcome never
+// fails the reconciliation, an unhealthy data source is left in place.
+func (c *external) validate(orgId int64, cr *v1alpha1.DataSource) {
+	result, err := c.service.TestDataSource(orgId, common.DefaultString(cr.Status.AtProvider.UID, ""))
+	switch {
+	case err != nil:
+		cr.SetConditions(v1alpha1.DataSourceUnhealthy(errors.Wrap(err, errFailedHealthCheck).Error()))
+	case !result.Healthy:
+		cr.SetConditions(v1alpha1.DataSourceUnhealthy(result.Message))
+	default:
+		cr.SetConditions(v1alpha1.DataSourceHealthy())
+	}
+}
+
+// log returns the logger of the data source.
+func (c *external) log(cr *v1alpha1.DataSource) logging.Logger {
+	return common.ResourceLogger(c.logger, v1alpha1.DataSourceKind, cr)
+}
+
+// recordEvent records an event for the data source, if the external client was created with a recorder.
+func (c *external) recordEvent(cr *v1alpha1.DataSource, e event.Event) {
+	if c.recorder != nil {
+		c.recorder.Event(cr, e)
+	}
+}
+
+// warnIfReadOnlyIgnored records a warning event if readOnly differs from the data source written to Grafana, as Grafana
+// does not accept readOnly in API requests.
+func (c *external) warnIfReadOnlyIgnored(cr *v1alpha1.DataSource) {
+	if cr.Status.AtProvider.ReadOnly == nil {
+		return
+	}
+	desired := common.DefaultBool(cr.Spec.ForProvider.ReadOnly, false)
+	if desired != *cr.Status.AtProvider.ReadOnly {
+		c.recordEvent(cr, event.Warning(reasonReadOnlyIgnored, errors.Errorf(msgReadOnlyIgnored, desired, *cr.Status.AtProvider.ReadOnly)))
+	}
+}
+
+// GetDataSource looks up the data source by the ID in status. Without one, the external-name annotation is tried as
+// UID to allow importing existing data sources, before falling back to the name in the spec. The external-name defaults
+// to the name of the resource, so a miss there is not conclusive.
+func (c *external) GetDataSource(orgId int64, cr *v1alpha1.DataSource) (*models.DataSource, error) {
+	if cr.Status.AtProvider.ID != nil {
+		return c.service.GetDataSourceById(orgId, getId(cr))
+	}
+	if externalName := meta.GetExternalName(cr); externalName != "" {
+		dataSource, err := c.service.GetDataSourceByUid(orgId, externalName)
+		if err != nil || dataSource != nil {
+			return dataSource, err
 		}
 	}
+	return c.service.GetDataSourceByName(orgId, *cr.Spec.ForProvider.Name)
+}
+
+// MakeJsonData returns the json data and secure json data to send to Grafana, including the HTTP headers and the TLS
+// certificates. If a signing key is configured, the hash of the secret values is returned as well, otherwise it is nil.
+func (c *external) MakeJsonData(ctx context.Context, cr *v1alpha1.DataSource) (*map[string]interface{}, *map[string]string, *string, error) {
+	jsonData, jsonDataErr := makeJSONData(cr.Spec.ForProvider.JSONDataEncoded)
+	httpHeaderSecret, tlsSecret, secureJsonDataEncoded, secretsErr := c.getSecrets(ctx, cr)
+	if err := kerrors.NewAggregate([]error{jsonDataErr, secretsErr}); err != nil {
+		return nil, nil, nil, err
+	}
+	jsonData = withJSONDataDefaults(cr.Spec.ForProvider, jsonData)
 
 	secureJSONData, err := makeSecureJSONData(secureJsonDataEncoded)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	jsonData, secureJSONData, err = common.JsonDataWithTLS(jsonData, secureJSONData, common.SecretToStringMap(tlsSecret))
+	if err != nil {
+		return nil, nil, nil, err
 	}
 	httpHeaderMap := common.SecretToStringMap(httpHeaderSecret)
+
+	var hash *string
+	if c.signingKey != nil {
+		h, err := hashSecureJSONData(c.signingKey, secureJSONData, httpHeaderMap)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		hash = &h
+	}
+
 	jsonData, secureJSONData = common.JsonDataWithHeaders(jsonData, secureJSONData, httpHeaderMap)
-	return &jsonData, &secureJSONData, err
+	return &jsonData, &secureJSONData, hash, err
+}
+
+// getSecrets reads the secrets referenced by the data source. All of them are read, so that every misconfigured
+// reference is reported at once.
+func (c *external) getSecrets(ctx context.Context, cr *v1alpha1.DataSource) (httpHeaderSecret *kubeV1.Secret, tlsSecret *kubeV1.Secret, secureJsonDataEncoded *string, err error) {
+	var errs []error
+	if ref := cr.Spec.ForProvider.HTTPHeadersSecretRef; ref != nil {
+		if httpHeaderSecret, err = c.getSecret(ctx, *ref); err != nil {
+			errs = append(errs, errors.Wrap(err, errFailedGetHeadersSecret))
+		}
+	}
+	if ref := cr.Spec.ForProvider.TLSConfigSecretRef; ref != nil {
+		if tlsSecret, err = c.getSecret(ctx, *ref); err != nil {
+			errs = append(errs, errors.Wrap(err, errFailedGetTLSSecret))
+		}
+	}
+	if ref := cr.Spec.ForProvider.SecureJSONDataEncodedSecretRef; ref != nil {
+		if secureJsonDataEncoded, err = c.getValueFromSecret(ctx, *ref); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := kerrors.NewAggregate(errs); err != nil {
+		return nil, nil, nil, err
+	}
+	return httpHeaderSecret, tlsSecret, secureJsonDataEncoded, nil
 }
 
 func (c *external) getSecret(ctx context.Context, reference v1.SecretReference) (*kubeV1.Secret, error) {
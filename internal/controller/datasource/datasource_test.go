@@ -18,16 +18,28 @@ package datasource
 
 import (
 	"context"
+	stdjson "encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
 	"github.com/argannor/provider-grafana/internal/controller/common"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -42,6 +54,47 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+var errBoom = errors.New("boom")
+
+func dataSource() *v1alpha1.DataSource {
+	return &v1alpha1.DataSource{
+		Spec: v1alpha1.DataSourceSpec{
+			ForProvider: v1alpha1.DataSourceParameters{
+				Name:  strRef("test"),
+				OrgID: strRef("1"),
+				Type:  strRef("prometheus"),
+				URL:   strRef("http://prometheus:9090"),
+			},
+		},
+	}
+}
+
+func healthCheckedDataSource() *v1alpha1.DataSource {
+	ds := dataSource()
+	ds.Spec.ForProvider.EnableHealthCheck = boolRef(true)
+	return ds
+}
+
+func grafanaDataSource() *models.DataSource {
+	return &models.DataSource{
+		Access:   "proxy",
+		ID:       2,
+		JSONData: map[string]interface{}{},
+		Name:     "test",
+		OrgID:    1,
+		Type:     "prometheus",
+		UID:      "abc",
+		URL:      "http://prometheus:9090",
+	}
+}
+
+func dataSourceConnectionDetails() managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		"id":  []byte("1:2"),
+		"uid": []byte("abc"),
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		service common.GrafanaAPI
@@ -64,7 +117,162 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotDataSource": {
+			reason: "An error should be returned if the managed resource is not a DataSource",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg:  &v1alpha1.Folder{},
+			},
+			want: want{
+				err: errors.New(errNotDataSource),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the DataSource cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourceByName", int64(1), "test").Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dataSource(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetDataSource),
+			},
+		},
+		"NotFound": {
+			reason: "The DataSource should be reported as missing if Grafana does not know it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourceByName", int64(1), "test").Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dataSource(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The DataSource should be reported as up to date if it matches the desired state",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dataSource(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: dataSourceConnectionDetails(),
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "The DataSource should be reported as outdated if it differs from the desired state",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				ds := grafanaDataSource()
+				ds.URL = "http://other:9090"
+				m.On("GetDataSourceByName", int64(1), "test").Return(ds, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  dataSource(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: dataSourceConnectionDetails(),
+				},
+			},
+		},
+		"UIDChanged": {
+			reason: "An error should be returned if the uid of the spec differs from the one in Grafana, because it cannot be changed",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg: func() resource.Managed {
+					ds := dataSource()
+					ds.Spec.ForProvider.UID = strRef("def")
+					return ds
+				}(),
+			},
+			want: want{
+				err: errors.Errorf(errUIDChanged, "def", "abc"),
+			},
+		},
+		"HealthCheckPassed": {
+			reason: "The DataSource should be reported as up to date if its health check passes",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+				m.On("TestDataSource", int64(1), "abc").Return(&common.DataSourceTestResult{Healthy: true}, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  healthCheckedDataSource(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: dataSourceConnectionDetails(),
+				},
+			},
+		},
+		"HealthCheckFailed": {
+			reason: "The DataSource should be reported as outdated if its health check fails, so the update re-tests it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+				m.On("TestDataSource", int64(1), "abc").Return(&common.DataSourceTestResult{Healthy: false, Message: "connection refused"}, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  healthCheckedDataSource(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: dataSourceConnectionDetails(),
+				},
+			},
+		},
+		"HealthCheckError": {
+			reason: "An error should be returned if the health check cannot be run",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+				m.On("TestDataSource", int64(1), "abc").Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  healthCheckedDataSource(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedHealthCheck),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -74,9 +282,265 @@ func TestObserve(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
+			if got.ResourceUpToDate && got.Diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): want no diff for an up to date resource, got %q\n", tc.reason, got.Diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type fields struct {
+		service common.GrafanaAPI
+	}
+
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		mg     resource.Managed
+		want   want
+	}{
+		"Created": {
+			reason: "The uid and id of the created DataSource should be returned as connection details",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateDataSource", int64(1), mock.Anything).Return(&models.AddDataSourceOKBody{Datasource: grafanaDataSource()}, nil)
+				return m
+			}()},
+			mg: dataSource(),
+			want: want{
+				o: managed.ExternalCreation{ConnectionDetails: dataSourceConnectionDetails()},
+			},
+		},
+		"CreatedWithoutDataSourceInResponse": {
+			reason: "The created DataSource should be looked up by its id if Grafana only responds with the id, so that its uid is known right away",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				var id int64 = 2
+				m.On("CreateDataSource", int64(1), mock.Anything).Return(&models.AddDataSourceOKBody{ID: &id}, nil)
+				m.On("GetDataSourceById", int64(1), "2").Return(grafanaDataSource(), nil)
+				return m
+			}()},
+			mg: dataSource(),
+			want: want{
+				o: managed.ExternalCreation{ConnectionDetails: dataSourceConnectionDetails()},
+			},
+		},
+		"CreateFailed": {
+			reason: "An error should be returned if the DataSource cannot be created",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateDataSource", int64(1), mock.Anything).Return(nil, errBoom)
+				return m
+			}()},
+			mg: dataSource(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedCreateDataSource),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.fields.service}
+			got, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveSetsHealthCheckCondition(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+	m.On("TestDataSource", int64(1), "abc").Return(&common.DataSourceTestResult{Healthy: false, Message: "connection refused"}, nil)
+	cr := healthCheckedDataSource()
+
+	e := external{service: m}
+	_, err := e.Observe(context.Background(), cr)
+	assert.Nil(t, err)
+
+	condition := cr.GetCondition(v1alpha1.TypeHealthCheckFailed)
+	assert.Equal(t, v1.ConditionTrue, condition.Status)
+	assert.Equal(t, "connection refused", condition.Message)
+	assert.Equal(t, v1.ConditionFalse, cr.GetCondition(xpv1.TypeReady).Status)
+}
+
+func TestObserveReportsDiff(t *testing.T) {
+	atGrafana := grafanaDataSource()
+	atGrafana.URL = "http://other:9090"
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDataSourceByName", int64(1), "test").Return(atGrafana, nil)
+	cr := dataSource()
+
+	e := external{service: m}
+	got, err := e.Observe(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.Contains(t, got.Diff, "http://other:9090")
+
+	// the managed reconciler replaces the Synced condition after Observe, which must not drop the diff
+	cr.SetConditions(xpv1.ReconcileSuccess())
+	drifted := cr.GetCondition(v1alpha1.TypeDrifted)
+	assert.Equal(t, v1.ConditionTrue, drifted.Status)
+	assert.Equal(t, common.DiffMessage(got.Diff), drifted.Message)
+
+	// the data source is looked up by the id observed before
+	m = &common.MockGrafanaAPI{}
+	m.On("GetDataSourceById", int64(1), "2").Return(grafanaDataSource(), nil)
+	e = external{service: m}
+	_, err = e.Observe(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.Equal(t, v1alpha1.ReasonNoDrift, cr.GetCondition(v1alpha1.TypeDrifted).Reason, "the Drifted condition should be cleared once the data source was updated")
+}
+
+func TestObserveCopiesAccessControlToStatus(t *testing.T) {
+	atGrafana := grafanaDataSource()
+	atGrafana.AccessControl = models.Metadata{"datasources:read": true, "datasources:write": false}
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDataSourceByName", int64(1), "test").Return(atGrafana, nil)
+	cr := dataSource()
+
+	e := external{service: m}
+	got, err := e.Observe(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"datasources:read": true, "datasources:write": false}, cr.Status.AtProvider.AccessControl)
+	// the access control is only observed, so it doesn't make the data source outdated
+	assert.True(t, got.ResourceUpToDate)
+}
+
+func TestCreateSetsDataSourceHealthyCondition(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		result  *common.DataSourceTestResult
+		err     error
+		status  v1.ConditionStatus
+		message string
+	}{
+		"Healthy": {
+			reason: "A passing health check should mark the DataSource as healthy",
+			result: &common.DataSourceTestResult{Healthy: true, Message: "Data source is working"},
+			status: v1.ConditionTrue,
+		},
+		"Unhealthy": {
+			reason:  "A failing health check should mark the DataSource as unhealthy without failing the creation",
+			result:  &common.DataSourceTestResult{Healthy: false, Message: "connection refused"},
+			status:  v1.ConditionFalse,
+			message: "connection refused",
+		},
+		"CheckFailed": {
+			reason:  "An error running the health check should mark the DataSource as unhealthy without failing the creation",
+			err:     errBoom,
+			status:  v1.ConditionFalse,
+			message: errors.Wrap(errBoom, errFailedHealthCheck).Error(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("CreateDataSource", int64(1), mock.Anything).Return(&models.AddDataSourceOKBody{Datasource: grafanaDataSource()}, nil)
+			m.On("TestDataSource", int64(1), "abc").Return(tc.result, tc.err)
+			cr := dataSource()
+			cr.Spec.ForProvider.ValidateOnCreate = boolRef(true)
+
+			e := external{service: m}
+			_, err := e.Create(context.Background(), cr)
+			assert.Nil(t, err, tc.reason)
+
+			condition := cr.GetCondition(v1alpha1.TypeDataSourceHealthy)
+			assert.Equal(t, tc.status, condition.Status, tc.reason)
+			assert.Equal(t, tc.message, condition.Message, tc.reason)
+		})
+	}
+}
+
+func TestSetsLastReconcileAnnotation(t *testing.T) {
+	updated := func() *v1alpha1.DataSource {
+		cr := dataSource()
+		id := "1:2"
+		cr.Status.AtProvider.ID = &id
+		return cr
+	}
+
+	cases := map[string]struct {
+		reason    string
+		cr        *v1alpha1.DataSource
+		reconcile func(e external, cr *v1alpha1.DataSource) error
+		set       bool
+	}{
+		"Observe": {
+			reason: "A successful observation should set the annotation",
+			cr:     dataSource(),
+			reconcile: func(e external, cr *v1alpha1.DataSource) error {
+				_, err := e.Observe(context.Background(), cr)
+				return err
+			},
+			set: true,
+		},
+		"Create": {
+			reason: "A successful creation should set the annotation",
+			cr:     dataSource(),
+			reconcile: func(e external, cr *v1alpha1.DataSource) error {
+				_, err := e.Create(context.Background(), cr)
+				return err
+			},
+			set: true,
+		},
+		"Update": {
+			reason: "A successful update should set the annotation",
+			cr:     updated(),
+			reconcile: func(e external, cr *v1alpha1.DataSource) error {
+				_, err := e.Update(context.Background(), cr)
+				return err
+			},
+			set: true,
+		},
+		"ObserveFailed": {
+			reason: "A failed observation should not set the annotation",
+			cr: func() *v1alpha1.DataSource {
+				cr := dataSource()
+				cr.Spec.ForProvider.OrgID = strRef("one")
+				return cr
+			}(),
+			reconcile: func(e external, cr *v1alpha1.DataSource) error {
+				_, err := e.Observe(context.Background(), cr)
+				return err
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+			m.On("CreateDataSource", int64(1), mock.Anything).Return(&models.AddDataSourceOKBody{Datasource: grafanaDataSource()}, nil)
+			m.On("UpdateDataSource", int64(1), "2", mock.Anything).Return(&models.UpdateDataSourceByIDOKBody{Datasource: grafanaDataSource()}, nil)
+
+			before := time.Now().Truncate(time.Second)
+			err := tc.reconcile(external{service: m}, tc.cr)
+			after := time.Now()
+			assert.Equal(t, tc.set, err == nil, tc.reason)
+
+			value, ok := tc.cr.GetAnnotations()[common.AnnotationKeyLastReconcileTime]
+			assert.Equal(t, tc.set, ok, tc.reason)
+			if !tc.set {
+				return
+			}
+			got, err := time.Parse(time.RFC3339, value)
+			assert.Nil(t, err, tc.reason)
+			assert.False(t, got.Before(before) || got.After(after), "%s: %s is not between %s and %s", tc.reason, got, before, after)
 		})
 	}
 }
@@ -130,7 +594,7 @@ func TestIsUpToDate(t *testing.T) {
 		Version:          0,
 		WithCredentials:  false,
 	}
-	probe, err := isUpToDate(cr, atGrafana, 1, headersSecret, strRef("{ \"secret\": \"secretValue\" }"))
+	probe, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, headersSecret, nil, strRef("{ \"secret\": \"secretValue\" }"), nil)
 	assert.Nil(t, err)
 	assert.True(t, probe)
 }
@@ -184,14 +648,899 @@ func TestIsNotUpToDate(t *testing.T) {
 		Version:          0,
 		WithCredentials:  false,
 	}
-	probe, err := isUpToDate(cr, atGrafana, 1, headersSecret, strRef("{ \"secret\": \"secretValue\" }"))
+	probe, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, headersSecret, nil, strRef("{ \"secret\": \"secretValue\" }"), nil)
+	assert.Nil(t, err)
+	assert.False(t, probe)
+}
+
+func TestIsUpToDateComparesNestedNumbers(t *testing.T) {
+	loki := `{
+		"maxLines": 1000,
+		"timeout": 60,
+		"derivedFields": [
+			{"name": "TraceID", "matcherRegex": "traceID=(\\w+)", "datasourceUid": "tempo", "url": "${__value.raw}"}
+		]
+	}`
+	tempo := `{
+		"tracesToLogsV2": {
+			"datasourceUid": "loki",
+			"spanStartTimeShift": "-1h",
+			"filterByTraceID": true,
+			"tags": [{"key": "service.name", "value": "service"}]
+		},
+		"serviceMap": {"datasourceUid": "prometheus"},
+		"nodeGraph": {"enabled": true},
+		"search": {"hide": false, "limit": 20, "spanLimits": [3, 10.5]},
+		"traceQuery": {"timeShiftEnabled": true, "spanStartTimeShift": "1h"}
+	}`
+
+	cases := map[string]struct {
+		reason    string
+		typ       string
+		jsonData  string
+		atGrafana string
+		want      bool
+	}{
+		"Loki": {
+			reason:    "Numbers of the spec should equal the float64 numbers Grafana returns",
+			typ:       "loki",
+			jsonData:  loki,
+			atGrafana: loki,
+			want:      true,
+		},
+		"Tempo": {
+			reason:    "Numbers nested in objects and arrays should equal the float64 numbers Grafana returns",
+			typ:       "tempo",
+			jsonData:  tempo,
+			atGrafana: tempo,
+			want:      true,
+		},
+		"TempoChanged": {
+			reason:    "A changed number nested in an array should be detected",
+			typ:       "tempo",
+			jsonData:  tempo,
+			atGrafana: strings.Replace(tempo, "10.5", "10", 1),
+			want:      false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DataSource{
+				Spec: v1alpha1.DataSourceSpec{
+					ForProvider: v1alpha1.DataSourceParameters{
+						JSONDataEncoded: strRef(tc.jsonData),
+						Name:            strRef(tc.typ),
+						OrgID:           strRef("1"),
+						Type:            strRef(tc.typ),
+					},
+				},
+			}
+			// the Grafana client decodes JSON numbers as float64
+			atGrafanaJSONData := map[string]interface{}{}
+			if err := stdjson.Unmarshal([]byte(tc.atGrafana), &atGrafanaJSONData); err != nil {
+				t.Fatal(err)
+			}
+			atGrafana := &models.DataSource{
+				Access:   "proxy",
+				JSONData: atGrafanaJSONData,
+				Name:     tc.typ,
+				OrgID:    1,
+				Type:     tc.typ,
+			}
+			got, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, &v1.Secret{}, nil, nil, nil)
+			assert.Nil(t, err, tc.reason)
+			assert.Equal(t, tc.want, got, tc.reason)
+		})
+	}
+}
+
+func TestIsUpToDateComparesWithCredentials(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		spec    *bool
+		grafana bool
+		want    bool
+	}{
+		"Unset": {
+			reason:  "An unset withCredentials should match false",
+			grafana: false,
+			want:    true,
+		},
+		"UnsetButEnabled": {
+			reason:  "An unset withCredentials should not match true, as it defaults to false",
+			grafana: true,
+			want:    false,
+		},
+		"Differs": {
+			reason:  "A data source should be outdated if withCredentials differs from Grafana",
+			spec:    boolRef(true),
+			grafana: false,
+			want:    false,
+		},
+		"Matches": {
+			reason:  "A data source should be up to date if withCredentials matches Grafana",
+			spec:    boolRef(true),
+			grafana: true,
+			want:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := dataSource()
+			cr.Spec.ForProvider.WithCredentials = tc.spec
+			atGrafana := grafanaDataSource()
+			atGrafana.WithCredentials = tc.grafana
+
+			got, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("isUpToDate(...): unexpected error %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nisUpToDate(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsUpToDateComparesReadOnly(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		spec    *bool
+		grafana bool
+		want    bool
+	}{
+		"Differs": {
+			reason:  "A data source should be outdated if readOnly is true, but Grafana returns false",
+			spec:    boolRef(true),
+			grafana: false,
+			want:    false,
+		},
+		"Matches": {
+			reason:  "A data source should be up to date if readOnly matches Grafana",
+			spec:    boolRef(true),
+			grafana: true,
+			want:    true,
+		},
+		"Unset": {
+			reason:  "An unset readOnly should match false",
+			grafana: false,
+			want:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := dataSource()
+			cr.Spec.ForProvider.ReadOnly = tc.spec
+			atGrafana := grafanaDataSource()
+			atGrafana.ReadOnly = tc.grafana
+
+			got, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("isUpToDate(...): unexpected error %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nisUpToDate(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestIsUpToDateWithJSONDataDefaults(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		apply    *bool
+		dsType   string
+		jsonData *string
+		grafana  map[string]interface{}
+		want     bool
+	}{
+		"PrometheusDefault": {
+			reason:  "The httpMethod Grafana stored for a Prometheus data source should not show as drift",
+			apply:   boolRef(true),
+			dsType:  "prometheus",
+			grafana: map[string]interface{}{"httpMethod": "POST"},
+			want:    true,
+		},
+		"PrometheusDefaultNotApplied": {
+			reason:  "The defaults should only be applied if the spec asks for them",
+			dsType:  "prometheus",
+			grafana: map[string]interface{}{"httpMethod": "POST"},
+			want:    false,
+		},
+		"PrometheusOverride": {
+			reason:   "A httpMethod of the spec should take precedence over the default",
+			apply:    boolRef(true),
+			dsType:   "prometheus",
+			jsonData: strRef(`{"httpMethod": "GET"}`),
+			grafana:  map[string]interface{}{"httpMethod": "POST"},
+			want:     false,
+		},
+		"LokiDefault": {
+			reason:  "The maxLines Grafana stored for a Loki data source should not show as drift",
+			apply:   boolRef(true),
+			dsType:  "loki",
+			grafana: map[string]interface{}{"maxLines": "1000"},
+			want:    true,
+		},
+		"UnknownType": {
+			reason:  "Types without defaults should be compared as they are",
+			apply:   boolRef(true),
+			dsType:  "graphite",
+			grafana: map[string]interface{}{},
+			want:    true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := dataSource()
+			cr.Spec.ForProvider.ApplyJSONDataDefaults = tc.apply
+			cr.Spec.ForProvider.Type = strRef(tc.dsType)
+			cr.Spec.ForProvider.JSONDataEncoded = tc.jsonData
+			atGrafana := grafanaDataSource()
+			atGrafana.Type = tc.dsType
+			atGrafana.JSONData = tc.grafana
+
+			got, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, nil, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("isUpToDate(...): unexpected error %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nisUpToDate(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMakeJsonDataAppliesDefaults(t *testing.T) {
+	cr := dataSource()
+	cr.Spec.ForProvider.ApplyJSONDataDefaults = boolRef(true)
+	cr.Spec.ForProvider.JSONDataEncoded = strRef(`{"timeInterval": "30s"}`)
+
+	e := external{}
+	jsonData, _, _, err := e.MakeJsonData(context.Background(), cr)
+	assert.Nil(t, err)
+	// the defaults are written to Grafana as well, so that they match what Grafana returns
+	assert.Equal(t, map[string]interface{}{"httpMethod": "POST", "timeInterval": "30s"}, *jsonData)
+}
+
+// recorder records the events of a test.
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestUpdateWarnsIfReadOnlyIsIgnored(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("UpdateDataSource", int64(1), "2", mock.Anything).Return(&models.UpdateDataSourceByIDOKBody{Datasource: grafanaDataSource()}, nil)
+	cr := dataSource()
+	cr.Spec.ForProvider.ReadOnly = boolRef(true)
+	id := "1:2"
+	cr.Status.AtProvider.ID = &id
+
+	r := &recorder{}
+	e := external{service: m, recorder: r}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error %v", err)
+	}
+
+	var got []event.Reason
+	for _, e := range r.events {
+		got = append(got, e.Reason)
+	}
+	if diff := cmp.Diff([]event.Reason{reasonUpdated, reasonReadOnlyIgnored}, got); diff != "" {
+		t.Errorf("e.Update(...): -want event reasons, +got event reasons:\n%s\n", diff)
+	}
+}
+
+func TestIsUpToDateDetectsBasicAuthPasswordChange(t *testing.T) {
+	applied, err := common.HashPassword("old", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string]struct {
+		reason     string
+		enabled    *bool
+		secureJSON string
+		want       bool
+	}{
+		"Unchanged": {
+			reason:     "A data source should be up to date if the basic auth password was applied",
+			enabled:    boolRef(true),
+			secureJSON: `{"basicAuthPassword":"old"}`,
+			want:       true,
+		},
+		"Changed": {
+			reason:     "A data source should be outdated if only the basic auth password changed",
+			enabled:    boolRef(true),
+			secureJSON: `{"basicAuthPassword":"new"}`,
+			want:       false,
+		},
+		"BasicAuthDisabled": {
+			reason:     "The basic auth password should be ignored if basic auth is disabled",
+			secureJSON: `{"basicAuthPassword":"new"}`,
+			want:       true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := dataSource()
+			cr.Spec.ForProvider.BasicAuthEnabled = tc.enabled
+			cr.Status.AtProvider.BasicAuthPasswordHash = &applied
+			atGrafana := grafanaDataSource()
+			atGrafana.BasicAuth = common.DefaultBool(tc.enabled, false)
+			atGrafana.SecureJSONFields = map[string]bool{"basicAuthPassword": true}
+
+			got, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, nil, nil, &tc.secureJSON, nil)
+			if err != nil {
+				t.Fatalf("isUpToDate(...): unexpected error %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nisUpToDate(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestDiffOmitsSecureValues(t *testing.T) {
+	headersSecret := &v1.Secret{
+		Data: map[string][]byte{"Test": []byte("Test-Value")},
+	}
+	cr := &v1alpha1.DataSource{
+		Spec: v1alpha1.DataSourceSpec{
+			ForProvider: v1alpha1.DataSourceParameters{
+				BasicAuthUsername: strRef("admin"),
+				JSONDataEncoded:   strRef("{\"public\": { \"value\": 1 } }"),
+				Name:              strRef("test"),
+				OrgID:             strRef("1"),
+				Type:              strRef("prometheus"),
+			},
+		},
+	}
+	atGrafana := &models.DataSource{
+		Access:        "proxy",
+		BasicAuthUser: "admin2",
+		JSONData: map[string]interface{}{
+			"public":          map[string]interface{}{"value": float64(1)},
+			"httpHeaderName1": "Test",
+		},
+		Name:             "test",
+		OrgID:            1,
+		SecureJSONFields: map[string]bool{"secret": true, "httpHeaderValue1": true},
+		Type:             "prometheus",
+	}
+
+	diff, err := Diff(cr, atGrafana, 1, headersSecret, nil, strRef("{ \"secret\": \"secretValue\" }"), []byte("signing-key"))
+	assert.Nil(t, err)
+	assert.Contains(t, diff, "admin2")
+	assert.NotContains(t, diff, "secretValue")
+	assert.NotContains(t, diff, "Test-Value")
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "-") || strings.HasPrefix(line, "+") {
+			// numbers in jsonData only differ in their type
+			assert.NotContains(t, line, "public")
+		}
+	}
+}
+
+func TestIsUpToDateWithMultipleHeaders(t *testing.T) {
+	headersSecret := &v1.Secret{
+		Data: map[string][]byte{
+			"X-Scope-OrgID": []byte("tenant"),
+			"Authorization": []byte("Bearer token"),
+		},
+	}
+	cr := &v1alpha1.DataSource{
+		Spec: v1alpha1.DataSourceSpec{
+			ForProvider: v1alpha1.DataSourceParameters{
+				OrgID: strRef("1"),
+				Type:  strRef("prometheus"),
+			},
+		},
+	}
+	atGrafana := &models.DataSource{
+		Access: "proxy",
+		JSONData: map[string]interface{}{
+			"httpHeaderName1": "Authorization",
+			"httpHeaderName2": "X-Scope-OrgID",
+		},
+		OrgID:            1,
+		SecureJSONFields: map[string]bool{"httpHeaderValue1": true, "httpHeaderValue2": true},
+		Type:             "prometheus",
+	}
+	// the header indices used to depend on the map iteration order, so check repeatedly
+	for i := 0; i < 20; i++ {
+		probe, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, headersSecret, nil, nil, nil)
+		assert.Nil(t, err)
+		assert.True(t, probe)
+	}
+}
+
+func TestIsUpToDateComparesSecureJsonDataHash(t *testing.T) {
+	signingKey := []byte("signing-key")
+	headersSecret := &v1.Secret{
+		Data: map[string][]byte{"Test": []byte("Test-Value")},
+	}
+	storedHash, err := hashSecureJSONData(signingKey, map[string]string{"secret": "secretValue"}, map[string]string{"Test": "Test-Value"})
+	assert.Nil(t, err)
+	cr := &v1alpha1.DataSource{
+		Spec: v1alpha1.DataSourceSpec{
+			ForProvider: v1alpha1.DataSourceParameters{
+				OrgID: strRef("1"),
+				Type:  strRef("prometheus"),
+			},
+		},
+		Status: v1alpha1.DataSourceStatus{
+			AtProvider: v1alpha1.DataSourceObservation{SecureJSONDataHash: &storedHash},
+		},
+	}
+	atGrafana := &models.DataSource{
+		Access:           "proxy",
+		JSONData:         map[string]interface{}{"httpHeaderName1": "Test"},
+		OrgID:            1,
+		SecureJSONFields: map[string]bool{"secret": true, "httpHeaderValue1": true},
+		Type:             "prometheus",
+	}
+
+	probe, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, headersSecret, nil, strRef("{ \"secret\": \"secretValue\" }"), signingKey)
+	assert.Nil(t, err)
+	assert.True(t, probe)
+
+	probe, err = isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, headersSecret, nil, strRef("{ \"secret\": \"changedValue\" }"), signingKey)
+	assert.Nil(t, err)
+	assert.False(t, probe)
+
+	probe, err = isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, headersSecret, nil, strRef("{ \"secret\": \"secretValue\" }"), []byte("rotated-key"))
 	assert.Nil(t, err)
 	assert.False(t, probe)
 }
 
+func tlsSecret(cert string) *v1.Secret {
+	return &v1.Secret{
+		Data: map[string][]byte{
+			common.TLSCertKey: []byte(cert),
+			common.TLSKeyKey:  []byte("key"),
+			common.TLSCAKey:   []byte("ca"),
+		},
+	}
+}
+
+func TestMakeJsonDataInjectsTLS(t *testing.T) {
+	cr := dataSource()
+	cr.Spec.ForProvider.JSONDataEncoded = strRef(`{"tlsSkipVerify": false}`)
+	cr.Spec.ForProvider.HTTPHeadersSecretRef = &xpv1.SecretReference{Name: "headers", Namespace: "default"}
+	cr.Spec.ForProvider.TLSConfigSecretRef = &xpv1.SecretReference{Name: "tls", Namespace: "default"}
+
+	e := external{
+		kube: &test.MockClient{
+			MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				switch key.Name {
+				case "headers":
+					obj.(*v1.Secret).Data = map[string][]byte{"X-Scope-OrgID": []byte("tenant")}
+				case "tls":
+					tlsSecret("cert").DeepCopyInto(obj.(*v1.Secret))
+				}
+				return nil
+			},
+		},
+		signingKey: []byte("signing-key"),
+	}
+
+	jsonData, secureJsonData, hash, err := e.MakeJsonData(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"httpHeaderName1":   "X-Scope-OrgID",
+		"tlsAuth":           true,
+		"tlsAuthWithCACert": true,
+		"tlsSkipVerify":     false,
+	}, *jsonData)
+	assert.Equal(t, map[string]string{
+		"httpHeaderValue1": "tenant",
+		"tlsCACert":        "ca",
+		"tlsClientCert":    "cert",
+		"tlsClientKey":     "key",
+	}, *secureJsonData)
+	assert.NotNil(t, hash)
+}
+
+func TestMakeJsonDataReadsSecretsFromTheirNamespace(t *testing.T) {
+	// data sources are cluster scoped, so their secrets are read from the namespace of each reference
+	secrets := map[client.ObjectKey]map[string][]byte{
+		{Namespace: "team-a", Name: "grafana"}:     {"secureJsonData": []byte(`{"password": "team-a"}`)},
+		{Namespace: "team-b", Name: "grafana"}:     {"secureJsonData": []byte(`{"password": "team-b"}`)},
+		{Namespace: "monitoring", Name: "headers"}: {"X-Scope-OrgID": []byte("monitoring")},
+	}
+	cr := dataSource()
+	cr.Spec.ForProvider.SecureJSONDataEncodedSecretRef = &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "grafana", Namespace: "team-b"},
+		Key:             "secureJsonData",
+	}
+	cr.Spec.ForProvider.HTTPHeadersSecretRef = &xpv1.SecretReference{Name: "headers", Namespace: "monitoring"}
+
+	e := external{
+		kube: &test.MockClient{
+			MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+				data, ok := secrets[key]
+				if !ok {
+					return errBoom
+				}
+				obj.(*v1.Secret).Data = data
+				return nil
+			},
+		},
+	}
+
+	_, secureJsonData, _, err := e.MakeJsonData(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"password":         "team-b",
+		"httpHeaderValue1": "monitoring",
+	}, *secureJsonData)
+}
+
+func TestMakeJsonDataNumbersHeadersAfterExistingOnes(t *testing.T) {
+	cr := dataSource()
+	cr.Spec.ForProvider.JSONDataEncoded = strRef(`{"httpHeaderName1": "X-Custom"}`)
+	cr.Spec.ForProvider.HTTPHeadersSecretRef = &xpv1.SecretReference{Name: "headers", Namespace: "default"}
+
+	e := external{
+		kube: &test.MockClient{
+			MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+				obj.(*v1.Secret).Data = map[string][]byte{"X-Scope-OrgID": []byte("tenant")}
+				return nil
+			},
+		},
+	}
+
+	jsonData, secureJsonData, _, err := e.MakeJsonData(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"httpHeaderName1": "X-Custom",
+		"httpHeaderName2": "X-Scope-OrgID",
+	}, *jsonData)
+	assert.Equal(t, map[string]string{
+		"httpHeaderValue2": "tenant",
+	}, *secureJsonData)
+}
+
+func TestMakeJsonDataRejectsIncompleteTLSSecret(t *testing.T) {
+	cr := dataSource()
+	cr.Spec.ForProvider.TLSConfigSecretRef = &xpv1.SecretReference{Name: "tls", Namespace: "default"}
+
+	e := external{
+		kube: &test.MockClient{
+			MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*v1.Secret).Data = map[string][]byte{common.TLSCertKey: []byte("cert")}
+				return nil
+			}),
+		},
+	}
+
+	_, _, _, err := e.MakeJsonData(context.Background(), cr)
+	assert.NotNil(t, err)
+}
+
+func TestMakeJsonDataReportsAllSecretErrors(t *testing.T) {
+	cr := dataSource()
+	cr.Spec.ForProvider.SecureJSONDataEncodedSecretRef = &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "grafana", Namespace: "default"},
+		Key:             "secureJsonData",
+	}
+	cr.Spec.ForProvider.HTTPHeadersSecretRef = &xpv1.SecretReference{Name: "headers", Namespace: "default"}
+
+	e := external{
+		kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+	}
+
+	_, _, _, err := e.MakeJsonData(context.Background(), cr)
+	want := kerrors.NewAggregate([]error{
+		errors.Wrap(errBoom, errFailedGetHeadersSecret),
+		errors.Wrap(errBoom, errGetSecret),
+	})
+	if diff := cmp.Diff(want.Error(), err.Error()); diff != "" {
+		t.Errorf("e.MakeJsonData(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestMakeJsonDataFailsIfTLSSecretIsMissing(t *testing.T) {
+	cr := dataSource()
+	cr.Spec.ForProvider.TLSConfigSecretRef = &xpv1.SecretReference{Name: "tls", Namespace: "default"}
+
+	e := external{
+		kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+	}
+
+	_, _, _, err := e.MakeJsonData(context.Background(), cr)
+	assert.True(t, errors.Is(err, errBoom))
+	assert.Contains(t, err.Error(), errFailedGetTLSSecret)
+}
+
+func TestIsUpToDateWithTLS(t *testing.T) {
+	signingKey := []byte("signing-key")
+	storedHash, err := hashSecureJSONData(signingKey, map[string]string{"tlsCACert": "ca", "tlsClientCert": "cert", "tlsClientKey": "key"}, map[string]string{})
+	assert.Nil(t, err)
+	cr := dataSource()
+	cr.Spec.ForProvider.TLSConfigSecretRef = &xpv1.SecretReference{Name: "tls", Namespace: "default"}
+	cr.Status.AtProvider.SecureJSONDataHash = &storedHash
+	atGrafana := grafanaDataSource()
+	atGrafana.JSONData = map[string]interface{}{"tlsAuth": true, "tlsAuthWithCACert": true}
+	atGrafana.SecureJSONFields = map[string]bool{"tlsCACert": true, "tlsClientCert": true, "tlsClientKey": true}
+
+	probe, err := isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, nil, tlsSecret("cert"), nil, signingKey)
+	assert.Nil(t, err)
+	assert.True(t, probe)
+
+	// a renewed certificate is only visible in the hash, Grafana does not return it
+	probe, err = isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, nil, tlsSecret("renewed"), nil, signingKey)
+	assert.Nil(t, err)
+	assert.False(t, probe)
+
+	// TLS is not enabled in Grafana yet
+	atGrafana.JSONData = map[string]interface{}{}
+	probe, err = isUpToDate(logging.NewNopLogger(), cr, atGrafana, 1, nil, tlsSecret("cert"), nil, signingKey)
+	assert.Nil(t, err)
+	assert.False(t, probe)
+
+	delta, err := Diff(cr, atGrafana, 1, nil, tlsSecret("cert"), nil, signingKey)
+	assert.Nil(t, err)
+	assert.Contains(t, delta, "tlsAuth")
+	assert.NotContains(t, delta, "cert\"")
+}
+
+func TestHashSecureJSONDataIsStable(t *testing.T) {
+	key := []byte("signing-key")
+	first, err := hashSecureJSONData(key, map[string]string{"a": "1", "b": "2"}, map[string]string{"X": "1", "Y": "2"})
+	assert.Nil(t, err)
+	second, err := hashSecureJSONData(key, map[string]string{"b": "2", "a": "1"}, map[string]string{"Y": "2", "X": "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, first, second)
+
+	// moving a value from the secure json data to the headers must change the hash
+	moved, err := hashSecureJSONData(key, map[string]string{"a": "1", "b": "2", "X": "1"}, map[string]string{"Y": "2"})
+	assert.Nil(t, err)
+	assert.NotEqual(t, first, moved)
+}
+
 func strRef(s string) *string {
 	return &s
 }
 func boolRef(b bool) *bool {
 	return &b
 }
+
+func TestOrgIdRequired(t *testing.T) {
+	// without orgId and defaultOrgId every operation should fail instead of dereferencing the orgId
+	want := errors.New(common.ErrOrgIdRequired)
+	e := external{service: &common.MockGrafanaAPI{}}
+	withoutOrgId := func() *v1alpha1.DataSource {
+		cr := dataSource()
+		cr.Spec.ForProvider.OrgID = nil
+		return cr
+	}
+
+	_, err := e.Observe(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	_, err = e.Create(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	_, err = e.Update(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	err = e.Delete(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestObserveAdoptsExisting(t *testing.T) {
+	// the data source was created by Terraform with another name, so it is only found by the UID of the spec
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDataSourceByName", int64(1), "test").Return(nil, nil)
+	m.On("GetDataSourceByUid", int64(1), "abc").Return(grafanaDataSource(), nil)
+
+	cr := dataSource()
+	cr.Spec.ForProvider.UID = strRef("abc")
+	cr.SetAnnotations(map[string]string{common.AnnotationKeyAdoptExisting: "true"})
+
+	e := external{service: m}
+	got, err := e.Observe(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	want := managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: dataSourceConnectionDetails()}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestObserveImportsByExternalName(t *testing.T) {
+	// the status is empty, but the external-name holds the UID of an existing data source with another name
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDataSourceByUid", int64(1), "abc").Return(grafanaDataSource(), nil)
+
+	cr := dataSource()
+	meta.SetExternalName(cr, "abc")
+
+	e := external{service: m}
+	got, err := e.Observe(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.True(t, got.ResourceExists)
+	assert.Equal(t, strRef("1:2"), cr.Status.AtProvider.ID)
+	m.AssertNotCalled(t, "GetDataSourceByName", mock.Anything, mock.Anything)
+}
+
+func TestObserveFallsBackToNameIfExternalNameIsNoUID(t *testing.T) {
+	// the external-name defaults to the name of the resource, which is no UID
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDataSourceByUid", int64(1), "test").Return(nil, nil)
+	m.On("GetDataSourceByName", int64(1), "test").Return(grafanaDataSource(), nil)
+
+	cr := dataSource()
+	meta.SetExternalName(cr, "test")
+
+	e := external{service: m}
+	got, err := e.Observe(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.True(t, got.ResourceExists)
+	m.AssertExpectations(t)
+}
+
+func TestCreateSetsExternalName(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateDataSource", int64(1), mock.Anything).Return(&models.AddDataSourceOKBody{Datasource: grafanaDataSource()}, nil)
+
+	cr := dataSource()
+	meta.SetExternalName(cr, "test")
+
+	e := external{service: m}
+	_, err := e.Create(context.Background(), cr)
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", meta.GetExternalName(cr))
+	assert.Equal(t, "abc", common.DefaultString(cr.Status.AtProvider.UID, ""))
+}
+
+func TestCheckJSONData(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		dsType   string
+		jsonData string
+		want     xpv1.ConditionReason
+		message  string
+	}{
+		"Valid": {
+			reason:   "Known keys with accepted values should look valid",
+			dsType:   "prometheus",
+			jsonData: `{"httpMethod":"POST","timeInterval":"30s"}`,
+			want:     v1alpha1.ReasonJSONDataLooksValid,
+		},
+		"WrongCase": {
+			reason:   "A key that differs from a known one in case should be reported",
+			dsType:   "prometheus",
+			jsonData: `{"HTTPMethod":"POST"}`,
+			want:     v1alpha1.ReasonJSONDataSuspicious,
+			message:  `key "HTTPMethod" is ignored by Grafana, did you mean "httpMethod"?`,
+		},
+		"UnexpectedValue": {
+			reason:   "A value that is not accepted should be reported",
+			dsType:   "prometheus",
+			jsonData: `{"httpMethod":"post"}`,
+			want:     v1alpha1.ReasonJSONDataSuspicious,
+			message:  `key "httpMethod" has the value post, expected one of ["GET" "POST"]`,
+		},
+		"MissingRequired": {
+			reason:   "A missing required key should be reported",
+			dsType:   "elasticsearch",
+			jsonData: `{"index":"logs-*"}`,
+			want:     v1alpha1.ReasonJSONDataSuspicious,
+			message:  `key "timeField" is missing`,
+		},
+		"UnknownType": {
+			reason:   "Data sources of types without known keys should not be checked",
+			dsType:   "mysql",
+			jsonData: `{"HTTPMethod":"POST"}`,
+		},
+		"InvalidJSON": {
+			reason:   "jsonData that cannot be parsed should be left to the sync, which fails anyway",
+			dsType:   "prometheus",
+			jsonData: `{`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := dataSource()
+			cr.Spec.ForProvider.Type = &tc.dsType
+			cr.Spec.ForProvider.JSONDataEncoded = &tc.jsonData
+
+			checkJSONData(cr)
+
+			got := cr.GetCondition(v1alpha1.TypeJSONDataValid)
+			if diff := cmp.Diff(tc.want, got.Reason); diff != "" {
+				t.Errorf("\n%s\ncheckJSONData(...): -want reason, +got reason:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.message, got.Message); diff != "" {
+				t.Errorf("\n%s\ncheckJSONData(...): -want message, +got message:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveSetsReadyCondition(t *testing.T) {
+	drifted := grafanaDataSource()
+	drifted.URL = "http://other:9090"
+
+	cases := map[string]struct {
+		reason    string
+		atGrafana *models.DataSource
+		err       error
+		status    v1.ConditionStatus
+		want      xpv1.ConditionReason
+	}{
+		"UpToDate": {
+			reason:    "A DataSource that is up to date should be available",
+			atGrafana: grafanaDataSource(),
+			status:    v1.ConditionTrue,
+			want:      xpv1.ReasonAvailable,
+		},
+		"Drifted": {
+			reason:    "A DataSource that differs from its spec should be unavailable, because it drifted",
+			atGrafana: drifted,
+			status:    v1.ConditionFalse,
+			want:      common.ReasonResourceDrifted,
+		},
+		"APIError": {
+			reason: "A DataSource that cannot be observed should be unavailable, because of the API error",
+			err:    errBoom,
+			status: v1.ConditionFalse,
+			want:   common.ReasonAPIError,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetDataSourceByName", int64(1), "test").Return(tc.atGrafana, tc.err)
+			cr := dataSource()
+			// the DataSource was available before
+			cr.SetConditions(xpv1.Available())
+
+			e := external{service: m}
+			_, err := e.Observe(context.Background(), cr)
+
+			ready := cr.GetCondition(xpv1.TypeReady)
+			assert.Equal(t, tc.status, ready.Status, tc.reason)
+			assert.Equal(t, tc.want, ready.Reason, tc.reason)
+			if tc.err != nil {
+				assert.EqualError(t, err, ready.Message, tc.reason)
+			}
+		})
+	}
+}
+
+func TestDeleteRefusedIfProtected(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	cr := dataSource()
+	meta.AddAnnotations(cr, map[string]string{common.AnnotationKeyDeletionProtection: "true"})
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), cr)
+	assert.EqualError(t, err, common.ErrDeletionProtected)
+	assert.Empty(t, m.Calls)
+	assert.Equal(t, v1.ConditionTrue, cr.GetCondition(common.TypeDeletionProtected).Status)
+}
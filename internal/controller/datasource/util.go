@@ -2,11 +2,19 @@ package datasource
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	stdjson "encoding/json"
+	"sort"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/json"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
 )
 
 func makeJSONData(data *string) (map[string]interface{}, error) {
@@ -19,6 +27,54 @@ func makeJSONData(data *string) (map[string]interface{}, error) {
 	return jd, nil
 }
 
+// normalizeJSONData converts the numbers in jsonData to float64, the way Grafana returns them.
+func normalizeJSONData(jsonData map[string]interface{}) (map[string]interface{}, error) {
+	raw, err := stdjson.Marshal(jsonData)
+	if err != nil {
+		return nil, errors.Wrap(err, errUnmarshalJson)
+	}
+	normalized := make(map[string]interface{})
+	if err := stdjson.Unmarshal(raw, &normalized); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalJson)
+	}
+	return normalized, nil
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// basicAuthPasswordKey is the key of the basic auth password in the secure JSON data.
+const basicAuthPasswordKey = "basicAuthPassword"
+
+// basicAuthPassword returns the basic auth password in the secure JSON data, if basic auth is enabled and has one.
+func basicAuthPassword(spec v1alpha1.DataSourceParameters, secureJSONData map[string]string) (string, bool) {
+	if !common.DefaultBool(spec.BasicAuthEnabled, false) {
+		return "", false
+	}
+	password, ok := secureJSONData[basicAuthPasswordKey]
+	return password, ok
+}
+
+// hashBasicAuthPassword returns the salted hash of the basic auth password, or nil if there is none. Unlike the hash of
+// all secure JSON data, it does not require a signing key.
+func hashBasicAuthPassword(spec v1alpha1.DataSourceParameters, secureJSONData map[string]string) (*string, error) {
+	password, ok := basicAuthPassword(spec, secureJSONData)
+	if !ok {
+		return nil, nil
+	}
+	hash, err := common.HashPassword(password, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &hash, nil
+}
+
 func makeSecureJSONData(data *string) (map[string]string, error) {
 	sjd := make(map[string]string)
 	if data != nil && *data != "" {
@@ -29,6 +85,22 @@ func makeSecureJSONData(data *string) (map[string]string, error) {
 	return sjd, nil
 }
 
+// hashSecureJSONData signs the secure JSON data and the HTTP header values with HMAC-SHA256. Both maps are
+// serialized with sorted keys, so the hash does not depend on the order of the keys. The headers are kept apart from
+// the secure JSON data, since their httpHeaderValueN keys are assigned in random order.
+func hashSecureJSONData(signingKey []byte, secureJSONData map[string]string, headers map[string]string) (string, error) {
+	canonical, err := json.Marshal(map[string]map[string]string{
+		"headers":        headers,
+		"secureJsonData": secureJSONData,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, errHashSecureJson)
+	}
+	mac := hmac.New(sha256.New, signingKey)
+	mac.Write(canonical)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
 func (c *external) getValueFromSecret(ctx context.Context, selector v1.SecretKeySelector) (*string, error) {
 	secret, err := c.getSecret(ctx, selector.SecretReference)
 	if resource.IgnoreNotFound(err) != nil {
@@ -0,0 +1,133 @@
+package datasource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+)
+
+// A jsonDataKey describes a key of the jsonData of a data source type.
+type jsonDataKey struct {
+	// name is the key as Grafana expects it, keys are case-sensitive.
+	name string
+	// required keys are reported if they are missing.
+	required bool
+	// values lists the accepted values of the key, any value is accepted if it is empty.
+	values []string
+	// defaultValue is the value Grafana assumes if the key is not set. Unless it is nil, it is added to the desired
+	// jsonData of data sources that don't set the key, so that it doesn't show as drift.
+	defaultValue interface{}
+}
+
+// knownJSONData lists the keys of the jsonData of well-known data source types. It is not meant to be complete:
+// unknown keys are accepted, but keys that differ from a listed one only in case are reported, as Grafana silently
+// ignores them. To check another type, add its keys here. The default values of the keys are applied by
+// withJSONDataDefaults.
+var knownJSONData = map[string][]jsonDataKey{
+	"prometheus": {
+		{name: "httpMethod", values: []string{"GET", "POST"}, defaultValue: "POST"},
+		{name: "manageAlerts"},
+		{name: "prometheusType", values: []string{"Cortex", "Mimir", "Prometheus", "Thanos"}},
+		{name: "queryTimeout"},
+		{name: "timeInterval"},
+	},
+	"loki": {
+		{name: "derivedFields"},
+		{name: "manageAlerts"},
+		{name: "maxLines", defaultValue: "1000"},
+	},
+	"tempo": {
+		{name: "nodeGraph"},
+		{name: "search"},
+		{name: "serviceMap"},
+		{name: "tracesToLogsV2"},
+		{name: "tracesToMetrics"},
+	},
+	"elasticsearch": {
+		{name: "index"},
+		{name: "interval", values: []string{"", "Daily", "Hourly", "Monthly", "Weekly", "Yearly"}},
+		{name: "logLevelField"},
+		{name: "logMessageField"},
+		{name: "maxConcurrentShardRequests"},
+		{name: "timeField", required: true},
+	},
+}
+
+// checkJSONData sets the JSONDataValid condition of data sources of a type listed in knownJSONData. The result is
+// only reported, the data source is synced regardless.
+func checkJSONData(cr *v1alpha1.DataSource) {
+	keys, ok := knownJSONData[common.DefaultString(cr.Spec.ForProvider.Type, "")]
+	if !ok {
+		return
+	}
+	jsonData, err := makeJSONData(cr.Spec.ForProvider.JSONDataEncoded)
+	if err != nil {
+		// syncing the data source fails with this error anyway
+		return
+	}
+	if problems := jsonDataProblems(keys, jsonData); len(problems) > 0 {
+		cr.SetConditions(v1alpha1.JSONDataSuspicious(strings.Join(problems, "; ")))
+		return
+	}
+	cr.SetConditions(v1alpha1.JSONDataLooksValid())
+}
+
+// withJSONDataDefaults adds the default values of the keys in knownJSONData for the type to jsonData, unless jsonData
+// sets them itself or the spec doesn't apply them. Grafana stores some of them when data sources are saved, so
+// jsonData without them would never be up to date.
+func withJSONDataDefaults(spec v1alpha1.DataSourceParameters, jsonData map[string]interface{}) map[string]interface{} {
+	if !common.DefaultBool(spec.ApplyJSONDataDefaults, false) {
+		return jsonData
+	}
+	for _, key := range knownJSONData[common.DefaultString(spec.Type, "")] {
+		if _, ok := jsonData[key.name]; !ok && key.defaultValue != nil {
+			jsonData[key.name] = key.defaultValue
+		}
+	}
+	return jsonData
+}
+
+// jsonDataProblems describes every key of jsonData that does not match the given keys.
+func jsonDataProblems(keys []jsonDataKey, jsonData map[string]interface{}) []string {
+	var problems []string
+	for _, key := range keys {
+		value, ok := jsonData[key.name]
+		if !ok {
+			if misspelled := findIgnoringCase(jsonData, key.name); misspelled != "" {
+				problems = append(problems, fmt.Sprintf("key %q is ignored by Grafana, did you mean %q?", misspelled, key.name))
+			} else if key.required {
+				problems = append(problems, fmt.Sprintf("key %q is missing", key.name))
+			}
+			continue
+		}
+		if len(key.values) > 0 && !isOneOf(value, key.values) {
+			problems = append(problems, fmt.Sprintf("key %q has the value %v, expected one of %q", key.name, value, key.values))
+		}
+	}
+	return problems
+}
+
+// findIgnoringCase returns the key of jsonData that matches name ignoring case, or an empty string if there is none.
+func findIgnoringCase(jsonData map[string]interface{}, name string) string {
+	for _, key := range sortedKeys(jsonData) {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return ""
+}
+
+func isOneOf(value interface{}, values []string) bool {
+	s, ok := value.(string)
+	if !ok {
+		return false
+	}
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
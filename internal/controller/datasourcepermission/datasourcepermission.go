@@ -0,0 +1,483 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasourcepermission
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotDataSourcePermission = "managed resource is not a DataSourcePermission custom resource"
+	errTrackPCUsage            = "cannot track ProviderConfig usage"
+	errGetPC                   = "cannot get ProviderConfig"
+	errOrgIdNotInt             = "orgId is not an integer"
+
+	errNewClient         = "cannot create new Service"
+	errGetPermissions    = "cannot get permissions of data source"
+	errUpdatePermissions = "cannot update permissions of data source"
+	errTeamIdNotInt      = "teamId %q is not an integer"
+	errUserIdNotInt      = "userId %q is not an integer"
+
+	// msgPermissionsUnavailable explains why a DataSourcePermission is not applied if Grafana lacks the API.
+	msgPermissionsUnavailable = "data source permissions are not available, they require Grafana Enterprise or Grafana Cloud"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles DataSourcePermission managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.DataSourcePermissionGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.DataSourcePermissionGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.DataSourcePermission{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.DataSourcePermission)
+	if !ok {
+		return nil, errors.New(errNotDataSourcePermission)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	defaultOrgID *int64
+}
+
+// principal is the role, team or user a permission is granted to. Exactly one of its fields is set.
+type principal struct {
+	BuiltInRole string
+	TeamID      int64
+	UserID      int64
+}
+
+// Observe reads the permissions of the data source. Grafana OSS does not offer data source permissions, in which case
+// the resource is reported as up to date with a condition explaining why nothing is applied.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DataSourcePermission)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDataSourcePermission)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	actual, err := c.getPermissions(orgId, *cr.Spec.ForProvider.DatasourceUID)
+	if isUnavailable(err) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		cr.SetConditions(v1alpha1.EnterpriseRequired(msgPermissionsUnavailable))
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        true,
+			ConnectionDetails:       managed.ConnectionDetails{},
+		}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if cr.Status.AtProvider.ID == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	desired, err := mapPermissions(cr.Spec.ForProvider.Permissions)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if meta.WasDeleted(cr) && len(actual) == 0 {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(cr, actual)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: permissionsEqual(desired, actual),
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Create applies the permissions to the data source, as the data source itself is not managed by this resource.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.DataSourcePermission)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotDataSourcePermission)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	if err := c.apply(cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.DataSourcePermission)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotDataSourcePermission)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.apply(cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Delete removes all permissions of the data source, as the resource manages its complete set of permissions.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.DataSourcePermission)
+	if !ok {
+		return errors.New(errNotDataSourcePermission)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	uid := *cr.Spec.ForProvider.DatasourceUID
+	actual, err := c.getPermissions(orgId, uid)
+	if isUnavailable(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(actual) == 0 {
+		return nil
+	}
+
+	err = c.service.UpdateDataSourcePermissions(orgId, uid, permissionCommands(nil, actual))
+	return errors.Wrap(err, errUpdatePermissions)
+}
+
+// apply sets the desired permissions and removes all others, so that the data source ends up with exactly the
+// permissions of the spec.
+func (c *external) apply(cr *v1alpha1.DataSourcePermission) error {
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	desired, err := mapPermissions(cr.Spec.ForProvider.Permissions)
+	if err != nil {
+		return err
+	}
+
+	uid := *cr.Spec.ForProvider.DatasourceUID
+	actual, err := c.getPermissions(orgId, uid)
+	if err != nil {
+		return err
+	}
+
+	if err := c.service.UpdateDataSourcePermissions(orgId, uid, permissionCommands(desired, actual)); err != nil {
+		return errors.Wrap(err, errUpdatePermissions)
+	}
+
+	id := fmt.Sprintf("%d:%s", orgId, uid)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = cr.Spec.ForProvider.OrgID
+	cr.Status.AtProvider.DatasourceUID = &uid
+	return nil
+}
+
+// getPermissions returns the permissions managed through the permissions API. Permissions granted by fixed roles are
+// returned by Grafana as well, but cannot be changed and are ignored.
+func (c *external) getPermissions(orgId int64, uid string) (map[principal]string, error) {
+	permissions, err := c.service.GetDataSourcePermissions(orgId, uid)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetPermissions)
+	}
+	actual := make(map[principal]string, len(permissions))
+	for _, permission := range permissions {
+		if !permission.IsManaged || permission.IsInherited {
+			continue
+		}
+		p := principal{BuiltInRole: permission.BuiltInRole, TeamID: permission.TeamID, UserID: permission.UserID}
+		actual[p] = permission.Permission
+	}
+	return actual, nil
+}
+
+// isUnavailable returns true if Grafana does not offer the data source permissions API.
+func isUnavailable(err error) bool {
+	return common.IsCode(err, http.StatusNotFound, http.StatusNotImplemented)
+}
+
+func mapPermissions(items []v1alpha1.DataSourcePermissionItem) (map[principal]string, error) {
+	desired := make(map[principal]string, len(items))
+	for _, item := range items {
+		p := principal{BuiltInRole: common.DefaultString(item.BuiltInRole, "")}
+		if item.TeamID != nil {
+			id, err := strconv.ParseInt(*item.TeamID, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, errTeamIdNotInt, *item.TeamID)
+			}
+			p.TeamID = id
+		}
+		if item.UserID != nil {
+			id, err := strconv.ParseInt(*item.UserID, 10, 64)
+			if err != nil {
+				return nil, errors.Wrapf(err, errUserIdNotInt, *item.UserID)
+			}
+			p.UserID = id
+		}
+		desired[p] = common.DefaultString(item.Permission, "")
+	}
+	return desired, nil
+}
+
+// permissionCommands sets every desired permission and removes the actual permissions that are not desired, by
+// setting them to an empty permission.
+func permissionCommands(desired, actual map[principal]string) []*models.SetResourcePermissionCommand {
+	var commands []*models.SetResourcePermissionCommand
+	for p, permission := range desired {
+		commands = append(commands, permissionCommand(p, permission))
+	}
+	for p := range actual {
+		if _, ok := desired[p]; !ok {
+			commands = append(commands, permissionCommand(p, ""))
+		}
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return lessPrincipal(principalOf(commands[i]), principalOf(commands[j]))
+	})
+	return commands
+}
+
+func permissionCommand(p principal, permission string) *models.SetResourcePermissionCommand {
+	return &models.SetResourcePermissionCommand{
+		BuiltInRole: p.BuiltInRole,
+		TeamID:      p.TeamID,
+		UserID:      p.UserID,
+		Permission:  permission,
+	}
+}
+
+func principalOf(command *models.SetResourcePermissionCommand) principal {
+	return principal{BuiltInRole: command.BuiltInRole, TeamID: command.TeamID, UserID: command.UserID}
+}
+
+func lessPrincipal(a, b principal) bool {
+	if a.BuiltInRole != b.BuiltInRole {
+		return a.BuiltInRole < b.BuiltInRole
+	}
+	if a.TeamID != b.TeamID {
+		return a.TeamID < b.TeamID
+	}
+	return a.UserID < b.UserID
+}
+
+func permissionsEqual(desired, actual map[principal]string) bool {
+	if len(desired) != len(actual) {
+		return false
+	}
+	for p, permission := range desired {
+		if actualPermission, ok := actual[p]; !ok || actualPermission != permission {
+			return false
+		}
+	}
+	return true
+}
+
+func copyToStatus(cr *v1alpha1.DataSourcePermission, actual map[principal]string) {
+	principals := make([]principal, 0, len(actual))
+	for p := range actual {
+		principals = append(principals, p)
+	}
+	sort.Slice(principals, func(i, j int) bool {
+		return lessPrincipal(principals[i], principals[j])
+	})
+
+	permissions := make([]v1alpha1.DataSourcePermissionItem, 0, len(principals))
+	for _, p := range principals {
+		permission := actual[p]
+		item := v1alpha1.DataSourcePermissionItem{Permission: &permission}
+		switch {
+		case p.TeamID != 0:
+			teamId := strconv.FormatInt(p.TeamID, 10)
+			item.TeamID = &teamId
+		case p.UserID != 0:
+			userId := strconv.FormatInt(p.UserID, 10)
+			item.UserID = &userId
+		default:
+			builtInRole := p.BuiltInRole
+			item.BuiltInRole = &builtInRole
+		}
+		permissions = append(permissions, item)
+	}
+	cr.Status.AtProvider.Permissions = permissions
+}
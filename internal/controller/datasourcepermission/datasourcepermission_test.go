@@ -0,0 +1,333 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package datasourcepermission
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func dataSourcePermission() *v1alpha1.DataSourcePermission {
+	return &v1alpha1.DataSourcePermission{
+		Spec: v1alpha1.DataSourcePermissionSpec{
+			ForProvider: v1alpha1.DataSourcePermissionParameters{
+				DatasourceUID: strRef("abc"),
+				OrgID:         strRef("1"),
+				Permissions: []v1alpha1.DataSourcePermissionItem{
+					{BuiltInRole: strRef("Viewer"), Permission: strRef("Query")},
+					{TeamID: strRef("2"), Permission: strRef("Edit")},
+				},
+			},
+		},
+		Status: v1alpha1.DataSourcePermissionStatus{
+			AtProvider: v1alpha1.DataSourcePermissionObservation{
+				ID: strRef("1:abc"),
+			},
+		},
+	}
+}
+
+func deletedDataSourcePermission() *v1alpha1.DataSourcePermission {
+	cr := dataSourcePermission()
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	return cr
+}
+
+// grafanaPermissions returns the permissions of the spec with the given permission of the team, as well as a
+// permission granted by a fixed role.
+func grafanaPermissions(teamPermission string) []*models.ResourcePermissionDTO {
+	return []*models.ResourcePermissionDTO{
+		{BuiltInRole: "Viewer", Permission: "Query", IsManaged: true},
+		{TeamID: 2, Permission: teamPermission, IsManaged: true},
+		{BuiltInRole: "Admin", Permission: "Admin"},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o          managed.ExternalObservation
+		conditions []v1.Condition
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotDataSourcePermission": {
+			reason:  "An error should be returned if the managed resource is not a DataSourcePermission",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotDataSourcePermission),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the permissions cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(nil, errBoom)
+				return m
+			}(),
+			mg: dataSourcePermission(),
+			want: want{
+				err: errors.Wrap(errBoom, errGetPermissions),
+			},
+		},
+		"NotApplied": {
+			reason: "The permissions should be reported as missing until they were applied",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(grafanaPermissions("Edit"), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := dataSourcePermission()
+				cr.Status.AtProvider.ID = nil
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The permissions should be reported as up to date if they match, ignoring those of fixed roles",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(grafanaPermissions("Edit"), nil)
+				return m
+			}(),
+			mg: dataSourcePermission(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+		"PermissionChanged": {
+			reason: "The permissions should be reported as outdated if the permission of a team differs",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(grafanaPermissions("Query"), nil)
+				return m
+			}(),
+			mg: dataSourcePermission(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+		"PermissionAddedInGrafana": {
+			reason: "The permissions should be reported as outdated if Grafana has a permission that is not in the spec",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(append(grafanaPermissions("Edit"),
+					&models.ResourcePermissionDTO{UserID: 3, Permission: "Admin", IsManaged: true}), nil)
+				return m
+			}(),
+			mg: dataSourcePermission(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+		"Unavailable": {
+			reason: "The permissions should be reported as up to date with an explaining condition if Grafana lacks the API",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(nil, runtime.NewAPIError("getResourcePermissions", nil, 404))
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := dataSourcePermission()
+				cr.Status.AtProvider.ID = nil
+				return cr
+			}(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1alpha1.EnterpriseRequired(msgPermissionsUnavailable)},
+			},
+		},
+		"UnavailableAfterDeletion": {
+			reason: "The permissions should be reported as deleted if Grafana lacks the API and the resource was deleted",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(nil, runtime.NewAPIError("getResourcePermissions", nil, 501))
+				return m
+			}(),
+			mg: deletedDataSourcePermission(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"RemovedAfterDeletion": {
+			reason: "The permissions should be reported as deleted once no managed permission is left",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return([]*models.ResourcePermissionDTO{
+					{BuiltInRole: "Admin", Permission: "Admin"},
+				}, nil)
+				return m
+			}(),
+			mg: deletedDataSourcePermission(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.DataSourcePermission); ok {
+				for _, want := range tc.want.conditions {
+					if got := cr.GetCondition(want.Type); !got.Equal(want) {
+						t.Errorf("\n%s\ne.Observe(...): want condition %v, got %v\n", tc.reason, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCreateRemovesPermissionsNotInSpec(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetDataSourcePermissions", int64(1), "abc").Return([]*models.ResourcePermissionDTO{
+		{BuiltInRole: "Editor", Permission: "Query", IsManaged: true},
+		{TeamID: 2, Permission: "Query", IsManaged: true},
+	}, nil)
+	m.On("UpdateDataSourcePermissions", int64(1), "abc", []*models.SetResourcePermissionCommand{
+		{TeamID: 2, Permission: "Edit"},
+		{BuiltInRole: "Editor", Permission: ""},
+		{BuiltInRole: "Viewer", Permission: "Query"},
+	}).Return(nil)
+
+	cr := dataSourcePermission()
+	cr.Status.AtProvider.ID = nil
+
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(strRef("1:abc"), cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateInvalidTeamId(t *testing.T) {
+	cr := dataSourcePermission()
+	cr.Spec.ForProvider.Permissions[1].TeamID = strRef("team")
+
+	e := external{service: &common.MockGrafanaAPI{}}
+	_, err := e.Update(context.Background(), cr)
+	_, errParse := strconv.ParseInt("team", 10, 64)
+	if diff := cmp.Diff(errors.Wrapf(errParse, errTeamIdNotInt, "team"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		service func() *common.MockGrafanaAPI
+		want    error
+	}{
+		"RemovesAllPermissions": {
+			reason: "All managed permissions of the data source should be removed",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(grafanaPermissions("Edit"), nil)
+				m.On("UpdateDataSourcePermissions", int64(1), "abc", []*models.SetResourcePermissionCommand{
+					{TeamID: 2, Permission: ""},
+					{BuiltInRole: "Viewer", Permission: ""},
+				}).Return(nil)
+				return m
+			},
+		},
+		"Unavailable": {
+			reason: "Nothing should be removed if Grafana lacks the API",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(nil, runtime.NewAPIError("getResourcePermissions", nil, 404))
+				return m
+			},
+		},
+		"UpdateFailed": {
+			reason: "An error should be returned if the permissions cannot be removed",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetDataSourcePermissions", int64(1), "abc").Return(grafanaPermissions("Edit"), nil)
+				m.On("UpdateDataSourcePermissions", int64(1), "abc", []*models.SetResourcePermissionCommand{
+					{TeamID: 2, Permission: ""},
+					{BuiltInRole: "Viewer", Permission: ""},
+				}).Return(errBoom)
+				return m
+			},
+			want: errors.Wrap(errBoom, errUpdatePermissions),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			e := external{service: m}
+			err := e.Delete(context.Background(), dataSourcePermission())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
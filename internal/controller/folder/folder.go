@@ -17,14 +17,12 @@ limitations under the License.
 package folder
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"fmt"
-	"io"
-	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
@@ -32,6 +30,8 @@ import (
 
 	"github.com/argannor/provider-grafana/internal/controller/common"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/google/go-cmp/cmp"
 	grafana "github.com/grafana/grafana-openapi-client-go/client"
 	"github.com/grafana/grafana-openapi-client-go/models"
 	"github.com/pkg/errors"
@@ -55,16 +55,30 @@ const (
 	errNotFolder    = "managed resource is not a Folder custom resource"
 	errTrackPCUsage = "cannot track ProviderConfig usage"
 	errGetPC        = "cannot get ProviderConfig"
-	errGetCreds     = "cannot get credentials"
-	errCredsFormat  = "credentials are not formatted as base64 encoded 'username:password' pair"
 	errOrgIdNotInt  = "orgId is not an integer"
 	errIdNotInt     = "folder ID is not an integer"
+	errIdFormat     = "folder ID %q is not formatted as 'orgId:id'"
 
 	errNewClient          = "cannot create new Service"
 	errFailedGetFolder    = "cannot get Folder from Grafana API"
 	errFailedCreateFolder = "cannot create Folder"
 	errFailedUpdateFolder = "cannot update Folder"
+	errFailedMoveFolder   = "cannot move Folder"
 	errFailedDeleteFolder = "cannot delete Folder"
+	errFailedCountFolder  = "cannot count the contents of Folder"
+	errFolderNotEmpty     = "folder is not empty, it contains %s: delete them first or set contentDeletionPolicy to Cascade"
+
+	msgCreated = "created folder %q with uid %q"
+	msgUpdated = "updated folder %q"
+	msgDeleted = "deleted folder %q"
+
+	// reasons of the events recorded when the provider changed a folder in Grafana
+	reasonCreated event.Reason = "CreatedFolder"
+	reasonUpdated event.Reason = "UpdatedFolder"
+	reasonDeleted event.Reason = "DeletedFolder"
+
+	// featureNestedFolders is the feature toggle of Grafana that enables parent folders
+	featureNestedFolders = "nestedFolders"
 )
 
 var (
@@ -77,22 +91,26 @@ var (
 // Setup adds a controller that reconciles Folder managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.FolderGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
 	}
 
-	r := managed.NewReconciler(mgr,
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
 		resource.ManagedKind(v1alpha1.FolderGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
 			newServiceFn: newService,
-			logger:       o.Logger}),
+			logger:       o.Logger,
+			recorder:     recorder}))),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -100,7 +118,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.Folder{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -109,6 +127,7 @@ type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
 	logger       logging.Logger
+	recorder     event.Recorder
 	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
 }
 
@@ -132,41 +151,42 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
 	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
+		return nil, err
 	}
 
-	decoder := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data))
-	decodedCredentials, err := io.ReadAll(decoder)
+	svc, err := c.newServiceFn(clientCfg)
 	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-	parts := strings.Split(string(decodedCredentials), ":")
-	if len(parts) != 2 {
-		return nil, errors.New(errCredsFormat)
+		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	clientCfg := grafana.DefaultTransportConfig()
-	clientCfg = clientCfg.WithHost(fmt.Sprintf("%s:%d", pc.Spec.Host, pc.Spec.Port))
-	clientCfg = clientCfg.WithSchemes(pc.Spec.Schemes)
-	clientCfg.BasicAuth = url.UserPassword(parts[0], parts[1])
-
-	svc, err := c.newServiceFn(clientCfg)
+	// without the feature flags the parent folders are applied and Grafana reports if it cannot nest them
+	info, err := common.ServerInfos.Get(pc.Name, svc.GetServerInfo)
 	if err != nil {
-		return nil, errors.Wrap(err, errNewClient)
+		c.logger.Debug("Cannot get the feature flags of Grafana", "error", err)
 	}
 
-	return &external{service: svc, logger: c.logger, kube: c.kube}, nil
+	return &external{
+		service:               svc,
+		logger:                c.logger,
+		recorder:              c.recorder,
+		kube:                  c.kube,
+		defaultOrgID:          pc.Spec.DefaultOrgID,
+		nestedFoldersDisabled: info != nil && !info.FeatureEnabled(featureNestedFolders),
+	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	service common.GrafanaAPI
-	logger  logging.Logger
-	kube    client.Client
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	recorder     event.Recorder
+	kube         client.Client
+	defaultOrgID *int64
+	// nestedFoldersDisabled is set if the feature flag nestedFolders is off, the parent folders are ignored then.
+	nestedFoldersDisabled bool
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -175,47 +195,83 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotFolder)
 	}
 
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	// orgId as int64
 	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	atGrafana, err := c.GetFolder(orgId, cr)
+	c.setParentFolderCondition(cr)
+
+	atGrafana, err := c.GetFolder(ctx, orgId, cr)
 
 	if err != nil {
-		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetFolder)
+		return managed.ExternalObservation{}, common.SetAPIError(cr, errors.Wrap(err, errFailedGetFolder))
 	}
 
 	if atGrafana == nil {
+		adopted, err := c.tryAdopt(orgId, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, common.SetAPIError(cr, errors.Wrap(err, errFailedGetFolder))
+		}
+		if adopted == nil {
+			c.log(cr).Debug("Folder does not exist")
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		c.log(cr).Debug("Adopting existing folder", "uid", adopted.UID)
+		// the adopted folder is updated to match the spec instead of creating a conflicting one
+		copyToStatus(adopted, cr, *cr.Spec.ForProvider.OrgID)
 		return managed.ExternalObservation{
-			ResourceExists: false,
+			ResourceExists:          true,
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        false,
+			ConnectionDetails:       connectionDetails(cr),
 		}, nil
 	}
 
-	upToDate := isUpToDate(cr, atGrafana)
+	parentUID := c.parentFolderUID(cr)
+	upToDate := isUpToDate(c.log(cr), cr, atGrafana, parentUID)
+	delta := ""
+	if !upToDate {
+		delta = Diff(cr, atGrafana, parentUID)
+	}
+	common.SetDiffCondition(cr, delta)
 
-	cr.SetConditions(v1.Available())
+	cr.SetConditions(common.AvailableCondition(upToDate))
 
 	copyToStatus(atGrafana, cr, *cr.Spec.ForProvider.OrgID)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
+	c.log(cr).Debug("Observed folder", "uid", atGrafana.UID, "upToDate", upToDate)
 
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
 		// (re)create the resource, or that it has successfully been deleted.
 		ResourceExists: true,
 
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
 		// Return false when the external resource exists, but it not up to date
 		// with the desired managed resource state. This lets the managed
 		// resource reconciler know that it needs to call Update.
 		ResourceUpToDate: upToDate,
 
+		Diff: delta,
+
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -225,6 +281,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotFolder)
 	}
 
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	cr.SetConditions(v1.Creating())
 
 	// orgId as int64
@@ -234,22 +294,43 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
+	adopted, err := c.tryAdopt(orgId, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedGetFolder)
+	}
+	if adopted != nil {
+		// the folder appeared since it was observed, it is updated on the next reconcile
+		c.log(cr).Debug("Adopting existing folder instead of creating it", "uid", adopted.UID)
+		copyToStatus(adopted, cr, *spec.OrgID)
+		return managed.ExternalCreation{
+			ConnectionDetails: connectionDetails(cr),
+		}, nil
+	}
+
 	command := &models.CreateFolderCommand{
-		ParentUID: common.DefaultString(spec.ParentFolderUID, ""),
+		ParentUID: common.DefaultString(c.parentFolderUID(cr), ""),
 		Title:     common.DefaultString(spec.Title, ""),
 		UID:       common.DefaultString(spec.UID, ""),
 	}
 
-	_, err = c.service.CreateFolder(orgId, command)
+	c.log(cr).Debug("Creating folder", "orgId", orgId, "uid", command.UID, "parentUid", command.ParentUID)
+	response, err := c.service.CreateFolder(orgId, command)
 
 	if err != nil {
 		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateFolder)
 	}
 
+	copyToStatus(response, cr, *spec.OrgID)
+	// the folder is found by its UID even if the status is lost
+	meta.SetExternalName(cr, response.UID)
+	c.log(cr).Debug("Created folder", "uid", response.UID)
+	c.recordEvent(cr, event.Normal(reasonCreated, fmt.Sprintf(msgCreated, response.Title, response.UID)))
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -259,6 +340,10 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotFolder)
 	}
 
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	// orgId as int64
 	spec := cr.Spec.ForProvider
 	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
@@ -266,24 +351,42 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	command := &models.UpdateFolderCommand{
-		Title:   common.DefaultString(spec.Title, ""),
-		Version: *cr.Status.AtProvider.Version,
-		// Overwrite?
+	uid := *cr.Status.AtProvider.UID
+
+	// the parent can only be changed by moving the folder
+	parentUID := c.parentFolderUID(cr)
+	if !common.CompareOptional(parentUID, common.DefaultString(cr.Status.AtProvider.ParentFolderUID, ""), "") {
+		c.log(cr).Debug("Moving folder", "orgId", orgId, "uid", uid, "parentUid", common.DefaultString(parentUID, ""))
+		response, err := c.service.MoveFolder(orgId, uid, common.DefaultString(parentUID, ""))
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errFailedMoveFolder)
+		}
+		copyToStatus(response, cr, *spec.OrgID)
 	}
 
-	response, err := c.service.UpdateFolder(orgId, *cr.Status.AtProvider.UID, command)
+	if !common.CompareOptional(spec.Title, common.DefaultString(cr.Status.AtProvider.Title, ""), "") {
+		command := &models.UpdateFolderCommand{
+			Title:   common.DefaultString(spec.Title, ""),
+			Version: *cr.Status.AtProvider.Version,
+			// Overwrite?
+		}
 
-	if err != nil {
-		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateFolder)
-	}
+		c.log(cr).Debug("Updating folder", "orgId", orgId, "uid", uid, "version", command.Version)
+		response, err := c.service.UpdateFolder(orgId, uid, command)
 
-	copyToStatus(response, cr, *spec.OrgID)
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateFolder)
+		}
+
+		copyToStatus(response, cr, *spec.OrgID)
+	}
+	c.recordEvent(cr, event.Normal(reasonUpdated, fmt.Sprintf(msgUpdated, uid)))
 
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -293,6 +396,14 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotFolder)
 	}
 
+	if err := common.CheckDeletionProtection(cr); err != nil {
+		return err
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
 	cr.SetConditions(v1.Deleting())
 
 	// orgId as int64
@@ -302,9 +413,51 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.Wrap(err, errOrgIdNotInt)
 	}
 
-	_, err = c.service.DeleteFolder(orgId, *cr.Status.AtProvider.UID)
+	if common.DefaultString(spec.ContentDeletionPolicy, v1alpha1.FolderContentDeletionPolicyCascade) == v1alpha1.FolderContentDeletionPolicyRefuse {
+		if err := c.checkEmpty(orgId, *cr.Status.AtProvider.UID); err != nil {
+			return err
+		}
+	}
+
+	c.log(cr).Debug("Deleting folder", "orgId", orgId, "uid", *cr.Status.AtProvider.UID)
+	_, err = c.service.DeleteFolder(orgId, *cr.Status.AtProvider.UID, common.DefaultBool(spec.ForceDeleteRules, false))
+	if err != nil {
+		return errors.Wrap(err, errFailedDeleteFolder)
+	}
 
-	return errors.Wrap(err, errFailedDeleteFolder)
+	c.recordEvent(cr, event.Normal(reasonDeleted, fmt.Sprintf(msgDeleted, *cr.Status.AtProvider.UID)))
+	return nil
+}
+
+// checkEmpty returns an error naming the contents of the folder, unless it is empty.
+func (c *external) checkEmpty(orgId int64, uid string) error {
+	counts, err := c.service.GetFolderDescendantCounts(orgId, uid)
+	if err != nil {
+		return errors.Wrap(err, errFailedCountFolder)
+	}
+	var contents []string
+	for kind, count := range counts {
+		if count > 0 {
+			contents = append(contents, fmt.Sprintf("%d %s", count, kind))
+		}
+	}
+	if len(contents) == 0 {
+		return nil
+	}
+	sort.Strings(contents)
+	return errors.Errorf(errFolderNotEmpty, strings.Join(contents, ", "))
+}
+
+// log returns the logger of the folder.
+func (c *external) log(cr *v1alpha1.Folder) logging.Logger {
+	return common.ResourceLogger(c.logger, v1alpha1.FolderKind, cr)
+}
+
+// recordEvent records an event for the folder, if the external client was created with a recorder.
+func (c *external) recordEvent(cr *v1alpha1.Folder, e event.Event) {
+	if c.recorder != nil {
+		c.recorder.Event(cr, e)
+	}
 }
 
 func copyToStatus(response *models.Folder, cr *v1alpha1.Folder, orgId string) {
@@ -318,27 +471,105 @@ func copyToStatus(response *models.Folder, cr *v1alpha1.Folder, orgId string) {
 	cr.Status.AtProvider.Version = &response.Version
 }
 
-func isUpToDate(cr *v1alpha1.Folder, atGrafana *models.Folder) bool {
+// connectionDetails exposes the computed outputs of the folder, so that they can be consumed by other resources.
+func connectionDetails(cr *v1alpha1.Folder) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{}
+	if cr.Status.AtProvider.UID != nil {
+		details["uid"] = []byte(*cr.Status.AtProvider.UID)
+	}
+	if cr.Status.AtProvider.URL != nil {
+		details["url"] = []byte(*cr.Status.AtProvider.URL)
+	}
+	return details
+}
+
+// isUpToDate compares the folder in Grafana with the spec and logs the fields that differ. The parent folder is passed
+// separately, as it is nil if Grafana does not support nested folders.
+func isUpToDate(log logging.Logger, cr *v1alpha1.Folder, atGrafana *models.Folder, parentUID *string) bool {
 	spec := cr.Spec.ForProvider
-	upToDate := true
+	comparison := &common.Comparison{}
+
+	comparison.Equal("title", common.CompareOptional(spec.Title, atGrafana.Title, ""))
+	comparison.Equal("parentFolderUid", common.CompareOptional(parentUID, atGrafana.ParentUID, ""))
 
-	upToDate = upToDate && common.CompareOptional(spec.Title, atGrafana.Title, "")
+	comparison.Log(log)
+	return comparison.UpToDate()
+}
 
-	return upToDate
+// folderState holds the fields of a folder that are compared by isUpToDate.
+type folderState struct {
+	Title     string
+	ParentUID string
 }
 
-func (c *external) GetFolder(orgId int64, cr *v1alpha1.Folder) (*models.Folder, error) {
+// Diff describes how the folder in Grafana differs from the spec, in the format of cmp.Diff.
+func Diff(cr *v1alpha1.Folder, atGrafana *models.Folder, parentUID *string) string {
+	spec := cr.Spec.ForProvider
+	desired := folderState{
+		Title:     common.DefaultString(spec.Title, ""),
+		ParentUID: common.DefaultString(parentUID, ""),
+	}
+	actual := folderState{
+		Title:     atGrafana.Title,
+		ParentUID: atGrafana.ParentUID,
+	}
+	return cmp.Diff(desired, actual)
+}
+
+// GetFolder looks up the folder by the UID or ID in status. Without them, the external-name annotation is tried as UID
+// to allow importing existing folders, before falling back to the title in the spec. The external-name defaults to the
+// name of the resource, so a miss there is not conclusive.
+func (c *external) GetFolder(ctx context.Context, orgId int64, cr *v1alpha1.Folder) (*models.Folder, error) {
 	switch status := cr.Status.AtProvider; {
 	case status.UID != nil:
 		return c.service.GetFolderByUid(orgId, *status.UID)
 	case status.ID != nil:
-		id := strings.Split(*cr.Status.AtProvider.ID, ":")[1]
+		_, id, found := strings.Cut(*status.ID, ":")
+		if !found {
+			return nil, errors.Errorf(errIdFormat, *status.ID)
+		}
 		idAsInt, err := strconv.ParseInt(id, 10, 64)
 		if err != nil {
 			return nil, errors.Wrap(err, errIdNotInt)
 		}
 		return c.service.GetFolderById(orgId, idAsInt)
 	default:
-		return c.service.GetFolderByName(orgId, *cr.Spec.ForProvider.Title, cr.Spec.ForProvider.ParentFolderUID)
+		if externalName := meta.GetExternalName(cr); externalName != "" {
+			folder, err := c.service.GetFolderByUid(orgId, externalName)
+			if err != nil || folder != nil {
+				return folder, err
+			}
+		}
+		return c.service.GetFolderByName(ctx, orgId, *cr.Spec.ForProvider.Title, c.parentFolderUID(cr))
+	}
+}
+
+// parentFolderUID returns the parent folder of the spec, or nil if Grafana does not support nested folders.
+func (c *external) parentFolderUID(cr *v1alpha1.Folder) *string {
+	if c.nestedFoldersDisabled {
+		return nil
+	}
+	return cr.Spec.ForProvider.ParentFolderUID
+}
+
+// setParentFolderCondition reports whether the parent folder of the spec is applied. Folders without a parent have no
+// such condition.
+func (c *external) setParentFolderCondition(cr *v1alpha1.Folder) {
+	if common.DefaultString(cr.Spec.ForProvider.ParentFolderUID, "") == "" {
+		return
+	}
+	if c.nestedFoldersDisabled {
+		cr.SetConditions(v1alpha1.NestedFoldersDisabled())
+		return
+	}
+	cr.SetConditions(v1alpha1.ParentFolderApplied())
+}
+
+// tryAdopt looks up a folder that was created outside the provider by the UID of the spec, if the resource is
+// annotated to adopt existing folders. GetFolder only finds such folders if their title matches the spec.
+func (c *external) tryAdopt(orgId int64, cr *v1alpha1.Folder) (*models.Folder, error) {
+	if !common.ShouldAdopt(cr) || cr.Spec.ForProvider.UID == nil {
+		return nil, nil
 	}
+	return c.service.GetFolderByUid(orgId, *cr.Spec.ForProvider.UID)
 }
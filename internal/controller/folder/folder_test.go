@@ -18,11 +18,21 @@ package folder
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
 	"github.com/argannor/provider-grafana/internal/controller/common"
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -37,6 +47,49 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func folder() *v1alpha1.Folder {
+	orgId := "1"
+	title := "test"
+	uid := "abc"
+	return &v1alpha1.Folder{
+		Spec: v1alpha1.FolderSpec{
+			ForProvider: v1alpha1.FolderParameters{
+				OrgID: &orgId,
+				Title: &title,
+			},
+		},
+		Status: v1alpha1.FolderStatus{
+			AtProvider: v1alpha1.FolderObservation{
+				UID: &uid,
+			},
+		},
+	}
+}
+
+func grafanaFolder(title string) *models.Folder {
+	return &models.Folder{
+		ID:      2,
+		OrgID:   1,
+		Title:   title,
+		UID:     "abc",
+		URL:     "/dashboards/f/abc/",
+		Version: 1,
+	}
+}
+
+func folderConnectionDetails() managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		"uid": []byte("abc"),
+		"url": []byte("/dashboards/f/abc/"),
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		service common.GrafanaAPI
@@ -59,7 +112,106 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotFolder": {
+			reason: "An error should be returned if the managed resource is not a Folder",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg:  &v1alpha1.Dashboard{},
+			},
+			want: want{
+				err: errors.New(errNotFolder),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the Folder cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  folder(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetFolder),
+			},
+		},
+		"NotFound": {
+			reason: "The Folder should be reported as missing if Grafana does not know it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  folder(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The Folder should be reported as up to date if it matches the desired state",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("test"), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  folder(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: folderConnectionDetails(),
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "The Folder should be reported as outdated if its title differs",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("other"), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  folder(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: folderConnectionDetails(),
+				},
+			},
+		},
+		"ParentMoved": {
+			reason: "The Folder should be reported as outdated if it was moved to another parent",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				moved := grafanaFolder("test")
+				moved.ParentUID = "other"
+				m.On("GetFolderByUid", int64(1), "abc").Return(moved, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  folder(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: folderConnectionDetails(),
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -69,9 +221,661 @@ func TestObserve(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got.ResourceExists && (got.Diff == "") != got.ResourceUpToDate {
+				t.Errorf("\n%s\ne.Observe(...): want a diff if and only if the resource is outdated, got %q\n", tc.reason, got.Diff)
+			}
+		})
+	}
+}
+
+func TestObserveReportsDiff(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("other"), nil)
+
+	cr := folder()
+	e := external{service: m}
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error %v", err)
+	}
+	if !strings.Contains(got.Diff, `"other"`) {
+		t.Errorf("e.Observe(...): want a diff of the title, got %q", got.Diff)
+	}
+	// the managed reconciler replaces the Synced condition after Observe, which must not drop the diff
+	cr.SetConditions(v1.ReconcileSuccess())
+	drifted := cr.GetCondition(v1alpha1.TypeDrifted)
+	if drifted.Status != corev1.ConditionTrue || drifted.Message != common.DiffMessage(got.Diff) {
+		t.Errorf("e.Observe(...): want a Drifted condition with the diff, got %v", drifted)
+	}
+
+	m = &common.MockGrafanaAPI{}
+	m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("test"), nil)
+	e = external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(v1alpha1.NotDrifted(), cr.GetCondition(v1alpha1.TypeDrifted), cmpopts.IgnoreFields(v1.Condition{}, "LastTransitionTime")); diff != "" {
+		t.Errorf("e.Observe(...): want the Drifted condition to be cleared once the folder was updated:\n%s", diff)
+	}
+}
+
+func TestObserveDefaultsOrgID(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetFolderByUid", int64(3), "abc").Return(grafanaFolder("test"), nil)
+
+	cr := folder()
+	cr.Spec.ForProvider.OrgID = nil
+	var defaultOrgID int64 = 3
+	e := external{service: m, defaultOrgID: &defaultOrgID}
+	got, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error %v", err)
+	}
+	if !got.ResourceLateInitialized {
+		t.Errorf("e.Observe(...): want the defaulted orgId to be late initialized")
+	}
+	if diff := cmp.Diff("3", common.DefaultString(cr.Spec.ForProvider.OrgID, "")); diff != "" {
+		t.Errorf("e.Observe(...): -want orgId, +got orgId:\n%s\n", diff)
+	}
+
+	cr.Spec.ForProvider.OrgID = nil
+	e = external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err == nil {
+		t.Errorf("e.Observe(...): want an error if neither orgId nor defaultOrgId is set")
+	}
+	m.AssertExpectations(t)
+}
+
+func TestIsUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		title     *string
+		atGrafana *models.Folder
+		want      bool
+	}{
+		"TitleMatches": {
+			reason:    "A folder with the title of the spec should be up to date",
+			title:     strRef("test"),
+			atGrafana: grafanaFolder("test"),
+			want:      true,
+		},
+		"TitleDiffers": {
+			reason:    "A folder with another title than the spec should be outdated",
+			title:     strRef("test"),
+			atGrafana: grafanaFolder("other"),
+			want:      false,
+		},
+		"NilTitleDefaultsToEmpty": {
+			reason:    "A spec without title should match a folder without title",
+			atGrafana: grafanaFolder(""),
+			want:      true,
+		},
+		"NilTitleWithTitleAtGrafana": {
+			reason:    "A spec without title should not match a folder with a title",
+			atGrafana: grafanaFolder("test"),
+			want:      false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := folder()
+			cr.Spec.ForProvider.Title = tc.title
+			if got := isUpToDate(logging.NewNopLogger(), cr, tc.atGrafana, cr.Spec.ForProvider.ParentFolderUID); got != tc.want {
+				t.Errorf("\n%s\nisUpToDate(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+// debugLogger records the keys and values of its debug messages.
+type debugLogger struct {
+	logging.Logger
+	messages map[string][]any
+}
+
+func (l *debugLogger) Debug(msg string, keysAndValues ...any) {
+	l.messages[msg] = keysAndValues
+}
+
+func TestIsUpToDateLogsDifferingFields(t *testing.T) {
+	cr := folder()
+	cr.Spec.ForProvider.Title = strRef("test")
+	atGrafana := grafanaFolder("other")
+	atGrafana.ParentUID = "parent"
+	log := &debugLogger{Logger: logging.NewNopLogger(), messages: map[string][]any{}}
+
+	if isUpToDate(log, cr, atGrafana, nil) {
+		t.Fatalf("isUpToDate(...): want folder with other title and parent to be outdated")
+	}
+	want := []any{"differing", []string{"title", "parentFolderUid"}}
+	if diff := cmp.Diff(want, log.messages["Resource is not up to date"]); diff != "" {
+		t.Errorf("isUpToDate(...): -want logged fields, +got logged fields:\n%s\n", diff)
+	}
+}
+
+func TestGetFolder(t *testing.T) {
+	_, errNotNumeric := strconv.ParseInt("abc", 10, 64)
+
+	type want struct {
+		folder *models.Folder
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason       string
+		status       v1alpha1.FolderObservation
+		parent       *string
+		externalName string
+		service      func() *common.MockGrafanaAPI
+		want         want
+	}{
+		"ByUID": {
+			reason: "The folder should be looked up by the UID in status if it is set",
+			status: v1alpha1.FolderObservation{UID: strRef("abc"), ID: strRef("1:2")},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("test"), nil)
+				return m
+			},
+			want: want{folder: grafanaFolder("test")},
+		},
+		"ByID": {
+			reason: "The folder should be looked up by the numeric ID in status if there is no UID",
+			status: v1alpha1.FolderObservation{ID: strRef("1:2")},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderById", int64(1), int64(2)).Return(grafanaFolder("test"), nil)
+				return m
+			},
+			want: want{folder: grafanaFolder("test")},
+		},
+		"ByTitle": {
+			reason: "The folder should be looked up by the title of the spec if neither UID nor ID are known",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByName", mock.Anything, int64(1), "test", (*string)(nil)).Return(grafanaFolder("test"), nil)
+				return m
+			},
+			want: want{folder: grafanaFolder("test")},
+		},
+		"ByExternalName": {
+			reason:       "The folder should be looked up by the external-name as UID if the status is empty, to import existing folders",
+			externalName: "abc",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("test"), nil)
+				return m
+			},
+			want: want{folder: grafanaFolder("test")},
+		},
+		"ExternalNameNotFound": {
+			reason:       "The folder should be looked up by its title if no folder has the external-name as UID",
+			externalName: "test",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "test").Return(nil, nil)
+				m.On("GetFolderByName", mock.Anything, int64(1), "test", (*string)(nil)).Return(grafanaFolder("test"), nil)
+				return m
+			},
+			want: want{folder: grafanaFolder("test")},
+		},
+		"ByTitleInParent": {
+			reason: "The folder should be looked up by the title within the parent folder of the spec",
+			parent: strRef("parent"),
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByName", mock.Anything, int64(1), "test", strRef("parent")).Return(grafanaFolder("test"), nil)
+				return m
+			},
+			want: want{folder: grafanaFolder("test")},
+		},
+		"IDWithoutOrg": {
+			reason:  "An error should be returned instead of panicking if the ID in status has no org prefix",
+			status:  v1alpha1.FolderObservation{ID: strRef("2")},
+			service: func() *common.MockGrafanaAPI { return &common.MockGrafanaAPI{} },
+			want:    want{err: errors.Errorf(errIdFormat, "2")},
+		},
+		"IDNotNumeric": {
+			reason:  "An error should be returned if the ID in status is not numeric",
+			status:  v1alpha1.FolderObservation{ID: strRef("1:abc")},
+			service: func() *common.MockGrafanaAPI { return &common.MockGrafanaAPI{} },
+			want:    want{err: errors.Wrap(errNotNumeric, errIdNotInt)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := folder()
+			cr.Status.AtProvider = tc.status
+			cr.Spec.ForProvider.ParentFolderUID = tc.parent
+			if tc.externalName != "" {
+				meta.SetExternalName(cr, tc.externalName)
+			}
+			m := tc.service()
+			e := external{service: m}
+			got, err := e.GetFolder(context.Background(), 1, cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.GetFolder(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.folder, got); diff != "" {
+				t.Errorf("\n%s\ne.GetFolder(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		want    want
+	}{
+		"Created": {
+			reason: "The uid and url of the created Folder should be returned as connection details",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateFolder", int64(1), mock.Anything).Return(grafanaFolder("test"), nil)
+				return m
+			}(),
+			want: want{
+				o: managed.ExternalCreation{ConnectionDetails: folderConnectionDetails()},
+			},
+		},
+		"CreateFailed": {
+			reason: "An error should be returned if the Folder cannot be created",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateFolder", int64(1), mock.Anything).Return(nil, errBoom)
+				return m
+			}(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedCreateFolder),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := folder()
+			cr.Status.AtProvider.UID = nil
+			e := external{service: tc.service}
+			got, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
 			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("UpdateFolder", int64(1), "abc", mock.Anything).Return(grafanaFolder("test"), nil)
+
+	cr := folder()
+	version := int64(1)
+	cr.Status.AtProvider.Version = &version
+
+	e := external{service: m}
+	got, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(managed.ExternalUpdate{ConnectionDetails: folderConnectionDetails()}, got); diff != "" {
+		t.Errorf("e.Update(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestUpdateMovesFolder(t *testing.T) {
+	moved := grafanaFolder("test")
+	moved.ParentUID = "parent"
+
+	m := &common.MockGrafanaAPI{}
+	m.On("MoveFolder", int64(1), "abc", "parent").Return(moved, nil)
+
+	cr := folder()
+	parent := "parent"
+	cr.Spec.ForProvider.ParentFolderUID = &parent
+	copyToStatus(grafanaFolder("test"), cr, "1")
+
+	e := external{service: m}
+	_, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertNotCalled(t, "UpdateFolder", mock.Anything, mock.Anything, mock.Anything)
+	if diff := cmp.Diff(&parent, cr.Status.AtProvider.ParentFolderUID); diff != "" {
+		t.Errorf("ParentFolderUID: -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestObserveIgnoresParentIfNestedFoldersDisabled(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("test"), nil)
+
+	cr := folder()
+	cr.Spec.ForProvider.ParentFolderUID = strRef("parent")
+
+	e := external{service: m, nestedFoldersDisabled: true}
+	got, err := e.Observe(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	if !got.ResourceUpToDate {
+		t.Errorf("e.Observe(...): a folder should be up to date if only its parent differs and nested folders are disabled")
+	}
+	if diff := cmp.Diff(v1alpha1.ReasonNestedFoldersDisabled, cr.GetCondition(v1alpha1.TypeParentFolderApplied).Reason); diff != "" {
+		t.Errorf("e.Observe(...): -want reason, +got reason:\n%s\n", diff)
+	}
+}
+
+func TestCreateSetsExternalName(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateFolder", int64(1), mock.Anything).Return(grafanaFolder("test"), nil)
+
+	cr := folder()
+	cr.Status.AtProvider.UID = nil
+	meta.SetExternalName(cr, "test")
+
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff("abc", meta.GetExternalName(cr)); diff != "" {
+		t.Errorf("e.Create(...): -want external-name, +got external-name:\n%s\n", diff)
+	}
+}
+
+func TestCreateIgnoresParentIfNestedFoldersDisabled(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateFolder", int64(1), &models.CreateFolderCommand{Title: "test"}).Return(grafanaFolder("test"), nil)
+
+	cr := folder()
+	cr.Status.AtProvider.UID = nil
+	cr.Spec.ForProvider.ParentFolderUID = strRef("parent")
+
+	e := external{service: m, nestedFoldersDisabled: true}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Errorf("e.Create(...): the folder should be created without its parent: %s", err)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason           string
+		forceDeleteRules *bool
+		want             bool
+	}{
+		"DefaultsToKeepingRules": {
+			reason: "Alert rules should not be deleted with the Folder unless requested",
+			want:   false,
+		},
+		"ForceDeleteRules": {
+			reason:           "Alert rules should be deleted with the Folder if forceDeleteRules is set",
+			forceDeleteRules: boolRef(true),
+			want:             true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("DeleteFolder", int64(1), "abc", tc.want).Return(&models.DeleteFolderOKBody{}, nil)
+
+			cr := folder()
+			cr.Spec.ForProvider.ForceDeleteRules = tc.forceDeleteRules
+
+			e := external{service: m}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertCalled(t, "DeleteFolder", int64(1), "abc", tc.want)
+		})
+	}
+}
+
+func TestDeleteContentDeletionPolicy(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		policy  *string
+		counts  models.DescendantCounts
+		err     error
+		deleted bool
+		want    error
+	}{
+		"CascadeDoesNotCount": {
+			reason:  "The Folder should be deleted along with its contents by default",
+			deleted: true,
+		},
+		"RefuseEmpty": {
+			reason:  "An empty Folder should be deleted if deletion of non-empty folders is refused",
+			policy:  strRef(v1alpha1.FolderContentDeletionPolicyRefuse),
+			counts:  models.DescendantCounts{"dashboard": 0, "alertrule": 0},
+			deleted: true,
+		},
+		"RefuseNotEmpty": {
+			reason: "The deletion should fail with the contents of the Folder if it is not empty",
+			policy: strRef(v1alpha1.FolderContentDeletionPolicyRefuse),
+			counts: models.DescendantCounts{"dashboard": 2, "alertrule": 1, "folder": 0},
+			want:   errors.Errorf(errFolderNotEmpty, "1 alertrule, 2 dashboard"),
+		},
+		"CountFailed": {
+			reason: "The deletion should fail if the contents of the Folder cannot be counted",
+			policy: strRef(v1alpha1.FolderContentDeletionPolicyRefuse),
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedCountFolder),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetFolderDescendantCounts", int64(1), "abc").Return(tc.counts, tc.err)
+			m.On("DeleteFolder", int64(1), "abc", false).Return(&models.DeleteFolderOKBody{}, nil)
+
+			cr := folder()
+			cr.Spec.ForProvider.ContentDeletionPolicy = tc.policy
+
+			e := external{service: m}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.policy == nil {
+				m.AssertNotCalled(t, "GetFolderDescendantCounts", int64(1), "abc")
+			}
+			if tc.deleted {
+				m.AssertCalled(t, "DeleteFolder", int64(1), "abc", false)
+			} else {
+				m.AssertNotCalled(t, "DeleteFolder", int64(1), "abc", false)
+			}
+		})
+	}
+}
+
+func boolRef(b bool) *bool {
+	return &b
+}
+
+func TestOrgIdRequired(t *testing.T) {
+	// without orgId and defaultOrgId every operation should fail instead of dereferencing the orgId
+	want := errors.New(common.ErrOrgIdRequired)
+	e := external{service: &common.MockGrafanaAPI{}}
+	withoutOrgId := func() *v1alpha1.Folder {
+		cr := folder()
+		cr.Spec.ForProvider.OrgID = nil
+		return cr
+	}
+
+	_, err := e.Observe(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	_, err = e.Create(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	_, err = e.Update(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	err = e.Delete(context.Background(), withoutOrgId())
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestAdoptExisting(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		adopt  bool
+		want   managed.ExternalObservation
+	}{
+		"Annotated": {
+			reason: "A Folder with the UID of the spec should be adopted and updated if the resource is annotated",
+			adopt:  true,
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: folderConnectionDetails()},
+		},
+		"NotAnnotated": {
+			reason: "A Folder should only be looked up by its title if the resource is not annotated",
+			want:   managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetFolderByName", mock.Anything, int64(1), "test", (*string)(nil)).Return(nil, nil)
+			m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("created by terraform"), nil)
+
+			cr := folder()
+			cr.Spec.ForProvider.UID = strRef("abc")
+			cr.Status.AtProvider = v1alpha1.FolderObservation{}
+			if tc.adopt {
+				cr.SetAnnotations(map[string]string{common.AnnotationKeyAdoptExisting: "true"})
+			}
+
+			e := external{service: m}
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
+
+func TestCreateAdoptsExisting(t *testing.T) {
+	// the folder was created between Observe and Create, so it is adopted instead of creating a conflicting one
+	m := &common.MockGrafanaAPI{}
+	m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("test"), nil)
+
+	cr := folder()
+	cr.Spec.ForProvider.UID = strRef("abc")
+	cr.Status.AtProvider = v1alpha1.FolderObservation{}
+	cr.SetAnnotations(map[string]string{common.AnnotationKeyAdoptExisting: "true"})
+
+	e := external{service: m}
+	got, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ConnectionDetails: folderConnectionDetails()}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	m.AssertNotCalled(t, "CreateFolder", mock.Anything, mock.Anything)
+}
+
+func TestObserveSetsReadyCondition(t *testing.T) {
+	type want struct {
+		status corev1.ConditionStatus
+		reason v1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service func() *common.MockGrafanaAPI
+		want    want
+	}{
+		"UpToDate": {
+			reason: "A Folder that is up to date should be available",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("test"), nil)
+				return m
+			},
+			want: want{status: corev1.ConditionTrue, reason: v1.ReasonAvailable},
+		},
+		"Drifted": {
+			reason: "A Folder that differs from its spec should be unavailable, because it drifted",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(grafanaFolder("other"), nil)
+				return m
+			},
+			want: want{status: corev1.ConditionFalse, reason: common.ReasonResourceDrifted},
+		},
+		"APIError": {
+			reason: "A Folder that cannot be observed should be unavailable, because of the API error",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetFolderByUid", int64(1), "abc").Return(nil, errBoom)
+				return m
+			},
+			want: want{status: corev1.ConditionFalse, reason: common.ReasonAPIError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := folder()
+			// the Folder was available before
+			cr.SetConditions(v1.Available())
+			e := external{service: tc.service()}
+			_, err := e.Observe(context.Background(), cr)
+
+			ready := cr.GetCondition(v1.TypeReady)
+			got := want{status: ready.Status, reason: ready.Reason}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want Ready condition, +got Ready condition:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.reason == common.ReasonAPIError && (err == nil || ready.Message != err.Error()) {
+				t.Errorf("\n%s\ne.Observe(...): want the error %v as message, got %q\n", tc.reason, err, ready.Message)
+			}
+		})
+	}
+}
+
+func TestDeleteRefusedIfProtected(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	cr := folder()
+	meta.AddAnnotations(cr, map[string]string{common.AnnotationKeyDeletionProtection: "true"})
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), cr)
+	if diff := cmp.Diff(errors.New(common.ErrDeletionProtected), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	if len(m.Calls) != 0 {
+		t.Errorf("e.Delete(...): want no requests to Grafana, got %v", m.Calls)
+	}
+	if got := cr.GetCondition(common.TypeDeletionProtected); got.Status != corev1.ConditionTrue {
+		t.Errorf("e.Delete(...): want the DeletionProtected condition, got %v", got)
+	}
+}
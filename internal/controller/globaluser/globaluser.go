@@ -0,0 +1,441 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package globaluser
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotGlobalUser = "managed resource is not a GlobalUser custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+
+	errNewClient                = "cannot create new Service"
+	errFailedGetUser            = "cannot get GlobalUser from Grafana API"
+	errFailedCreateUser         = "cannot create GlobalUser"
+	errFailedUpdateUser         = "cannot update GlobalUser"
+	errFailedUpdatePermissions  = "cannot update admin permission of GlobalUser"
+	errFailedUpdateDisabled     = "cannot enable or disable GlobalUser"
+	errFailedUpdatePassword     = "cannot update password of GlobalUser"
+	errFailedDeleteUser         = "cannot delete GlobalUser"
+	errGetPassword              = "cannot get password from secret"
+	errGeneratePassword         = "cannot generate random password"
+	errPasswordSecretKeyMissing = "password secret does not contain key %q"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles GlobalUser managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.GlobalUserGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.GlobalUserGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.GlobalUser{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.GlobalUser)
+	if !ok {
+		return nil, errors.New(errNotGlobalUser)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service common.GrafanaAPI
+	logger  logging.Logger
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.GlobalUser)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGlobalUser)
+	}
+
+	atGrafana, err := c.GetUser(cr)
+
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetUser)
+	}
+
+	if atGrafana == nil {
+		adopted, err := c.tryAdopt(cr)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetUser)
+		}
+		if adopted == nil {
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		// the adopted user is updated to match the spec instead of creating a conflicting one
+		copyToStatus(adopted, cr)
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			ConnectionDetails: connectionDetails(cr),
+		}, nil
+	}
+
+	password, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	upToDate := isUpToDate(cr, atGrafana, password)
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.GlobalUser)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotGlobalUser)
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	adopted, err := c.tryAdopt(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedGetUser)
+	}
+	if adopted != nil {
+		// the user appeared since it was observed, it is updated on the next reconcile
+		copyToStatus(adopted, cr)
+		return managed.ExternalCreation{
+			ConnectionDetails: connectionDetails(cr),
+		}, nil
+	}
+
+	spec := cr.Spec.ForProvider
+	password, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	var passwordHash *string
+	if password != nil {
+		hash, err := common.HashPassword(*password, nil)
+		if err != nil {
+			return managed.ExternalCreation{}, err
+		}
+		passwordHash = &hash
+	} else {
+		generated, err := randomPassword()
+		if err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errGeneratePassword)
+		}
+		password = &generated
+	}
+
+	response, err := c.service.AdminCreateUser(&models.AdminCreateUserForm{
+		Email:    common.DefaultString(spec.Email, ""),
+		Login:    common.DefaultString(spec.Login, ""),
+		Name:     common.DefaultString(spec.Name, ""),
+		Password: *password,
+	})
+
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateUser)
+	}
+
+	id := strconv.FormatInt(response.ID, 10)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.UserID = &response.ID
+	cr.Status.AtProvider.PasswordHash = passwordHash
+
+	// admin permission and disabled state can't be set on creation
+	if common.DefaultBool(spec.IsAdmin, false) {
+		if err := c.service.UpdateUserPermissions(response.ID, true); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errFailedUpdatePermissions)
+		}
+	}
+	if common.DefaultBool(spec.IsDisabled, false) {
+		if err := c.service.DisableUser(response.ID); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errFailedUpdateDisabled)
+		}
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr),
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.GlobalUser)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGlobalUser)
+	}
+
+	spec := cr.Spec.ForProvider
+	status := cr.Status.AtProvider
+	id := *status.UserID
+
+	_, err := c.service.UpdateUser(id, &models.UpdateUserCommand{
+		Email: common.DefaultString(spec.Email, ""),
+		Login: common.DefaultString(spec.Login, common.DefaultString(spec.Email, "")),
+		Name:  common.DefaultString(spec.Name, ""),
+	})
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateUser)
+	}
+
+	if isAdmin := common.DefaultBool(spec.IsAdmin, false); isAdmin != common.DefaultBool(status.IsAdmin, false) {
+		if err := c.service.UpdateUserPermissions(id, isAdmin); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdatePermissions)
+		}
+	}
+
+	if isDisabled := common.DefaultBool(spec.IsDisabled, false); isDisabled != common.DefaultBool(status.IsDisabled, false) {
+		if isDisabled {
+			err = c.service.DisableUser(id)
+		} else {
+			err = c.service.EnableUser(id)
+		}
+		if err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateDisabled)
+		}
+	}
+
+	password, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if password != nil && !common.PasswordMatches(*password, status.PasswordHash) {
+		if err := c.service.UpdateUserPassword(id, *password); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdatePassword)
+		}
+		hash, err := common.HashPassword(*password, nil)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		cr.Status.AtProvider.PasswordHash = &hash
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr),
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.GlobalUser)
+	if !ok {
+		return errors.New(errNotGlobalUser)
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	err := c.service.DeleteUser(*cr.Status.AtProvider.UserID)
+
+	return errors.Wrap(err, errFailedDeleteUser)
+}
+
+func (c *external) GetUser(cr *v1alpha1.GlobalUser) (*models.UserProfileDTO, error) {
+	switch spec := cr.Spec.ForProvider; {
+	case cr.Status.AtProvider.UserID != nil:
+		return c.service.GetUserById(*cr.Status.AtProvider.UserID)
+	case spec.Login != nil:
+		return c.service.GetUserByLoginOrEmail(*spec.Login)
+	default:
+		return c.service.GetUserByLoginOrEmail(common.DefaultString(spec.Email, ""))
+	}
+}
+
+// getPassword returns the password referenced by the spec, or nil if the password is not managed by the provider.
+func (c *external) getPassword(ctx context.Context, cr *v1alpha1.GlobalUser) (*string, error) {
+	ref := cr.Spec.ForProvider.PasswordSecretRef
+	if ref == nil {
+		return nil, nil
+	}
+	data, err := resource.ExtractSecret(ctx, c.kube, v1.CommonCredentialSelectors{SecretRef: ref})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetPassword)
+	}
+	if len(data) == 0 {
+		return nil, errors.Errorf(errPasswordSecretKeyMissing, ref.Key)
+	}
+	password := string(data)
+	return &password, nil
+}
+
+// tryAdopt looks up a user that was created outside the provider by the email of the spec, if the resource is
+// annotated to adopt existing users. GetUser only looks up users by their login if it is set.
+func (c *external) tryAdopt(cr *v1alpha1.GlobalUser) (*models.UserProfileDTO, error) {
+	spec := cr.Spec.ForProvider
+	if !common.ShouldAdopt(cr) || spec.Login == nil || spec.Email == nil {
+		return nil, nil
+	}
+	return c.service.GetUserByLoginOrEmail(*spec.Email)
+}
+
+func copyToStatus(response *models.UserProfileDTO, cr *v1alpha1.GlobalUser) {
+	id := strconv.FormatInt(response.ID, 10)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.UserID = &response.ID
+	cr.Status.AtProvider.Email = &response.Email
+	cr.Status.AtProvider.Login = &response.Login
+	cr.Status.AtProvider.Name = &response.Name
+	cr.Status.AtProvider.IsAdmin = &response.IsGrafanaAdmin
+	cr.Status.AtProvider.IsDisabled = &response.IsDisabled
+}
+
+// connectionDetails publishes the login of the user, so that it can be used together with the password secret.
+func connectionDetails(cr *v1alpha1.GlobalUser) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{}
+	if cr.Status.AtProvider.ID != nil {
+		details["id"] = []byte(*cr.Status.AtProvider.ID)
+	}
+	if cr.Status.AtProvider.Login != nil {
+		details["login"] = []byte(*cr.Status.AtProvider.Login)
+	}
+	return details
+}
+
+// isUpToDate compares all fields of the user. Grafana doesn't return the password, so it is compared against the
+// hash of the password last set by the provider.
+func isUpToDate(cr *v1alpha1.GlobalUser, atGrafana *models.UserProfileDTO, password *string) bool {
+	spec := cr.Spec.ForProvider
+	upToDate := true
+
+	upToDate = upToDate && common.CompareOptional(spec.Email, atGrafana.Email, "")
+	upToDate = upToDate && common.CompareOptional(spec.Login, atGrafana.Login, common.DefaultString(spec.Email, ""))
+	upToDate = upToDate && common.CompareOptional(spec.Name, atGrafana.Name, "")
+	upToDate = upToDate && common.CompareOptional(spec.IsAdmin, atGrafana.IsGrafanaAdmin, false)
+	upToDate = upToDate && common.CompareOptional(spec.IsDisabled, atGrafana.IsDisabled, false)
+
+	if password != nil {
+		upToDate = upToDate && common.PasswordMatches(*password, cr.Status.AtProvider.PasswordHash)
+	}
+
+	return upToDate
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package globaluser
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func globalUser() *v1alpha1.GlobalUser {
+	email := "user@example.com"
+	name := "User"
+	var userId int64 = 2
+	return &v1alpha1.GlobalUser{
+		Spec: v1alpha1.GlobalUserSpec{
+			ForProvider: v1alpha1.GlobalUserParameters{
+				Email: &email,
+				Name:  &name,
+			},
+		},
+		Status: v1alpha1.GlobalUserStatus{
+			AtProvider: v1alpha1.GlobalUserObservation{
+				UserID: &userId,
+			},
+		},
+	}
+}
+
+func withPassword(cr *v1alpha1.GlobalUser, hash *string) *v1alpha1.GlobalUser {
+	cr.Spec.ForProvider.PasswordSecretRef = &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "user", Namespace: "default"},
+		Key:             "password",
+	}
+	cr.Status.AtProvider.PasswordHash = hash
+	return cr
+}
+
+func grafanaUser(name string) *models.UserProfileDTO {
+	return &models.UserProfileDTO{
+		ID:    2,
+		Email: "user@example.com",
+		Login: "user@example.com",
+		Name:  name,
+	}
+}
+
+func userConnectionDetails() managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		"id":    []byte("2"),
+		"login": []byte("user@example.com"),
+	}
+}
+
+// passwordSecret returns a kube client that serves the password secret of withPassword.
+func passwordSecret(password string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte(password)}
+			return nil
+		},
+	}
+}
+
+func hashOf(t *testing.T, password string) *string {
+	hash, err := common.HashPassword(password, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &hash
+}
+
+func TestObserve(t *testing.T) {
+	type fields struct {
+		service common.GrafanaAPI
+		kube    client.Client
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		mg     resource.Managed
+		want   want
+	}{
+		"NotGlobalUser": {
+			reason: "An error should be returned if the managed resource is not a GlobalUser",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			mg:     &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotGlobalUser),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the user cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetUserById", int64(2)).Return(nil, errBoom)
+				return m
+			}()},
+			mg: globalUser(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetUser),
+			},
+		},
+		"NotFound": {
+			reason: "A user without ID in status should be looked up by its email and reported as missing if Grafana does not know it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetUserByLoginOrEmail", "user@example.com").Return(nil, nil)
+				return m
+			}()},
+			mg: func() resource.Managed {
+				cr := globalUser()
+				cr.Status.AtProvider.UserID = nil
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The user should be reported as up to date if it matches the desired state",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetUserById", int64(2)).Return(grafanaUser("User"), nil)
+				return m
+			}()},
+			mg: globalUser(),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: userConnectionDetails(),
+				},
+			},
+		},
+		"NameChanged": {
+			reason: "The user should be reported as outdated if its name differs",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetUserById", int64(2)).Return(grafanaUser("Other"), nil)
+				return m
+			}()},
+			mg: globalUser(),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: userConnectionDetails(),
+				},
+			},
+		},
+		"PasswordUnchanged": {
+			reason: "The user should be reported as up to date if the password matches the stored hash",
+			fields: fields{
+				service: func() common.GrafanaAPI {
+					m := &common.MockGrafanaAPI{}
+					m.On("GetUserById", int64(2)).Return(grafanaUser("User"), nil)
+					return m
+				}(),
+				kube: passwordSecret("secret"),
+			},
+			mg: withPassword(globalUser(), hashOf(t, "secret")),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: userConnectionDetails(),
+				},
+			},
+		},
+		"PasswordChanged": {
+			reason: "The user should be reported as outdated if the password does not match the stored hash",
+			fields: fields{
+				service: func() common.GrafanaAPI {
+					m := &common.MockGrafanaAPI{}
+					m.On("GetUserById", int64(2)).Return(grafanaUser("User"), nil)
+					return m
+				}(),
+				kube: passwordSecret("changed"),
+			},
+			mg: withPassword(globalUser(), hashOf(t, "secret")),
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: userConnectionDetails(),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.fields.service, kube: tc.fields.kube}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("AdminCreateUser", &models.AdminCreateUserForm{Email: "user@example.com", Name: "User", Password: "secret"}).
+		Return(&models.AdminCreateUserResponse{ID: 2}, nil)
+	m.On("UpdateUserPermissions", int64(2), true).Return(nil)
+
+	cr := withPassword(globalUser(), nil)
+	cr.Status.AtProvider = v1alpha1.GlobalUserObservation{}
+	isAdmin := true
+	cr.Spec.ForProvider.IsAdmin = &isAdmin
+
+	e := external{service: m, kube: passwordSecret("secret")}
+	got, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{"id": []byte("2")}}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	if !common.PasswordMatches("secret", cr.Status.AtProvider.PasswordHash) {
+		t.Errorf("e.Create(...): expected the hash of the password in status, got %v", cr.Status.AtProvider.PasswordHash)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		password string
+		service  func() *common.MockGrafanaAPI
+		err      error
+	}{
+		"PasswordUnchanged": {
+			reason:   "The password should not be updated if it matches the stored hash",
+			password: "secret",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("UpdateUser", int64(2), mock.Anything).Return(&models.SuccessResponseBody{}, nil)
+				return m
+			},
+		},
+		"PasswordChanged": {
+			reason:   "The password should be updated if it does not match the stored hash",
+			password: "changed",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("UpdateUser", int64(2), mock.Anything).Return(&models.SuccessResponseBody{}, nil)
+				m.On("UpdateUserPassword", int64(2), "changed").Return(nil)
+				return m
+			},
+		},
+		"UpdateFailed": {
+			reason:   "An error should be returned if the user cannot be updated",
+			password: "secret",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("UpdateUser", int64(2), mock.Anything).Return(nil, errBoom)
+				return m
+			},
+			err: errors.Wrap(errBoom, errFailedUpdateUser),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			cr := withPassword(globalUser(), hashOf(t, "secret"))
+			e := external{service: m, kube: passwordSecret(tc.password)}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if tc.err != nil {
+				return
+			}
+			if !common.PasswordMatches(tc.password, cr.Status.AtProvider.PasswordHash) {
+				t.Errorf("\n%s\ne.Update(...): expected the hash of the current password in status", tc.reason)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestObserveAdoptsExisting(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		adopt  bool
+		want   managed.ExternalObservation
+	}{
+		"Annotated": {
+			reason: "A user with the email of the spec should be adopted and updated if the resource is annotated",
+			adopt:  true,
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: userConnectionDetails()},
+		},
+		"NotAnnotated": {
+			reason: "A user should only be looked up by its login if the resource is not annotated",
+			want:   managed.ExternalObservation{ResourceExists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetUserByLoginOrEmail", "user").Return(nil, nil)
+			m.On("GetUserByLoginOrEmail", "user@example.com").Return(grafanaUser("User"), nil)
+
+			login := "user"
+			cr := globalUser()
+			cr.Spec.ForProvider.Login = &login
+			cr.Status.AtProvider = v1alpha1.GlobalUserObservation{}
+			if tc.adopt {
+				cr.SetAnnotations(map[string]string{common.AnnotationKeyAdoptExisting: "true"})
+			}
+
+			e := external{service: m}
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package globaluser
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// randomPassword generates a password for users whose password is not managed by the provider.
+func randomPassword() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
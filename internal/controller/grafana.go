@@ -17,14 +17,33 @@ limitations under the License.
 package controller
 
 import (
+	"github.com/argannor/provider-grafana/internal/controller/alertrule"
+	"github.com/argannor/provider-grafana/internal/controller/annotation"
+	"github.com/argannor/provider-grafana/internal/controller/apikey"
 	"github.com/argannor/provider-grafana/internal/controller/dashboard"
 	"github.com/argannor/provider-grafana/internal/controller/datasource"
+	"github.com/argannor/provider-grafana/internal/controller/datasourcepermission"
 	"github.com/argannor/provider-grafana/internal/controller/folder"
+	"github.com/argannor/provider-grafana/internal/controller/globaluser"
+	"github.com/argannor/provider-grafana/internal/controller/librarypanel"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/argannor/provider-grafana/internal/controller/config"
+	"github.com/argannor/provider-grafana/internal/controller/messagetemplate"
+	"github.com/argannor/provider-grafana/internal/controller/mutetiming"
 	"github.com/argannor/provider-grafana/internal/controller/organization"
+	"github.com/argannor/provider-grafana/internal/controller/orgpreferences"
+	"github.com/argannor/provider-grafana/internal/controller/providerconfig"
+	"github.com/argannor/provider-grafana/internal/controller/recordingrule"
+	"github.com/argannor/provider-grafana/internal/controller/report"
+	"github.com/argannor/provider-grafana/internal/controller/role"
+	"github.com/argannor/provider-grafana/internal/controller/roleassignment"
+	"github.com/argannor/provider-grafana/internal/controller/snapshot"
+	"github.com/argannor/provider-grafana/internal/controller/ssosettings"
+	"github.com/argannor/provider-grafana/internal/controller/teammembership"
+	"github.com/argannor/provider-grafana/internal/controller/teampermission"
+	"github.com/argannor/provider-grafana/internal/controller/teampreferences"
 )
 
 // Setup creates all Grafana controllers with the supplied logger and adds them to
@@ -32,10 +51,29 @@ import (
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	for _, setup := range []func(ctrl.Manager, controller.Options) error{
 		config.Setup,
+		providerconfig.Setup,
+		alertrule.Setup,
+		annotation.Setup,
+		apikey.Setup,
 		dashboard.Setup,
 		datasource.Setup,
+		datasourcepermission.Setup,
 		folder.Setup,
+		globaluser.Setup,
+		librarypanel.Setup,
+		messagetemplate.Setup,
+		mutetiming.Setup,
 		organization.Setup,
+		orgpreferences.Setup,
+		recordingrule.Setup,
+		report.Setup,
+		role.Setup,
+		roleassignment.Setup,
+		snapshot.Setup,
+		ssosettings.Setup,
+		teammembership.Setup,
+		teampermission.Setup,
+		teampreferences.Setup,
 	} {
 		if err := setup(mgr, o); err != nil {
 			return err
@@ -0,0 +1,427 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package librarypanel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotLibraryPanel = "managed resource is not a LibraryPanel custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errOrgIdNotInt     = "orgId is not an integer"
+
+	errNewClient                = "cannot create new Service"
+	errFailedGetLibraryPanel    = "cannot get LibraryPanel from Grafana API"
+	errFailedGetConnections     = "cannot get dashboards connected to LibraryPanel"
+	errFailedCreateLibraryPanel = "cannot create LibraryPanel"
+	errFailedUpdateLibraryPanel = "cannot update LibraryPanel"
+	errFailedDeleteLibraryPanel = "cannot delete LibraryPanel"
+	errStillConnected           = "cannot delete LibraryPanel while it is used by dashboards %v, remove it from them first"
+
+	errUnmarshalJson = "cannot unmarshal JSON data"
+	errCompareModel  = "failed to compare modelJson"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles LibraryPanel managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.LibraryPanelGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.LibraryPanelGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.LibraryPanel{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.LibraryPanel)
+	if !ok {
+		return nil, errors.New(errNotLibraryPanel)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.LibraryPanel)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotLibraryPanel)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	atGrafana, err := c.GetLibraryPanel(orgId, cr)
+
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetLibraryPanel)
+	}
+
+	if atGrafana == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	connections, err := c.service.GetLibraryPanelConnections(orgId, atGrafana.UID)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetConnections)
+	}
+
+	upToDate, err := isUpToDate(cr, atGrafana)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr, *cr.Spec.ForProvider.OrgID)
+	cr.Status.AtProvider.DashboardUIDs = dashboardUIDs(connections)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.LibraryPanel)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotLibraryPanel)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	model, err := parseModelJson(spec.ModelJSON)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	response, err := c.service.CreateLibraryPanel(orgId, &models.CreateLibraryElementCommand{
+		FolderUID: common.DefaultString(spec.FolderUID, ""),
+		Model:     model,
+		Name:      *spec.Name,
+		UID:       common.DefaultString(spec.UID, ""),
+	})
+
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateLibraryPanel)
+	}
+
+	copyToStatus(response, cr, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.LibraryPanel)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotLibraryPanel)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	model, err := parseModelJson(spec.ModelJSON)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// Grafana rejects the patch unless it contains the current version
+	response, err := c.service.UpdateLibraryPanel(orgId, *cr.Status.AtProvider.UID, &models.PatchLibraryElementCommand{
+		FolderUID: common.DefaultString(spec.FolderUID, ""),
+		Model:     model,
+		Name:      *spec.Name,
+		UID:       *cr.Status.AtProvider.UID,
+		Version:   common.DefaultInt64(cr.Status.AtProvider.Version, 0),
+	})
+
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateLibraryPanel)
+	}
+
+	copyToStatus(response, cr, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.LibraryPanel)
+	if !ok {
+		return errors.New(errNotLibraryPanel)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	// Grafana refuses to delete library panels that are still in use. We check this upfront to return an error that
+	// tells the user what to do, the deletion is retried on the next reconcile.
+	connections, err := c.service.GetLibraryPanelConnections(orgId, *cr.Status.AtProvider.UID)
+	if err != nil {
+		return errors.Wrap(err, errFailedGetConnections)
+	}
+	if len(connections) > 0 {
+		uids := make([]string, 0, len(connections))
+		for _, uid := range dashboardUIDs(connections) {
+			uids = append(uids, *uid)
+		}
+		return errors.Errorf(errStillConnected, uids)
+	}
+
+	_, err = c.service.DeleteLibraryPanel(orgId, *cr.Status.AtProvider.UID)
+
+	return errors.Wrap(err, errFailedDeleteLibraryPanel)
+}
+
+func (c *external) GetLibraryPanel(orgId int64, cr *v1alpha1.LibraryPanel) (*models.LibraryElementDTO, error) {
+	if cr.Status.AtProvider.UID != nil {
+		return c.service.GetLibraryPanelByUid(orgId, *cr.Status.AtProvider.UID)
+	} else if cr.Spec.ForProvider.UID != nil {
+		return c.service.GetLibraryPanelByUid(orgId, *cr.Spec.ForProvider.UID)
+	} else {
+		return c.service.GetLibraryPanelByName(orgId, *cr.Spec.ForProvider.Name, cr.Spec.ForProvider.FolderUID)
+	}
+}
+
+func copyToStatus(response *models.LibraryElementDTO, cr *v1alpha1.LibraryPanel, orgId string) {
+	id := fmt.Sprintf("%s:%s", orgId, response.UID)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.UID = &response.UID
+	cr.Status.AtProvider.PanelID = &response.ID
+	cr.Status.AtProvider.Name = &response.Name
+	cr.Status.AtProvider.Description = &response.Description
+	cr.Status.AtProvider.FolderUID = &response.FolderUID
+	cr.Status.AtProvider.Type = &response.Type
+	cr.Status.AtProvider.Version = &response.Version
+	if response.Meta != nil {
+		cr.Status.AtProvider.FolderName = &response.Meta.FolderName
+	}
+}
+
+func dashboardUIDs(connections []*models.LibraryElementConnectionDTO) []*string {
+	uids := make([]*string, 0, len(connections))
+	for _, connection := range connections {
+		uid := connection.ConnectionUID
+		uids = append(uids, &uid)
+	}
+	return uids
+}
+
+func parseModelJson(modelJson *string) (map[string]interface{}, error) {
+	model := make(map[string]interface{})
+	if modelJson == nil || *modelJson == "" {
+		return model, nil
+	}
+	if err := json.Unmarshal([]byte(*modelJson), &model); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalJson)
+	}
+	return model, nil
+}
+
+// isUpToDate compares the model semantically, so formatting and key order of modelJson don't matter. Grafana adds
+// fields like the description and type to the stored model, only fields present in modelJson are compared.
+func isUpToDate(cr *v1alpha1.LibraryPanel, atGrafana *models.LibraryElementDTO) (bool, error) {
+	spec := cr.Spec.ForProvider
+	upToDate := true
+
+	upToDate = upToDate && common.CompareOptional(spec.Name, atGrafana.Name, "")
+	upToDate = upToDate && common.CompareOptional(spec.FolderUID, atGrafana.FolderUID, "")
+
+	desired, err := parseModelJson(spec.ModelJSON)
+	if err != nil {
+		return false, err
+	}
+	actual, ok := atGrafana.Model.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	relevant := make(map[string]interface{}, len(desired))
+	for key := range desired {
+		if value, found := actual[key]; found {
+			relevant[key] = value
+		}
+	}
+	modelUpToDate, err := common.CompareMap(desired, relevant)
+	if err != nil {
+		return false, errors.Wrap(err, errCompareModel)
+	}
+	upToDate = upToDate && modelUpToDate
+
+	return upToDate, nil
+}
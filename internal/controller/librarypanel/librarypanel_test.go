@@ -0,0 +1,270 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package librarypanel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func libraryPanel() *v1alpha1.LibraryPanel {
+	orgId := "1"
+	name := "test"
+	folderUid := "folder"
+	model := `{"type": "text", "options": {"content": "hello", "lines": 2}}`
+	uid := "abc"
+	return &v1alpha1.LibraryPanel{
+		Spec: v1alpha1.LibraryPanelSpec{
+			ForProvider: v1alpha1.LibraryPanelParameters{
+				FolderUID: &folderUid,
+				ModelJSON: &model,
+				Name:      &name,
+				OrgID:     &orgId,
+			},
+		},
+		Status: v1alpha1.LibraryPanelStatus{
+			AtProvider: v1alpha1.LibraryPanelObservation{
+				UID: &uid,
+			},
+		},
+	}
+}
+
+func grafanaLibraryPanel(content string) *models.LibraryElementDTO {
+	return &models.LibraryElementDTO{
+		FolderUID: "folder",
+		ID:        3,
+		Kind:      1,
+		// Grafana adds fields to the model and returns numbers as float64
+		Model: map[string]interface{}{
+			"description": "",
+			"type":        "text",
+			"options":     map[string]interface{}{"content": content, "lines": float64(2)},
+		},
+		Name:    "test",
+		OrgID:   1,
+		Type:    "text",
+		UID:     "abc",
+		Version: 1,
+	}
+}
+
+func grafanaConnections() []*models.LibraryElementConnectionDTO {
+	return []*models.LibraryElementConnectionDTO{{ConnectionUID: "dashboard"}}
+}
+
+func TestObserve(t *testing.T) {
+	type fields struct {
+		service common.GrafanaAPI
+		logger  logging.Logger
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"NotLibraryPanel": {
+			reason: "An error should be returned if the managed resource is not a LibraryPanel",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg:  &v1alpha1.Folder{},
+			},
+			want: want{
+				err: errors.New(errNotLibraryPanel),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the LibraryPanel cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetLibraryPanelByUid", int64(1), "abc").Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  libraryPanel(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetLibraryPanel),
+			},
+		},
+		"NotFound": {
+			reason: "The LibraryPanel should be reported as missing if Grafana does not know it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetLibraryPanelByUid", int64(1), "abc").Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  libraryPanel(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The LibraryPanel should be reported as up to date if the model is semantically equal",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetLibraryPanelByUid", int64(1), "abc").Return(grafanaLibraryPanel("hello"), nil)
+				m.On("GetLibraryPanelConnections", int64(1), "abc").Return(grafanaConnections(), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  libraryPanel(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "The LibraryPanel should be reported as outdated if the model differs",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetLibraryPanelByUid", int64(1), "abc").Return(grafanaLibraryPanel("changed"), nil)
+				m.On("GetLibraryPanelConnections", int64(1), "abc").Return(grafanaConnections(), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  libraryPanel(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.fields.service, logger: tc.fields.logger}
+			got, err := e.Observe(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		service common.GrafanaAPI
+		mg      resource.Managed
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   error
+	}{
+		"StillConnected": {
+			reason: "A LibraryPanel used by dashboards must not be deleted",
+			args: args{
+				service: func() common.GrafanaAPI {
+					m := &common.MockGrafanaAPI{}
+					m.On("GetLibraryPanelConnections", int64(1), "abc").Return(grafanaConnections(), nil)
+					return m
+				}(),
+				mg: libraryPanel(),
+			},
+			want: errors.Errorf(errStillConnected, []string{"dashboard"}),
+		},
+		"Deleted": {
+			reason: "A LibraryPanel without connections should be deleted",
+			args: args{
+				service: func() common.GrafanaAPI {
+					m := &common.MockGrafanaAPI{}
+					m.On("GetLibraryPanelConnections", int64(1), "abc").Return([]*models.LibraryElementConnectionDTO{}, nil)
+					m.On("DeleteLibraryPanel", int64(1), "abc").Return(&models.SuccessResponseBody{}, nil)
+					return m
+				}(),
+				mg: libraryPanel(),
+			},
+			want: nil,
+		},
+		"DeleteFailed": {
+			reason: "An error should be returned if Grafana fails to delete the LibraryPanel",
+			args: args{
+				service: func() common.GrafanaAPI {
+					m := &common.MockGrafanaAPI{}
+					m.On("GetLibraryPanelConnections", int64(1), "abc").Return([]*models.LibraryElementConnectionDTO{}, nil)
+					m.On("DeleteLibraryPanel", int64(1), "abc").Return(nil, errBoom)
+					return m
+				}(),
+				mg: libraryPanel(),
+			},
+			want: errors.Wrap(errBoom, errFailedDeleteLibraryPanel),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.args.service}
+			err := e.Delete(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package messagetemplate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+const content = `{{ define "slack.title" }}{{ .CommonLabels.alertname }}{{ end }}`
+
+func strRef(s string) *string {
+	return &s
+}
+
+func messageTemplate() *v1alpha1.MessageTemplate {
+	return &v1alpha1.MessageTemplate{
+		Spec: v1alpha1.MessageTemplateSpec{
+			ForProvider: v1alpha1.MessageTemplateParameters{
+				Name:     strRef("slack"),
+				OrgID:    strRef("1"),
+				Template: strRef(content),
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o          managed.ExternalObservation
+		conditions []v1.Condition
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotMessageTemplate": {
+			reason:  "An error should be returned if the managed resource is not a MessageTemplate",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotMessageTemplate),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the template cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMessageTemplate", int64(1), "slack").Return(nil, errBoom)
+				return m
+			}(),
+			mg: messageTemplate(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetMessageTemplate),
+			},
+		},
+		"NotFound": {
+			reason: "The template should be reported as missing if Grafana has none with its name",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMessageTemplate", int64(1), "slack").Return(nil, nil)
+				return m
+			}(),
+			mg: messageTemplate(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The template should be up to date if its content matches",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMessageTemplate", int64(1), "slack").Return(&models.NotificationTemplate{Name: "slack", Template: content}, nil)
+				return m
+			}(),
+			mg: messageTemplate(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+		"WhitespaceChanged": {
+			reason: "The template should be outdated if only whitespace differs, as it is part of the rendered messages",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMessageTemplate", int64(1), "slack").Return(&models.NotificationTemplate{Name: "slack", Template: content + "\n"}, nil)
+				return m
+			}(),
+			mg: messageTemplate(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.MessageTemplate); ok {
+				for _, want := range tc.want.conditions {
+					if got := cr.GetCondition(want.Type); !got.Equal(want) {
+						t.Errorf("\n%s\ne.Observe(...): want condition %v, got %v\n", tc.reason, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("SetMessageTemplate", int64(1), "slack", content).Return(nil)
+
+	cr := messageTemplate()
+
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(strRef("1:slack"), cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Deleted": {
+			reason: "The template should be deleted by its name",
+		},
+		"AlreadyDeleted": {
+			reason: "No error should be returned if the template is already gone",
+			err:    runtime.NewAPIError("deleteTemplate", nil, 404),
+		},
+		"DeleteFailed": {
+			reason: "An error should be returned if the template cannot be deleted",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedDeleteMessageTemplate),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("DeleteMessageTemplate", int64(1), "slack").Return(tc.err)
+			e := external{service: m}
+			err := e.Delete(context.Background(), messageTemplate())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,393 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutetiming
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotMuteTiming = "managed resource is not a MuteTiming custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errOrgIdNotInt   = "orgId is not an integer"
+
+	errNewClient              = "cannot create new Service"
+	errFailedGetMuteTiming    = "cannot get MuteTiming from Grafana API"
+	errFailedCreateMuteTiming = "cannot create MuteTiming"
+	errFailedUpdateMuteTiming = "cannot update MuteTiming"
+	errFailedDeleteMuteTiming = "cannot delete MuteTiming"
+	errStillReferenced        = "cannot delete MuteTiming %q while it is used by notification policies, remove it from them first"
+
+	errUnmarshalJson   = "cannot unmarshal timeIntervalsJson"
+	errMarshalJson     = "cannot marshal time intervals"
+	errCompareInterval = "failed to compare timeIntervalsJson"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles MuteTiming managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.MuteTimingGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.MuteTimingGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.MuteTiming{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.MuteTiming)
+	if !ok {
+		return nil, errors.New(errNotMuteTiming)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	defaultOrgID *int64
+}
+
+// Observe looks the mute timing up by its name, so mute timings that already exist in Grafana are adopted.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.MuteTiming)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMuteTiming)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	atGrafana, err := c.service.GetMuteTiming(orgId, *cr.Spec.ForProvider.Name)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetMuteTiming)
+	}
+
+	if atGrafana == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	upToDate, err := isUpToDate(cr, atGrafana)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(cr, atGrafana.Name, *cr.Spec.ForProvider.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.MuteTiming)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMuteTiming)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	intervals, err := parseTimeIntervals(spec.TimeIntervalsJSON)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	err = c.service.CreateMuteTiming(orgId, &models.MuteTimeInterval{Name: *spec.Name, TimeIntervals: intervals})
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateMuteTiming)
+	}
+
+	copyToStatus(cr, *spec.Name, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.MuteTiming)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMuteTiming)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	intervals, err := parseTimeIntervals(spec.TimeIntervalsJSON)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	err = c.service.UpdateMuteTiming(orgId, *spec.Name, &models.MuteTimeInterval{Name: *spec.Name, TimeIntervals: intervals})
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateMuteTiming)
+	}
+
+	copyToStatus(cr, *spec.Name, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Delete removes the mute timing by its name. Grafana refuses to delete mute timings that are still used by notification
+// policies, the deletion is retried on the next reconcile until they were removed from the policies.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.MuteTiming)
+	if !ok {
+		return errors.New(errNotMuteTiming)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	err = c.service.DeleteMuteTiming(orgId, *spec.Name)
+	switch {
+	case common.IsCode(err, http.StatusNotFound):
+		return nil
+	case common.IsCode(err, http.StatusConflict):
+		return errors.Wrapf(err, errStillReferenced, *spec.Name)
+	}
+	return errors.Wrap(err, errFailedDeleteMuteTiming)
+}
+
+func copyToStatus(cr *v1alpha1.MuteTiming, name string, orgId string) {
+	id := fmt.Sprintf("%s:%s", orgId, name)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.Name = &name
+}
+
+// parseTimeIntervals decodes timeIntervalsJson into the time intervals of the Grafana API. Unknown fields are rejected,
+// as Grafana would silently drop them, e.g. if a field is misspelled.
+func parseTimeIntervals(timeIntervalsJson *string) ([]*models.TimeInterval, error) {
+	intervals := make([]*models.TimeInterval, 0)
+	if timeIntervalsJson == nil || *timeIntervalsJson == "" {
+		return intervals, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader([]byte(*timeIntervalsJson)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&intervals); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalJson)
+	}
+	return intervals, nil
+}
+
+// decodeTimeIntervals turns the time intervals into plain JSON values that can be compared with common.CompareSlice.
+// Empty lists mean the same as absent ones, so both are dropped.
+func decodeTimeIntervals(intervals []*models.TimeInterval) ([]interface{}, error) {
+	encoded, err := json.Marshal(intervals)
+	if err != nil {
+		return nil, errors.Wrap(err, errMarshalJson)
+	}
+	decoded := make([]interface{}, 0, len(intervals))
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, errors.Wrap(err, errMarshalJson)
+	}
+	for _, interval := range decoded {
+		fields, ok := interval.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, value := range fields {
+			if value == nil {
+				delete(fields, key)
+			} else if list, ok := value.([]interface{}); ok && len(list) == 0 {
+				delete(fields, key)
+			}
+		}
+	}
+	return decoded, nil
+}
+
+// isUpToDate compares the decoded time intervals, so formatting and key order of timeIntervalsJson don't matter.
+func isUpToDate(cr *v1alpha1.MuteTiming, atGrafana *models.MuteTimeInterval) (bool, error) {
+	intervals, err := parseTimeIntervals(cr.Spec.ForProvider.TimeIntervalsJSON)
+	if err != nil {
+		return false, err
+	}
+	desired, err := decodeTimeIntervals(intervals)
+	if err != nil {
+		return false, err
+	}
+	actual, err := decodeTimeIntervals(atGrafana.TimeIntervals)
+	if err != nil {
+		return false, err
+	}
+	upToDate, err := common.CompareSlice(desired, actual)
+	if err != nil {
+		return false, errors.Wrap(err, errCompareInterval)
+	}
+	return upToDate, nil
+}
@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package mutetiming
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func muteTiming(timeIntervalsJson string) *v1alpha1.MuteTiming {
+	return &v1alpha1.MuteTiming{
+		Spec: v1alpha1.MuteTimingSpec{
+			ForProvider: v1alpha1.MuteTimingParameters{
+				Name:              strRef("weekends"),
+				OrgID:             strRef("1"),
+				TimeIntervalsJSON: &timeIntervalsJson,
+			},
+		},
+	}
+}
+
+// weekends is the mute timing as returned by Grafana, which omits empty lists.
+func weekends() *models.MuteTimeInterval {
+	return &models.MuteTimeInterval{
+		Name: "weekends",
+		TimeIntervals: []*models.TimeInterval{{
+			Weekdays: []string{"saturday", "sunday"},
+			Times:    []*models.TimeIntervalRange{{StartTime: "00:00", EndTime: "24:00"}},
+		}},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o          managed.ExternalObservation
+		conditions []v1.Condition
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotMuteTiming": {
+			reason:  "An error should be returned if the managed resource is not a MuteTiming",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotMuteTiming),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the mute timing cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMuteTiming", int64(1), "weekends").Return(nil, errBoom)
+				return m
+			}(),
+			mg: muteTiming(`[]`),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetMuteTiming),
+			},
+		},
+		"NotFound": {
+			reason: "The mute timing should be reported as missing if Grafana has none with its name",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMuteTiming", int64(1), "weekends").Return(nil, nil)
+				return m
+			}(),
+			mg: muteTiming(`[]`),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The mute timing should be up to date if the decoded intervals match, regardless of formatting, key order and empty lists",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMuteTiming", int64(1), "weekends").Return(weekends(), nil)
+				return m
+			}(),
+			mg: muteTiming(`[{"times": [{"end_time": "24:00", "start_time": "00:00"}],
+				"months": [], "weekdays": ["saturday", "sunday"]}]`),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+		"IntervalChanged": {
+			reason: "The mute timing should be outdated if an interval differs",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMuteTiming", int64(1), "weekends").Return(weekends(), nil)
+				return m
+			}(),
+			mg: muteTiming(`[{"weekdays": ["saturday"], "times": [{"start_time": "00:00", "end_time": "24:00"}]}]`),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+		"UnknownField": {
+			reason: "An error should be returned if timeIntervalsJson has a field Grafana doesn't know",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetMuteTiming", int64(1), "weekends").Return(weekends(), nil)
+				return m
+			}(),
+			mg: muteTiming(`[{"weekday": ["saturday"]}]`),
+			want: want{
+				err: errors.Wrap(errors.New(`json: unknown field "weekday"`), errUnmarshalJson),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.MuteTiming); ok {
+				for _, want := range tc.want.conditions {
+					if got := cr.GetCondition(want.Type); !got.Equal(want) {
+						t.Errorf("\n%s\ne.Observe(...): want condition %v, got %v\n", tc.reason, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateMuteTiming", int64(1), weekends()).Return(nil)
+
+	intervals, _ := json.Marshal(weekends().TimeIntervals)
+	cr := muteTiming(string(intervals))
+
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(strRef("1:weekends"), cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Deleted": {
+			reason: "The mute timing should be deleted by its name",
+		},
+		"AlreadyDeleted": {
+			reason: "No error should be returned if the mute timing is already gone",
+			err:    runtime.NewAPIError("deleteMuteTiming", nil, 404),
+		},
+		"StillReferenced": {
+			reason: "An error naming the mute timing should be returned if notification policies still use it",
+			err:    runtime.NewAPIError("deleteMuteTiming", nil, 409),
+			want:   errors.Wrapf(runtime.NewAPIError("deleteMuteTiming", nil, 409), errStillReferenced, "weekends"),
+		},
+		"DeleteFailed": {
+			reason: "An error should be returned if the mute timing cannot be deleted",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedDeleteMuteTiming),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("DeleteMuteTiming", int64(1), "weekends").Return(tc.err)
+			e := external{service: m}
+			err := e.Delete(context.Background(), muteTiming(`[]`))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -17,13 +17,13 @@ limitations under the License.
 package organization
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
 	"fmt"
-	"io"
-	"net/url"
+	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
@@ -36,7 +36,9 @@ import (
 	"github.com/grafana/grafana-openapi-client-go/models"
 
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -57,8 +59,6 @@ const (
 	errNotOrganization = "managed resource is not a Organization custom resource"
 	errTrackPCUsage    = "cannot track ProviderConfig usage"
 	errGetPC           = "cannot get ProviderConfig"
-	errGetCreds        = "cannot get credentials"
-	errCredsFormat     = "credentials are not formatted as base64 encoded 'username:password' pair"
 
 	errNewClient = "cannot create new Service"
 
@@ -67,8 +67,28 @@ const (
 	errUnexpectedRole = "unexpected role"
 	errCreateOrg      = "cannot create organization"
 	errDeleteOrg      = "cannot delete organization"
+	errNoOtherOrg     = "the user of the provider is not a member of any other organization"
 	errOrgNotFound    = "cannot find organization"
 	errUpdateUser     = "cannot update user"
+	errAddOrgUser     = "cannot add user %s to organization"
+	errUpdateOrgUser  = "cannot update role of user %s in organization"
+	errRemoveOrgUser  = "cannot remove user %s from organization"
+	errGetOrgQuotas   = "cannot get quotas of organization"
+	errUpdateOrgQuota = "cannot update quota %s of organization"
+	errUpdateOrgAddr  = "cannot update address of organization"
+
+	msgAddedUser       = "added user %s as %s"
+	msgUpdatedUserRole = "changed role of user %s to %s"
+	msgRemovedUser     = "removed user %s"
+
+	msgPendingAddUser        = "add %s as %s"
+	msgPendingUpdateUserRole = "change role of %s to %s"
+	msgPendingRemoveUser     = "remove %s"
+
+	// reasons of the events recorded when the provider changed the members of an organization in Grafana
+	reasonAddedUser       event.Reason = "AddedUser"
+	reasonUpdatedUserRole event.Reason = "UpdatedUserRole"
+	reasonRemovedUser     event.Reason = "RemovedUser"
 )
 
 var (
@@ -78,6 +98,17 @@ var (
 	}
 )
 
+// the targets of the organization quotas in the Grafana API
+const (
+	quotaTargetAlertRules  = "alert_rule"
+	quotaTargetDashboards  = "dashboard"
+	quotaTargetDataSources = "data_source"
+	quotaTargetUsers       = "user"
+)
+
+// quotaTargets lists the targets in the order they are updated.
+var quotaTargets = []string{quotaTargetAlertRules, quotaTargetDashboards, quotaTargetDataSources, quotaTargetUsers}
+
 type OrgUser struct {
 	ID    int64
 	Email string
@@ -100,6 +131,8 @@ const (
 // Setup adds a controller that reconciles Organization managed resources.
 func Setup(mgr ctrl.Manager, o controller.Options) error {
 	name := managed.ControllerName(v1alpha1.OrganizationGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
 
 	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
 	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
@@ -107,16 +140,18 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	}
 
 	logger := o.Logger.WithValues("controller", name)
-	r := managed.NewReconciler(mgr,
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
 		resource.ManagedKind(v1alpha1.OrganizationGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
 			newServiceFn: newService,
-			logger:       logger}),
+			logger:       logger,
+			recorder:     recorder}))),
 		managed.WithLogger(logger),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -124,7 +159,7 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		WithOptions(o.ForControllerRuntime()).
 		WithEventFilter(resource.DesiredStateChanged()).
 		For(&v1alpha1.Organization{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -134,6 +169,7 @@ type connector struct {
 	usage        resource.Tracker
 	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
 	logger       logging.Logger
+	recorder     event.Recorder
 }
 
 // Connect typically produces an ExternalClient by:
@@ -156,33 +192,17 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	cd := pc.Spec.Credentials
-	data, err := resource.CommonCredentialExtractor(ctx, cd.Source, c.kube, cd.CommonCredentialSelectors)
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
 	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-
-	decoder := base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data))
-	decodedCredentials, err := io.ReadAll(decoder)
-	if err != nil {
-		return nil, errors.Wrap(err, errGetCreds)
-	}
-	parts := strings.Split(string(decodedCredentials), ":")
-	if len(parts) != 2 {
-		return nil, errors.New(errCredsFormat)
+		return nil, err
 	}
 
-	clientCfg := grafana.DefaultTransportConfig()
-	clientCfg = clientCfg.WithHost(fmt.Sprintf("%s:%d", pc.Spec.Host, pc.Spec.Port))
-	clientCfg = clientCfg.WithSchemes(pc.Spec.Schemes)
-	clientCfg.BasicAuth = url.UserPassword(parts[0], parts[1])
-
 	svc, err := c.newServiceFn(clientCfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc, logger: c.logger}, nil
+	return &external{service: svc, logger: c.logger, recorder: c.recorder, users: common.Users, host: clientCfg.Host}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -190,8 +210,11 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
-	service common.GrafanaAPI
-	logger  logging.Logger
+	service  common.GrafanaAPI
+	logger   logging.Logger
+	recorder event.Recorder
+	users    *common.UserCache
+	host     string
 }
 
 type grafanaRole string
@@ -241,6 +264,19 @@ func (c *external) observeActualParameters(cr *v1alpha1.Organization) (*v1alpha1
 		}
 	}
 
+	// quotas are only requested if they are managed, Grafana rejects the request if quotas are disabled
+	if cr.Spec.ForProvider.Quotas != nil {
+		quotas, err := c.service.GetOrgQuotas(org.ID)
+		if err != nil {
+			return nil, org.ID, errors.Wrap(err, errGetOrgQuotas)
+		}
+		actual.Quotas = quotasFromGrafana(quotas)
+	}
+	// the address is only reported if it is managed, so that unmanaged organizations don't show an empty one
+	if cr.Spec.ForProvider.Address != nil {
+		actual.Address = addressFromGrafana(org.Address)
+	}
+
 	return &actual, org.ID, nil
 }
 
@@ -255,6 +291,31 @@ func copyToStatus(cr *v1alpha1.Organization, actual *v1alpha1.OrganizationParame
 	cr.Status.AtProvider.Editors = actual.Editors
 	cr.Status.AtProvider.Viewers = actual.Viewers
 	cr.Status.AtProvider.UsersWithoutAccess = actual.UsersWithoutAccess
+	cr.Status.AtProvider.Quotas = actual.Quotas
+	cr.Status.AtProvider.Address = actual.Address
+	cr.Status.AtProvider.PendingUserChanges = summarizeUserChanges(userChanges(mapUsers(*actual), mapUsers(cr.Spec.ForProvider)))
+}
+
+// summarizeUserChanges describes the membership changes the next update applies. The descriptions are sorted, so that
+// the status only changes if the changes do.
+func summarizeUserChanges(changes []UserChange) []string {
+	if len(changes) == 0 {
+		return nil
+	}
+	summary := make([]string, 0, len(changes))
+	for _, change := range changes {
+		u := change.User
+		switch change.Type {
+		case Add:
+			summary = append(summary, fmt.Sprintf(msgPendingAddUser, u.Email, u.Role))
+		case Update:
+			summary = append(summary, fmt.Sprintf(msgPendingUpdateUserRole, u.Email, u.Role))
+		case Remove:
+			summary = append(summary, fmt.Sprintf(msgPendingRemoveUser, u.Email))
+		}
+	}
+	sort.Strings(summary)
+	return summary
 }
 
 func (c *external) usersEqualIgnoreOrder(a, b []*string) bool {
@@ -285,7 +346,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	actual, orgId, err := c.observeActualParameters(cr)
 	if err != nil {
-		return managed.ExternalObservation{}, err
+		return managed.ExternalObservation{}, common.SetAPIError(cr, err)
 	}
 	if actual == nil {
 		return managed.ExternalObservation{
@@ -303,11 +364,14 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	upToDate = upToDate && c.usersEqualIgnoreOrder(cr.Spec.ForProvider.Editors, actual.Editors)
 	upToDate = upToDate && c.usersEqualIgnoreOrder(cr.Spec.ForProvider.Viewers, actual.Viewers)
 	upToDate = upToDate && c.usersEqualIgnoreOrder(cr.Spec.ForProvider.UsersWithoutAccess, actual.UsersWithoutAccess)
+	upToDate = upToDate && quotasUpToDate(cr.Spec.ForProvider.Quotas, actual.Quotas)
+	upToDate = upToDate && addressUpToDate(cr.Spec.ForProvider.Address, actual.Address)
 
-	cr.SetConditions(v1.Available())
+	cr.SetConditions(common.AvailableCondition(upToDate))
 
 	delta := cmp.Diff(cr.Spec.ForProvider, *actual)
 
+	common.SetLastReconcileAnnotation(mg, time.Now())
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
 		// the managed resource reconciler know that it needs to call Create to
@@ -323,7 +387,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 		// Return any details that may be required to connect to the external
 		// resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, nil
 }
 
@@ -345,39 +409,232 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	idAsString := fmt.Sprintf("%d", org.OrgID)
 	cr.Status.AtProvider.ID = &idAsString
 
-	err = c.updateUsers(cr, v1alpha1.OrganizationParameters{}, org.OrgID)
+	err = kerrors.NewAggregate([]error{
+		c.updateUsers(ctx, cr, v1alpha1.OrganizationParameters{}, org.OrgID),
+		c.updateQuotas(cr.Spec.ForProvider.Quotas, nil, *org.OrgID),
+		c.updateAddress(cr.Spec.ForProvider.Address, nil, *org.OrgID),
+	})
+	if err == nil {
+		common.SetLastReconcileAnnotation(mg, time.Now())
+	}
 
 	// TODO: according to the documentation we should not return an error if the resource already exists, but we need
 	//   to ensure, that the existing resource should be adopted somehow according to
 	//   https://github.com/crossplane/crossplane-runtime/issues/27
-	return managed.ExternalCreation{}, errors.Wrap(err, errCreateOrg)
+	return managed.ExternalCreation{ConnectionDetails: connectionDetails(cr)}, errors.Wrap(err, errCreateOrg)
 }
 
-func (c *external) updateUsers(cr *v1alpha1.Organization, actual v1alpha1.OrganizationParameters, orgID *int64) error {
+// connectionDetails exposes the numeric ID of the organization, so that it can be consumed by other resources.
+func connectionDetails(cr *v1alpha1.Organization) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{}
+	if cr.Status.AtProvider.OrgID != nil {
+		details["orgId"] = []byte(strconv.FormatInt(*cr.Status.AtProvider.OrgID, 10))
+	}
+	return details
+}
+
+func (c *external) updateUsers(ctx context.Context, cr *v1alpha1.Organization, actual v1alpha1.OrganizationParameters, orgID *int64) error {
 	var err error
 	changes := userChanges(mapUsers(actual), mapUsers(cr.Spec.ForProvider))
-	changes, err = c.addUserIdsToChanges(&cr.Spec.ForProvider, changes, *orgID)
+	changes, err = c.addUserIdsToChanges(ctx, &cr.Spec.ForProvider, changes, *orgID)
 	if err != nil {
 		return errors.Wrap(err, errUpdateUser)
 	}
-	for _, change := range changes {
-		u := change.User
-		switch change.Type {
-		case Add:
-			_, err = c.service.AddOrgUser(*orgID, &models.AddOrgUserCommand{LoginOrEmail: strings.ToLower(u.Email), Role: u.Role})
-		case Update:
-			_, err = c.service.UpdateOrgUser(*orgID, u.ID, &models.UpdateOrgUserCommand{Role: u.Role})
-		case Remove:
-			_, err = c.service.RemoveOrgUser(u.ID, *orgID)
+	// the changes of a batch are sent at once, the batches one after another
+	batchSize := 1
+	if cr.Spec.ForProvider.BatchSize != nil && *cr.Spec.ForProvider.BatchSize > 1 {
+		batchSize = int(*cr.Spec.ForProvider.BatchSize)
+	}
+	errs := make([]error, len(changes))
+	for start := 0; start < len(changes); start += batchSize {
+		end := start + batchSize
+		if end > len(changes) {
+			end = len(changes)
 		}
-		if err != nil && !strings.Contains(err.Error(), "409") {
-			// TODO: gather errors and return them all at once
-			return errors.Wrap(err, errUpdateUser)
+		var g errgroup.Group
+		for i := start; i < end; i++ {
+			i := i
+			g.Go(func() error {
+				errs[i] = c.applyUserChange(cr, changes[i], *orgID)
+				return nil
+			})
 		}
+		_ = g.Wait()
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// applyUserChange sends a single membership change to Grafana and records an event if it succeeded.
+func (c *external) applyUserChange(cr *v1alpha1.Organization, change UserChange, orgID int64) error {
+	u := change.User
+	var err error
+	var errFormat string
+	var e event.Event
+	switch change.Type {
+	case Add:
+		_, err = c.service.AddOrgUser(orgID, &models.AddOrgUserCommand{LoginOrEmail: strings.ToLower(u.Email), Role: u.Role})
+		errFormat = errAddOrgUser
+		e = event.Normal(reasonAddedUser, fmt.Sprintf(msgAddedUser, u.Email, u.Role))
+	case Update:
+		_, err = c.service.UpdateOrgUser(orgID, u.ID, &models.UpdateOrgUserCommand{Role: u.Role})
+		errFormat = errUpdateOrgUser
+		e = event.Normal(reasonUpdatedUserRole, fmt.Sprintf(msgUpdatedUserRole, u.Email, u.Role))
+	case Remove:
+		_, err = c.service.RemoveOrgUser(u.ID, orgID)
+		errFormat = errRemoveOrgUser
+		e = event.Normal(reasonRemovedUser, fmt.Sprintf(msgRemovedUser, u.Email))
+	}
+	switch {
+	case err == nil:
+		c.recordEvent(cr, e)
+	case !common.IsCode(err, http.StatusConflict):
+		// a conflict means the user already is in the desired state
+		return errors.Wrapf(err, errFormat, u.Email)
 	}
 	return nil
 }
 
+// quotaFields maps the quota targets of Grafana to the fields of the quotas.
+func quotaFields(quotas *v1alpha1.OrganizationQuotas) map[string]**int64 {
+	return map[string]**int64{
+		quotaTargetAlertRules:  &quotas.AlertRules,
+		quotaTargetDashboards:  &quotas.Dashboards,
+		quotaTargetDataSources: &quotas.DataSources,
+		quotaTargetUsers:       &quotas.Users,
+	}
+}
+
+// quotasFromGrafana returns the limits of the quotas reported by Grafana. Unknown targets are ignored.
+func quotasFromGrafana(dtos []*models.QuotaDTO) *v1alpha1.OrganizationQuotas {
+	quotas := &v1alpha1.OrganizationQuotas{}
+	fields := quotaFields(quotas)
+	for _, dto := range dtos {
+		if field, ok := fields[dto.Target]; ok {
+			limit := dto.Limit
+			*field = &limit
+		}
+	}
+	return quotas
+}
+
+// quotaChanges returns the limits of the quotas in desired that differ from actual, by target. Quotas that are nil in
+// desired are not managed and never changed.
+func quotaChanges(desired, actual *v1alpha1.OrganizationQuotas) map[string]int64 {
+	changes := make(map[string]int64)
+	if desired == nil {
+		return changes
+	}
+	if actual == nil {
+		actual = &v1alpha1.OrganizationQuotas{}
+	}
+	actualFields := quotaFields(actual)
+	for target, field := range quotaFields(desired) {
+		if *field == nil {
+			continue
+		}
+		if current := *actualFields[target]; current == nil || *current != **field {
+			changes[target] = **field
+		}
+	}
+	return changes
+}
+
+func quotasUpToDate(desired, actual *v1alpha1.OrganizationQuotas) bool {
+	return len(quotaChanges(desired, actual)) == 0
+}
+
+// updateQuotas sets the quotas that differ from actual. Grafana updates one quota per request, so all of them are
+// attempted and the errors are aggregated.
+func (c *external) updateQuotas(desired, actual *v1alpha1.OrganizationQuotas, orgID int64) error {
+	changes := quotaChanges(desired, actual)
+	var errs []error
+	for _, target := range quotaTargets {
+		limit, ok := changes[target]
+		if !ok {
+			continue
+		}
+		if err := c.service.UpdateOrgQuota(orgID, target, limit); err != nil {
+			errs = append(errs, errors.Wrapf(err, errUpdateOrgQuota, target))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// addressFromGrafana returns the address reported by Grafana. Organizations without an address have all fields empty.
+func addressFromGrafana(a *models.Address) *v1alpha1.OrganizationAddress {
+	if a == nil {
+		a = &models.Address{}
+	}
+	return &v1alpha1.OrganizationAddress{
+		Address1: &a.Address1,
+		Address2: &a.Address2,
+		City:     &a.City,
+		State:    &a.State,
+		ZipCode:  &a.ZipCode,
+		Country:  &a.Country,
+	}
+}
+
+// addressFields returns the fields of desired paired with the ones of actual. A nil actual address has all fields
+// empty.
+func addressFields(desired, actual *v1alpha1.OrganizationAddress) [][2]*string {
+	if actual == nil {
+		actual = &v1alpha1.OrganizationAddress{}
+	}
+	return [][2]*string{
+		{desired.Address1, actual.Address1},
+		{desired.Address2, actual.Address2},
+		{desired.City, actual.City},
+		{desired.State, actual.State},
+		{desired.ZipCode, actual.ZipCode},
+		{desired.Country, actual.Country},
+	}
+}
+
+// addressUpToDate returns whether the fields set in desired match actual. Fields that are not set are not managed.
+func addressUpToDate(desired, actual *v1alpha1.OrganizationAddress) bool {
+	if desired == nil {
+		return true
+	}
+	for _, field := range addressFields(desired, actual) {
+		current := common.DefaultString(field[1], "")
+		if !common.CompareOptional(field[0], current, current) {
+			return false
+		}
+	}
+	return true
+}
+
+// updateAddress sets the address of the organization. Grafana replaces the whole address, so the fields that are not
+// set in desired keep their actual value.
+func (c *external) updateAddress(desired, actual *v1alpha1.OrganizationAddress, orgID int64) error {
+	if desired == nil {
+		return nil
+	}
+	if actual == nil {
+		actual = &v1alpha1.OrganizationAddress{}
+	}
+	value := func(desired, actual *string) string {
+		return common.DefaultString(desired, common.DefaultString(actual, ""))
+	}
+	form := &models.UpdateOrgAddressForm{
+		Address1: value(desired.Address1, actual.Address1),
+		Address2: value(desired.Address2, actual.Address2),
+		City:     value(desired.City, actual.City),
+		State:    value(desired.State, actual.State),
+		Zipcode:  value(desired.ZipCode, actual.ZipCode),
+		Country:  value(desired.Country, actual.Country),
+	}
+	return errors.Wrap(c.service.UpdateOrg(orgID, form), errUpdateOrgAddr)
+}
+
+// recordEvent records an event for the organization, if the external client was created with a recorder.
+func (c *external) recordEvent(cr *v1alpha1.Organization, e event.Event) {
+	if c.recorder != nil {
+		c.recorder.Event(cr, e)
+	}
+}
+
 func mapUsers(p v1alpha1.OrganizationParameters) map[string]OrgUser {
 	var normalizedMail string
 	users := make(map[string]OrgUser)
@@ -401,15 +658,13 @@ func mapUsers(p v1alpha1.OrganizationParameters) map[string]OrgUser {
 }
 
 // nolint: gocyclo
-func (c *external) addUserIdsToChanges(d *v1alpha1.OrganizationParameters, changes []UserChange, orgId int64) ([]UserChange, error) {
-	gUserMap := make(map[string]int64)
-	gUsers, err := c.service.GetAllUsers()
+func (c *external) addUserIdsToChanges(ctx context.Context, d *v1alpha1.OrganizationParameters, changes []UserChange, orgId int64) ([]UserChange, error) {
+	gUserMap, err := c.users.Get(c.host, func() ([]*models.UserSearchHitDTO, error) {
+		return c.service.GetAllUsers(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, u := range gUsers {
-		gUserMap[u.Email] = u.ID
-	}
 	output := make([]UserChange, 0)
 	create := true
 	if d.CreateUsers != nil {
@@ -425,10 +680,11 @@ func (c *external) addUserIdsToChanges(d *v1alpha1.OrganizationParameters, chang
 			return nil, fmt.Errorf("error adding user %s. User does not exist in Grafana", change.User.Email)
 		}
 		if !ok && create {
-			id, err = c.service.CreateUser(strings.ToLower(change.User.Email))
+			id, err = c.createUser(strings.ToLower(change.User.Email))
 			if err != nil {
 				return nil, err
 			}
+			c.users.Invalidate(c.host)
 		}
 		change.User.ID = id
 		output = append(output, change)
@@ -436,6 +692,21 @@ func (c *external) addUserIdsToChanges(d *v1alpha1.OrganizationParameters, chang
 	return output, nil
 }
 
+// createUser creates a placeholder user for the email. Grafana rejects the creation if the user exists, which happens if
+// the search missed it, e.g. because it was disabled. In that case the existing user is looked up and reused.
+func (c *external) createUser(email string) (int64, error) {
+	id, err := c.service.CreateUser(email)
+	if !common.IsCode(err, http.StatusConflict, http.StatusPreconditionFailed) {
+		return id, err
+	}
+	existing, lookupErr := c.service.GetUserByLoginOrEmail(email)
+	if lookupErr != nil || existing == nil {
+		return 0, err
+	}
+	c.logger.Debug("reusing existing user instead of creating it", "email", email, "id", existing.ID)
+	return existing.ID, nil
+}
+
 func userChanges(stateUsers, configUsers map[string]OrgUser) []UserChange {
 	var changes []UserChange
 	for _, user := range configUsers {
@@ -467,7 +738,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotOrganization)
 	}
 
-	actual, _, err := c.observeActualParameters(cr)
+	actual, orgId, err := c.observeActualParameters(cr)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
@@ -481,14 +752,25 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	usersUpToDate = usersUpToDate && c.usersEqualIgnoreOrder(cr.Spec.ForProvider.Viewers, actual.Viewers)
 	usersUpToDate = usersUpToDate && c.usersEqualIgnoreOrder(cr.Spec.ForProvider.UsersWithoutAccess, actual.UsersWithoutAccess)
 
+	var errs []error
 	if !usersUpToDate {
-		err = c.updateUsers(cr, *actual, cr.Status.AtProvider.OrgID)
+		errs = append(errs, c.updateUsers(ctx, cr, *actual, cr.Status.AtProvider.OrgID))
+	}
+	if !quotasUpToDate(cr.Spec.ForProvider.Quotas, actual.Quotas) {
+		errs = append(errs, c.updateQuotas(cr.Spec.ForProvider.Quotas, actual.Quotas, orgId))
+	}
+	if !addressUpToDate(cr.Spec.ForProvider.Address, actual.Address) {
+		errs = append(errs, c.updateAddress(cr.Spec.ForProvider.Address, actual.Address, orgId))
+	}
+	err = kerrors.NewAggregate(errs)
+	if err == nil {
+		common.SetLastReconcileAnnotation(mg, time.Now())
 	}
 
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: connectionDetails(cr),
 	}, err
 }
 
@@ -498,6 +780,10 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotOrganization)
 	}
 
+	if err := common.CheckDeletionProtection(cr); err != nil {
+		return err
+	}
+
 	cr.SetConditions(v1.Deleting())
 
 	orgID := cr.Status.AtProvider.OrgID
@@ -505,18 +791,28 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return nil
 	}
 
-	currentUser, err := c.service.GetSignedInUser()
+	orgs, err := c.service.GetSignedInUserOrgs()
 	if err != nil {
 		return errors.Wrap(err, errDeleteOrg)
 	}
-
-	if currentUser.OrgID == *orgID {
-		err = c.service.SwitchToLowestOrgId()
-	}
-	if err != nil {
-		return errors.Wrap(err, errDeleteOrg)
+	fromOrgID, found := lowestOtherOrgID(orgs, *orgID)
+	if !found {
+		return errors.Wrap(errors.New(errNoOtherOrg), errDeleteOrg)
 	}
 
-	_, err = c.service.DeleteOrgByID(*orgID)
+	_, err = c.service.DeleteOrgByID(*orgID, fromOrgID)
 	return errors.Wrap(err, errDeleteOrg)
 }
+
+// lowestOtherOrgID returns the lowest ID of the given organizations that is not orgID.
+func lowestOtherOrgID(orgs []*models.UserOrgDTO, orgID int64) (int64, bool) {
+	var lowest int64
+	found := false
+	for _, org := range orgs {
+		if org.OrgID != orgID && (!found || org.OrgID < lowest) {
+			lowest = org.OrgID
+			found = true
+		}
+	}
+	return lowest, found
+}
@@ -18,12 +18,25 @@ package organization
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
 	"github.com/argannor/provider-grafana/internal/controller/common"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	oasruntime "github.com/go-openapi/runtime"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -38,6 +51,55 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
+var errBoom = errors.New("boom")
+
+func organization() *v1alpha1.Organization {
+	name := "test"
+	admin := "admin@example.com"
+	viewer := "viewer@example.com"
+	return &v1alpha1.Organization{
+		Spec: v1alpha1.OrganizationSpec{
+			ForProvider: v1alpha1.OrganizationParameters{
+				Name:    &name,
+				Admins:  []*string{&admin},
+				Viewers: []*string{&viewer},
+			},
+		},
+	}
+}
+
+func int64Ref(i int64) *int64 {
+	return &i
+}
+
+func int32Ref(i int32) *int32 {
+	return &i
+}
+
+// organizationWithQuotas returns an organization that manages its dashboard and user quotas.
+func organizationWithQuotas() *v1alpha1.Organization {
+	o := organization()
+	o.Spec.ForProvider.Quotas = &v1alpha1.OrganizationQuotas{Dashboards: int64Ref(10), Users: int64Ref(-1)}
+	return o
+}
+
+func grafanaOrgQuotas(dashboards int64) []*models.QuotaDTO {
+	return []*models.QuotaDTO{
+		{OrgID: 2, Target: "alert_rule", Limit: 100},
+		{OrgID: 2, Target: "dashboard", Limit: dashboards, Used: 3},
+		{OrgID: 2, Target: "data_source", Limit: 10},
+		{OrgID: 2, Target: "user", Limit: -1},
+		{OrgID: 2, Target: "api_key", Limit: 10},
+	}
+}
+
+func grafanaOrgUsers(viewerRole string) []*models.OrgUserDTO {
+	return []*models.OrgUserDTO{
+		{UserID: 1, Email: "admin@example.com", Role: "Admin"},
+		{UserID: 2, Email: "viewer@example.com", Role: viewerRole},
+	}
+}
+
 func TestObserve(t *testing.T) {
 	type fields struct {
 		service common.GrafanaAPI
@@ -60,7 +122,146 @@ func TestObserve(t *testing.T) {
 		args   args
 		want   want
 	}{
-		// TODO: Add test cases.
+		"NotOrganization": {
+			reason: "An error should be returned if the managed resource is not an Organization",
+			fields: fields{service: &common.MockGrafanaAPI{}},
+			args: args{
+				ctx: context.Background(),
+				mg:  &v1alpha1.Folder{},
+			},
+			want: want{
+				err: errors.New(errNotOrganization),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the Organization cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  organization(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetOrg),
+			},
+		},
+		"NotFound": {
+			reason: "The Organization should be reported as missing if Grafana does not know it",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(nil, nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  organization(),
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The Organization should be reported as up to date if all users have their desired role",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+				m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  organization(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"orgId": []byte("2")},
+				},
+			},
+		},
+		"NotUpToDate": {
+			reason: "The Organization should be reported as outdated if a user has a different role",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+				m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Editor"), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  organization(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{"orgId": []byte("2")},
+				},
+			},
+		},
+		"QuotasUpToDate": {
+			reason: "The Organization should be reported as up to date if the managed quotas have their desired limit",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+				m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+				m.On("GetOrgQuotas", int64(2)).Return(grafanaOrgQuotas(10), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  organizationWithQuotas(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"orgId": []byte("2")},
+				},
+			},
+		},
+		"QuotasNotUpToDate": {
+			reason: "The Organization should be reported as outdated if a managed quota has a different limit",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+				m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+				m.On("GetOrgQuotas", int64(2)).Return(grafanaOrgQuotas(-1), nil)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  organizationWithQuotas(),
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{"orgId": []byte("2")},
+				},
+			},
+		},
+		"GetQuotasFailed": {
+			reason: "An error should be returned if the quotas cannot be fetched from Grafana",
+			fields: fields{service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+				m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+				m.On("GetOrgQuotas", int64(2)).Return(nil, errBoom)
+				return m
+			}()},
+			args: args{
+				ctx: context.Background(),
+				mg:  organizationWithQuotas(),
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetOrgQuotas),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -70,9 +271,867 @@ func TestObserve(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+			// the diff is meant for humans and its formatting is not stable, so we ignore it
+			if diff := cmp.Diff(tc.want.o, got, cmpopts.IgnoreFields(managed.ExternalObservation{}, "Diff")); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		o   managed.ExternalCreation
+		err error
+	}
+
+	orgId := int64(2)
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		want    want
+	}{
+		"Created": {
+			reason: "The numeric ID of the created Organization should be returned as connection detail",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateOrg", "test").Return(&models.CreateOrgOKBody{OrgID: &orgId}, nil)
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{}, nil)
+				return m
+			}(),
+			want: want{
+				o: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{"orgId": []byte("2")}},
+			},
+		},
+		"CreateFailed": {
+			reason: "An error should be returned if the Organization cannot be created",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("CreateOrg", "test").Return(nil, errBoom)
+				return m
+			}(),
+			want: want{
+				err: errors.Wrap(errBoom, errCreateOrg),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := organization()
+			cr.Spec.ForProvider.Admins = nil
+			cr.Spec.ForProvider.Viewers = nil
+			e := external{service: tc.service}
+			got, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// apiError mimics the errors returned by the Grafana client for responses
+// without a dedicated type.
+type apiError struct {
+	code int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("unknown error (status %d)", e.code)
+}
+
+func (e *apiError) IsCode(code int) bool {
+	return e.code == code
+}
+
+func TestUpdateUsersIgnoresConflicts(t *testing.T) {
+	errNotConflict := errors.New("user with id 409 not found")
+	m := &common.MockGrafanaAPI{}
+	m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{
+		{ID: 1, Email: "admin@example.com"},
+		{ID: 2, Email: "viewer@example.com"},
+	}, nil)
+	m.On("AddOrgUser", int64(1), &models.AddOrgUserCommand{LoginOrEmail: "admin@example.com", Role: "Admin"}).Return(nil, &apiError{code: 409})
+	m.On("AddOrgUser", int64(1), &models.AddOrgUserCommand{LoginOrEmail: "viewer@example.com", Role: "Viewer"}).Return(nil, errNotConflict)
+
+	orgId := int64(1)
+	e := external{service: m}
+	err := e.updateUsers(context.Background(), organization(), v1alpha1.OrganizationParameters{}, &orgId)
+
+	want := kerrors.NewAggregate([]error{errors.Wrapf(errNotConflict, errAddOrgUser, "viewer@example.com")})
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.updateUsers(context.Background(), ...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestUpdateUsersReportsAllErrors(t *testing.T) {
+	errAdd := errors.New("add failed")
+	errRemove := errors.New("remove failed")
+	m := &common.MockGrafanaAPI{}
+	m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{
+		{ID: 1, Email: "admin@example.com"},
+		{ID: 2, Email: "viewer@example.com"},
+		{ID: 3, Email: "editor@example.com"},
+	}, nil)
+	m.On("AddOrgUser", int64(1), &models.AddOrgUserCommand{LoginOrEmail: "viewer@example.com", Role: "Viewer"}).Return(nil, errAdd)
+	m.On("UpdateOrgUser", int64(1), int64(1), &models.UpdateOrgUserCommand{Role: "Admin"}).Return(&models.SuccessResponseBody{}, nil)
+	m.On("RemoveOrgUser", int64(3), int64(1)).Return(nil, errRemove)
+
+	// admin@example.com gets promoted, viewer@example.com added and editor@example.com removed
+	admin := "admin@example.com"
+	editor := "editor@example.com"
+	actual := v1alpha1.OrganizationParameters{
+		Editors: []*string{&editor},
+		Viewers: []*string{&admin},
+	}
+
+	orgId := int64(1)
+	e := external{service: m}
+	err := e.updateUsers(context.Background(), organization(), actual, &orgId)
+
+	var aggregate kerrors.Aggregate
+	if !errors.As(err, &aggregate) {
+		t.Fatalf("e.updateUsers(context.Background(), ...): want aggregated errors, got %v", err)
+	}
+	want := []string{
+		errors.Wrapf(errAdd, errAddOrgUser, "viewer@example.com").Error(),
+		errors.Wrapf(errRemove, errRemoveOrgUser, "editor@example.com").Error(),
+	}
+	got := make([]string, 0, len(aggregate.Errors()))
+	for _, err := range aggregate.Errors() {
+		got = append(got, err.Error())
+	}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("e.updateUsers(context.Background(), ...): -want errors, +got errors:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateUsersInBatches(t *testing.T) {
+	errAdd := errors.New("add failed")
+	errRemove := errors.New("remove failed")
+
+	// four users each are added, promoted and removed
+	var admins, viewers, editors []*string
+	var grafanaUsers []*models.UserSearchHitDTO
+	for i := 1; i <= 4; i++ {
+		admin, viewer, editor := fmt.Sprintf("admin%d@example.com", i), fmt.Sprintf("viewer%d@example.com", i), fmt.Sprintf("editor%d@example.com", i)
+		admins, viewers, editors = append(admins, &admin), append(viewers, &viewer), append(editors, &editor)
+		grafanaUsers = append(grafanaUsers,
+			&models.UserSearchHitDTO{ID: int64(10 + i), Email: admin},
+			&models.UserSearchHitDTO{ID: int64(20 + i), Email: viewer},
+			&models.UserSearchHitDTO{ID: int64(30 + i), Email: editor},
+		)
+	}
+	desired := v1alpha1.OrganizationParameters{Admins: admins, Viewers: viewers}
+	actual := v1alpha1.OrganizationParameters{Viewers: admins, Editors: editors}
+
+	cases := map[string]struct {
+		failing map[string]error
+		want    []string
+	}{
+		"AllApplied": {},
+		"PartialFailure": {
+			failing: map[string]error{
+				"viewer2@example.com": errAdd,
+				"editor4@example.com": errRemove,
+			},
+			want: []string{
+				errors.Wrapf(errRemove, errRemoveOrgUser, "editor4@example.com").Error(),
+				errors.Wrapf(errAdd, errAddOrgUser, "viewer2@example.com").Error(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetAllUsers", mock.Anything).Return(grafanaUsers, nil)
+			for i := 1; i <= 4; i++ {
+				viewer, editor := fmt.Sprintf("viewer%d@example.com", i), fmt.Sprintf("editor%d@example.com", i)
+				m.On("UpdateOrgUser", int64(1), int64(10+i), &models.UpdateOrgUserCommand{Role: "Admin"}).Return(&models.SuccessResponseBody{}, nil).Once()
+				m.On("AddOrgUser", int64(1), &models.AddOrgUserCommand{LoginOrEmail: viewer, Role: "Viewer"}).Return(nil, tc.failing[viewer]).Once()
+				m.On("RemoveOrgUser", int64(30+i), int64(1)).Return(nil, tc.failing[editor]).Once()
+			}
+
+			cr := organization()
+			cr.Spec.ForProvider = desired
+			cr.Spec.ForProvider.BatchSize = int32Ref(10)
+			orgId := int64(1)
+			e := external{service: m}
+			err := e.updateUsers(context.Background(), cr, actual, &orgId)
+
+			var got []string
+			var aggregate kerrors.Aggregate
+			if errors.As(err, &aggregate) {
+				for _, err := range aggregate.Errors() {
+					got = append(got, err.Error())
+				}
+			} else if err != nil {
+				t.Fatalf("e.updateUsers(context.Background(), ...): want aggregated errors, got %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("e.updateUsers(context.Background(), ...): -want errors, +got errors:\n%s\n", diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestObserveCopiesQuotasToStatus(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+	m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+	m.On("GetOrgQuotas", int64(2)).Return(grafanaOrgQuotas(10), nil)
+
+	cr := organizationWithQuotas()
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+
+	want := &v1alpha1.OrganizationQuotas{
+		AlertRules:  int64Ref(100),
+		Dashboards:  int64Ref(10),
+		DataSources: int64Ref(10),
+		Users:       int64Ref(-1),
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider.Quotas); diff != "" {
+		t.Errorf("e.Observe(...): -want quotas, +got quotas:\n%s\n", diff)
+	}
+}
+
+func TestObserveSummarizesPendingUserChanges(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+	m.On("GetOrgUsers", int64(2)).Return([]*models.OrgUserDTO{
+		{UserID: 1, Email: "admin@example.com", Role: "Admin"},
+		{UserID: 2, Email: "viewer@example.com", Role: "Editor"},
+		{UserID: 3, Email: "gone@example.com", Role: "Viewer"},
+	}, nil)
+
+	cr := organization()
+	editor := "Editor@Example.com"
+	cr.Spec.ForProvider.Editors = []*string{&editor}
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+
+	want := []string{
+		"add editor@example.com as Editor",
+		"change role of viewer@example.com to Viewer",
+		"remove gone@example.com",
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider.PendingUserChanges); diff != "" {
+		t.Errorf("e.Observe(...): -want pending user changes, +got pending user changes:\n%s\n", diff)
+	}
+
+	m = &common.MockGrafanaAPI{}
+	m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+	m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+	cr = organization()
+	cr.Status.AtProvider.PendingUserChanges = want
+	e = external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	if cr.Status.AtProvider.PendingUserChanges != nil {
+		t.Errorf("e.Observe(...): want no pending user changes once the members are up to date, got %v", cr.Status.AtProvider.PendingUserChanges)
+	}
+}
+
+func TestQuotaChanges(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		desired *v1alpha1.OrganizationQuotas
+		actual  *v1alpha1.OrganizationQuotas
+		want    map[string]int64
+	}{
+		"NotManaged": {
+			reason: "Quotas should not be changed if none are managed",
+			actual: quotasFromGrafana(grafanaOrgQuotas(10)),
+			want:   map[string]int64{},
+		},
+		"UpToDate": {
+			reason:  "Quotas that have their desired limit should not be changed",
+			desired: &v1alpha1.OrganizationQuotas{Dashboards: int64Ref(10), Users: int64Ref(-1)},
+			actual:  quotasFromGrafana(grafanaOrgQuotas(10)),
+			want:    map[string]int64{},
+		},
+		"Changed": {
+			reason:  "Only the managed quotas that differ should be changed",
+			desired: &v1alpha1.OrganizationQuotas{AlertRules: int64Ref(50), Dashboards: int64Ref(10), DataSources: int64Ref(-1)},
+			actual:  quotasFromGrafana(grafanaOrgQuotas(10)),
+			want:    map[string]int64{"alert_rule": 50, "data_source": -1},
+		},
+		"NotReported": {
+			reason:  "Quotas that Grafana did not report should be set",
+			desired: &v1alpha1.OrganizationQuotas{Dashboards: int64Ref(10), Users: int64Ref(5)},
+			want:    map[string]int64{"dashboard": 10, "user": 5},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := quotaChanges(tc.desired, tc.actual)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nquotaChanges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(len(tc.want) == 0, quotasUpToDate(tc.desired, tc.actual)); diff != "" {
+				t.Errorf("\n%s\nquotasUpToDate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateQuotasReportsAllErrors(t *testing.T) {
+	errDashboards := errors.New("dashboards failed")
+	errUsers := errors.New("users failed")
+	m := &common.MockGrafanaAPI{}
+	m.On("UpdateOrgQuota", int64(2), "alert_rule", int64(50)).Return(nil)
+	m.On("UpdateOrgQuota", int64(2), "dashboard", int64(10)).Return(errDashboards)
+	m.On("UpdateOrgQuota", int64(2), "user", int64(5)).Return(errUsers)
+
+	desired := &v1alpha1.OrganizationQuotas{AlertRules: int64Ref(50), Dashboards: int64Ref(10), Users: int64Ref(5)}
+	e := external{service: m}
+	err := e.updateQuotas(desired, nil, 2)
+
+	want := kerrors.NewAggregate([]error{
+		errors.Wrapf(errDashboards, errUpdateOrgQuota, "dashboard"),
+		errors.Wrapf(errUsers, errUpdateOrgQuota, "user"),
+	})
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.updateQuotas(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateSetsQuotas(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+	m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+	m.On("GetOrgQuotas", int64(2)).Return(grafanaOrgQuotas(-1), nil)
+	m.On("UpdateOrgQuota", int64(2), "dashboard", int64(10)).Return(nil)
+
+	cr := organizationWithQuotas()
+	cr.Status.AtProvider.OrgID = int64Ref(2)
+	e := external{service: m}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %v", err)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestAddressUpToDate(t *testing.T) {
+	city := "Berlin"
+	zipCode := "10115"
+	actual := addressFromGrafana(&models.Address{Address1: "Main Street 1", City: "Berlin", Country: "Germany"})
+	cases := map[string]struct {
+		desired *v1alpha1.OrganizationAddress
+		actual  *v1alpha1.OrganizationAddress
+		want    bool
+	}{
+		"NotManaged": {
+			desired: nil,
+			actual:  actual,
+			want:    true,
+		},
+		"UnsetFieldsAreIgnored": {
+			desired: &v1alpha1.OrganizationAddress{City: &city},
+			actual:  actual,
+			want:    true,
+		},
+		"FieldDiffers": {
+			desired: &v1alpha1.OrganizationAddress{City: &city, ZipCode: &zipCode},
+			actual:  actual,
+			want:    false,
+		},
+		"NoAddressInGrafana": {
+			desired: &v1alpha1.OrganizationAddress{City: &city},
+			actual:  addressFromGrafana(nil),
+			want:    false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := addressUpToDate(tc.desired, tc.actual); got != tc.want {
+				t.Errorf("addressUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestUpdateSetsAddress(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test", Address: &models.Address{
+		Address1: "Main Street 1", City: "Berlin", Country: "Germany",
+	}}, nil)
+	m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+	m.On("UpdateOrg", int64(2), &models.UpdateOrgAddressForm{
+		Address1: "Main Street 1", City: "Berlin", Zipcode: "10115", Country: "Germany",
+	}).Return(nil)
+
+	zipCode := "10115"
+	cr := organization()
+	cr.Spec.ForProvider.Address = &v1alpha1.OrganizationAddress{ZipCode: &zipCode}
+	cr.Status.AtProvider.OrgID = int64Ref(2)
+	e := external{service: m}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error: %v", err)
+	}
+	m.AssertExpectations(t)
+}
+
+// recorder records the events of a test.
+type recorder struct {
+	events []event.Event
+}
+
+func (r *recorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+}
+
+func (r *recorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestUpdateUsersRecordsEvents(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{
+		{ID: 1, Email: "admin@example.com"},
+		{ID: 2, Email: "viewer@example.com"},
+		{ID: 3, Email: "editor@example.com"},
+	}, nil)
+	m.On("AddOrgUser", int64(1), &models.AddOrgUserCommand{LoginOrEmail: "viewer@example.com", Role: "Viewer"}).Return(nil, &apiError{code: 409})
+	m.On("UpdateOrgUser", int64(1), int64(1), &models.UpdateOrgUserCommand{Role: "Admin"}).Return(&models.SuccessResponseBody{}, nil)
+	m.On("RemoveOrgUser", int64(3), int64(1)).Return(&models.SuccessResponseBody{}, nil)
+
+	// admin@example.com gets promoted, viewer@example.com already is a member and editor@example.com is removed
+	admin := "admin@example.com"
+	editor := "editor@example.com"
+	actual := v1alpha1.OrganizationParameters{
+		Editors: []*string{&editor},
+		Viewers: []*string{&admin},
+	}
+
+	orgId := int64(1)
+	r := &recorder{}
+	e := external{service: m, recorder: r}
+	if err := e.updateUsers(context.Background(), organization(), actual, &orgId); err != nil {
+		t.Fatalf("e.updateUsers(context.Background(), ...): unexpected error %v", err)
+	}
+
+	want := []event.Event{
+		event.Normal(reasonUpdatedUserRole, "changed role of user admin@example.com to Admin"),
+		event.Normal(reasonRemovedUser, "removed user editor@example.com"),
+	}
+	if diff := cmp.Diff(want, r.events, cmpopts.SortSlices(func(a, b event.Event) bool { return a.Message < b.Message })); diff != "" {
+		t.Errorf("e.updateUsers(context.Background(), ...): -want events, +got events:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		service func() *common.MockGrafanaAPI
+		want    error
+	}{
+		"FromLowestOtherOrg": {
+			reason: "The organization should be deleted from the lowest other organization of the user",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSignedInUserOrgs").Return([]*models.UserOrgDTO{{OrgID: 5}, {OrgID: 2}, {OrgID: 3}}, nil)
+				m.On("DeleteOrgByID", int64(2), int64(3)).Return(&models.SuccessResponseBody{}, nil)
+				return m
+			},
+		},
+		"NoOtherOrg": {
+			reason: "An error should be returned if the user is not a member of any other organization",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSignedInUserOrgs").Return([]*models.UserOrgDTO{{OrgID: 2}}, nil)
+				return m
+			},
+			want: errors.Wrap(errors.New(errNoOtherOrg), errDeleteOrg),
+		},
+		"DeleteFailed": {
+			reason: "An error should be returned if Grafana refuses to delete the organization",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSignedInUserOrgs").Return([]*models.UserOrgDTO{{OrgID: 1}, {OrgID: 2}}, nil)
+				m.On("DeleteOrgByID", int64(2), int64(1)).Return(nil, errBoom)
+				return m
+			},
+			want: errors.Wrap(errBoom, errDeleteOrg),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			cr := organization()
+			orgId := int64(2)
+			cr.Status.AtProvider.OrgID = &orgId
+
+			e := external{service: m}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func strRefs(s ...string) []*string {
+	refs := make([]*string, 0, len(s))
+	for i := range s {
+		refs = append(refs, &s[i])
+	}
+	return refs
+}
+
+func lessUserChange(a, b UserChange) bool {
+	return a.User.Email < b.User.Email
+}
+
+func TestUserChanges(t *testing.T) {
+	admin := OrgUser{Email: "admin@example.com", Role: "Admin"}
+	viewer := OrgUser{Email: "viewer@example.com", Role: "Viewer"}
+	editor := OrgUser{Email: "editor@example.com", Role: "Editor"}
+
+	cases := map[string]struct {
+		reason string
+		state  map[string]OrgUser
+		config map[string]OrgUser
+		want   []UserChange
+	}{
+		"AddsOnly": {
+			reason: "Users missing in Grafana should be added",
+			state:  map[string]OrgUser{admin.Email: admin},
+			config: map[string]OrgUser{admin.Email: admin, viewer.Email: viewer},
+			want:   []UserChange{{Add, viewer}},
+		},
+		"RemovesOnly": {
+			reason: "Users missing in the configuration should be removed",
+			state:  map[string]OrgUser{admin.Email: admin, viewer.Email: viewer},
+			config: map[string]OrgUser{admin.Email: admin},
+			want:   []UserChange{{Remove, viewer}},
+		},
+		"UpdatesOnly": {
+			reason: "Users with a different role should be updated to the configured role",
+			state:  map[string]OrgUser{admin.Email: admin, viewer.Email: viewer},
+			config: map[string]OrgUser{admin.Email: admin, viewer.Email: {Email: viewer.Email, Role: "Editor"}},
+			want:   []UserChange{{Update, OrgUser{Email: viewer.Email, Role: "Editor"}}},
+		},
+		"Mixed": {
+			reason: "Adds, updates and removes should be computed in a single pass",
+			state:  map[string]OrgUser{admin.Email: admin, viewer.Email: viewer},
+			config: map[string]OrgUser{admin.Email: {Email: admin.Email, Role: "Viewer"}, editor.Email: editor},
+			want: []UserChange{
+				{Update, OrgUser{Email: admin.Email, Role: "Viewer"}},
+				{Add, editor},
+				{Remove, viewer},
+			},
+		},
+		"NoChanges": {
+			reason: "No changes should be computed if Grafana matches the configuration",
+			state:  map[string]OrgUser{admin.Email: admin},
+			config: map[string]OrgUser{admin.Email: admin},
+			want:   nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := userChanges(tc.state, tc.config)
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(lessUserChange)); diff != "" {
+				t.Errorf("\n%s\nuserChanges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUsersEqualIgnoreOrder(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		a      []*string
+		b      []*string
+		want   bool
+	}{
+		"SameOrder": {
+			reason: "Identical lists should be equal",
+			a:      strRefs("a@example.com", "b@example.com"),
+			b:      strRefs("a@example.com", "b@example.com"),
+			want:   true,
+		},
+		"OtherOrder": {
+			reason: "The order of the users should not matter",
+			a:      strRefs("a@example.com", "b@example.com"),
+			b:      strRefs("b@example.com", "a@example.com"),
+			want:   true,
+		},
+		"OtherCase": {
+			reason: "Emails should be compared case-insensitively",
+			a:      strRefs("A@Example.com"),
+			b:      strRefs("a@example.com"),
+			want:   true,
+		},
+		"DifferentLength": {
+			reason: "Lists of different length should not be equal",
+			a:      strRefs("a@example.com"),
+			b:      strRefs("a@example.com", "b@example.com"),
+			want:   false,
+		},
+		"DifferentUsers": {
+			reason: "Lists with different users should not be equal",
+			a:      strRefs("a@example.com"),
+			b:      strRefs("b@example.com"),
+			want:   false,
+		},
+		"Empty": {
+			reason: "Empty and nil lists should be equal",
+			a:      []*string{},
+			b:      nil,
+			want:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{}
+			if got := e.usersEqualIgnoreOrder(tc.a, tc.b); got != tc.want {
+				t.Errorf("\n%s\ne.usersEqualIgnoreOrder(...): want %t, got %t\n", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestMapUsersNormalizesEmails(t *testing.T) {
+	p := v1alpha1.OrganizationParameters{
+		Admins:             strRefs("Admin@Example.com"),
+		Editors:            strRefs("EDITOR@example.com"),
+		Viewers:            strRefs("viewer@example.com"),
+		UsersWithoutAccess: strRefs("None@example.COM"),
+	}
+	want := map[string]OrgUser{
+		"admin@example.com":  {Email: "admin@example.com", Role: "Admin"},
+		"editor@example.com": {Email: "editor@example.com", Role: "Editor"},
+		"viewer@example.com": {Email: "viewer@example.com", Role: "Viewer"},
+		"none@example.com":   {Email: "none@example.com", Role: "None"},
+	}
+	if diff := cmp.Diff(want, mapUsers(p)); diff != "" {
+		t.Errorf("mapUsers(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestAddUserIdsToChanges(t *testing.T) {
+	type want struct {
+		changes []UserChange
+		err     error
+	}
+
+	existing := OrgUser{Email: "admin@example.com", Role: "Admin"}
+	missing := OrgUser{Email: "new@example.com", Role: "Viewer"}
+
+	cases := map[string]struct {
+		reason      string
+		createUsers *bool
+		changes     []UserChange
+		service     func() *common.MockGrafanaAPI
+		want        want
+	}{
+		"ExistingUser": {
+			reason:  "The ID of users known to Grafana should be added to the change",
+			changes: []UserChange{{Add, existing}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{{ID: 1, Email: "admin@example.com"}}, nil)
+				return m
+			},
+			want: want{
+				changes: []UserChange{{Add, OrgUser{ID: 1, Email: "admin@example.com", Role: "Admin"}}},
+			},
+		},
+		"MissingUserNotCreated": {
+			reason:      "An error should be returned if a user does not exist in Grafana and users must not be created",
+			createUsers: func() *bool { b := false; return &b }(),
+			changes:     []UserChange{{Add, missing}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{{ID: 1, Email: "admin@example.com"}}, nil)
+				return m
+			},
+			want: want{
+				err: fmt.Errorf("error adding user %s. User does not exist in Grafana", "new@example.com"),
+			},
+		},
+		"MissingUserCreated": {
+			reason:      "Users that do not exist in Grafana should be created if users may be created",
+			createUsers: func() *bool { b := true; return &b }(),
+			changes:     []UserChange{{Add, missing}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{{ID: 1, Email: "admin@example.com"}}, nil)
+				m.On("CreateUser", "new@example.com").Return(int64(2), nil)
+				return m
+			},
+			want: want{
+				changes: []UserChange{{Add, OrgUser{ID: 2, Email: "new@example.com", Role: "Viewer"}}},
+			},
+		},
+		"CreateUserFailed": {
+			reason:  "An error should be returned if a missing user cannot be created",
+			changes: []UserChange{{Add, missing}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{}, nil)
+				m.On("CreateUser", "new@example.com").Return(int64(0), errBoom)
+				return m
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"CreateConflictReusesExistingUser": {
+			reason:  "A user that the search missed but Grafana reports as existing on creation should be looked up and reused",
+			changes: []UserChange{{Add, missing}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{}, nil)
+				m.On("CreateUser", "new@example.com").Return(int64(0), oasruntime.NewAPIError("adminCreateUser", nil, 409))
+				m.On("GetUserByLoginOrEmail", "new@example.com").Return(&models.UserProfileDTO{ID: 3, Email: "new@example.com"}, nil)
+				return m
+			},
+			want: want{
+				changes: []UserChange{{Add, OrgUser{ID: 3, Email: "new@example.com", Role: "Viewer"}}},
+			},
+		},
+		"CreateConflictUserNotFound": {
+			reason:  "The conflict should be returned if the existing user cannot be found either",
+			changes: []UserChange{{Add, missing}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{}, nil)
+				m.On("CreateUser", "new@example.com").Return(int64(0), oasruntime.NewAPIError("adminCreateUser", nil, 409))
+				m.On("GetUserByLoginOrEmail", "new@example.com").Return(nil, nil)
+				return m
+			},
+			want: want{
+				err: oasruntime.NewAPIError("adminCreateUser", nil, 409),
+			},
+		},
+		"RemoveMissingUser": {
+			reason:  "Removing a user that no longer exists in Grafana should be skipped",
+			changes: []UserChange{{Remove, missing}, {Remove, existing}},
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{{ID: 1, Email: "admin@example.com"}}, nil)
+				return m
+			},
+			want: want{
+				changes: []UserChange{{Remove, OrgUser{ID: 1, Email: "admin@example.com", Role: "Admin"}}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			e := external{service: m, logger: logging.NewNopLogger()}
+			got, err := e.addUserIdsToChanges(context.Background(), &v1alpha1.OrganizationParameters{CreateUsers: tc.createUsers}, tc.changes, 1)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.addUserIdsToChanges(context.Background(), ...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.changes, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\ne.addUserIdsToChanges(context.Background(), ...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestObserveSetsReadyCondition(t *testing.T) {
+	type want struct {
+		status corev1.ConditionStatus
+		reason xpv1.ConditionReason
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service func() *common.MockGrafanaAPI
+		want    want
+	}{
+		"UpToDate": {
+			reason: "A Organization that is up to date should be available",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+				m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Viewer"), nil)
+				return m
+			},
+			want: want{status: corev1.ConditionTrue, reason: xpv1.ReasonAvailable},
+		},
+		"Drifted": {
+			reason: "A Organization that differs from its spec should be unavailable, because it drifted",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(&models.OrgDetailsDTO{ID: 2, Name: "test"}, nil)
+				m.On("GetOrgUsers", int64(2)).Return(grafanaOrgUsers("Editor"), nil)
+				return m
+			},
+			want: want{status: corev1.ConditionFalse, reason: common.ReasonResourceDrifted},
+		},
+		"APIError": {
+			reason: "A Organization that cannot be observed should be unavailable, because of the API error",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetOrgByName", "test").Return(nil, errBoom)
+				return m
+			},
+			want: want{status: corev1.ConditionFalse, reason: common.ReasonAPIError},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := organization()
+			// the Organization was available before
+			cr.SetConditions(xpv1.Available())
+			e := external{service: tc.service()}
+			_, err := e.Observe(context.Background(), cr)
+
+			ready := cr.GetCondition(xpv1.TypeReady)
+			got := want{status: ready.Status, reason: ready.Reason}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want Ready condition, +got Ready condition:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.reason == common.ReasonAPIError && (err == nil || ready.Message != err.Error()) {
+				t.Errorf("\n%s\ne.Observe(...): want the error %v as message, got %q\n", tc.reason, err, ready.Message)
+			}
+		})
+	}
+}
+
+func TestDeleteRefusedIfProtected(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	cr := organization()
+	meta.AddAnnotations(cr, map[string]string{common.AnnotationKeyDeletionProtection: "true"})
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), cr)
+	if diff := cmp.Diff(errors.New(common.ErrDeletionProtected), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	if len(m.Calls) != 0 {
+		t.Errorf("e.Delete(...): want no requests to Grafana, got %v", m.Calls)
+	}
+	if got := cr.GetCondition(common.TypeDeletionProtected); got.Status != corev1.ConditionTrue {
+		t.Errorf("e.Delete(...): want the DeletionProtected condition, got %v", got)
+	}
+}
@@ -0,0 +1,337 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orgpreferences
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotOrgPreferences = "managed resource is not a OrgPreferences custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errOrgIdNotInt       = "orgId is not an integer"
+
+	errNewClient                  = "cannot create new Service"
+	errFailedGetOrgPreferences    = "cannot get OrgPreferences from Grafana API"
+	errFailedUpdateOrgPreferences = "cannot update OrgPreferences"
+	errFailedResetOrgPreferences  = "cannot reset OrgPreferences to defaults"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles OrgPreferences managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.OrgPreferencesGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.OrgPreferencesGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.OrgPreferences{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.OrgPreferences)
+	if !ok {
+		return nil, errors.New(errNotOrgPreferences)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	defaultOrgID *int64
+}
+
+// Observe reads the preferences of the organization. Preferences always exist in Grafana, so they are only reported as
+// existing once they were applied by the provider, and as deleted once they have been reset to their defaults.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.OrgPreferences)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotOrgPreferences)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if cr.Status.AtProvider.ID == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	atGrafana, err := c.service.GetOrgPreferences(orgId)
+
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetOrgPreferences)
+	}
+
+	if meta.WasDeleted(cr) && isDefault(atGrafana) {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	upToDate := isUpToDate(cr, atGrafana)
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr, *cr.Spec.ForProvider.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Create applies the preferences, as they can't be created in Grafana.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.OrgPreferences)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotOrgPreferences)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	if err := c.apply(cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.OrgPreferences)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotOrgPreferences)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.apply(cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Delete resets the preferences of the organization to their defaults.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.OrgPreferences)
+	if !ok {
+		return errors.New(errNotOrgPreferences)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	_, err = c.service.UpdateOrgPreferences(orgId, &models.UpdatePrefsCmd{})
+
+	return errors.Wrap(err, errFailedResetOrgPreferences)
+}
+
+// apply replaces all preferences of the organization with the desired ones.
+func (c *external) apply(cr *v1alpha1.OrgPreferences) error {
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	command := &models.UpdatePrefsCmd{
+		HomeDashboardUID: common.DefaultString(spec.HomeDashboardUID, ""),
+		Theme:            common.DefaultString(spec.Theme, ""),
+		Timezone:         common.DefaultString(spec.Timezone, ""),
+		WeekStart:        common.DefaultString(spec.WeekStart, ""),
+	}
+	if _, err := c.service.UpdateOrgPreferences(orgId, command); err != nil {
+		return errors.Wrap(err, errFailedUpdateOrgPreferences)
+	}
+
+	copyToStatus(&models.Preferences{
+		HomeDashboardUID: command.HomeDashboardUID,
+		Theme:            command.Theme,
+		Timezone:         command.Timezone,
+		WeekStart:        command.WeekStart,
+	}, cr, *spec.OrgID)
+	return nil
+}
+
+func copyToStatus(response *models.Preferences, cr *v1alpha1.OrgPreferences, orgId string) {
+	cr.Status.AtProvider.ID = &orgId
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.HomeDashboardUID = &response.HomeDashboardUID
+	cr.Status.AtProvider.Theme = &response.Theme
+	cr.Status.AtProvider.Timezone = &response.Timezone
+	cr.Status.AtProvider.WeekStart = &response.WeekStart
+}
+
+func isDefault(atGrafana *models.Preferences) bool {
+	return atGrafana.HomeDashboardUID == "" && atGrafana.HomeDashboardID == 0 &&
+		atGrafana.Theme == "" && atGrafana.Timezone == "" && atGrafana.WeekStart == ""
+}
+
+func isUpToDate(cr *v1alpha1.OrgPreferences, atGrafana *models.Preferences) bool {
+	spec := cr.Spec.ForProvider
+	upToDate := true
+
+	upToDate = upToDate && common.CompareOptional(spec.HomeDashboardUID, atGrafana.HomeDashboardUID, "")
+	upToDate = upToDate && common.CompareOptional(spec.Theme, atGrafana.Theme, "")
+	upToDate = upToDate && common.CompareOptional(spec.Timezone, atGrafana.Timezone, "")
+	upToDate = upToDate && common.CompareOptional(spec.WeekStart, atGrafana.WeekStart, "")
+
+	return upToDate
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providerconfig
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+)
+
+const (
+	errGetPC        = "cannot get ProviderConfig"
+	errNewClient    = "cannot create new Service"
+	errSignIn       = "cannot sign in to Grafana"
+	errUpdateStatus = "cannot update status of ProviderConfig"
+
+	reconcileTimeout = 1 * time.Minute
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that verifies the credentials of ProviderConfigs by
+// signing in to Grafana. The result is reported as the Ready condition.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := "providerconfig/credentials." + v1beta1.ProviderConfigGroupKind
+
+	r := &Reconciler{
+		kube:         mgr.GetClient(),
+		logger:       o.Logger.WithValues("controller", name),
+		newServiceFn: newService,
+		pollInterval: o.PollInterval,
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1beta1.ProviderConfig{}).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A Reconciler checks the credentials of a ProviderConfig whenever it changes,
+// and again after every poll interval to notice revoked credentials.
+type Reconciler struct {
+	kube         client.Client
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+	pollInterval time.Duration
+}
+
+// Reconcile signs in to Grafana with the credentials of the ProviderConfig and
+// sets its Ready condition accordingly.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	log := r.logger.WithValues("request", req)
+
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+
+	pc := &v1beta1.ProviderConfig{}
+	if err := r.kube.Get(ctx, req.NamespacedName, pc); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPC)
+	}
+	if meta.WasDeleted(pc) {
+		return reconcile.Result{}, nil
+	}
+
+	var svc common.GrafanaAPI
+	clientCfg, err := common.NewTransportConfig(ctx, r.kube, pc)
+	if err == nil {
+		svc, err = r.signIn(clientCfg)
+	}
+	switch {
+	case err == nil:
+		pc.SetConditions(xpv1.Available())
+		r.setServerInfo(pc, svc)
+	case clientCfg == nil || common.IsCode(err, 401, 403):
+		log.Debug("Invalid credentials", "error", err)
+		pc.SetConditions(v1beta1.CredentialsInvalid(err.Error()))
+	default:
+		// the credentials might be fine, but Grafana could not be asked
+		log.Debug("Cannot reach Grafana", "error", err)
+		pc.SetConditions(v1beta1.Unreachable(err.Error()))
+	}
+
+	return reconcile.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.kube.Status().Update(ctx, pc), errUpdateStatus)
+}
+
+// signIn builds a client like the connectors of the managed resources and asks
+// Grafana for the signed in user.
+func (r *Reconciler) signIn(clientCfg *grafana.TransportConfig) (common.GrafanaAPI, error) {
+	svc, err := r.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	_, err = svc.GetSignedInUser()
+	return svc, errors.Wrap(err, errSignIn)
+}
+
+// setServerInfo reports the version and the enabled feature toggles of Grafana
+// in the status. They are informational only, so errors are just logged.
+func (r *Reconciler) setServerInfo(pc *v1beta1.ProviderConfig, svc common.GrafanaAPI) {
+	info, err := common.ServerInfos.Get(pc.Name, svc.GetServerInfo)
+	if err != nil {
+		r.logger.Debug("Cannot get the version of Grafana", "error", err)
+		return
+	}
+
+	pc.Status.Version = info.Version
+	pc.Status.FeatureToggles = nil
+	for name, enabled := range info.FeatureToggles {
+		if enabled {
+			pc.Status.FeatureToggles = append(pc.Status.FeatureToggles, name)
+		}
+	}
+	sort.Strings(pc.Status.FeatureToggles)
+}
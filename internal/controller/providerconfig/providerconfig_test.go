@@ -0,0 +1,163 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providerconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/client/signed_in_user"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func providerConfig() *v1beta1.ProviderConfig {
+	return &v1beta1.ProviderConfig{
+		Spec: v1beta1.ProviderConfigSpec{
+			Credentials: v1beta1.ProviderCredentials{
+				Source: xpv1.CredentialsSourceSecret,
+				CommonCredentialSelectors: xpv1.CommonCredentialSelectors{
+					SecretRef: &xpv1.SecretKeySelector{
+						SecretReference: xpv1.SecretReference{Name: "grafana", Namespace: "crossplane-system"},
+						Key:             "credentials",
+					},
+				},
+			},
+			Host:    "grafana",
+			Port:    3000,
+			Schemes: []string{"http"},
+		},
+	}
+}
+
+// kubeClient serves the ProviderConfig and its credentials secret and records the status update.
+func kubeClient(credentials string, updated *v1beta1.ProviderConfig) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *v1beta1.ProviderConfig:
+				providerConfig().DeepCopyInto(o)
+			case *corev1.Secret:
+				o.Data = map[string][]byte{"credentials": []byte(credentials)}
+			}
+			return nil
+		},
+		MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+			obj.(*v1beta1.ProviderConfig).DeepCopyInto(updated)
+			return nil
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	valid := base64.StdEncoding.EncodeToString([]byte("admin:admin"))
+
+	cases := map[string]struct {
+		reason      string
+		credentials string
+		signIn      error
+		want        xpv1.ConditionReason
+		wantStatus  v1beta1.ProviderConfigStatus
+	}{
+		"Valid": {
+			reason:      "The ProviderConfig should be ready and report the server info if Grafana accepts the credentials",
+			credentials: valid,
+			want:        xpv1.ReasonAvailable,
+			wantStatus: v1beta1.ProviderConfigStatus{
+				Version:        "10.3.1",
+				FeatureToggles: []string{"nestedFolders", "publicDashboards"},
+			},
+		},
+		"Rejected": {
+			reason:      "The credentials should be reported as invalid if Grafana rejects them",
+			credentials: valid,
+			signIn:      &signed_in_user.GetSignedInUserUnauthorized{Payload: &models.ErrorResponseBody{}},
+			want:        v1beta1.ReasonCredentialsInvalid,
+		},
+		"Malformed": {
+			reason:      "The credentials should be reported as invalid if they are no 'username:password' pair",
+			credentials: base64.StdEncoding.EncodeToString([]byte("admin")),
+			want:        v1beta1.ReasonCredentialsInvalid,
+		},
+		"Unreachable": {
+			reason:      "Grafana should be reported as unreachable if signing in fails for other reasons",
+			credentials: valid,
+			signIn:      errBoom,
+			want:        v1beta1.ReasonUnreachable,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("GetSignedInUser").Return(&models.UserProfileDTO{Login: "admin"}, tc.signIn)
+			m.On("GetServerInfo").Return(&common.ServerInfo{
+				Version:        "10.3.1",
+				FeatureToggles: map[string]bool{"publicDashboards": true, "nestedFolders": true, "dashgpt": false},
+			}, nil)
+
+			updated := &v1beta1.ProviderConfig{}
+			r := &Reconciler{
+				kube:   kubeClient(tc.credentials, updated),
+				logger: logging.NewNopLogger(),
+				newServiceFn: func(_ *grafana.TransportConfig) (common.GrafanaAPI, error) {
+					return m, nil
+				},
+				pollInterval: time.Minute,
+			}
+
+			got, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "default"}})
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(reconcile.Result{RequeueAfter: time.Minute}, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, updated.GetCondition(xpv1.TypeReady).Reason); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want reason of Ready condition, +got reason of Ready condition:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantStatus, updated.Status, cmpopts.IgnoreFields(v1beta1.ProviderConfigStatus{}, "ProviderConfigStatus")); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordingrule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotRecordingRule = "managed resource is not a RecordingRule custom resource"
+	errTrackPCUsage     = "cannot track ProviderConfig usage"
+	errGetPC            = "cannot get ProviderConfig"
+	errOrgIdNotInt      = "orgId is not an integer"
+	errNoFolderUID      = "folderUid is not set and could not be resolved from folderRef or folderSelector"
+	errNoGroupName      = "neither groupName nor name is set"
+
+	errNewClient                 = "cannot create new Service"
+	errFailedGetRecordingRule    = "cannot get RecordingRule group from Grafana API"
+	errFailedCreateRecordingRule = "cannot create RecordingRule group"
+	errFailedUpdateRecordingRule = "cannot update RecordingRule group"
+	errFailedDeleteRecordingRule = "cannot delete RecordingRule group"
+
+	// defaultInterval is used if no interval is configured, it matches Grafana's default evaluation interval.
+	defaultInterval = "1m"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles RecordingRule managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RecordingRuleGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.RecordingRuleGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.RecordingRule{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RecordingRule)
+	if !ok {
+		return nil, errors.New(errNotRecordingRule)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+// location returns the organization, folder and name of the rule group of the managed resource.
+func location(cr *v1alpha1.RecordingRule) (int64, string, string, error) {
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return 0, "", "", errors.Wrap(err, errOrgIdNotInt)
+	}
+	if spec.FolderUID == nil {
+		return 0, "", "", errors.New(errNoFolderUID)
+	}
+	group := common.DefaultString(spec.GroupName, common.DefaultString(spec.Name, ""))
+	if group == "" {
+		return 0, "", "", errors.New(errNoGroupName)
+	}
+	return orgId, *spec.FolderUID, group, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RecordingRule)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRecordingRule)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	orgId, folderUID, group, err := location(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	atGrafana, err := c.service.GetRulerRuleGroup(orgId, folderUID, group)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetRecordingRule)
+	}
+
+	// the ruler API has no notion of an empty rule group, a group without rules does not exist
+	if atGrafana == nil || len(atGrafana.Rules) == 0 {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr, *cr.Spec.ForProvider.OrgID, folderUID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: isUpToDate(cr, atGrafana),
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RecordingRule)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRecordingRule)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	orgId, folderUID, group, err := location(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	ruleGroup := makeRuleGroup(cr, group)
+	if err := c.service.SetRulerRuleGroup(orgId, folderUID, ruleGroup); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateRecordingRule)
+	}
+
+	copyToStatus(ruleGroup, cr, *cr.Spec.ForProvider.OrgID, folderUID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RecordingRule)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRecordingRule)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	orgId, folderUID, group, err := location(cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// the ruler API replaces groups atomically, so the whole group is written even if only a single rule changed
+	ruleGroup := makeRuleGroup(cr, group)
+	if err := c.service.SetRulerRuleGroup(orgId, folderUID, ruleGroup); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateRecordingRule)
+	}
+
+	copyToStatus(ruleGroup, cr, *cr.Spec.ForProvider.OrgID, folderUID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RecordingRule)
+	if !ok {
+		return errors.New(errNotRecordingRule)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	orgId, folderUID, group, err := location(cr)
+	if err != nil {
+		return err
+	}
+
+	if err := c.service.DeleteRulerRuleGroup(orgId, folderUID, group); err != nil {
+		return errors.Wrap(err, errFailedDeleteRecordingRule)
+	}
+	return nil
+}
+
+func copyToStatus(ruleGroup *common.RulerRuleGroup, cr *v1alpha1.RecordingRule, orgId string, folderUID string) {
+	id := fmt.Sprintf("%s:%s:%s", orgId, folderUID, ruleGroup.Name)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.FolderUID = &folderUID
+	cr.Status.AtProvider.GroupName = &ruleGroup.Name
+	cr.Status.AtProvider.Interval = &ruleGroup.Interval
+	rules := make([]v1alpha1.RecordingRuleDefinition, 0, len(ruleGroup.Rules))
+	for _, rule := range ruleGroup.Rules {
+		record, expr := rule.Record, rule.Expr
+		rules = append(rules, v1alpha1.RecordingRuleDefinition{Record: &record, Expr: &expr})
+	}
+	cr.Status.AtProvider.Rules = rules
+}
+
+// makeRuleGroup builds the complete rule group as it should be stored in Grafana.
+func makeRuleGroup(cr *v1alpha1.RecordingRule, group string) *common.RulerRuleGroup {
+	spec := cr.Spec.ForProvider
+	rules := make([]*common.RulerRule, 0, len(spec.Rules))
+	for _, rule := range spec.Rules {
+		rules = append(rules, &common.RulerRule{
+			Record: common.DefaultString(rule.Record, ""),
+			Expr:   common.DefaultString(rule.Expr, ""),
+		})
+	}
+	return &common.RulerRuleGroup{
+		Name:     group,
+		Interval: common.DefaultString(spec.Interval, defaultInterval),
+		Rules:    rules,
+	}
+}
+
+// sameInterval compares two intervals by their duration, as Grafana may return another notation than configured,
+// e.g. 1m for 60s. Intervals that cannot be parsed, like 1d, are compared literally.
+func sameInterval(desired string, actual string) bool {
+	desiredDuration, desiredErr := time.ParseDuration(desired)
+	actualDuration, actualErr := time.ParseDuration(actual)
+	if desiredErr != nil || actualErr != nil {
+		return desired == actual
+	}
+	return desiredDuration == actualDuration
+}
+
+// isUpToDate compares the rules of the group one by one by their record and expression. Rules are evaluated in
+// order, so a changed order is a difference as well.
+func isUpToDate(cr *v1alpha1.RecordingRule, atGrafana *common.RulerRuleGroup) bool {
+	desired := makeRuleGroup(cr, atGrafana.Name)
+
+	actualInterval := atGrafana.Interval
+	if actualInterval == "" {
+		actualInterval = defaultInterval
+	}
+	if !sameInterval(desired.Interval, actualInterval) {
+		return false
+	}
+	if len(desired.Rules) != len(atGrafana.Rules) {
+		return false
+	}
+	for i, rule := range desired.Rules {
+		actual := atGrafana.Rules[i]
+		if rule.Record != actual.Record || strings.TrimSpace(rule.Expr) != strings.TrimSpace(actual.Expr) {
+			return false
+		}
+	}
+	return true
+}
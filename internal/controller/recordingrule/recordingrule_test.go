@@ -0,0 +1,277 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package recordingrule
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func recordingRule() *v1alpha1.RecordingRule {
+	return &v1alpha1.RecordingRule{
+		Spec: v1alpha1.RecordingRuleSpec{
+			ForProvider: v1alpha1.RecordingRuleParameters{
+				FolderUID: strRef("abc"),
+				Name:      strRef("test"),
+				OrgID:     strRef("1"),
+				Rules: []v1alpha1.RecordingRuleDefinition{
+					{Record: strRef("job:requests:rate5m"), Expr: strRef("sum by (job) (rate(requests_total[5m]))")},
+					{Record: strRef("job:errors:rate5m"), Expr: strRef("sum by (job) (rate(errors_total[5m]))")},
+				},
+			},
+		},
+	}
+}
+
+func rulerRuleGroup(errorsExpr string) *common.RulerRuleGroup {
+	return &common.RulerRuleGroup{
+		Name:     "test",
+		Interval: "1m",
+		Rules: []*common.RulerRule{
+			{Record: "job:requests:rate5m", Expr: "sum by (job) (rate(requests_total[5m]))"},
+			{Record: "job:errors:rate5m", Expr: errorsExpr},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotRecordingRule": {
+			reason:  "An error should be returned if the managed resource is not a RecordingRule",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotRecordingRule),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the rule group cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRulerRuleGroup", int64(1), "abc", "test").Return(nil, errBoom)
+				return m
+			}(),
+			mg: recordingRule(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetRecordingRule),
+			},
+		},
+		"NotFound": {
+			reason: "The rule group should be reported as missing if Grafana does not know it",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRulerRuleGroup", int64(1), "abc", "test").Return(nil, nil)
+				return m
+			}(),
+			mg: recordingRule(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The rule group should be reported as up to date if all rules match",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRulerRuleGroup", int64(1), "abc", "test").Return(rulerRuleGroup("sum by (job) (rate(errors_total[5m]))"), nil)
+				return m
+			}(),
+			mg: recordingRule(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"IntervalInOtherNotation": {
+			reason: "The rule group should be reported as up to date if the interval only differs in notation",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRulerRuleGroup", int64(1), "abc", "test").Return(rulerRuleGroup("sum by (job) (rate(errors_total[5m]))"), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := recordingRule()
+				cr.Spec.ForProvider.Interval = strRef("60s")
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"SingleExpressionChanged": {
+			reason: "The rule group should be reported as outdated if the expression of a single rule differs",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRulerRuleGroup", int64(1), "abc", "test").Return(rulerRuleGroup("sum by (job) (rate(errors_total[1m]))"), nil)
+				return m
+			}(),
+			mg: recordingRule(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"RuleRemoved": {
+			reason: "The rule group should be reported as outdated if a rule was removed from the spec",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRulerRuleGroup", int64(1), "abc", "test").Return(rulerRuleGroup("sum by (job) (rate(errors_total[5m]))"), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := recordingRule()
+				cr.Spec.ForProvider.Rules = cr.Spec.ForProvider.Rules[:1]
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		mg     *v1alpha1.RecordingRule
+		group  *common.RulerRuleGroup
+	}{
+		"DefaultGroupName": {
+			reason: "The rule group should be named after the resource if no group name is set",
+			mg:     recordingRule(),
+			group:  rulerRuleGroup("sum by (job) (rate(errors_total[5m]))"),
+		},
+		"ExplicitGroupName": {
+			reason: "The configured group name and interval should be used if they are set",
+			mg: func() *v1alpha1.RecordingRule {
+				cr := recordingRule()
+				cr.Spec.ForProvider.GroupName = strRef("other")
+				cr.Spec.ForProvider.Interval = strRef("5m")
+				return cr
+			}(),
+			group: func() *common.RulerRuleGroup {
+				g := rulerRuleGroup("sum by (job) (rate(errors_total[5m]))")
+				g.Name = "other"
+				g.Interval = "5m"
+				return g
+			}(),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("SetRulerRuleGroup", int64(1), "abc", tc.group).Return(nil)
+
+			e := external{service: m}
+			_, err := e.Create(context.Background(), tc.mg)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			id := "1:abc:" + tc.group.Name
+			if diff := cmp.Diff(&id, tc.mg.Status.AtProvider.ID); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want ID, +got ID:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUpdateReplacesWholeGroup(t *testing.T) {
+	// only the expression of the second rule changed, but the ruler API replaces groups atomically
+	cr := recordingRule()
+	cr.Spec.ForProvider.Rules[1].Expr = strRef("sum by (job) (rate(errors_total[1m]))")
+
+	m := &common.MockGrafanaAPI{}
+	m.On("SetRulerRuleGroup", int64(1), "abc", rulerRuleGroup("sum by (job) (rate(errors_total[1m]))")).Return(nil)
+
+	e := external{service: m}
+	_, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(cr.Spec.ForProvider.Rules, cr.Status.AtProvider.Rules); diff != "" {
+		t.Errorf("e.Update(...): -want rules in status, +got rules in status:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateFailed(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("SetRulerRuleGroup", int64(1), "abc", rulerRuleGroup("sum by (job) (rate(errors_total[5m]))")).Return(errBoom)
+
+	e := external{service: m}
+	_, err := e.Update(context.Background(), recordingRule())
+	if diff := cmp.Diff(errors.Wrap(errBoom, errFailedUpdateRecordingRule), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteRulerRuleGroup", int64(1), "abc", "test").Return(nil)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), recordingRule())
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
@@ -0,0 +1,589 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/go-openapi/strfmt"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotReport    = "managed resource is not a Report custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errOrgIdNotInt  = "orgId is not an integer"
+	errTimeFormat   = "%s is not an RFC 3339 timestamp"
+
+	errNewClient          = "cannot create new Service"
+	errFailedGetReport    = "cannot get Report from Grafana API"
+	errFailedCreateReport = "cannot create Report"
+	errFailedUpdateReport = "cannot update Report"
+	errFailedDeleteReport = "cannot delete Report"
+
+	// msgReportsUnavailable explains why a Report is not applied if Grafana lacks the API.
+	msgReportsUnavailable = "reports are not available, they require Grafana Enterprise or Grafana Cloud"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles Report managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.ReportGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.ReportGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Report{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Report)
+	if !ok {
+		return nil, errors.New(errNotReport)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Report)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotReport)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	reports, err := c.service.GetReports(orgId)
+	if isUnavailable(err) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		cr.SetConditions(v1alpha1.EnterpriseRequired(msgReportsUnavailable))
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        true,
+			ConnectionDetails:       managed.ConnectionDetails{},
+		}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetReport)
+	}
+
+	atGrafana := findReport(reports, cr.Status.AtProvider.ReportID, cr.Spec.ForProvider.Name)
+	if atGrafana == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	upToDate, err := isUpToDate(cr, atGrafana)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr, *cr.Spec.ForProvider.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Report)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotReport)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	command, err := toConfig(spec)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	id, err := c.service.CreateReport(orgId, command)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateReport)
+	}
+
+	statusId := fmt.Sprintf("%s:%d", *spec.OrgID, id)
+	cr.Status.AtProvider.ID = &statusId
+	cr.Status.AtProvider.ReportID = &id
+	cr.Status.AtProvider.OrgID = spec.OrgID
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Report)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotReport)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	command, err := toConfig(spec)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	err = c.service.UpdateReport(orgId, *cr.Status.AtProvider.ReportID, command)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateReport)
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Report)
+	if !ok {
+		return errors.New(errNotReport)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	// a report that was never created, e.g. because Grafana lacks the API, has nothing to delete
+	if cr.Status.AtProvider.ReportID == nil {
+		return nil
+	}
+
+	err = c.service.DeleteReport(orgId, *cr.Status.AtProvider.ReportID)
+	if isUnavailable(err) {
+		return nil
+	}
+
+	return errors.Wrap(err, errFailedDeleteReport)
+}
+
+// findReport returns the report with the given ID, or the report with the given name if the ID is not known yet.
+// The lookup by name finds a report whose creation succeeded without the ID being persisted in the status.
+func findReport(reports []*models.Report, id *int64, name *string) *models.Report {
+	for _, report := range reports {
+		if id != nil && report.ID == *id {
+			return report
+		}
+		if id == nil && name != nil && report.Name == *name {
+			return report
+		}
+	}
+	return nil
+}
+
+func copyToStatus(response *models.Report, cr *v1alpha1.Report, orgId string) {
+	id := fmt.Sprintf("%s:%d", orgId, response.ID)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.ReportID = &response.ID
+	cr.Status.AtProvider.Name = &response.Name
+	dashboardUID := dashboardUID(response)
+	cr.Status.AtProvider.DashboardUID = &dashboardUID
+	cr.Status.AtProvider.Recipients = refs(recipients(response.Recipients))
+	cr.Status.AtProvider.Formats = refs(formats(response.Formats))
+	cr.Status.AtProvider.Schedule = nil
+	if response.Schedule != nil {
+		cr.Status.AtProvider.Schedule = &v1alpha1.ReportSchedule{
+			Frequency:    &response.Schedule.Frequency,
+			StartTime:    fromDate(response.Schedule.StartDate),
+			EndTime:      fromDate(response.Schedule.EndDate),
+			WorkdaysOnly: &response.Schedule.WorkdaysOnly,
+		}
+	}
+}
+
+// isUpToDate compares the report with the spec, fields that are not set in the spec are compared with the defaults
+// of Grafana. Recipients and formats are compared ignoring their order, schedule times are compared as instants.
+func isUpToDate(cr *v1alpha1.Report, atGrafana *models.Report) (bool, error) {
+	spec := cr.Spec.ForProvider
+	upToDate := true
+
+	layout, orientation := "", ""
+	if atGrafana.Options != nil {
+		layout, orientation = atGrafana.Options.Layout, atGrafana.Options.Orientation
+	}
+
+	upToDate = upToDate && common.CompareOptional(spec.Name, atGrafana.Name, "")
+	upToDate = upToDate && common.CompareOptional(spec.DashboardUID, dashboardUID(atGrafana), "")
+	upToDate = upToDate && equalIgnoreOrder(values(spec.Recipients), recipients(atGrafana.Recipients))
+	upToDate = upToDate && common.CompareOptional(spec.ReplyTo, atGrafana.ReplyTo, "")
+	upToDate = upToDate && common.CompareOptional(spec.Message, atGrafana.Message, "")
+	upToDate = upToDate && equalIgnoreOrder(specFormats(spec.Formats), formats(atGrafana.Formats))
+	upToDate = upToDate && common.CompareOptional(spec.IncludeDashboardLink, atGrafana.EnableDashboardURL, true)
+	upToDate = upToDate && common.CompareOptional(spec.IncludeTableCsv, atGrafana.EnableCSV, false)
+	upToDate = upToDate && common.CompareOptional(spec.Layout, layout, "grid")
+	upToDate = upToDate && common.CompareOptional(spec.Orientation, orientation, "landscape")
+
+	if spec.Schedule == nil {
+		return upToDate, nil
+	}
+	schedule := atGrafana.Schedule
+	if schedule == nil {
+		schedule = &models.ReportSchedule{}
+	}
+	start, err := toTime("startTime", spec.Schedule.StartTime)
+	if err != nil {
+		return false, err
+	}
+	end, err := toTime("endTime", spec.Schedule.EndTime)
+	if err != nil {
+		return false, err
+	}
+
+	upToDate = upToDate && common.CompareOptional(spec.Schedule.Frequency, schedule.Frequency, "")
+	upToDate = upToDate && common.CompareOptional(spec.Schedule.WorkdaysOnly, schedule.WorkdaysOnly, false)
+	upToDate = upToDate && common.CompareOptional(epochMillis(start), dateMillis(schedule.StartDate), 0)
+	upToDate = upToDate && common.CompareOptional(epochMillis(end), dateMillis(schedule.EndDate), 0)
+
+	return upToDate, nil
+}
+
+// toConfig converts the spec to the request of Grafana. The time of day of the report is taken from the start time,
+// which is sent in UTC.
+func toConfig(spec v1alpha1.ReportParameters) (*models.CreateOrUpdateReportConfig, error) {
+	dashboardUID := common.DefaultString(spec.DashboardUID, "")
+	command := &models.CreateOrUpdateReportConfig{
+		DashboardUID: dashboardUID,
+		Dashboards: []*models.ReportDashboard{
+			{Dashboard: &models.ReportDashboardID{UID: dashboardUID}},
+		},
+		EnableCSV:          common.DefaultBool(spec.IncludeTableCsv, false),
+		EnableDashboardURL: common.DefaultBool(spec.IncludeDashboardLink, true),
+		Message:            common.DefaultString(spec.Message, ""),
+		Name:               common.DefaultString(spec.Name, ""),
+		Options: &models.ReportOptions{
+			Layout:      common.DefaultString(spec.Layout, "grid"),
+			Orientation: common.DefaultString(spec.Orientation, "landscape"),
+		},
+		Recipients: strings.Join(values(spec.Recipients), ","),
+		ReplyTo:    common.DefaultString(spec.ReplyTo, ""),
+	}
+	for _, format := range specFormats(spec.Formats) {
+		command.Formats = append(command.Formats, models.Type(format))
+	}
+
+	if spec.Schedule == nil {
+		return command, nil
+	}
+	start, err := toTime("startTime", spec.Schedule.StartTime)
+	if err != nil {
+		return nil, err
+	}
+	end, err := toTime("endTime", spec.Schedule.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	command.Schedule = &models.ReportSchedule{
+		Frequency:    common.DefaultString(spec.Schedule.Frequency, ""),
+		StartDate:    toDateTime(start),
+		EndDate:      toDateTime(end),
+		TimeZone:     "UTC",
+		WorkdaysOnly: common.DefaultBool(spec.Schedule.WorkdaysOnly, false),
+	}
+	if start != nil {
+		command.Schedule.Hour = int64(start.UTC().Hour())
+		command.Schedule.Minute = int64(start.UTC().Minute())
+	}
+	return command, nil
+}
+
+// isUnavailable returns true if Grafana does not offer the reporting API, which is the case for Grafana OSS.
+func isUnavailable(err error) bool {
+	return common.IsCode(err, http.StatusNotFound, http.StatusNotImplemented)
+}
+
+// dashboardUID returns the UID of the first dashboard of the report, older versions of Grafana only know a single one.
+func dashboardUID(report *models.Report) string {
+	if len(report.Dashboards) > 0 && report.Dashboards[0].Dashboard != nil {
+		return report.Dashboards[0].Dashboard.UID
+	}
+	return report.DashboardUID
+}
+
+// recipients splits the comma separated recipients of Grafana.
+func recipients(s string) []string {
+	result := make([]string, 0)
+	for _, recipient := range strings.Split(s, ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			result = append(result, recipient)
+		}
+	}
+	return result
+}
+
+func formats(types []models.Type) []string {
+	result := make([]string, 0, len(types))
+	for _, t := range types {
+		result = append(result, string(t))
+	}
+	return result
+}
+
+// specFormats returns the formats of the spec, reports are sent as PDF if none are set.
+func specFormats(refs []*string) []string {
+	if len(refs) == 0 {
+		return []string{"pdf"}
+	}
+	return values(refs)
+}
+
+func values(refs []*string) []string {
+	result := make([]string, 0, len(refs))
+	for _, value := range refs {
+		if value != nil {
+			result = append(result, *value)
+		}
+	}
+	return result
+}
+
+func refs(values []string) []*string {
+	result := make([]*string, 0, len(values))
+	for i := range values {
+		result = append(result, &values[i])
+	}
+	return result
+}
+
+func equalIgnoreOrder(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, value := range a {
+		counts[value]++
+	}
+	for _, value := range b {
+		if counts[value] == 0 {
+			return false
+		}
+		counts[value]--
+	}
+	return true
+}
+
+// toTime parses an RFC 3339 timestamp, nil is returned if it is not set.
+func toTime(field string, timestamp *string) (*time.Time, error) {
+	if timestamp == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *timestamp)
+	if err != nil {
+		return nil, errors.Wrapf(err, errTimeFormat, field)
+	}
+	return &t, nil
+}
+
+func epochMillis(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	millis := t.UnixMilli()
+	return &millis
+}
+
+func toDateTime(t *time.Time) *strfmt.DateTime {
+	if t == nil {
+		return nil
+	}
+	dateTime := strfmt.DateTime(t.UTC())
+	return &dateTime
+}
+
+// dateMillis converts a date of Grafana to epoch milliseconds, 0 is returned if it is not set.
+func dateMillis(dateTime *strfmt.DateTime) int64 {
+	if dateTime == nil {
+		return 0
+	}
+	return time.Time(*dateTime).UnixMilli()
+}
+
+func fromDate(dateTime *strfmt.DateTime) *string {
+	if dateTime == nil {
+		return nil
+	}
+	timestamp := time.Time(*dateTime).UTC().Format(time.RFC3339)
+	return &timestamp
+}
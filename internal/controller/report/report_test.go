@@ -0,0 +1,388 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/client/reports"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+// errNotFound is what Grafana OSS responds with, as it does not offer the reporting API.
+var errNotFound = runtime.NewAPIError("getReports", nil, 404)
+
+func strRef(s string) *string {
+	return &s
+}
+
+func report() *v1alpha1.Report {
+	return &v1alpha1.Report{
+		Spec: v1alpha1.ReportSpec{
+			ForProvider: v1alpha1.ReportParameters{
+				DashboardUID: strRef("abc"),
+				Name:         strRef("weekly"),
+				OrgID:        strRef("1"),
+				Recipients:   []*string{strRef("a@example.com"), strRef("b@example.com")},
+				Schedule: &v1alpha1.ReportSchedule{
+					Frequency: strRef("weekly"),
+					StartTime: strRef("2024-01-01T09:30:00+01:00"),
+				},
+			},
+		},
+	}
+}
+
+func atGrafana() *models.Report {
+	start := strfmt.DateTime(time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC))
+	return &models.Report{
+		ID:                 4,
+		Name:               "weekly",
+		Dashboards:         []*models.ReportDashboard{{Dashboard: &models.ReportDashboardID{UID: "abc"}}},
+		EnableDashboardURL: true,
+		Formats:            []models.Type{"pdf"},
+		Options:            &models.ReportOptions{Layout: "grid", Orientation: "landscape"},
+		Recipients:         "b@example.com,a@example.com",
+		Schedule: &models.ReportSchedule{
+			Frequency: "weekly",
+			StartDate: &start,
+			Hour:      8,
+			Minute:    30,
+			TimeZone:  "UTC",
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotReport": {
+			reason:  "An error should be returned if the managed resource is not a Report",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotReport),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the reports cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return(nil, errBoom)
+				return m
+			}(),
+			mg: report(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetReport),
+			},
+		},
+		"Unavailable": {
+			reason: "The report should be reported as up to date if Grafana does not offer reports, instead of recreating it over and over",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return(nil, errNotFound)
+				return m
+			}(),
+			mg: report(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"UnavailableDeleted": {
+			reason: "A deleted report should be reported as gone if Grafana does not offer reports, so the finalizer is removed",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return(nil, errNotFound)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := report()
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotFound": {
+			reason: "The report should be reported as missing if Grafana does not know it",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return([]*models.Report{{ID: 5, Name: "other"}}, nil)
+				return m
+			}(),
+			mg: report(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"FoundByID": {
+			reason: "The report should be looked up by its ID once it is known, even if it was renamed",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				r := atGrafana()
+				r.Name = "renamed"
+				m.On("GetReports", int64(1)).Return([]*models.Report{r}, nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := report()
+				id := int64(4)
+				cr.Status.AtProvider.ReportID = &id
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"UpToDate": {
+			reason: "The report should be reported as up to date if recipients only differ in order and the start time only in its zone",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return([]*models.Report{atGrafana()}, nil)
+				return m
+			}(),
+			mg: report(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"FrequencyChanged": {
+			reason: "The report should be reported as outdated if the frequency of the schedule differs",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return([]*models.Report{atGrafana()}, nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := report()
+				cr.Spec.ForProvider.Schedule.Frequency = strRef("daily")
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"FormatAdded": {
+			reason: "The report should be reported as outdated if a format was added to the spec",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return([]*models.Report{atGrafana()}, nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := report()
+				cr.Spec.ForProvider.Formats = []*string{strRef("pdf"), strRef("csv")}
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"InvalidStartTime": {
+			reason: "An error should be returned if the start time is not an RFC 3339 timestamp",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetReports", int64(1)).Return([]*models.Report{atGrafana()}, nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := report()
+				cr.Spec.ForProvider.Schedule.StartTime = strRef("monday")
+				return cr
+			}(),
+			want: want{
+				err: errors.Wrapf(func() error { _, err := time.Parse(time.RFC3339, "monday"); return err }(), errTimeFormat, "startTime"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveUnavailableSetsCondition(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetReports", int64(1)).Return(nil, errNotFound)
+
+	cr := report()
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	want := v1alpha1.EnterpriseRequired(msgReportsUnavailable)
+	if diff := cmp.Diff(want, cr.GetCondition(xpv1.TypeReady)); diff != "" {
+		t.Errorf("e.Observe(...): -want Ready condition, +got Ready condition:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	start := strfmt.DateTime(time.Date(2024, 1, 1, 8, 30, 0, 0, time.UTC))
+	want := &models.CreateOrUpdateReportConfig{
+		DashboardUID:       "abc",
+		Dashboards:         []*models.ReportDashboard{{Dashboard: &models.ReportDashboardID{UID: "abc"}}},
+		EnableDashboardURL: true,
+		Formats:            []models.Type{"pdf"},
+		Name:               "weekly",
+		Options:            &models.ReportOptions{Layout: "grid", Orientation: "landscape"},
+		Recipients:         "a@example.com,b@example.com",
+		Schedule: &models.ReportSchedule{
+			Frequency: "weekly",
+			StartDate: &start,
+			Hour:      8,
+			Minute:    30,
+			TimeZone:  "UTC",
+		},
+	}
+
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateReport", int64(1), want).Return(int64(4), nil)
+
+	cr := report()
+	e := external{service: m}
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	id := "1:4"
+	if diff := cmp.Diff(&id, cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Updated": {
+			reason: "The report should be updated by its ID",
+		},
+		"Failed": {
+			reason: "An error should be returned if the report cannot be updated",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedUpdateReport),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := report()
+			id := int64(4)
+			cr.Status.AtProvider.ReportID = &id
+			cr.Spec.ForProvider.Formats = []*string{strRef("csv")}
+
+			m := &common.MockGrafanaAPI{}
+			m.On("UpdateReport", int64(1), int64(4), mock.MatchedBy(func(c *models.CreateOrUpdateReportConfig) bool {
+				return len(c.Formats) == 1 && c.Formats[0] == "csv"
+			})).Return(tc.err)
+
+			e := external{service: m}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Deleted": {
+			reason: "The report should be deleted by its ID",
+		},
+		"Unavailable": {
+			reason: "No error should be returned if the report or the reporting API is gone",
+			err:    reports.NewDeleteReportNotFound(),
+		},
+		"Failed": {
+			reason: "An error should be returned if the report cannot be deleted",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedDeleteReport),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := report()
+			id := int64(4)
+			cr.Status.AtProvider.ReportID = &id
+
+			m := &common.MockGrafanaAPI{}
+			m.On("DeleteReport", int64(1), int64(4)).Return(tc.err)
+
+			e := external{service: m}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
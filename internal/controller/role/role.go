@@ -0,0 +1,444 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotRole      = "managed resource is not a Role custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage"
+	errGetPC        = "cannot get ProviderConfig"
+	errOrgIdNotInt  = "orgId is not an integer"
+
+	errNewClient        = "cannot create new Service"
+	errFailedGetRole    = "cannot get Role from Grafana API"
+	errFailedCreateRole = "cannot create Role"
+	errFailedUpdateRole = "cannot update Role"
+	errFailedDeleteRole = "cannot delete Role"
+
+	// msgRolesUnavailable explains why a Role is not applied if Grafana lacks the API.
+	msgRolesUnavailable = "roles are not available, they require Grafana Enterprise or Grafana Cloud"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles Role managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RoleGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.RoleGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Role{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Role)
+	if !ok {
+		return nil, errors.New(errNotRole)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Role)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRole)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	roles, err := c.service.GetRoles(orgId)
+	if isUnavailable(err) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		cr.SetConditions(v1alpha1.EnterpriseRequired(msgRolesUnavailable))
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        true,
+			ConnectionDetails:       managed.ConnectionDetails{},
+		}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetRole)
+	}
+
+	uid := findRole(roles, cr)
+	if uid == "" {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	atGrafana, err := c.service.GetRole(orgId, uid)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetRole)
+	}
+
+	if atGrafana == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	cr.SetConditions(v1.Available())
+	upToDate := isUpToDate(cr, atGrafana)
+	copyToStatus(atGrafana, cr, *cr.Spec.ForProvider.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Role)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRole)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	result, err := c.service.CreateRole(orgId, &models.CreateRoleForm{
+		Description: common.DefaultString(spec.Description, ""),
+		DisplayName: common.DefaultString(spec.DisplayName, ""),
+		Global:      common.DefaultBool(spec.Global, false),
+		Group:       common.DefaultString(spec.Group, ""),
+		Hidden:      common.DefaultBool(spec.Hidden, false),
+		Name:        common.DefaultString(spec.Name, ""),
+		Permissions: permissions(spec.Permissions),
+		UID:         common.DefaultString(spec.UID, ""),
+		Version:     1,
+	})
+
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errFailedCreateRole)
+	}
+
+	copyToStatus(result, cr, *spec.OrgID)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Role)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRole)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	// Grafana only accepts updates that increment the version of the role
+	version := common.DefaultInt64(cr.Status.AtProvider.Version, 0) + 1
+	err = c.service.UpdateRole(orgId, *cr.Status.AtProvider.UID, &models.UpdateRoleCommand{
+		Description: common.DefaultString(spec.Description, ""),
+		DisplayName: common.DefaultString(spec.DisplayName, ""),
+		Global:      common.DefaultBool(spec.Global, false),
+		Group:       common.DefaultString(spec.Group, ""),
+		Hidden:      common.DefaultBool(spec.Hidden, false),
+		Name:        common.DefaultString(spec.Name, ""),
+		Permissions: permissions(spec.Permissions),
+		Version:     version,
+	})
+
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errFailedUpdateRole)
+	}
+
+	cr.Status.AtProvider.Version = &version
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Role)
+	if !ok {
+		return errors.New(errNotRole)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	// a role that was never created, e.g. because Grafana lacks the API, has nothing to delete
+	if cr.Status.AtProvider.UID == nil {
+		return nil
+	}
+
+	err = c.service.DeleteRole(orgId, *cr.Status.AtProvider.UID, common.DefaultBool(spec.Global, false))
+	if isUnavailable(err) {
+		return nil
+	}
+
+	return errors.Wrap(err, errFailedDeleteRole)
+}
+
+// findRole returns the UID of the role in status or spec, or of the role with the name of the spec if neither is set.
+// Names of roles are unique, so a role whose creation succeeded without the UID being persisted is found by its name.
+func findRole(roles []*models.RoleDTO, cr *v1alpha1.Role) string {
+	uid := cr.Status.AtProvider.UID
+	if uid == nil {
+		uid = cr.Spec.ForProvider.UID
+	}
+	for _, role := range roles {
+		if uid != nil && role.UID == *uid {
+			return role.UID
+		}
+		if uid == nil && role.Name == common.DefaultString(cr.Spec.ForProvider.Name, "") {
+			return role.UID
+		}
+	}
+	return ""
+}
+
+func copyToStatus(response *models.RoleDTO, cr *v1alpha1.Role, orgId string) {
+	id := fmt.Sprintf("%s:%s", orgId, response.UID)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.UID = &response.UID
+	cr.Status.AtProvider.Name = &response.Name
+	cr.Status.AtProvider.Version = &response.Version
+	cr.Status.AtProvider.Permissions = make([]v1alpha1.RolePermission, 0, len(response.Permissions))
+	for _, p := range response.Permissions {
+		action, scope := p.Action, p.Scope
+		cr.Status.AtProvider.Permissions = append(cr.Status.AtProvider.Permissions, v1alpha1.RolePermission{Action: &action, Scope: &scope})
+	}
+}
+
+// isUpToDate compares the role with the spec, the order of the permissions does not matter. Global is immutable and
+// therefore not compared.
+func isUpToDate(cr *v1alpha1.Role, atGrafana *models.RoleDTO) bool {
+	spec := cr.Spec.ForProvider
+	upToDate := true
+
+	upToDate = upToDate && common.CompareOptional(spec.Name, atGrafana.Name, "")
+	upToDate = upToDate && common.CompareOptional(spec.DisplayName, atGrafana.DisplayName, "")
+	upToDate = upToDate && common.CompareOptional(spec.Description, atGrafana.Description, "")
+	upToDate = upToDate && common.CompareOptional(spec.Group, atGrafana.Group, "")
+	upToDate = upToDate && common.CompareOptional(spec.Hidden, atGrafana.Hidden, false)
+	upToDate = upToDate && permissionsEqualIgnoreOrder(permissions(spec.Permissions), atGrafana.Permissions)
+
+	return upToDate
+}
+
+// isUnavailable returns true if Grafana does not offer the role-based access control API, which is the case for
+// Grafana OSS.
+func isUnavailable(err error) bool {
+	return common.IsCode(err, http.StatusNotFound, http.StatusNotImplemented)
+}
+
+func permissions(spec []v1alpha1.RolePermission) []*models.Permission {
+	result := make([]*models.Permission, 0, len(spec))
+	for _, p := range spec {
+		result = append(result, &models.Permission{
+			Action: common.DefaultString(p.Action, ""),
+			Scope:  common.DefaultString(p.Scope, ""),
+		})
+	}
+	return result
+}
+
+type permission struct {
+	action string
+	scope  string
+}
+
+func permissionsEqualIgnoreOrder(desired, actual []*models.Permission) bool {
+	if len(desired) != len(actual) {
+		return false
+	}
+	counts := make(map[permission]int, len(desired))
+	for _, p := range desired {
+		counts[permission{p.Action, p.Scope}]++
+	}
+	for _, p := range actual {
+		key := permission{p.Action, p.Scope}
+		if counts[key] == 0 {
+			return false
+		}
+		counts[key]--
+	}
+	return true
+}
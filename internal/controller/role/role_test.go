@@ -0,0 +1,323 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+// errNotFound is what Grafana OSS responds with, as it does not offer role-based access control.
+var errNotFound = runtime.NewAPIError("listRoles", nil, 404)
+
+func strRef(s string) *string {
+	return &s
+}
+
+func role() *v1alpha1.Role {
+	return &v1alpha1.Role{
+		Spec: v1alpha1.RoleSpec{
+			ForProvider: v1alpha1.RoleParameters{
+				Name:  strRef("custom:reader"),
+				OrgID: strRef("1"),
+				Permissions: []v1alpha1.RolePermission{
+					{Action: strRef("dashboards:read"), Scope: strRef("dashboards:*")},
+					{Action: strRef("folders:read"), Scope: strRef("folders:*")},
+				},
+			},
+		},
+	}
+}
+
+func grafanaRole(permissions ...*models.Permission) *models.RoleDTO {
+	return &models.RoleDTO{UID: "abc", Name: "custom:reader", Version: 2, Permissions: permissions}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	dashboards := &models.Permission{Action: "dashboards:read", Scope: "dashboards:*"}
+	folders := &models.Permission{Action: "folders:read", Scope: "folders:*"}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotRole": {
+			reason:  "An error should be returned if the managed resource is not a Role",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotRole),
+			},
+		},
+		"ListFailed": {
+			reason: "An error should be returned if the roles cannot be listed",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(nil, errBoom)
+				return m
+			}(),
+			mg: role(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetRole),
+			},
+		},
+		"Unavailable": {
+			reason: "The role should be reported as up to date if Grafana does not offer roles, instead of recreating it over and over",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(nil, errNotFound)
+				return m
+			}(),
+			mg: role(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"UnavailableDeleted": {
+			reason: "A deleted role should be reported as gone if Grafana does not offer roles, so the finalizer is removed",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(nil, errNotFound)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := role()
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotFound": {
+			reason: "The role should be reported as missing if no role has its name",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return([]*models.RoleDTO{{UID: "def", Name: "custom:writer"}}, nil)
+				return m
+			}(),
+			mg: role(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotFoundByUID": {
+			reason: "The role should be reported as missing if no role has the UID of the spec, even if the name matches",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return([]*models.RoleDTO{{UID: "abc", Name: "custom:reader"}}, nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := role()
+				cr.Spec.ForProvider.UID = strRef("def")
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The role should be reported as up to date if the permissions only differ in order",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return([]*models.RoleDTO{{UID: "abc", Name: "custom:reader"}}, nil)
+				m.On("GetRole", int64(1), "abc").Return(grafanaRole(folders, dashboards), nil)
+				return m
+			}(),
+			mg: role(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"PermissionRemoved": {
+			reason: "The role should be reported as outdated if a permission was removed in Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return([]*models.RoleDTO{{UID: "abc", Name: "custom:reader"}}, nil)
+				m.On("GetRole", int64(1), "abc").Return(grafanaRole(dashboards), nil)
+				return m
+			}(),
+			mg: role(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"ScopeChanged": {
+			reason: "The role should be reported as outdated if the scope of a permission differs",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return([]*models.RoleDTO{{UID: "abc", Name: "custom:reader"}}, nil)
+				m.On("GetRole", int64(1), "abc").Return(grafanaRole(dashboards, &models.Permission{Action: "folders:read", Scope: "folders:uid:abc"}), nil)
+				return m
+			}(),
+			mg: role(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveUnavailableSetsCondition(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetRoles", int64(1)).Return(nil, errNotFound)
+
+	cr := role()
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	want := v1alpha1.EnterpriseRequired(msgRolesUnavailable)
+	if diff := cmp.Diff(want, cr.GetCondition(xpv1.TypeReady)); diff != "" {
+		t.Errorf("e.Observe(...): -want Ready condition, +got Ready condition:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateRole", int64(1), &models.CreateRoleForm{
+		Name: "custom:reader",
+		Permissions: []*models.Permission{
+			{Action: "dashboards:read", Scope: "dashboards:*"},
+			{Action: "folders:read", Scope: "folders:*"},
+		},
+		Version: 1,
+	}).Return(&models.RoleDTO{UID: "abc", Name: "custom:reader", Version: 1}, nil)
+
+	cr := role()
+	e := external{service: m}
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	id := "1:abc"
+	if diff := cmp.Diff(&id, cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateIncrementsVersion(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("UpdateRole", int64(1), "abc", &models.UpdateRoleCommand{
+		Name: "custom:reader",
+		Permissions: []*models.Permission{
+			{Action: "dashboards:read", Scope: "dashboards:*"},
+			{Action: "folders:read", Scope: "folders:*"},
+		},
+		Version: 3,
+	}).Return(nil)
+
+	cr := role()
+	uid := "abc"
+	var version int64 = 2
+	cr.Status.AtProvider.UID = &uid
+	cr.Status.AtProvider.Version = &version
+	e := external{service: m}
+	_, err := e.Update(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff(int64(3), common.DefaultInt64(cr.Status.AtProvider.Version, 0)); diff != "" {
+		t.Errorf("e.Update(...): -want version, +got version:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Deleted": {
+			reason: "The role should be deleted by its UID",
+		},
+		"Unavailable": {
+			reason: "No error should be returned if the role or the roles API is gone",
+			err:    runtime.NewAPIError("deleteRole", nil, 404),
+		},
+		"Failed": {
+			reason: "An error should be returned if the role cannot be deleted",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedDeleteRole),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := role()
+			uid := "abc"
+			cr.Status.AtProvider.UID = &uid
+
+			m := &common.MockGrafanaAPI{}
+			m.On("DeleteRole", int64(1), "abc", false).Return(tc.err)
+
+			e := external{service: m}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,518 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roleassignment
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotRoleAssignment = "managed resource is not a RoleAssignment custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errOrgIdNotInt       = "orgId is not an integer"
+	errRoleNotFound      = "role %q not found"
+	errTeamNotFound      = "team %q not found"
+	errUserNotFound      = "user %q not found"
+	errUserMissing       = "login or email is required if the type of an assignment is user"
+
+	errUnknownAssignmentType = "unknown assignment type %q"
+
+	errNewClient                  = "cannot create new Service"
+	errFailedGetRoles             = "cannot get Roles from Grafana API"
+	errFailedGetRoleAssignment    = "cannot get RoleAssignment from Grafana API"
+	errFailedGetTeam              = "cannot get team %q"
+	errFailedGetUser              = "cannot get user %q"
+	errFailedUpdateRoleAssignment = "cannot update RoleAssignment"
+	errFailedDeleteRoleAssignment = "cannot delete RoleAssignment"
+
+	assignmentTypeUser           = "user"
+	assignmentTypeTeam           = "team"
+	assignmentTypeServiceAccount = "serviceAccount"
+
+	// msgRolesUnavailable explains why a RoleAssignment is not applied if Grafana lacks the API.
+	msgRolesUnavailable = "roles are not available, they require Grafana Enterprise or Grafana Cloud"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles RoleAssignment managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.RoleAssignmentGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.RoleAssignmentGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.RoleAssignment{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RoleAssignment)
+	if !ok {
+		return nil, errors.New(errNotRoleAssignment)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RoleAssignment)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRoleAssignment)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	// the assignments of a missing role are not found either, so the roles are listed to tell both cases apart
+	roles, err := c.service.GetRoles(orgId)
+	if isUnavailable(err) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		cr.SetConditions(v1alpha1.EnterpriseRequired(msgRolesUnavailable))
+		return managed.ExternalObservation{
+			ResourceExists:          true,
+			ResourceLateInitialized: orgIDDefaulted,
+			ResourceUpToDate:        true,
+			ConnectionDetails:       managed.ConnectionDetails{},
+		}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetRoles)
+	}
+
+	roleUid := common.DefaultString(cr.Spec.ForProvider.RoleUID, "")
+	if !hasRole(roles, roleUid) {
+		if meta.WasDeleted(cr) {
+			// the assignments were deleted along with the role
+			return managed.ExternalObservation{
+				ResourceExists: false,
+			}, nil
+		}
+		return managed.ExternalObservation{}, errors.Errorf(errRoleNotFound, roleUid)
+	}
+
+	// the role exists regardless of its assignments, so they are only considered applied once they were set
+	if cr.Status.AtProvider.ID == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	actual, err := c.service.GetRoleAssignments(orgId, roleUid)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetRoleAssignment)
+	}
+
+	desired, err := c.desiredAssignments(ctx, orgId, cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(v1.Available())
+	upToDate := isUpToDate(desired, actual)
+	copyToStatus(actual, cr, *cr.Spec.ForProvider.OrgID, roleUid)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RoleAssignment)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRoleAssignment)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RoleAssignment)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRoleAssignment)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// apply replaces the assignments of the role with the ones of the spec.
+func (c *external) apply(ctx context.Context, cr *v1alpha1.RoleAssignment) error {
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*spec.OrgID, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	desired, err := c.desiredAssignments(ctx, orgId, spec)
+	if err != nil {
+		return err
+	}
+
+	roleUid := common.DefaultString(spec.RoleUID, "")
+	if err := c.service.SetRoleAssignments(orgId, roleUid, desired); err != nil {
+		return errors.Wrap(err, errFailedUpdateRoleAssignment)
+	}
+
+	copyToStatus(&models.RoleAssignmentsDTO{
+		ServiceAccounts: desired.ServiceAccounts,
+		Teams:           desired.Teams,
+		Users:           desired.Users,
+	}, cr, *spec.OrgID, roleUid)
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RoleAssignment)
+	if !ok {
+		return errors.New(errNotRoleAssignment)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	err = c.service.SetRoleAssignments(orgId, common.DefaultString(spec.RoleUID, ""), &models.SetRoleAssignmentsCommand{
+		ServiceAccounts: []int64{},
+		Teams:           []int64{},
+		Users:           []int64{},
+	})
+	if isUnavailable(err) {
+		return nil
+	}
+
+	return errors.Wrap(err, errFailedDeleteRoleAssignment)
+}
+
+// desiredAssignments converts the spec to the request of Grafana. Teams are looked up by their numeric ID or, if the
+// ID is not numeric, by their UID. Users of assignments are looked up by their login or email. An assignee that is
+// listed more than once is only sent once.
+func (c *external) desiredAssignments(ctx context.Context, orgId int64, spec v1alpha1.RoleAssignmentParameters) (*models.SetRoleAssignmentsCommand, error) {
+	command := &models.SetRoleAssignmentsCommand{
+		ServiceAccounts: values(spec.ServiceAccounts),
+		Teams:           make([]int64, 0, len(spec.Teams)),
+		Users:           values(spec.Users),
+	}
+	for _, team := range spec.Teams {
+		if team == nil {
+			continue
+		}
+		id, err := c.teamID(ctx, orgId, *team)
+		if err != nil {
+			return nil, err
+		}
+		command.Teams = append(command.Teams, id)
+	}
+	for _, assignment := range spec.Assignments {
+		switch common.DefaultString(assignment.Type, "") {
+		case assignmentTypeUser:
+			id, err := c.userID(assignment)
+			if err != nil {
+				return nil, err
+			}
+			command.Users = append(command.Users, id)
+		case assignmentTypeTeam:
+			id, err := c.teamID(ctx, orgId, common.DefaultString(assignment.Team, ""))
+			if err != nil {
+				return nil, err
+			}
+			command.Teams = append(command.Teams, id)
+		case assignmentTypeServiceAccount:
+			command.ServiceAccounts = append(command.ServiceAccounts, common.DefaultInt64(assignment.ServiceAccountID, 0))
+		default:
+			return nil, errors.Errorf(errUnknownAssignmentType, common.DefaultString(assignment.Type, ""))
+		}
+	}
+	command.ServiceAccounts = unique(command.ServiceAccounts)
+	command.Teams = unique(command.Teams)
+	command.Users = unique(command.Users)
+	return command, nil
+}
+
+// teamID returns the numeric ID of the team, which is looked up by its UID if it is not numeric.
+func (c *external) teamID(ctx context.Context, orgId int64, team string) (int64, error) {
+	if id, err := strconv.ParseInt(team, 10, 64); err == nil {
+		return id, nil
+	}
+	found, err := c.service.GetTeamByUid(ctx, orgId, team)
+	if err != nil {
+		return 0, errors.Wrapf(err, errFailedGetTeam, team)
+	}
+	if found == nil {
+		return 0, errors.Errorf(errTeamNotFound, team)
+	}
+	return found.ID, nil
+}
+
+// userID returns the ID of the user of the assignment, which is looked up by its login or, if it has none, its email.
+func (c *external) userID(assignment v1alpha1.Assignment) (int64, error) {
+	loginOrEmail := common.DefaultString(assignment.Login, common.DefaultString(assignment.Email, ""))
+	if loginOrEmail == "" {
+		return 0, errors.New(errUserMissing)
+	}
+	found, err := c.service.GetUserByLoginOrEmail(loginOrEmail)
+	if err != nil {
+		return 0, errors.Wrapf(err, errFailedGetUser, loginOrEmail)
+	}
+	if found == nil {
+		return 0, errors.Errorf(errUserNotFound, loginOrEmail)
+	}
+	return found.ID, nil
+}
+
+func hasRole(roles []*models.RoleDTO, uid string) bool {
+	for _, role := range roles {
+		if role.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func copyToStatus(response *models.RoleAssignmentsDTO, cr *v1alpha1.RoleAssignment, orgId string, roleUid string) {
+	id := fmt.Sprintf("%s:%s", orgId, roleUid)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.RoleUID = &roleUid
+	cr.Status.AtProvider.ServiceAccounts = refs(response.ServiceAccounts)
+	cr.Status.AtProvider.Teams = refs(response.Teams)
+	cr.Status.AtProvider.Users = refs(response.Users)
+}
+
+// isUpToDate compares the assignments of the role, their order does not matter.
+func isUpToDate(desired *models.SetRoleAssignmentsCommand, actual *models.RoleAssignmentsDTO) bool {
+	return equalIgnoreOrder(desired.ServiceAccounts, actual.ServiceAccounts) &&
+		equalIgnoreOrder(desired.Teams, actual.Teams) &&
+		equalIgnoreOrder(desired.Users, actual.Users)
+}
+
+// isUnavailable returns true if Grafana does not offer the role-based access control API, which is the case for
+// Grafana OSS.
+func isUnavailable(err error) bool {
+	return common.IsCode(err, http.StatusNotFound, http.StatusNotImplemented)
+}
+
+func values(refs []*int64) []int64 {
+	result := make([]int64, 0, len(refs))
+	for _, value := range refs {
+		if value != nil {
+			result = append(result, *value)
+		}
+	}
+	return result
+}
+
+func refs(values []int64) []*int64 {
+	result := make([]*int64, 0, len(values))
+	for i := range values {
+		result = append(result, &values[i])
+	}
+	return result
+}
+
+// unique returns the values without duplicates, keeping the first occurrence of each.
+func unique(values []int64) []int64 {
+	seen := make(map[int64]bool, len(values))
+	result := make([]int64, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func equalIgnoreOrder(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[int64]int, len(a))
+	for _, value := range a {
+		counts[value]++
+	}
+	for _, value := range b {
+		if counts[value] == 0 {
+			return false
+		}
+		counts[value]--
+	}
+	return true
+}
@@ -0,0 +1,365 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package roleassignment
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+// errNotFound is what Grafana OSS responds with, as it does not offer role-based access control.
+var errNotFound = runtime.NewAPIError("listRoles", nil, 404)
+
+func strRef(s string) *string {
+	return &s
+}
+
+func roleAssignment() *v1alpha1.RoleAssignment {
+	id := "1:abc"
+	var user, serviceAccount int64 = 2, 3
+	return &v1alpha1.RoleAssignment{
+		Spec: v1alpha1.RoleAssignmentSpec{
+			ForProvider: v1alpha1.RoleAssignmentParameters{
+				OrgID:           strRef("1"),
+				RoleUID:         strRef("abc"),
+				ServiceAccounts: []*int64{&serviceAccount},
+				Teams:           []*string{strRef("4"), strRef("platform")},
+				Users:           []*int64{&user},
+			},
+		},
+		Status: v1alpha1.RoleAssignmentStatus{
+			AtProvider: v1alpha1.RoleAssignmentObservation{ID: &id},
+		},
+	}
+}
+
+func roles() []*models.RoleDTO {
+	return []*models.RoleDTO{{UID: "abc", Name: "custom:reader"}}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotRoleAssignment": {
+			reason:  "An error should be returned if the managed resource is not a RoleAssignment",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotRoleAssignment),
+			},
+		},
+		"Unavailable": {
+			reason: "The assignments should be reported as up to date if Grafana does not offer roles, instead of setting them over and over",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(nil, errNotFound)
+				return m
+			}(),
+			mg: roleAssignment(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"UnavailableDeleted": {
+			reason: "Deleted assignments should be reported as gone if Grafana does not offer roles, so the finalizer is removed",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(nil, errNotFound)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := roleAssignment()
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"RoleNotFound": {
+			reason: "An error should be returned if the role does not exist",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return([]*models.RoleDTO{}, nil)
+				return m
+			}(),
+			mg: roleAssignment(),
+			want: want{
+				err: errors.Errorf(errRoleNotFound, "abc"),
+			},
+		},
+		"RoleDeleted": {
+			reason: "Deleted assignments should be reported as gone if their role was deleted",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return([]*models.RoleDTO{}, nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := roleAssignment()
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"NotApplied": {
+			reason: "The assignments should be reported as missing until they were set once",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(roles(), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := roleAssignment()
+				cr.Status.AtProvider.ID = nil
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The assignments should be reported as up to date if they only differ in order",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(roles(), nil)
+				m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 5}, nil)
+				m.On("GetRoleAssignments", int64(1), "abc").Return(&models.RoleAssignmentsDTO{
+					RoleUID: "abc", ServiceAccounts: []int64{3}, Teams: []int64{5, 4}, Users: []int64{2},
+				}, nil)
+				return m
+			}(),
+			mg: roleAssignment(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"UserAdded": {
+			reason: "The assignments should be reported as outdated if the role was assigned to another user in Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(roles(), nil)
+				m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 5}, nil)
+				m.On("GetRoleAssignments", int64(1), "abc").Return(&models.RoleAssignmentsDTO{
+					RoleUID: "abc", ServiceAccounts: []int64{3}, Teams: []int64{4, 5}, Users: []int64{2, 6},
+				}, nil)
+				return m
+			}(),
+			mg: roleAssignment(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"TeamNotFound": {
+			reason: "An error should be returned if a team cannot be found by its UID",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetRoles", int64(1)).Return(roles(), nil)
+				m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(nil, nil)
+				m.On("GetRoleAssignments", int64(1), "abc").Return(&models.RoleAssignmentsDTO{RoleUID: "abc"}, nil)
+				return m
+			}(),
+			mg: roleAssignment(),
+			want: want{
+				err: errors.Errorf(errTeamNotFound, "platform"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveUnavailableSetsCondition(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetRoles", int64(1)).Return(nil, errNotFound)
+
+	cr := roleAssignment()
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	want := v1alpha1.EnterpriseRequired(msgRolesUnavailable)
+	if diff := cmp.Diff(want, cr.GetCondition(xpv1.TypeReady)); diff != "" {
+		t.Errorf("e.Observe(...): -want Ready condition, +got Ready condition:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 5}, nil)
+	m.On("SetRoleAssignments", int64(1), "abc", &models.SetRoleAssignmentsCommand{
+		ServiceAccounts: []int64{3},
+		Teams:           []int64{4, 5},
+		Users:           []int64{2},
+	}).Return(nil)
+
+	cr := roleAssignment()
+	cr.Status.AtProvider.ID = nil
+	e := external{service: m}
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	id := "1:abc"
+	if diff := cmp.Diff(&id, cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateAssignments(t *testing.T) {
+	var serviceAccount int64 = 7
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 5}, nil)
+	m.On("GetUserByLoginOrEmail", "jane").Return(&models.UserProfileDTO{ID: 8}, nil)
+	m.On("GetUserByLoginOrEmail", "john@example.com").Return(&models.UserProfileDTO{ID: 2}, nil)
+	m.On("SetRoleAssignments", int64(1), "abc", &models.SetRoleAssignmentsCommand{
+		ServiceAccounts: []int64{3, 7},
+		Teams:           []int64{4, 5},
+		Users:           []int64{2, 8},
+	}).Return(nil)
+
+	cr := roleAssignment()
+	cr.Status.AtProvider.ID = nil
+	cr.Spec.ForProvider.Assignments = []v1alpha1.Assignment{
+		{Type: strRef("user"), Login: strRef("jane")},
+		// john is already assigned by his ID
+		{Type: strRef("user"), Email: strRef("john@example.com")},
+		{Type: strRef("team"), Team: strRef("platform")},
+		{Type: strRef("serviceAccount"), ServiceAccountID: &serviceAccount},
+	}
+	e := external{service: m}
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateUserNotFound(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 5}, nil)
+	m.On("GetUserByLoginOrEmail", "jane").Return(nil, nil)
+
+	cr := roleAssignment()
+	cr.Spec.ForProvider.Assignments = []v1alpha1.Assignment{{Type: strRef("user"), Login: strRef("jane")}}
+	e := external{service: m}
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(errors.Errorf(errUserNotFound, "jane"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateFailed(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 5}, nil)
+	m.On("SetRoleAssignments", int64(1), "abc", mock.Anything).Return(errBoom)
+
+	e := external{service: m}
+	_, err := e.Update(context.Background(), roleAssignment())
+	if diff := cmp.Diff(errors.Wrap(errBoom, errFailedUpdateRoleAssignment), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Deleted": {
+			reason: "All assignments of the role should be removed",
+		},
+		"Unavailable": {
+			reason: "No error should be returned if the role or the roles API is gone",
+			err:    runtime.NewAPIError("setRoleAssignments", nil, 404),
+		},
+		"Failed": {
+			reason: "An error should be returned if the assignments cannot be removed",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedDeleteRoleAssignment),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("SetRoleAssignments", int64(1), "abc", &models.SetRoleAssignmentsCommand{
+				ServiceAccounts: []int64{},
+				Teams:           []int64{},
+				Users:           []int64{},
+			}).Return(tc.err)
+
+			e := external{service: m}
+			err := e.Delete(context.Background(), roleAssignment())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
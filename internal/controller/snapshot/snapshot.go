@@ -0,0 +1,396 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotSnapshot   = "managed resource is not a Snapshot custom resource"
+	errTrackPCUsage  = "cannot track ProviderConfig usage"
+	errGetPC         = "cannot get ProviderConfig"
+	errOrgIdNotInt   = "orgId is not an integer"
+	errDashboardJSON = "cannot parse dashboardJson"
+
+	errNewClient            = "cannot create new Service"
+	errFailedGetSnapshot    = "cannot get Snapshot from Grafana API"
+	errFailedCreateSnapshot = "cannot create Snapshot"
+	errFailedDeleteSnapshot = "cannot delete Snapshot"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles Snapshot managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SnapshotGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.SnapshotGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Snapshot{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return nil, errors.New(errNotSnapshot)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	kube         client.Client
+	defaultOrgID *int64
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSnapshot)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// snapshots have no name to look them up by, so they only exist once created
+	if cr.Status.AtProvider.Key == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	atGrafana, err := c.service.GetSnapshotByKey(orgId, *cr.Status.AtProvider.Key)
+
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetSnapshot)
+	}
+
+	// expired snapshots are gone as well, so they are created again
+	if atGrafana == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	upToDate, err := isUpToDate(cr, atGrafana)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.SetConditions(v1.Available())
+	if atGrafana.Meta != nil && !time.Time(atGrafana.Meta.Expires).IsZero() {
+		expiresAt := time.Time(atGrafana.Meta.Expires).UTC().Format(time.RFC3339)
+		cr.Status.AtProvider.ExpiresAt = &expiresAt
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSnapshot)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	if err := c.create(orgId, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr),
+	}, nil
+}
+
+// Update re-creates the snapshot, since Grafana can't update snapshots. The new snapshot has a new key and URL.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSnapshot)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*cr.Spec.ForProvider.OrgID, 10, 64)
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	// the dashboard is parsed before the old snapshot is deleted, so an invalid spec doesn't lose it
+	if _, err := parseDashboardJSON(cr.Spec.ForProvider.DashboardJSON); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.delete(orgId, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.create(orgId, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr),
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Snapshot)
+	if !ok {
+		return errors.New(errNotSnapshot)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	return c.delete(orgId, cr)
+}
+
+// create creates the snapshot of the spec and stores its keys in the status.
+func (c *external) create(orgId int64, cr *v1alpha1.Snapshot) error {
+	spec := cr.Spec.ForProvider
+	dashboard, err := parseDashboardJSON(spec.DashboardJSON)
+	if err != nil {
+		return err
+	}
+
+	var expires int64
+	if spec.Expires != nil {
+		expires = int64(spec.Expires.Duration.Seconds())
+	}
+
+	response, err := c.service.CreateSnapshot(orgId, &models.CreateDashboardSnapshotCommand{
+		Dashboard: dashboard,
+		Expires:   expires,
+		External:  spec.External,
+		Name:      common.DefaultString(spec.Name, ""),
+	})
+
+	if err != nil {
+		return errors.Wrap(err, errFailedCreateSnapshot)
+	}
+
+	id := fmt.Sprintf("%s:%d", *spec.OrgID, response.ID)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = spec.OrgID
+	cr.Status.AtProvider.Key = &response.Key
+	cr.Status.AtProvider.DeleteKey = &response.DeleteKey
+	cr.Status.AtProvider.URL = &response.URL
+	cr.Status.AtProvider.ExpiresAt = nil
+	if expires > 0 {
+		expiresAt := time.Now().Add(spec.Expires.Duration).UTC().Format(time.RFC3339)
+		cr.Status.AtProvider.ExpiresAt = &expiresAt
+	}
+	return nil
+}
+
+// delete deletes the snapshot by its delete key, which is the only way to delete a snapshot that doesn't require
+// the permissions of its creator.
+func (c *external) delete(orgId int64, cr *v1alpha1.Snapshot) error {
+	if cr.Status.AtProvider.DeleteKey == nil {
+		return nil
+	}
+	err := c.service.DeleteSnapshot(orgId, *cr.Status.AtProvider.DeleteKey)
+	return errors.Wrap(err, errFailedDeleteSnapshot)
+}
+
+func parseDashboardJSON(dashboardJSON *string) (map[string]interface{}, error) {
+	dashboard := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(common.DefaultString(dashboardJSON, "{}")), &dashboard); err != nil {
+		return nil, errors.Wrap(err, errDashboardJSON)
+	}
+	return dashboard, nil
+}
+
+// isUpToDate compares the dashboard of the snapshot. Name, expiry and whether the snapshot is external are not
+// returned by Grafana, they are immutable instead.
+func isUpToDate(cr *v1alpha1.Snapshot, atGrafana *models.DashboardFullWithMeta) (bool, error) {
+	desired, err := parseDashboardJSON(cr.Spec.ForProvider.DashboardJSON)
+	if err != nil {
+		return false, err
+	}
+	actual, ok := atGrafana.Dashboard.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	return common.CompareMap(desired, actual)
+}
+
+// connectionDetails publishes the public URL of the snapshot and its keys, so that it can be shared and deleted
+// without access to the Snapshot resource.
+func connectionDetails(cr *v1alpha1.Snapshot) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{}
+	if cr.Status.AtProvider.URL != nil {
+		details["url"] = []byte(*cr.Status.AtProvider.URL)
+	}
+	if cr.Status.AtProvider.Key != nil {
+		details["key"] = []byte(*cr.Status.AtProvider.Key)
+	}
+	if cr.Status.AtProvider.DeleteKey != nil {
+		details["deleteKey"] = []byte(*cr.Status.AtProvider.DeleteKey)
+	}
+	return details
+}
@@ -0,0 +1,287 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func snapshot() *v1alpha1.Snapshot {
+	return &v1alpha1.Snapshot{
+		Spec: v1alpha1.SnapshotSpec{
+			ForProvider: v1alpha1.SnapshotParameters{
+				DashboardJSON: strRef(`{"title": "Incident", "panels": []}`),
+				Name:          strRef("incident"),
+				OrgID:         strRef("1"),
+			},
+		},
+		Status: v1alpha1.SnapshotStatus{
+			AtProvider: v1alpha1.SnapshotObservation{
+				ID:        strRef("1:3"),
+				OrgID:     strRef("1"),
+				Key:       strRef("key"),
+				DeleteKey: strRef("delete-key"),
+				URL:       strRef("http://grafana/dashboard/snapshot/key"),
+			},
+		},
+	}
+}
+
+func grafanaSnapshot(title string) *models.DashboardFullWithMeta {
+	return &models.DashboardFullWithMeta{
+		Dashboard: map[string]interface{}{"title": title, "panels": []interface{}{}},
+		Meta:      &models.DashboardMeta{Expires: strfmt.DateTime(time.Date(2074, 1, 1, 12, 0, 0, 0, time.UTC))},
+	}
+}
+
+func snapshotConnectionDetails() managed.ConnectionDetails {
+	return managed.ConnectionDetails{
+		"url":       []byte("http://grafana/dashboard/snapshot/key"),
+		"key":       []byte("key"),
+		"deleteKey": []byte("delete-key"),
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotSnapshot": {
+			reason:  "An error should be returned if the managed resource is not a Snapshot",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotSnapshot),
+			},
+		},
+		"NotCreated": {
+			reason:  "A Snapshot without key in status should be reported as missing",
+			service: &common.MockGrafanaAPI{},
+			mg: func() resource.Managed {
+				cr := snapshot()
+				cr.Status.AtProvider = v1alpha1.SnapshotObservation{}
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the snapshot cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSnapshotByKey", int64(1), "key").Return(nil, errBoom)
+				return m
+			}(),
+			mg: snapshot(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetSnapshot),
+			},
+		},
+		"NotFound": {
+			reason: "A Snapshot should be reported as missing if it was deleted in Grafana or expired",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSnapshotByKey", int64(1), "key").Return(nil, nil)
+				return m
+			}(),
+			mg: snapshot(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "A Snapshot should be reported as up to date if Grafana has the dashboard of the spec",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSnapshotByKey", int64(1), "key").Return(grafanaSnapshot("Incident"), nil)
+				return m
+			}(),
+			mg: snapshot(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: snapshotConnectionDetails()},
+			},
+		},
+		"DashboardChanged": {
+			reason: "A Snapshot should be reported as outdated if the dashboard of the spec changed",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSnapshotByKey", int64(1), "key").Return(grafanaSnapshot("Outage"), nil)
+				return m
+			}(),
+			mg: snapshot(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: snapshotConnectionDetails()},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveCopiesExpiryToStatus(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetSnapshotByKey", int64(1), "key").Return(grafanaSnapshot("Incident"), nil)
+
+	cr := snapshot()
+	e := external{service: m}
+	if _, err := e.Observe(context.Background(), cr); err != nil {
+		t.Fatalf("e.Observe(...): unexpected error %v", err)
+	}
+	if diff := cmp.Diff(strRef("2074-01-01T12:00:00Z"), cr.Status.AtProvider.ExpiresAt); diff != "" {
+		t.Errorf("e.Observe(...): -want expiresAt, +got expiresAt:\n%s\n", diff)
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("CreateSnapshot", int64(1), &models.CreateDashboardSnapshotCommand{
+		Dashboard: map[string]interface{}{"title": "Incident", "panels": []interface{}{}},
+		Expires:   3600,
+		Name:      "incident",
+	}).Return(&models.CreateDashboardSnapshotOKBody{ID: 3, Key: "key", DeleteKey: "delete-key", URL: "http://grafana/dashboard/snapshot/key"}, nil)
+
+	cr := snapshot()
+	cr.Spec.ForProvider.Expires = &metav1.Duration{Duration: time.Hour}
+	cr.Status.AtProvider = v1alpha1.SnapshotObservation{}
+	e := external{service: m}
+	got, err := e.Create(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	want := snapshot().Status.AtProvider
+	if diff := cmp.Diff(want, cr.Status.AtProvider, cmpopts.IgnoreFields(v1alpha1.SnapshotObservation{}, "ExpiresAt")); diff != "" {
+		t.Errorf("e.Create(...): -want status, +got status:\n%s\n", diff)
+	}
+	if cr.Status.AtProvider.ExpiresAt == nil {
+		t.Errorf("e.Create(...): want expiresAt to be set for a snapshot that expires")
+	}
+	if diff := cmp.Diff(managed.ExternalCreation{ConnectionDetails: snapshotConnectionDetails()}, got); diff != "" {
+		t.Errorf("e.Create(...): -want, +got:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateRecreates(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteSnapshot", int64(1), "delete-key").Return(nil)
+	m.On("CreateSnapshot", int64(1), &models.CreateDashboardSnapshotCommand{
+		Dashboard: map[string]interface{}{"title": "Outage"},
+		Name:      "incident",
+	}).Return(&models.CreateDashboardSnapshotOKBody{ID: 4, Key: "new-key", DeleteKey: "new-delete-key", URL: "http://grafana/dashboard/snapshot/new-key"}, nil)
+
+	cr := snapshot()
+	cr.Spec.ForProvider.DashboardJSON = strRef(`{"title": "Outage"}`)
+	e := external{service: m}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("e.Update(...): unexpected error %v", err)
+	}
+	want := v1alpha1.SnapshotObservation{
+		ID:        strRef("1:4"),
+		OrgID:     strRef("1"),
+		Key:       strRef("new-key"),
+		DeleteKey: strRef("new-delete-key"),
+		URL:       strRef("http://grafana/dashboard/snapshot/new-key"),
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("e.Update(...): -want status, +got status:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateKeepsSnapshotOfInvalidDashboard(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+
+	cr := snapshot()
+	cr.Spec.ForProvider.DashboardJSON = strRef(`{"title": `)
+	e := external{service: m}
+	if _, err := e.Update(context.Background(), cr); err == nil {
+		t.Fatalf("e.Update(...): want error for invalid dashboardJson")
+	}
+	m.AssertNotCalled(t, "DeleteSnapshot", int64(1), "delete-key")
+}
+
+func TestDelete(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteSnapshot", int64(1), "delete-key").Return(nil)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), snapshot())
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestDeleteFailed(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("DeleteSnapshot", int64(1), "delete-key").Return(errBoom)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), snapshot())
+	if diff := cmp.Diff(errors.Wrap(errBoom, errFailedDeleteSnapshot), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+}
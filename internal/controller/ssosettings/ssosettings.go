@@ -0,0 +1,433 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssosettings
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotSSOSettings = "managed resource is not a SSOSettings custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage"
+	errGetPC          = "cannot get ProviderConfig"
+	errGetSigningKey  = "cannot get signing key"
+
+	errNewClient               = "cannot create new Service"
+	errFailedGetSSOSettings    = "cannot get SSOSettings from Grafana API"
+	errFailedUpdateSSOSettings = "cannot update SSOSettings"
+	errFailedResetSSOSettings  = "cannot reset SSOSettings to defaults"
+	errGetSecureSettings       = "cannot get secure settings"
+	errGetSecrets              = "cannot get secrets of the settings"
+	errUnmarshalSettings       = "cannot unmarshal settings"
+	errUnmarshalSecureSettings = "cannot unmarshal secure settings"
+	errHashSecureSettings      = "cannot hash secure settings"
+	errCompareSettings         = "cannot compare settings"
+
+	// sourceDatabase is the source of settings that were stored via the API, as opposed to those read from the
+	// configuration file of Grafana.
+	sourceDatabase = "database"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles SSOSettings managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.SSOSettingsGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.SSOSettingsGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.SSOSettings{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.SSOSettings)
+	if !ok {
+		return nil, errors.New(errNotSSOSettings)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	var signingKey []byte
+	if pc.Spec.SigningKeySecretRef != nil {
+		signingKey, err = resource.ExtractSecret(ctx, c.kube, v1.CommonCredentialSelectors{SecretRef: pc.Spec.SigningKeySecretRef})
+		if err != nil {
+			return nil, errors.Wrap(err, errGetSigningKey)
+		}
+		if len(signingKey) == 0 {
+			return nil, errors.New(errGetSigningKey)
+		}
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, kube: c.kube, signingKey: signingKey}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service common.GrafanaAPI
+	logger  logging.Logger
+	kube    client.Client
+	// signingKey is used to hash the secure settings, change detection of secret values is disabled if it is nil
+	signingKey []byte
+}
+
+// Observe reads the settings of the SSO provider. Grafana knows settings for every provider, so they are only reported
+// as existing once they are stored in its database, and as deleted once Grafana falls back to its configuration file.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.SSOSettings)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSSOSettings)
+	}
+
+	atGrafana, err := c.service.GetSSOSettings(providerOf(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedGetSSOSettings)
+	}
+
+	if atGrafana == nil || atGrafana.Source != sourceDatabase {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	upToDate := true
+	if !meta.WasDeleted(cr) {
+		upToDate, err = c.isUpToDate(ctx, cr, atGrafana)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(atGrafana, cr)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: upToDate,
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Create stores the settings in Grafana, as they can't be created.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.SSOSettings)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSSOSettings)
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.SSOSettings)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSSOSettings)
+	}
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Delete removes the settings from the database of Grafana, which resets the provider to the settings of its
+// configuration file.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.SSOSettings)
+	if !ok {
+		return errors.New(errNotSSOSettings)
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	err := c.service.DeleteSSOSettings(providerOf(cr))
+	if common.IsCode(err, http.StatusNotFound) {
+		return nil
+	}
+	return errors.Wrap(err, errFailedResetSSOSettings)
+}
+
+// apply replaces the settings of the provider with the desired ones, including the secure settings.
+func (c *external) apply(ctx context.Context, cr *v1alpha1.SSOSettings) error {
+	provider := providerOf(cr)
+	settings, err := makeSettings(settingsOf(cr))
+	if err != nil {
+		return err
+	}
+	secureSettings, err := c.getSecureSettings(ctx, cr)
+	if err != nil {
+		return err
+	}
+	hash, err := c.hash(secureSettings)
+	if err != nil {
+		return err
+	}
+
+	merged := make(map[string]interface{}, len(settings)+len(secureSettings))
+	for key, value := range settings {
+		merged[key] = value
+	}
+	for key, value := range secureSettings {
+		merged[key] = value
+	}
+	if err := c.service.UpdateSSOSettings(provider, merged); err != nil {
+		return errors.Wrap(err, errFailedUpdateSSOSettings)
+	}
+
+	copyToStatus(&models.GetProviderSettingsOKBody{Source: sourceDatabase}, cr)
+	cr.Status.AtProvider.SecureSettingsHash = hash
+	return nil
+}
+
+// isUpToDate compares the desired settings with the ones Grafana returns. Grafana returns all settings of the provider
+// including defaults, so only the keys of the spec are compared. Secure settings are returned masked, instead the
+// hash of the values last sent to Grafana is compared.
+func (c *external) isUpToDate(ctx context.Context, cr *v1alpha1.SSOSettings, atGrafana *models.GetProviderSettingsOKBody) (bool, error) {
+	desired, err := makeSettings(settingsOf(cr))
+	if err != nil {
+		return false, err
+	}
+	actual, _ := atGrafana.Settings.(map[string]interface{})
+	upToDate, err := common.CompareMap(desired, subset(actual, desired))
+	if err != nil {
+		return false, errors.Wrap(err, errCompareSettings)
+	}
+
+	if c.signingKey != nil {
+		secureSettings, err := c.getSecureSettings(ctx, cr)
+		if err != nil {
+			return false, err
+		}
+		hash, err := c.hash(secureSettings)
+		if err != nil {
+			return false, err
+		}
+		stored := cr.Status.AtProvider.SecureSettingsHash
+		upToDate = upToDate && stored != nil && *stored == *hash
+	}
+
+	return upToDate, nil
+}
+
+// getSecureSettings reads the secure settings referenced by the spec, they are empty if there is no reference. The keys
+// of the secret referenced by secretsRef take precedence over those of the deprecated secureSettingsSecretRef.
+func (c *external) getSecureSettings(ctx context.Context, cr *v1alpha1.SSOSettings) (map[string]interface{}, error) {
+	secureSettings := make(map[string]interface{})
+	if ref := cr.Spec.ForProvider.SecureSettingsSecretRef; ref != nil {
+		data, err := resource.ExtractSecret(ctx, c.kube, v1.CommonCredentialSelectors{SecretRef: ref})
+		if err != nil {
+			return nil, errors.Wrap(err, errGetSecureSettings)
+		}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &secureSettings); err != nil {
+				return nil, errors.Wrap(err, errUnmarshalSecureSettings)
+			}
+		}
+	}
+	if ref := cr.Spec.ForProvider.SecretsRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, errors.Wrap(err, errGetSecrets)
+		}
+		for key, value := range secret.Data {
+			secureSettings[key] = string(value)
+		}
+	}
+	return secureSettings, nil
+}
+
+// hash signs the secure settings with HMAC-SHA256, nil is returned if no signing key is configured. The settings are
+// serialized with sorted keys, so the hash does not depend on their order.
+func (c *external) hash(secureSettings map[string]interface{}) (*string, error) {
+	if c.signingKey == nil {
+		return nil, nil
+	}
+	canonical, err := json.Marshal(secureSettings)
+	if err != nil {
+		return nil, errors.Wrap(err, errHashSecureSettings)
+	}
+	mac := hmac.New(sha256.New, c.signingKey)
+	mac.Write(canonical)
+	h := hex.EncodeToString(mac.Sum(nil))
+	return &h, nil
+}
+
+// makeSettings decodes the settings of the spec. Numbers are decoded as float64, the way Grafana returns them.
+func makeSettings(data *string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	if data != nil && *data != "" {
+		if err := json.Unmarshal([]byte(*data), &settings); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalSettings)
+		}
+	}
+	return settings, nil
+}
+
+// subset returns the entries of actual whose keys are in desired.
+func subset(actual map[string]interface{}, desired map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(desired))
+	for key := range desired {
+		if value, ok := actual[key]; ok {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// providerOf returns the key of the SSO provider of the spec, provider takes precedence over the deprecated
+// providerName.
+func providerOf(cr *v1alpha1.SSOSettings) string {
+	if cr.Spec.ForProvider.Provider != nil {
+		return *cr.Spec.ForProvider.Provider
+	}
+	return common.DefaultString(cr.Spec.ForProvider.ProviderName, "")
+}
+
+// settingsOf returns the encoded settings of the spec, settings take precedence over the deprecated settingsEncoded.
+func settingsOf(cr *v1alpha1.SSOSettings) *string {
+	if cr.Spec.ForProvider.Settings != nil {
+		return cr.Spec.ForProvider.Settings
+	}
+	return cr.Spec.ForProvider.SettingsEncoded
+}
+
+func copyToStatus(response *models.GetProviderSettingsOKBody, cr *v1alpha1.SSOSettings) {
+	provider := providerOf(cr)
+	source := response.Source
+	cr.Status.AtProvider.ID = &provider
+	cr.Status.AtProvider.ProviderName = &provider
+	cr.Status.AtProvider.Source = &source
+}
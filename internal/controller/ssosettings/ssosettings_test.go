@@ -0,0 +1,351 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ssosettings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func ssoSettings() *v1alpha1.SSOSettings {
+	return &v1alpha1.SSOSettings{
+		Spec: v1alpha1.SSOSettingsSpec{
+			ForProvider: v1alpha1.SSOSettingsParameters{
+				Provider:   strRef("github"),
+				Settings:   strRef(`{"clientId": "abc", "enabled": true}`),
+				SecretsRef: &v1.SecretReference{Name: "github", Namespace: "crossplane-system"},
+			},
+		},
+	}
+}
+
+func providerSettings(source string, clientId string) *models.GetProviderSettingsOKBody {
+	return &models.GetProviderSettingsOKBody{
+		ID:       "1",
+		Provider: "github",
+		Source:   source,
+		Settings: map[string]interface{}{
+			"clientId":     clientId,
+			"clientSecret": "*********",
+			"enabled":      true,
+			"scopes":       "user:email,read:org",
+		},
+	}
+}
+
+// secretClient serves the secrets of the SSOSettings.
+func secretClient(secrets map[string]string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			data := make(map[string][]byte, len(secrets))
+			for key, value := range secrets {
+				data[key] = []byte(value)
+			}
+			obj.(*corev1.Secret).Data = data
+			return nil
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotSSOSettings": {
+			reason:  "An error should be returned if the managed resource is not SSOSettings",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotSSOSettings),
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the settings cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSSOSettings", "github").Return(nil, errBoom)
+				return m
+			}(),
+			mg: ssoSettings(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetSSOSettings),
+			},
+		},
+		"FromConfigurationFile": {
+			reason: "Settings should be reported as missing as long as Grafana reads them from its configuration file",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSSOSettings", "github").Return(providerSettings("system", "abc"), nil)
+				return m
+			}(),
+			mg: ssoSettings(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "Settings should be reported as up to date if all settings of the spec match, ignoring defaults",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSSOSettings", "github").Return(providerSettings("database", "abc"), nil)
+				return m
+			}(),
+			mg: ssoSettings(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"SettingChanged": {
+			reason: "Settings should be reported as outdated if a setting of the spec differs",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSSOSettings", "github").Return(providerSettings("database", "def"), nil)
+				return m
+			}(),
+			mg: ssoSettings(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"Deleted": {
+			reason: "Settings should be reported as up to date while they are being reset",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetSSOSettings", "github").Return(providerSettings("database", "def"), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := ssoSettings()
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service, kube: secretClient(map[string]string{"clientSecret": "secret"})}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestObserveSecureSettingsChanged(t *testing.T) {
+	signingKey := []byte("signing-key")
+	cr := ssoSettings()
+
+	m := &common.MockGrafanaAPI{}
+	m.On("GetSSOSettings", "github").Return(providerSettings("database", "abc"), nil)
+	m.On("UpdateSSOSettings", "github", map[string]interface{}{"clientId": "abc", "enabled": true, "clientSecret": "secret"}).Return(nil)
+
+	e := external{service: m, kube: secretClient(map[string]string{"clientSecret": "secret"}), signingKey: signingKey}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): %v", err)
+	}
+	got, err := e.Observe(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	if !got.ResourceUpToDate {
+		t.Errorf("e.Observe(...): want settings to be up to date after they were applied")
+	}
+
+	e.kube = secretClient(map[string]string{"clientSecret": "rotated"})
+	got, err = e.Observe(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Observe(...): -want error, +got error:\n%s\n", diff)
+	}
+	if got.ResourceUpToDate {
+		t.Errorf("e.Observe(...): want settings to be outdated after the secure settings changed")
+	}
+}
+
+func TestCreateWithDeprecatedFields(t *testing.T) {
+	deprecated := func() *v1alpha1.SSOSettings {
+		return &v1alpha1.SSOSettings{
+			Spec: v1alpha1.SSOSettingsSpec{
+				ForProvider: v1alpha1.SSOSettingsParameters{
+					ProviderName:    strRef("github"),
+					SettingsEncoded: strRef(`{"clientId": "abc", "enabled": true}`),
+					SecureSettingsSecretRef: &v1.SecretKeySelector{
+						SecretReference: v1.SecretReference{Name: "github-json", Namespace: "crossplane-system"},
+						Key:             "secureSettings",
+					},
+				},
+			},
+		}
+	}
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Name == "github-json" {
+				obj.(*corev1.Secret).Data = map[string][]byte{"secureSettings": []byte(`{"clientSecret": "old", "privateKey": "key"}`)}
+			} else {
+				obj.(*corev1.Secret).Data = map[string][]byte{"clientSecret": []byte("new")}
+			}
+			return nil
+		},
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.SSOSettings
+		want   map[string]interface{}
+	}{
+		"DeprecatedFields": {
+			reason: "The deprecated fields should still be applied",
+			cr:     deprecated(),
+			want:   map[string]interface{}{"clientId": "abc", "enabled": true, "clientSecret": "old", "privateKey": "key"},
+		},
+		"NewFieldsTakePrecedence": {
+			reason: "provider, settings and the keys of secretsRef should take precedence over the deprecated fields",
+			cr: func() *v1alpha1.SSOSettings {
+				cr := deprecated()
+				cr.Spec.ForProvider.Provider = strRef("gitlab")
+				cr.Spec.ForProvider.Settings = strRef(`{"clientId": "def"}`)
+				cr.Spec.ForProvider.SecretsRef = &v1.SecretReference{Name: "github", Namespace: "crossplane-system"}
+				return cr
+			}(),
+			want: map[string]interface{}{"clientId": "def", "clientSecret": "new", "privateKey": "key"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			provider := providerOf(tc.cr)
+			m := &common.MockGrafanaAPI{}
+			m.On("UpdateSSOSettings", provider, tc.want).Return(nil)
+
+			e := external{service: m, kube: kube}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cr := ssoSettings()
+
+	m := &common.MockGrafanaAPI{}
+	m.On("UpdateSSOSettings", "github", map[string]interface{}{"clientId": "abc", "enabled": true, "clientSecret": "secret"}).Return(nil)
+
+	e := external{service: m, kube: secretClient(map[string]string{"clientSecret": "secret"})}
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	want := v1alpha1.SSOSettingsObservation{ID: strRef("github"), ProviderName: strRef("github"), Source: strRef("database")}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("e.Create(...): -want status, +got status:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestUpdateFailed(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("UpdateSSOSettings", "github", map[string]interface{}{"clientId": "abc", "enabled": true, "clientSecret": "secret"}).Return(errBoom)
+
+	e := external{service: m, kube: secretClient(map[string]string{"clientSecret": "secret"})}
+	_, err := e.Update(context.Background(), ssoSettings())
+	if diff := cmp.Diff(errors.Wrap(errBoom, errFailedUpdateSSOSettings), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Update(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Reset": {
+			reason: "Deleting should remove the settings from the database of Grafana",
+		},
+		"UnknownProvider": {
+			reason: "Deleting should succeed if Grafana does not know the provider",
+			err:    runtime.NewAPIError("removeProviderSettings", nil, 404),
+		},
+		"Failed": {
+			reason: "An error should be returned if the settings cannot be reset",
+			err:    errBoom,
+			want:   errors.Wrap(errBoom, errFailedResetSSOSettings),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &common.MockGrafanaAPI{}
+			m.On("DeleteSSOSettings", "github").Return(tc.err)
+
+			e := external{service: m}
+			err := e.Delete(context.Background(), ssoSettings())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
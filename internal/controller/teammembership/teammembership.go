@@ -0,0 +1,547 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teammembership
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotTeamMembership = "managed resource is not a TeamMembership custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errOrgIdNotInt       = "orgId is not an integer"
+
+	errNewClient         = "cannot create new Service"
+	errGetTeam           = "cannot get team"
+	errTeamNotFound      = "team %s does not exist"
+	errGetTeamMembers    = "cannot get members of team"
+	errGetUsers          = "cannot get users"
+	errUserNotFound      = "cannot add user %s to team, the user does not exist in Grafana"
+	errCreateUser        = "cannot create user %s"
+	errAddTeamMember     = "cannot add user %s to team"
+	errUpdateTeamMember  = "cannot update role of user %s in team"
+	errRemoveTeamMember  = "cannot remove user %s from team"
+	errUpdateTeamMembers = "cannot update members of team"
+
+	roleMember = "Member"
+	roleAdmin  = "Admin"
+
+	// adminPermission is the permission of team admins in the team API, members have no permission.
+	adminPermission int64 = 4
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles TeamMembership managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.TeamMembershipGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.TeamMembershipGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.TeamMembership{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.TeamMembership)
+	if !ok {
+		return nil, errors.New(errNotTeamMembership)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, users: common.Users, host: clientCfg.Host, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	users        *common.UserCache
+	host         string
+	defaultOrgID *int64
+}
+
+type changeType int8
+
+const (
+	add changeType = iota
+	update
+	remove
+)
+
+// teamMember is a member of a team with its normalized email address.
+type teamMember struct {
+	ID    int64
+	Email string
+	Role  string
+}
+
+type memberChange struct {
+	Type   changeType
+	Member teamMember
+}
+
+// Observe reads the members of the team. The team itself is not managed by this resource, so the membership is only
+// reported as existing once it was applied by the provider, and as deleted once none of its members are left.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TeamMembership)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamMembership)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if cr.Status.AtProvider.ID == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	team, err := c.getTeam(ctx, orgId, *cr.Spec.ForProvider.TeamID)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetTeam)
+	}
+	if team == nil {
+		// the memberships vanished together with the team
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	actual, err := c.getMembers(orgId, team.ID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	desired := mapMembers(cr.Spec.ForProvider.Members)
+
+	if meta.WasDeleted(cr) && !containsAny(actual, desired) {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(cr, team.ID, actual)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: membersEqualIgnoreOrder(desired, actual),
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Create applies the members to the team, as the team itself is not managed by this resource.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TeamMembership)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamMembership)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TeamMembership)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeamMembership)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Delete removes the members of the resource from the team. Members that were added to the team by other means after
+// the resource was last applied are left untouched.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TeamMembership)
+	if !ok {
+		return errors.New(errNotTeamMembership)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	team, err := c.getTeam(ctx, orgId, *cr.Spec.ForProvider.TeamID)
+	if err != nil {
+		return errors.Wrap(err, errGetTeam)
+	}
+	if team == nil {
+		// the memberships vanished together with the team
+		return nil
+	}
+
+	actual, err := c.getMembers(orgId, team.ID)
+	if err != nil {
+		return err
+	}
+
+	var changes []memberChange
+	for email := range mapMembers(cr.Spec.ForProvider.Members) {
+		if member, ok := actual[email]; ok {
+			changes = append(changes, memberChange{remove, member})
+		}
+	}
+	return errors.Wrap(c.applyChanges(orgId, team.ID, changes), errUpdateTeamMembers)
+}
+
+// getTeam looks up the team by its numeric ID or, if the ID is not numeric, by its UID.
+func (c *external) getTeam(ctx context.Context, orgId int64, teamId string) (*models.TeamDTO, error) {
+	if id, err := strconv.ParseInt(teamId, 10, 64); err == nil {
+		return c.service.GetTeamById(orgId, id)
+	}
+	return c.service.GetTeamByUid(ctx, orgId, teamId)
+}
+
+func (c *external) getMembers(orgId int64, teamId int64) (map[string]teamMember, error) {
+	members, err := c.service.GetTeamMembers(orgId, teamId)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTeamMembers)
+	}
+	actual := make(map[string]teamMember, len(members))
+	for _, member := range members {
+		role := roleMember
+		if int64(member.Permission) == adminPermission {
+			role = roleAdmin
+		}
+		email := strings.ToLower(member.Email)
+		actual[email] = teamMember{ID: member.UserID, Email: email, Role: role}
+	}
+	return actual, nil
+}
+
+// apply adds, updates and removes members of the team until it matches the desired members.
+func (c *external) apply(ctx context.Context, cr *v1alpha1.TeamMembership) error {
+	// orgId as int64
+	spec := cr.Spec.ForProvider
+	orgId, err := strconv.ParseInt(*(spec.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	team, err := c.getTeam(ctx, orgId, *spec.TeamID)
+	if err != nil {
+		return errors.Wrap(err, errGetTeam)
+	}
+	if team == nil {
+		return errors.Errorf(errTeamNotFound, *spec.TeamID)
+	}
+
+	actual, err := c.getMembers(orgId, team.ID)
+	if err != nil {
+		return err
+	}
+
+	changes, err := c.addUserIdsToChanges(ctx, memberChanges(actual, mapMembers(spec.Members)), common.DefaultBool(spec.CreateUsers, true))
+	if err != nil {
+		return errors.Wrap(err, errUpdateTeamMembers)
+	}
+	if err := c.applyChanges(orgId, team.ID, changes); err != nil {
+		return errors.Wrap(err, errUpdateTeamMembers)
+	}
+
+	copyToStatus(cr, team.ID, mapMembers(spec.Members))
+	return nil
+}
+
+// applyChanges applies all changes, even if some of them fail, and returns the aggregated errors.
+func (c *external) applyChanges(orgId int64, teamId int64, changes []memberChange) error {
+	var errs []error
+	for _, change := range changes {
+		m := change.Member
+		var err error
+		var errFormat string
+		switch change.Type {
+		case add:
+			err = c.service.AddTeamMember(orgId, teamId, m.ID)
+			errFormat = errAddTeamMember
+			if err == nil && m.Role == roleAdmin {
+				err = c.service.UpdateTeamMember(orgId, teamId, m.ID, adminPermission)
+			}
+		case update:
+			var permission int64
+			if m.Role == roleAdmin {
+				permission = adminPermission
+			}
+			err = c.service.UpdateTeamMember(orgId, teamId, m.ID, permission)
+			errFormat = errUpdateTeamMember
+		case remove:
+			err = c.service.RemoveTeamMember(orgId, teamId, m.ID)
+			errFormat = errRemoveTeamMember
+		}
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, errFormat, m.Email))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// addUserIdsToChanges looks up the IDs of users that are added to the team. Updated and removed members already carry
+// the ID of their user.
+func (c *external) addUserIdsToChanges(ctx context.Context, changes []memberChange, create bool) ([]memberChange, error) {
+	var userIds map[string]int64
+	for i, change := range changes {
+		if change.Type != add {
+			continue
+		}
+		if userIds == nil {
+			var err error
+			if userIds, err = c.users.Get(c.host, func() ([]*models.UserSearchHitDTO, error) {
+				return c.service.GetAllUsers(ctx)
+			}); err != nil {
+				return nil, errors.Wrap(err, errGetUsers)
+			}
+		}
+		id, ok := userIds[change.Member.Email]
+		if !ok && !create {
+			return nil, errors.Errorf(errUserNotFound, change.Member.Email)
+		}
+		if !ok {
+			var err error
+			if id, err = c.service.CreateUser(change.Member.Email); err != nil {
+				return nil, errors.Wrapf(err, errCreateUser, change.Member.Email)
+			}
+			c.users.Invalidate(c.host)
+		}
+		changes[i].Member.ID = id
+	}
+	return changes, nil
+}
+
+func memberChanges(actual, desired map[string]teamMember) []memberChange {
+	var changes []memberChange
+	for email, member := range desired {
+		current, ok := actual[email]
+		if !ok {
+			changes = append(changes, memberChange{add, member})
+			continue
+		}
+		if current.Role != member.Role {
+			member.ID = current.ID
+			changes = append(changes, memberChange{update, member})
+		}
+	}
+	for email, member := range actual {
+		if _, ok := desired[email]; !ok {
+			changes = append(changes, memberChange{remove, member})
+		}
+	}
+	return changes
+}
+
+// mapMembers normalizes the desired members by their lower case email address, like the users of organizations.
+func mapMembers(members []v1alpha1.TeamMember) map[string]teamMember {
+	mapped := make(map[string]teamMember, len(members))
+	for _, member := range members {
+		email := strings.ToLower(common.DefaultString(member.Email, ""))
+		mapped[email] = teamMember{Email: email, Role: common.DefaultString(member.Role, roleMember)}
+	}
+	return mapped
+}
+
+func membersEqualIgnoreOrder(desired, actual map[string]teamMember) bool {
+	if len(desired) != len(actual) {
+		return false
+	}
+	for email, member := range desired {
+		if current, ok := actual[email]; !ok || current.Role != member.Role {
+			return false
+		}
+	}
+	return true
+}
+
+func containsAny(actual, desired map[string]teamMember) bool {
+	for email := range desired {
+		if _, ok := actual[email]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func copyToStatus(cr *v1alpha1.TeamMembership, teamId int64, members map[string]teamMember) {
+	orgId := *cr.Spec.ForProvider.OrgID
+	id := fmt.Sprintf("%s:%d", orgId, teamId)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = &orgId
+	cr.Status.AtProvider.TeamID = &teamId
+
+	emails := make([]string, 0, len(members))
+	for email := range members {
+		emails = append(emails, email)
+	}
+	// the order of the members must not depend on the iteration order of the map, otherwise the status changes
+	// between reconciles
+	sort.Strings(emails)
+	observed := make([]v1alpha1.TeamMember, 0, len(members))
+	for _, email := range emails {
+		email, role := email, members[email].Role
+		observed = append(observed, v1alpha1.TeamMember{Email: &email, Role: &role})
+	}
+	cr.Status.AtProvider.Members = observed
+}
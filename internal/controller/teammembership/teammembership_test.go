@@ -0,0 +1,310 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teammembership
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func boolRef(b bool) *bool {
+	return &b
+}
+
+func teamMembership() *v1alpha1.TeamMembership {
+	id := "1:7"
+	return &v1alpha1.TeamMembership{
+		Spec: v1alpha1.TeamMembershipSpec{
+			ForProvider: v1alpha1.TeamMembershipParameters{
+				OrgID:  strRef("1"),
+				TeamID: strRef("7"),
+				Members: []v1alpha1.TeamMember{
+					{Email: strRef("Alice@example.com"), Role: strRef("Admin")},
+					{Email: strRef("bob@example.com")},
+				},
+			},
+		},
+		Status: v1alpha1.TeamMembershipStatus{
+			AtProvider: v1alpha1.TeamMembershipObservation{
+				ID: &id,
+			},
+		},
+	}
+}
+
+func grafanaMembers(bobPermission int64) []*models.TeamMemberDTO {
+	return []*models.TeamMemberDTO{
+		{UserID: 2, Email: "alice@example.com", Permission: models.PermissionType(adminPermission)},
+		{UserID: 3, Email: "Bob@example.com", Permission: models.PermissionType(bobPermission)},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotTeamMembership": {
+			reason:  "An error should be returned if the managed resource is not a TeamMembership",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotTeamMembership),
+			},
+		},
+		"NotApplied": {
+			reason:  "The membership should be reported as missing until it was applied",
+			service: &common.MockGrafanaAPI{},
+			mg: func() resource.Managed {
+				cr := teamMembership()
+				cr.Status.AtProvider.ID = nil
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"TeamGone": {
+			reason: "The membership should be reported as missing if the team does not exist anymore",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(nil, nil)
+				return m
+			}(),
+			mg: teamMembership(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetMembersFailed": {
+			reason: "An error should be returned if the members cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return(nil, errBoom)
+				return m
+			}(),
+			mg: teamMembership(),
+			want: want{
+				err: errors.Wrap(errBoom, errGetTeamMembers),
+			},
+		},
+		"UpToDate": {
+			reason: "The membership should be reported as up to date if all members have their role, regardless of the case of their emails",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return(grafanaMembers(0), nil)
+				return m
+			}(),
+			mg: teamMembership(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"TeamByUid": {
+			reason: "A team ID that is not numeric should be looked up as UID",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return(grafanaMembers(0), nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := teamMembership()
+				cr.Spec.ForProvider.TeamID = strRef("platform")
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"RoleChanged": {
+			reason: "The membership should be reported as outdated if a member has another role",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return(grafanaMembers(adminPermission), nil)
+				return m
+			}(),
+			mg: teamMembership(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"RemovedAfterDeletion": {
+			reason: "The membership should be reported as deleted once none of its members are left in the team",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return([]*models.TeamMemberDTO{{UserID: 4, Email: "carol@example.com"}}, nil)
+				return m
+			}(),
+			mg: func() resource.Managed {
+				cr := teamMembership()
+				now := metav1.Now()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		mg      *v1alpha1.TeamMembership
+		service func() *common.MockGrafanaAPI
+		err     error
+	}{
+		"AddUpdateRemove": {
+			reason: "Missing members should be added, members with another role updated and unlisted members removed",
+			mg:     teamMembership(),
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return([]*models.TeamMemberDTO{
+					{UserID: 3, Email: "bob@example.com", Permission: models.PermissionType(adminPermission)},
+					{UserID: 4, Email: "carol@example.com"},
+				}, nil)
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{{ID: 2, Email: "alice@example.com"}}, nil)
+				m.On("AddTeamMember", int64(1), int64(7), int64(2)).Return(nil)
+				m.On("UpdateTeamMember", int64(1), int64(7), int64(2), adminPermission).Return(nil)
+				m.On("UpdateTeamMember", int64(1), int64(7), int64(3), int64(0)).Return(nil)
+				m.On("RemoveTeamMember", int64(1), int64(7), int64(4)).Return(nil)
+				return m
+			},
+		},
+		"CreateMissingUser": {
+			reason: "Users that do not exist in Grafana should be created by default",
+			mg:     teamMembership(),
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return([]*models.TeamMemberDTO{{UserID: 2, Email: "alice@example.com", Permission: models.PermissionType(adminPermission)}}, nil)
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{{ID: 2, Email: "alice@example.com"}}, nil)
+				m.On("CreateUser", "bob@example.com").Return(int64(3), nil)
+				m.On("AddTeamMember", int64(1), int64(7), int64(3)).Return(nil)
+				return m
+			},
+		},
+		"DoNotCreateUsers": {
+			reason: "An error should be returned for unknown users if users must not be created",
+			mg: func() *v1alpha1.TeamMembership {
+				cr := teamMembership()
+				cr.Spec.ForProvider.CreateUsers = boolRef(false)
+				return cr
+			}(),
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamMembers", int64(1), int64(7)).Return([]*models.TeamMemberDTO{{UserID: 2, Email: "alice@example.com", Permission: models.PermissionType(adminPermission)}}, nil)
+				m.On("GetAllUsers", mock.Anything).Return([]*models.UserSearchHitDTO{{ID: 2, Email: "alice@example.com"}}, nil)
+				return m
+			},
+			err: errors.Wrap(errors.Errorf(errUserNotFound, "bob@example.com"), errUpdateTeamMembers),
+		},
+		"TeamNotFound": {
+			reason: "An error should be returned if the team does not exist",
+			mg:     teamMembership(),
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(nil, nil)
+				return m
+			},
+			err: errors.Errorf(errTeamNotFound, "7"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			e := external{service: m}
+			_, err := e.Update(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDeleteRemovesOnlyOwnMembers(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+	m.On("GetTeamMembers", int64(1), int64(7)).Return([]*models.TeamMemberDTO{
+		{UserID: 2, Email: "alice@example.com", Permission: models.PermissionType(adminPermission)},
+		{UserID: 4, Email: "carol@example.com"},
+	}, nil)
+	m.On("RemoveTeamMember", int64(1), int64(7), int64(2)).Return(nil)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), teamMembership())
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
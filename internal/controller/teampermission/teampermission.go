@@ -0,0 +1,380 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teampermission
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	providerV1alpha1 "github.com/argannor/provider-grafana/apis/v1alpha1"
+
+	"github.com/argannor/provider-grafana/internal/controller/common"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	grafana "github.com/grafana/grafana-openapi-client-go/client"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	apisv1beta1 "github.com/argannor/provider-grafana/apis/v1beta1"
+	"github.com/argannor/provider-grafana/internal/features"
+)
+
+const (
+	errNotTeamPermission = "managed resource is not a TeamPermission custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage"
+	errGetPC             = "cannot get ProviderConfig"
+	errOrgIdNotInt       = "orgId is not an integer"
+
+	errNewClient        = "cannot create new Service"
+	errGetTeam          = "cannot get team"
+	errTeamNotFound     = "team %q does not exist"
+	errGetPermissions   = "cannot get permissions of team on folder"
+	errAddPermission    = "cannot add permission of team on folder"
+	errRemovePermission = "cannot remove permission of team on folder"
+)
+
+var (
+	newService = func(config *grafana.TransportConfig) (common.GrafanaAPI, error) {
+		client := *grafana.NewHTTPClientWithConfig(nil, config)
+		return common.NewGrafanaAPI(client), nil
+	}
+)
+
+// Setup adds a controller that reconciles TeamPermission managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.TeamPermissionGroupKind)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	rateLimits := common.NewRateLimitRequeues()
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), providerV1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	r := managed.NewReconciler(common.WithAPIUnavailableCondition(mgr),
+		resource.ManagedKind(v1alpha1.TeamPermissionGroupVersionKind),
+		managed.WithExternalConnecter(common.WithAPIUnavailable(rateLimits.WithEvents(recorder, &connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1beta1.ProviderConfigUsage{}),
+			newServiceFn: newService,
+			logger:       o.Logger}))),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithPollIntervalHook(common.PollIntervalHook(recorder)),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.TeamPermission{}).
+		Complete(ratelimiter.NewReconciler(name, rateLimits.Reconciler(r), o.GlobalRateLimiter))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	logger       logging.Logger
+	newServiceFn func(config *grafana.TransportConfig) (common.GrafanaAPI, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.TeamPermission)
+	if !ok {
+		return nil, errors.New(errNotTeamPermission)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1beta1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCfg, err := common.NewTransportConfig(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := c.newServiceFn(clientCfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, logger: c.logger, defaultOrgID: pc.Spec.DefaultOrgID}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	service      common.GrafanaAPI
+	logger       logging.Logger
+	defaultOrgID *int64
+}
+
+// Observe reads the permission of the team on the folder. The resource is reported as missing if the team, the folder
+// or the permission of the team on it vanished.
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TeamPermission)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTeamPermission)
+	}
+
+	orgIDDefaulted, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if cr.Status.AtProvider.ID == nil {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	team, err := c.getTeam(ctx, orgId, *cr.Spec.ForProvider.TeamID)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetTeam)
+	}
+	if team == nil {
+		// the permission vanished together with the team
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	actual, err := c.getPermission(orgId, *cr.Spec.ForProvider.FolderUID, team.ID)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if actual == "" {
+		return managed.ExternalObservation{
+			ResourceExists: false,
+		}, nil
+	}
+
+	cr.SetConditions(v1.Available())
+	copyToStatus(cr, team.ID, actual)
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalObservation{
+		// Return false when the external resource does not exist. This lets
+		// the managed resource reconciler know that it needs to call Create to
+		// (re)create the resource, or that it has successfully been deleted.
+		ResourceExists: true,
+
+		// Persist an orgId defaulted from the ProviderConfig in the spec.
+		ResourceLateInitialized: orgIDDefaulted,
+
+		// Return false when the external resource exists, but it not up to date
+		// with the desired managed resource state. This lets the managed
+		// resource reconciler know that it needs to call Update.
+		ResourceUpToDate: isUpToDate(cr),
+
+		// Return any details that may be required to connect to the external
+		// resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Create grants the permission to the team, as neither the team nor the folder are managed by this resource.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TeamPermission)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTeamPermission)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(v1.Creating())
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TeamPermission)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTeamPermission)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := c.apply(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	common.SetLastReconcileAnnotation(mg, time.Now())
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Delete removes only the permission of the team on the folder, permissions of the team on other folders and of others
+// on the folder are kept.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TeamPermission)
+	if !ok {
+		return errors.New(errNotTeamPermission)
+	}
+
+	if _, err := common.DefaultOrgID(&cr.Spec.ForProvider.OrgID, c.defaultOrgID); err != nil {
+		return err
+	}
+
+	cr.SetConditions(v1.Deleting())
+
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	team, err := c.getTeam(ctx, orgId, *cr.Spec.ForProvider.TeamID)
+	if err != nil {
+		return errors.Wrap(err, errGetTeam)
+	}
+	if team == nil {
+		// the permission vanished together with the team
+		return nil
+	}
+
+	folderUid := *cr.Spec.ForProvider.FolderUID
+	actual, err := c.getPermission(orgId, folderUid, team.ID)
+	if err != nil {
+		return err
+	}
+	if actual == "" {
+		return nil
+	}
+
+	err = c.service.RemoveTeamPermission(orgId, folderUid, team.ID)
+	return errors.Wrap(err, errRemovePermission)
+}
+
+func (c *external) apply(ctx context.Context, cr *v1alpha1.TeamPermission) error {
+	// orgId as int64
+	orgId, err := strconv.ParseInt(*(cr.Spec.ForProvider.OrgID), 10, 64)
+	if err != nil {
+		return errors.Wrap(err, errOrgIdNotInt)
+	}
+
+	teamId := *cr.Spec.ForProvider.TeamID
+	team, err := c.getTeam(ctx, orgId, teamId)
+	if err != nil {
+		return errors.Wrap(err, errGetTeam)
+	}
+	if team == nil {
+		return errors.Errorf(errTeamNotFound, teamId)
+	}
+
+	folderUid := *cr.Spec.ForProvider.FolderUID
+	permission := common.DefaultString(cr.Spec.ForProvider.Permission, "")
+	if err := c.service.AddTeamPermission(orgId, folderUid, team.ID, permission); err != nil {
+		return errors.Wrap(err, errAddPermission)
+	}
+
+	id := fmt.Sprintf("%d:%s:%d", orgId, folderUid, team.ID)
+	cr.Status.AtProvider.ID = &id
+	cr.Status.AtProvider.OrgID = cr.Spec.ForProvider.OrgID
+	cr.Status.AtProvider.FolderUID = &folderUid
+	copyToStatus(cr, team.ID, permission)
+	return nil
+}
+
+// getTeam looks up the team by its numeric ID or, if the ID is not numeric, by its UID.
+func (c *external) getTeam(ctx context.Context, orgId int64, teamId string) (*models.TeamDTO, error) {
+	if id, err := strconv.ParseInt(teamId, 10, 64); err == nil {
+		return c.service.GetTeamById(orgId, id)
+	}
+	return c.service.GetTeamByUid(ctx, orgId, teamId)
+}
+
+// getPermission returns the permission the team is granted directly on the folder, or an empty string if it has none
+// or the folder does not exist. Permissions inherited from parent folders or granted by fixed roles are ignored.
+func (c *external) getPermission(orgId int64, folderUid string, teamId int64) (string, error) {
+	permissions, err := c.service.GetTeamPermissions(orgId, folderUid, teamId)
+	if common.IsCode(err, http.StatusNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, errGetPermissions)
+	}
+	for _, permission := range permissions {
+		if permission.IsManaged && !permission.IsInherited {
+			return permission.Permission, nil
+		}
+	}
+	return "", nil
+}
+
+// isUpToDate compares both the folder the permission was granted on and the level of the permission.
+func isUpToDate(cr *v1alpha1.TeamPermission) bool {
+	spec := cr.Spec.ForProvider
+	status := cr.Status.AtProvider
+	return common.DefaultString(spec.FolderUID, "") == common.DefaultString(status.FolderUID, "") &&
+		common.DefaultString(spec.Permission, "") == common.DefaultString(status.Permission, "")
+}
+
+func copyToStatus(cr *v1alpha1.TeamPermission, teamId int64, permission string) {
+	cr.Status.AtProvider.TeamID = &teamId
+	cr.Status.AtProvider.Permission = &permission
+}
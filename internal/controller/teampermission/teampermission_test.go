@@ -0,0 +1,300 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teampermission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/go-openapi/runtime"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func strRef(s string) *string {
+	return &s
+}
+
+func teamPermission() *v1alpha1.TeamPermission {
+	return &v1alpha1.TeamPermission{
+		Spec: v1alpha1.TeamPermissionSpec{
+			ForProvider: v1alpha1.TeamPermissionParameters{
+				FolderUID:  strRef("abc"),
+				OrgID:      strRef("1"),
+				Permission: strRef("Edit"),
+				TeamID:     strRef("2"),
+			},
+		},
+		Status: v1alpha1.TeamPermissionStatus{
+			AtProvider: v1alpha1.TeamPermissionObservation{
+				ID:        strRef("1:abc:2"),
+				FolderUID: strRef("abc"),
+			},
+		},
+	}
+}
+
+// grafanaPermissions returns the permission of the team on the folder, as well as one it inherits from a parent folder.
+func grafanaPermissions(permission string) []*models.ResourcePermissionDTO {
+	return []*models.ResourcePermissionDTO{
+		{TeamID: 2, Permission: "Admin", IsManaged: true, IsInherited: true},
+		{TeamID: 2, Permission: permission, IsManaged: true},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o          managed.ExternalObservation
+		conditions []v1.Condition
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotTeamPermission": {
+			reason:  "An error should be returned if the managed resource is not a TeamPermission",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotTeamPermission),
+			},
+		},
+		"NotApplied": {
+			reason:  "The permission should be reported as missing until it was applied",
+			service: &common.MockGrafanaAPI{},
+			mg: func() resource.Managed {
+				cr := teamPermission()
+				cr.Status.AtProvider.ID = nil
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"TeamDeleted": {
+			reason: "The permission should be reported as missing if the team was deleted",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(nil, nil)
+				return m
+			}(),
+			mg: teamPermission(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"FolderDeleted": {
+			reason: "The permission should be reported as missing if the folder was deleted",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(nil, runtime.NewAPIError("getResourcePermissions", nil, 404))
+				return m
+			}(),
+			mg: teamPermission(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the permissions cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(nil, errBoom)
+				return m
+			}(),
+			mg: teamPermission(),
+			want: want{
+				err: errors.Wrap(errBoom, errGetPermissions),
+			},
+		},
+		"PermissionRemoved": {
+			reason: "The permission should be reported as missing if the team only inherits a permission on the folder",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(grafanaPermissions("Edit")[:1], nil)
+				return m
+			}(),
+			mg: teamPermission(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"UpToDate": {
+			reason: "The permission should be reported as up to date if it matches, ignoring inherited ones",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(grafanaPermissions("Edit"), nil)
+				return m
+			}(),
+			mg: teamPermission(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+		"PermissionChanged": {
+			reason: "The permission should be reported as outdated if the team has another permission on the folder",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(grafanaPermissions("View"), nil)
+				return m
+			}(),
+			mg: teamPermission(),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				conditions: []v1.Condition{v1.Available()},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.TeamPermission); ok {
+				for _, want := range tc.want.conditions {
+					if got := cr.GetCondition(want.Type); !got.Equal(want) {
+						t.Errorf("\n%s\ne.Observe(...): want condition %v, got %v\n", tc.reason, want, got)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCreateByTeamUid(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamByUid", mock.Anything, int64(1), "team").Return(&models.TeamDTO{ID: 2, UID: "team"}, nil)
+	m.On("AddTeamPermission", int64(1), "abc", int64(2), "Edit").Return(nil)
+
+	cr := teamPermission()
+	cr.Spec.ForProvider.TeamID = strRef("team")
+	cr.Status.AtProvider = v1alpha1.TeamPermissionObservation{}
+
+	e := external{service: m}
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error %v", err)
+	}
+	teamId := int64(2)
+	want := v1alpha1.TeamPermissionObservation{
+		FolderUID:  strRef("abc"),
+		ID:         strRef("1:abc:2"),
+		OrgID:      strRef("1"),
+		Permission: strRef("Edit"),
+		TeamID:     &teamId,
+	}
+	if diff := cmp.Diff(want, cr.Status.AtProvider); diff != "" {
+		t.Errorf("e.Create(...): -want status, +got status:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateTeamNotFound(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamById", int64(1), int64(2)).Return(nil, nil)
+
+	e := external{service: m}
+	_, err := e.Create(context.Background(), teamPermission())
+	if diff := cmp.Diff(errors.Errorf(errTeamNotFound, "2"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		service func() *common.MockGrafanaAPI
+		want    error
+	}{
+		"RemovesPermissionOfTeam": {
+			reason: "Only the permission of the team on the folder should be removed",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(grafanaPermissions("Edit"), nil)
+				m.On("RemoveTeamPermission", int64(1), "abc", int64(2)).Return(nil)
+				return m
+			},
+		},
+		"AlreadyRemoved": {
+			reason: "Nothing should be removed if the team has no permission on the folder",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(nil, runtime.NewAPIError("getResourcePermissions", nil, 404))
+				return m
+			},
+		},
+		"RemoveFailed": {
+			reason: "An error should be returned if the permission cannot be removed",
+			service: func() *common.MockGrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(2)).Return(&models.TeamDTO{ID: 2}, nil)
+				m.On("GetTeamPermissions", int64(1), "abc", int64(2)).Return(grafanaPermissions("Edit"), nil)
+				m.On("RemoveTeamPermission", int64(1), "abc", int64(2)).Return(errBoom)
+				return m
+			},
+			want: errors.Wrap(errBoom, errRemovePermission),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := tc.service()
+			e := external{service: m}
+			err := e.Delete(context.Background(), teamPermission())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			m.AssertExpectations(t)
+		})
+	}
+}
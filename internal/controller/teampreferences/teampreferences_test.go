@@ -0,0 +1,231 @@
+/*
+Copyright 2022 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package teampreferences
+
+import (
+	"context"
+	"testing"
+
+	"github.com/argannor/provider-grafana/apis/oss/v1alpha1"
+	"github.com/argannor/provider-grafana/internal/controller/common"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-openapi-client-go/models"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Unlike many Kubernetes projects Crossplane does not use third party testing
+// libraries, per the common Go test review comments. Crossplane encourages the
+// use of table driven unit tests. The tests of the crossplane-runtime project
+// are representative of the testing style Crossplane encourages.
+//
+// https://github.com/golang/go/wiki/TestComments
+// https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
+
+var errBoom = errors.New("boom")
+
+func teamPreferences() *v1alpha1.TeamPreferences {
+	orgId := "1"
+	teamId := "7"
+	theme := "dark"
+	timezone := "utc"
+	id := "1:7"
+	return &v1alpha1.TeamPreferences{
+		Spec: v1alpha1.TeamPreferencesSpec{
+			ForProvider: v1alpha1.TeamPreferencesParameters{
+				OrgID:    &orgId,
+				TeamID:   &teamId,
+				Theme:    &theme,
+				Timezone: &timezone,
+			},
+		},
+		Status: v1alpha1.TeamPreferencesStatus{
+			AtProvider: v1alpha1.TeamPreferencesObservation{
+				ID: &id,
+			},
+		},
+	}
+}
+
+func deletedTeamPreferences() *v1alpha1.TeamPreferences {
+	cr := teamPreferences()
+	now := metav1.Now()
+	cr.SetDeletionTimestamp(&now)
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		service common.GrafanaAPI
+		mg      resource.Managed
+		want    want
+	}{
+		"NotTeamPreferences": {
+			reason:  "An error should be returned if the managed resource is not TeamPreferences",
+			service: &common.MockGrafanaAPI{},
+			mg:      &v1alpha1.Folder{},
+			want: want{
+				err: errors.New(errNotTeamPreferences),
+			},
+		},
+		"NotApplied": {
+			reason:  "Preferences should be reported as missing until they were applied",
+			service: &common.MockGrafanaAPI{},
+			mg: func() resource.Managed {
+				cr := teamPreferences()
+				cr.Status.AtProvider.ID = nil
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"TeamGone": {
+			reason: "Preferences should be reported as missing if the team was deleted",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(nil, nil)
+				return m
+			}(),
+			mg: teamPreferences(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"GetFailed": {
+			reason: "An error should be returned if the preferences cannot be fetched from Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamPreferences", int64(1), int64(7)).Return(nil, errBoom)
+				return m
+			}(),
+			mg: teamPreferences(),
+			want: want{
+				err: errors.Wrap(errBoom, errFailedGetTeamPreferences),
+			},
+		},
+		"UpToDate": {
+			reason: "Preferences should be reported as up to date if all fields match",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamPreferences", int64(1), int64(7)).Return(&models.Preferences{Theme: "dark", Timezone: "utc"}, nil)
+				return m
+			}(),
+			mg: teamPreferences(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"HomeDashboardChanged": {
+			reason: "Preferences should be reported as outdated if the home dashboard was changed in Grafana",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamPreferences", int64(1), int64(7)).Return(&models.Preferences{HomeDashboardUID: "other", Theme: "dark", Timezone: "utc"}, nil)
+				return m
+			}(),
+			mg: teamPreferences(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+			},
+		},
+		"ResetAfterDeletion": {
+			reason: "Preferences should be reported as deleted once they have been reset to their defaults",
+			service: func() common.GrafanaAPI {
+				m := &common.MockGrafanaAPI{}
+				m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+				m.On("GetTeamPreferences", int64(1), int64(7)).Return(&models.Preferences{}, nil)
+				return m
+			}(),
+			mg: deletedTeamPreferences(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{service: tc.service}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamByUid", mock.Anything, int64(1), "platform").Return(&models.TeamDTO{ID: 7}, nil)
+	m.On("UpdateTeamPreferences", int64(1), int64(7), &models.UpdatePrefsCmd{Theme: "dark", Timezone: "utc"}).Return(nil)
+
+	cr := teamPreferences()
+	teamUid := "platform"
+	cr.Spec.ForProvider.TeamID = &teamUid
+	cr.Status.AtProvider.ID = nil
+
+	e := external{service: m}
+	_, err := e.Create(context.Background(), cr)
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+	if diff := cmp.Diff("1:7", *cr.Status.AtProvider.ID); diff != "" {
+		t.Errorf("e.Create(...): -want ID, +got ID:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestCreateTeamNotFound(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamById", int64(1), int64(7)).Return(nil, nil)
+
+	e := external{service: m}
+	_, err := e.Create(context.Background(), teamPreferences())
+	if diff := cmp.Diff(errors.Errorf(errTeamNotFound, "7"), err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Create(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m := &common.MockGrafanaAPI{}
+	m.On("GetTeamById", int64(1), int64(7)).Return(&models.TeamDTO{ID: 7}, nil)
+	m.On("UpdateTeamPreferences", int64(1), int64(7), &models.UpdatePrefsCmd{}).Return(nil)
+
+	e := external{service: m}
+	err := e.Delete(context.Background(), teamPreferences())
+	if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+		t.Errorf("e.Delete(...): -want error, +got error:\n%s\n", diff)
+	}
+	m.AssertExpectations(t)
+}